@@ -5,7 +5,6 @@ import (
 	"go-hris/internal/shared/connection"
 	"log"
 	"os"
-	"path/filepath"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -48,14 +47,5 @@ func BuildApp(router *gin.Engine, logger *zap.Logger) error {
 		return err
 	}
 
-	payslipDir := os.Getenv("PAYSLIP_STORAGE_DIR")
-	if payslipDir == "" {
-		payslipDir = filepath.Join("storage", "payslips")
-	}
-	if err := os.MkdirAll(payslipDir, 0o755); err != nil {
-		return err
-	}
-	router.Static("/files/payslips", payslipDir)
-
 	return nil
 }
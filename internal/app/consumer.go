@@ -3,15 +3,20 @@ package app
 import (
 	"context"
 	"fmt"
+	"go-hris/internal/employee"
 	"go-hris/internal/employeesalary"
 	"go-hris/internal/events"
+	"go-hris/internal/messaging/kafka"
 	"go-hris/internal/messaging/kafka/consumer"
 	"go-hris/internal/payroll"
 	"go-hris/internal/shared/connection"
+	"go-hris/internal/shared/counter"
+	"go-hris/internal/shared/storage"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/redis/go-redis/v9"
 	kafkago "github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
@@ -46,11 +51,34 @@ func RunConsumer() error {
 	employeeSalaryRepo := employeesalary.NewRepository(gormDB)
 	employeeSalaryService := employeesalary.NewService(sqlDB, employeeSalaryRepo)
 	payrollRepo := payroll.NewRepository(gormDB)
-	payrollService := payroll.NewService(sqlDB, payrollRepo)
+	blobStore, err := storage.NewFromEnv()
+	if err != nil {
+		return err
+	}
+	payrollService := payroll.NewServiceWithStorage(sqlDB, payrollRepo, nil, blobStore)
+
+	// Import karyawan memakai alur Create yang sama dengan API, termasuk outbox dan
+	// invalidasi cache opsi karyawan (opsional jika REDIS_ADDR tidak diisi).
+	var redisClient *redis.Client
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		redisClient, err = connection.ConnectRedisWithRetry(redisAddr, 5)
+		if err != nil {
+			return err
+		}
+		defer redisClient.Close()
+	}
+	employeeService := employee.NewServiceWithOutbox(
+		sqlDB,
+		employee.NewRepository(gormDB),
+		counter.NewRepository(gormDB),
+		kafka.NewOutboxRepository(sqlDB),
+		redisClient,
+		logger,
+	)
 
 	reader := kafkago.NewReader(kafkago.ReaderConfig{
 		Brokers:        []string{kafkaBroker},
-		Topic:          events.EmployeeCreatedTopic,
+		Topic:          events.EmployeeLifecycleTopic,
 		GroupID:        "go-hris-employee-salary",
 		CommitInterval: 0,
 		StartOffset:    kafkago.FirstOffset,
@@ -64,12 +92,21 @@ func RunConsumer() error {
 		StartOffset:    kafkago.FirstOffset,
 	})
 	defer payslipReader.Close()
+	importReader := kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:        []string{kafkaBroker},
+		Topic:          events.EmployeeImportRequestedTopic,
+		GroupID:        "go-hris-employee-import",
+		CommitInterval: 0,
+		StartOffset:    kafkago.FirstOffset,
+	})
+	defer importReader.Close()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go consumer.ConsumeEmployeeLifecycle(ctx, reader, employeeSalaryService, logger)
 	go consumer.ConsumePayrollPayslipRequested(ctx, payslipReader, payrollService, logger)
+	go consumer.ConsumeEmployeeImportRequested(ctx, importReader, employeeService, logger)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -16,6 +16,7 @@ import (
 	"go-hris/internal/rbac/infra"
 	"go-hris/internal/rbac/rbac_http"
 	"go-hris/internal/shared/counter"
+	"go-hris/internal/shared/storage"
 	"go-hris/internal/user"
 	"path/filepath"
 
@@ -55,6 +56,16 @@ func registerModules(
 	}
 	rbacService := rbac.NewService(rbacRepo, enforcer)
 
+	// --- Storage (payslip dan file lain) ---
+	blobStore, err := storage.NewFromEnv()
+	if err != nil {
+		return err
+	}
+	if localStore, ok := blobStore.(*storage.LocalStore); ok {
+		// Backend lokal menyajikan file sendiri lewat signed URL; S3 diakses langsung oleh client.
+		router.GET(localStore.RoutePath(), localStore.Handler)
+	}
+
 	// --- Services ---
 	companyService := company.NewService(companyRepo)
 	authService := auth.NewService(authRepo, rbacService, employeeRepo, companyRepo)
@@ -63,7 +74,7 @@ func registerModules(
 	employeeSalaryService := employeesalary.NewService(db, employeeSalaryRepo)
 	employeeService := employee.NewServiceWithOutbox(db, employeeRepo, counterRepo, outboxRepo, rdb)
 	leaveService := leave.NewService(db, leaveRepo)
-	payrollService := payroll.NewServiceWithOutbox(db, payrollRepo, outboxRepo)
+	payrollService := payroll.NewServiceWithStorage(db, payrollRepo, outboxRepo, blobStore)
 	positionService := position.NewService(db, positionRepo, rdb)
 	userService := user.NewService(userRepo, rbacService)
 
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"go-hris/internal/employee"
 	"go-hris/internal/messaging/kafka"
 	"go-hris/internal/messaging/kafka/producer"
 	"go-hris/internal/shared/connection"
@@ -60,6 +61,13 @@ func RunWorker() error {
 		3*time.Second,
 	)
 
+	go employee.RunTerminationSweep(
+		ctx,
+		employee.NewRepository(gormDB),
+		logger,
+		time.Hour,
+	)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -1,5 +1,27 @@
 package attendance
 
+import "go-hris/internal/shared/pagination"
+
+// GetAttendancesFilterRequest adalah query string list absensi. sort_by: attendance_date
+// (default, terbaru dulu), clock_in, status, employee_name; q mencari nama karyawan.
+type GetAttendancesFilterRequest struct {
+	pagination.Request
+	EmployeeID string `form:"employee_id" binding:"omitempty,uuid"` // Hanya berlaku untuk akses read all
+	Status     string `form:"status" binding:"omitempty,oneof=PRESENT LATE ABSENT"`
+	Source     string `form:"source"`
+	DateFrom   string `form:"date_from" binding:"omitempty,datetime=2006-01-02"`
+	DateTo     string `form:"date_to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type AttendanceQueryFilter struct {
+	EmployeeID *string
+	Status     *string
+	Source     *string
+	DateFrom   *string
+	DateTo     *string
+	Page       pagination.Query
+}
+
 type ClockInRequest struct {
 	Latitude  *float64 `json:"latitude"`
 	Longitude *float64 `json:"longitude"`
@@ -4,7 +4,6 @@ import (
 	"go-hris/internal/shared/apperror"
 	"go-hris/internal/shared/response"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -75,33 +74,20 @@ func (h *Handler) GetAll(c *gin.Context) {
 	hasReadAll := c.GetBool("has_read_all")
 	canReadAll := hasReadAll && isPrivilegedRole(role)
 
-	resp, err := h.service.GetAll(c.Request.Context(), companyID, actorID, canReadAll)
-	if err != nil {
-		writeServiceError(c, err)
+	var filterReq GetAttendancesFilterRequest
+	if err := c.ShouldBindQuery(&filterReq); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	total := int64(len(resp))
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > len(resp) {
-		start = len(resp)
-	}
-	if end > len(resp) {
-		end = len(resp)
+	resp, total, err := h.service.GetAll(c.Request.Context(), companyID, actorID, canReadAll, filterReq)
+	if err != nil {
+		writeServiceError(c, err)
+		return
 	}
 
-	meta := response.NewPaginationMeta(total, page, pageSize)
-	response.Success(c, http.StatusOK, resp[start:end], &meta)
+	meta := filterReq.Meta(total)
+	response.Success(c, http.StatusOK, resp, &meta)
 }
 
 func isPrivilegedRole(role string) bool {
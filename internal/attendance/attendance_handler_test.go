@@ -17,7 +17,7 @@ import (
 type fakeService struct {
 	clockInFn  func(ctx context.Context, companyID, employeeID string, req attendance.ClockInRequest) (attendance.AttendanceResponse, error)
 	clockOutFn func(ctx context.Context, companyID, employeeID string, req attendance.ClockOutRequest) (attendance.AttendanceResponse, error)
-	getAllFn   func(ctx context.Context, companyID, actorID string, canReadAll bool) ([]attendance.AttendanceResponse, error)
+	getAllFn   func(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq attendance.GetAttendancesFilterRequest) ([]attendance.AttendanceResponse, int64, error)
 }
 
 func (f *fakeService) ClockIn(ctx context.Context, companyID, employeeID string, req attendance.ClockInRequest) (attendance.AttendanceResponse, error) {
@@ -26,8 +26,8 @@ func (f *fakeService) ClockIn(ctx context.Context, companyID, employeeID string,
 func (f *fakeService) ClockOut(ctx context.Context, companyID, employeeID string, req attendance.ClockOutRequest) (attendance.AttendanceResponse, error) {
 	return f.clockOutFn(ctx, companyID, employeeID, req)
 }
-func (f *fakeService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]attendance.AttendanceResponse, error) {
-	return f.getAllFn(ctx, companyID, actorID, canReadAll)
+func (f *fakeService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq attendance.GetAttendancesFilterRequest) ([]attendance.AttendanceResponse, int64, error) {
+	return f.getAllFn(ctx, companyID, actorID, canReadAll, filterReq)
 }
 
 func TestHandler_ClockInAndGetAll(t *testing.T) {
@@ -41,10 +41,12 @@ func TestHandler_ClockInAndGetAll(t *testing.T) {
 			assert.Equal(t, employeeID, eid)
 			return attendance.AttendanceResponse{ID: uuid.New().String(), EmployeeID: eid, CompanyID: cid}, nil
 		},
-		getAllFn: func(ctx context.Context, cid, actorID string, canReadAll bool) ([]attendance.AttendanceResponse, error) {
+		getAllFn: func(ctx context.Context, cid, actorID string, canReadAll bool, filterReq attendance.GetAttendancesFilterRequest) ([]attendance.AttendanceResponse, int64, error) {
 			assert.Equal(t, employeeID, actorID)
 			assert.False(t, canReadAll)
-			return []attendance.AttendanceResponse{{ID: uuid.New().String()}, {ID: uuid.New().String()}}, nil
+			assert.Equal(t, 1, filterReq.PageSize)
+			assert.Equal(t, "LATE", filterReq.Status)
+			return []attendance.AttendanceResponse{{ID: uuid.New().String()}}, 2, nil
 		},
 		clockOutFn: func(ctx context.Context, companyID, employeeID string, req attendance.ClockOutRequest) (attendance.AttendanceResponse, error) {
 			return attendance.AttendanceResponse{}, nil
@@ -68,8 +70,17 @@ func TestHandler_ClockInAndGetAll(t *testing.T) {
 	c2.Set("employee_id", employeeID)
 	c2.Set("role", "EMPLOYEE")
 	c2.Set("has_read_all", true)
-	c2.Request = httptest.NewRequest(http.MethodGet, "/attendances?page=1&page_size=1", nil)
+	c2.Request = httptest.NewRequest(http.MethodGet, "/attendances?page=1&page_size=1&status=LATE", nil)
 	h.GetAll(c2)
 	assert.Equal(t, http.StatusOK, w2.Code)
 	assert.Contains(t, w2.Body.String(), "\"meta\"")
+	assert.Contains(t, w2.Body.String(), "\"totalPages\":2")
+
+	w3 := httptest.NewRecorder()
+	c3, _ := gin.CreateTestContext(w3)
+	c3.Set("company_id", companyID)
+	c3.Set("employee_id", employeeID)
+	c3.Request = httptest.NewRequest(http.MethodGet, "/attendances?date_from=01-02-2025", nil)
+	h.GetAll(c3)
+	assert.Equal(t, http.StatusBadRequest, w3.Code)
 }
@@ -3,6 +3,7 @@ package attendance
 import (
 	"context"
 	"database/sql"
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/tenant"
 	"time"
 
@@ -14,8 +15,7 @@ type Repository interface {
 	WithTx(tx *sql.Tx) Repository
 	Create(ctx context.Context, a *Attendance) error
 	FindByEmployeeAndDate(ctx context.Context, companyID, employeeID string, date time.Time) (*Attendance, error)
-	FindAllByCompany(ctx context.Context, companyID string) ([]Attendance, error)
-	FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]Attendance, error)
+	FindPageByCompany(ctx context.Context, companyID string, filter AttendanceQueryFilter) ([]Attendance, int64, error)
 	Update(ctx context.Context, a *Attendance) error
 }
 
@@ -46,24 +46,55 @@ func (r *repository) FindByEmployeeAndDate(ctx context.Context, companyID, emplo
 	return &a, err
 }
 
-func (r *repository) FindAllByCompany(ctx context.Context, companyID string) ([]Attendance, error) {
-	var rows []Attendance
-	err := r.db.WithContext(ctx).
-		Preload("Employee").
-		Scopes(tenant.Scope(companyID)).
-		Order("attendance_date DESC, clock_in DESC").
-		Find(&rows).Error
-	return rows, err
+var attendanceListSort = pagination.Sort{
+	Columns: map[string]string{
+		"attendance_date": "attendances.attendance_date",
+		"clock_in":        "attendances.clock_in",
+		"status":          "attendances.status",
+		"employee_name":   "employees.full_name",
+	},
+	Default:    "attendance_date",
+	DefaultDir: "desc",
+	Secondary:  map[string]string{"attendance_date": "attendances.clock_in"},
+	TieBreaker: "attendances.id",
 }
 
-func (r *repository) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]Attendance, error) {
+// FindPageByCompany mengembalikan satu halaman absensi sesuai filter beserta total seluruh
+// data yang cocok. Join employees dipakai untuk search dan sort nama karyawan.
+func (r *repository) FindPageByCompany(ctx context.Context, companyID string, filter AttendanceQueryFilter) ([]Attendance, int64, error) {
+	db := r.db.WithContext(ctx).
+		Model(&Attendance{}).
+		Joins("LEFT JOIN employees ON employees.id = attendances.employee_id").
+		Where("attendances.company_id = ?", companyID).
+		Scopes(pagination.Search(filter.Page.Search, "employees.full_name"))
+
+	if filter.EmployeeID != nil {
+		db = db.Where("attendances.employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != nil {
+		db = db.Where("attendances.status = ?", *filter.Status)
+	}
+	if filter.Source != nil {
+		db = db.Where("attendances.source = ?", *filter.Source)
+	}
+	if filter.DateFrom != nil {
+		db = db.Where("attendances.attendance_date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		db = db.Where("attendances.attendance_date <= ?", *filter.DateTo)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var rows []Attendance
-	err := r.db.WithContext(ctx).
-		Scopes(tenant.Scope(companyID)).
-		Where("employee_id = ?", employeeID).
-		Order("attendance_date DESC, clock_in DESC").
+	err := db.Preload("Employee").
+		Scopes(pagination.Paginate(filter.Page)).
 		Find(&rows).Error
-	return rows, err
+	return rows, total, err
 }
 
 func (r *repository) Update(ctx context.Context, a *Attendance) error {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"go-hris/internal/shared/apperror"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,7 +21,7 @@ const (
 type Service interface {
 	ClockIn(ctx context.Context, companyID, employeeID string, req ClockInRequest) (AttendanceResponse, error)
 	ClockOut(ctx context.Context, companyID, employeeID string, req ClockOutRequest) (AttendanceResponse, error)
-	GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]AttendanceResponse, error)
+	GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq GetAttendancesFilterRequest) ([]AttendanceResponse, int64, error)
 }
 
 type service struct {
@@ -125,27 +126,75 @@ func (s *service) ClockOut(ctx context.Context, companyID, employeeID string, re
 	return mapToResponse(*row), nil
 }
 
-func (s *service) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]AttendanceResponse, error) {
-	var (
-		rows []Attendance
-		err  error
-	)
-	if canReadAll {
-		rows, err = s.repo.FindAllByCompany(ctx, companyID)
-	} else {
-		if _, parseErr := uuid.Parse(actorID); parseErr != nil {
-			return nil, apperror.New(apperror.CodeInvalidInput, "invalid actor id", 400)
+// GetAll mengembalikan satu halaman absensi dan total data hasil filter. Tanpa akses read all
+// hanya absensi milik actor yang dikembalikan, filter employee_id diabaikan.
+func (s *service) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq GetAttendancesFilterRequest) ([]AttendanceResponse, int64, error) {
+	filter, err := buildListFilter(filterReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canReadAll {
+		if _, err := uuid.Parse(actorID); err != nil {
+			return nil, 0, apperror.New(apperror.CodeInvalidInput, "invalid actor id", 400)
 		}
-		rows, err = s.repo.FindAllByCompanyAndEmployee(ctx, companyID, actorID)
+		filter.EmployeeID = &actorID
 	}
+
+	rows, total, err := s.repo.FindPageByCompany(ctx, companyID, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	res := make([]AttendanceResponse, len(rows))
 	for i, r := range rows {
 		res[i] = mapToResponse(r)
 	}
-	return res, nil
+	return res, total, nil
+}
+
+// buildListFilter memvalidasi filter list sebelum diteruskan ke SQL agar input yang salah
+// menjadi 400, bukan error database.
+func buildListFilter(req GetAttendancesFilterRequest) (AttendanceQueryFilter, error) {
+	filter := AttendanceQueryFilter{
+		Status: optionalString(req.Status),
+		Source: optionalString(req.Source),
+		Page:   req.Query(attendanceListSort),
+	}
+	if employeeID := optionalString(req.EmployeeID); employeeID != nil {
+		if _, err := uuid.Parse(*employeeID); err != nil {
+			return AttendanceQueryFilter{}, apperror.New(apperror.CodeInvalidInput, "invalid employee_id", 400)
+		}
+		filter.EmployeeID = employeeID
+	}
+
+	var from, to time.Time
+	if v := optionalString(req.DateFrom); v != nil {
+		d, err := time.Parse("2006-01-02", *v)
+		if err != nil {
+			return AttendanceQueryFilter{}, apperror.New(apperror.CodeInvalidInput, "date_from must use format YYYY-MM-DD", 400)
+		}
+		from = d
+		filter.DateFrom = v
+	}
+	if v := optionalString(req.DateTo); v != nil {
+		d, err := time.Parse("2006-01-02", *v)
+		if err != nil {
+			return AttendanceQueryFilter{}, apperror.New(apperror.CodeInvalidInput, "date_to must use format YYYY-MM-DD", 400)
+		}
+		to = d
+		filter.DateTo = v
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return AttendanceQueryFilter{}, apperror.New(apperror.CodeInvalidInput, "date_from must be before or equal to date_to", 400)
+	}
+	return filter, nil
+}
+
+func optionalString(v string) *string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil
+	}
+	return &v
 }
 
 func mapToResponse(a Attendance) AttendanceResponse {
@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"go-hris/internal/shared/apperror"
+
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -17,8 +19,7 @@ type fakeRepo struct {
 	withTxFn                func(tx *sql.Tx) Repository
 	createFn                func(ctx context.Context, a *Attendance) error
 	findByEmployeeAndDateFn func(ctx context.Context, companyID, employeeID string, date time.Time) (*Attendance, error)
-	findAllByCompanyFn      func(ctx context.Context, companyID string) ([]Attendance, error)
-	findAllByCompanyEmpFn   func(ctx context.Context, companyID, employeeID string) ([]Attendance, error)
+	findPageByCompanyFn     func(ctx context.Context, companyID string, filter AttendanceQueryFilter) ([]Attendance, int64, error)
 	updateFn                func(ctx context.Context, a *Attendance) error
 }
 
@@ -27,11 +28,8 @@ func (f *fakeRepo) Create(ctx context.Context, a *Attendance) error { return f.c
 func (f *fakeRepo) FindByEmployeeAndDate(ctx context.Context, companyID, employeeID string, date time.Time) (*Attendance, error) {
 	return f.findByEmployeeAndDateFn(ctx, companyID, employeeID, date)
 }
-func (f *fakeRepo) FindAllByCompany(ctx context.Context, companyID string) ([]Attendance, error) {
-	return f.findAllByCompanyFn(ctx, companyID)
-}
-func (f *fakeRepo) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]Attendance, error) {
-	return f.findAllByCompanyEmpFn(ctx, companyID, employeeID)
+func (f *fakeRepo) FindPageByCompany(ctx context.Context, companyID string, filter AttendanceQueryFilter) ([]Attendance, int64, error) {
+	return f.findPageByCompanyFn(ctx, companyID, filter)
 }
 func (f *fakeRepo) Update(ctx context.Context, a *Attendance) error { return f.updateFn(ctx, a) }
 
@@ -48,7 +46,6 @@ func TestService_ClockInAndClockOut(t *testing.T) {
 	repo.withTxFn = func(tx *sql.Tx) Repository { return repo }
 	repo.createFn = func(ctx context.Context, a *Attendance) error { saved = *a; return nil }
 	repo.updateFn = func(ctx context.Context, a *Attendance) error { saved = *a; return nil }
-	repo.findAllByCompanyFn = func(ctx context.Context, companyID string) ([]Attendance, error) { return nil, nil }
 	repo.findByEmployeeAndDateFn = func(ctx context.Context, companyID, employeeID string, date time.Time) (*Attendance, error) {
 		if saved.ID == uuid.Nil {
 			return nil, gorm.ErrRecordNotFound
@@ -84,7 +81,6 @@ func TestService_ClockIn_Duplicate(t *testing.T) {
 	repo.withTxFn = func(tx *sql.Tx) Repository { return repo }
 	repo.createFn = func(ctx context.Context, a *Attendance) error { return nil }
 	repo.updateFn = func(ctx context.Context, a *Attendance) error { return nil }
-	repo.findAllByCompanyFn = func(ctx context.Context, companyID string) ([]Attendance, error) { return nil, nil }
 	repo.findByEmployeeAndDateFn = func(ctx context.Context, companyID, employeeID string, date time.Time) (*Attendance, error) {
 		return &Attendance{ID: uuid.New()}, nil
 	}
@@ -97,3 +93,65 @@ func TestService_ClockIn_Duplicate(t *testing.T) {
 	assert.True(t, errors.Is(err, errors.New("already clocked in for today")) || err.Error() == "already clocked in for today")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestService_GetAll(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	otherID := uuid.New().String()
+
+	t.Run("employee only sees own attendances", func(t *testing.T) {
+		repo := &fakeRepo{}
+		repo.findPageByCompanyFn = func(ctx context.Context, cid string, filter AttendanceQueryFilter) ([]Attendance, int64, error) {
+			assert.Equal(t, companyID, cid)
+			assert.Equal(t, actorID, *filter.EmployeeID)
+			assert.Equal(t, "LATE", *filter.Status)
+			assert.Nil(t, filter.Source)
+			assert.Equal(t, "attendances.attendance_date DESC, attendances.clock_in DESC, attendances.id DESC", filter.Page.OrderBy)
+			return []Attendance{{ID: uuid.New(), AttendanceDate: time.Now(), ClockIn: time.Now()}}, 12, nil
+		}
+		svc := NewService(nil, repo)
+
+		res, total, err := svc.GetAll(ctx, companyID, actorID, false, GetAttendancesFilterRequest{EmployeeID: otherID, Status: "LATE"})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(12), total)
+	})
+
+	t.Run("read all uses employee filter", func(t *testing.T) {
+		repo := &fakeRepo{}
+		repo.findPageByCompanyFn = func(ctx context.Context, cid string, filter AttendanceQueryFilter) ([]Attendance, int64, error) {
+			assert.Equal(t, otherID, *filter.EmployeeID)
+			assert.Equal(t, "2025-01-01", *filter.DateFrom)
+			assert.Equal(t, "2025-01-31", *filter.DateTo)
+			return nil, 0, nil
+		}
+		svc := NewService(nil, repo)
+
+		_, _, err := svc.GetAll(ctx, companyID, actorID, true, GetAttendancesFilterRequest{EmployeeID: otherID, DateFrom: "2025-01-01", DateTo: "2025-01-31"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid date range", func(t *testing.T) {
+		svc := NewService(nil, &fakeRepo{})
+
+		_, _, err := svc.GetAll(ctx, companyID, actorID, true, GetAttendancesFilterRequest{DateFrom: "2025-02-01", DateTo: "2025-01-01"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid filters are rejected before query", func(t *testing.T) {
+		svc := NewService(nil, &fakeRepo{})
+
+		for _, req := range []GetAttendancesFilterRequest{
+			{EmployeeID: "not-a-uuid"},
+			{DateFrom: "2025-02-30"},
+			{DateTo: "31-01-2025"},
+		} {
+			_, _, err := svc.GetAll(ctx, companyID, actorID, true, req)
+			var appErr *apperror.AppError
+			if assert.ErrorAs(t, err, &appErr) {
+				assert.Equal(t, 400, appErr.HTTPStatus)
+			}
+		}
+	})
+}
@@ -57,36 +57,6 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, a any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, a)
 }
 
-// FindAllByCompany mocks base method.
-func (m *MockRepository) FindAllByCompany(ctx context.Context, companyID string) ([]attendance.Attendance, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByCompany", ctx, companyID)
-	ret0, _ := ret[0].([]attendance.Attendance)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByCompany indicates an expected call of FindAllByCompany.
-func (mr *MockRepositoryMockRecorder) FindAllByCompany(ctx, companyID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompany", reflect.TypeOf((*MockRepository)(nil).FindAllByCompany), ctx, companyID)
-}
-
-// FindAllByCompanyAndEmployee mocks base method.
-func (m *MockRepository) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]attendance.Attendance, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByCompanyAndEmployee", ctx, companyID, employeeID)
-	ret0, _ := ret[0].([]attendance.Attendance)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByCompanyAndEmployee indicates an expected call of FindAllByCompanyAndEmployee.
-func (mr *MockRepositoryMockRecorder) FindAllByCompanyAndEmployee(ctx, companyID, employeeID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompanyAndEmployee", reflect.TypeOf((*MockRepository)(nil).FindAllByCompanyAndEmployee), ctx, companyID, employeeID)
-}
-
 // FindByEmployeeAndDate mocks base method.
 func (m *MockRepository) FindByEmployeeAndDate(ctx context.Context, companyID, employeeID string, date time.Time) (*attendance.Attendance, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +72,22 @@ func (mr *MockRepositoryMockRecorder) FindByEmployeeAndDate(ctx, companyID, empl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmployeeAndDate", reflect.TypeOf((*MockRepository)(nil).FindByEmployeeAndDate), ctx, companyID, employeeID, date)
 }
 
+// FindPageByCompany mocks base method.
+func (m *MockRepository) FindPageByCompany(ctx context.Context, companyID string, filter attendance.AttendanceQueryFilter) ([]attendance.Attendance, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCompany", ctx, companyID, filter)
+	ret0, _ := ret[0].([]attendance.Attendance)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCompany indicates an expected call of FindPageByCompany.
+func (mr *MockRepositoryMockRecorder) FindPageByCompany(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCompany", reflect.TypeOf((*MockRepository)(nil).FindPageByCompany), ctx, companyID, filter)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, a *attendance.Attendance) error {
 	m.ctrl.T.Helper()
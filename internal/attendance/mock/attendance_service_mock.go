@@ -72,16 +72,17 @@ func (mr *MockServiceMockRecorder) ClockOut(ctx, companyID, employeeID, req any)
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]attendance.AttendanceResponse, error) {
+func (m *MockService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq attendance.GetAttendancesFilterRequest) ([]attendance.AttendanceResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, actorID, canReadAll)
+	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, actorID, canReadAll, filterReq)
 	ret0, _ := ret[0].([]attendance.AttendanceResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, actorID, canReadAll any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, actorID, canReadAll, filterReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, actorID, canReadAll)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, actorID, canReadAll, filterReq)
 }
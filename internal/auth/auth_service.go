@@ -52,6 +52,10 @@ func (s *service) Login(ctx context.Context, email, password string) (accessToke
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
 		return "", "", AuthResponse{}, autherrors.ErrInvalidCredentials
 	}
+	// Akun nonaktif, mis. karyawan yang sudah melewati hari terakhir setelah terminasi
+	if !user.IsActive {
+		return "", "", AuthResponse{}, autherrors.ErrUserInactive
+	}
 
 	// 3. Load company policy untuk Casbin
 	if err := s.rbac.LoadCompanyPolicy(user.CompanyID.String()); err != nil {
@@ -123,6 +127,9 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (string
 	if err != nil {
 		return "", "", AuthResponse{}, autherrors.ErrUserNotFound
 	}
+	if !user.IsActive {
+		return "", "", AuthResponse{}, autherrors.ErrUserInactive
+	}
 
 	role := user.Role
 	if role == "" {
@@ -46,6 +46,7 @@ func TestService_Login(t *testing.T) {
 		Email:      "admin@example.com",
 		Password:   string(pw),
 		Role:       "EMPLOYEE",
+		IsActive:   true,
 	}
 
 	t.Run("Success Login", func(t *testing.T) {
@@ -81,6 +82,17 @@ func TestService_Login(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("Inactive User", func(t *testing.T) {
+		inactive := *mockUser
+		inactive.IsActive = false
+		mockRepo.EXPECT().
+			GetByEmail(ctx, mockUser.Email).
+			Return(&inactive, nil)
+
+		_, _, _, err := service.Login(ctx, mockUser.Email, password)
+		assert.ErrorIs(t, err, autherrors.ErrUserInactive)
+	})
+
 }
 
 func TestService_Register(t *testing.T) {
@@ -85,4 +85,10 @@ var (
 		"Invalid user id",
 		http.StatusBadRequest,
 	)
+
+	ErrUserInactive = apperror.New(
+		apperror.CodeForbidden,
+		"User account is inactive",
+		http.StatusForbidden,
+	)
 )
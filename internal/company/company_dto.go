@@ -7,11 +7,13 @@ type CompanyResponse struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	IsActive bool   `json:"is_active"`
+	Logo     string `json:"logo,omitempty"`
 }
 
 type UpdateCompanyRequest struct {
-	Name     string `json:"name"`
-	IsActive *bool  `json:"is_active"`
+	Name     string  `json:"name"`
+	IsActive *bool   `json:"is_active"`
+	Logo     *string `json:"logo"` // Data URI PNG/JPEG (maks. 256 KB), string kosong menghapus logo
 }
 
 type UpsertCompanyRegistrationRequest struct {
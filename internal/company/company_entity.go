@@ -12,6 +12,7 @@ type Company struct {
 	Name          string                `gorm:"type:varchar(150);not null"`
 	Email         string                `gorm:"type:varchar(255);index"`
 	IsActive      bool                  `gorm:"not null;default:true"`
+	Logo          *string               `gorm:"type:text"` // Data URI PNG/JPEG, dipakai di header payslip
 	CreatedAt     time.Time             `gorm:"not null;default:now()"`
 	UpdatedAt     time.Time             `gorm:"not null;default:now()"`
 	DeletedAt     gorm.DeletedAt        `gorm:"index"`
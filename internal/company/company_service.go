@@ -2,6 +2,7 @@ package company
 
 import (
 	"context"
+	"encoding/base64"
 	companyerrors "go-hris/internal/company/errors"
 	"strings"
 
@@ -69,6 +70,18 @@ func (s *service) Update(ctx context.Context, id string, req UpdateCompanyReques
 		comp.IsActive = *req.IsActive
 	}
 
+	if req.Logo != nil {
+		logo := strings.TrimSpace(*req.Logo)
+		if logo == "" {
+			comp.Logo = nil
+		} else {
+			if err := validateLogo(logo); err != nil {
+				return nil, err
+			}
+			comp.Logo = &logo
+		}
+	}
+
 	err = s.repo.Update(ctx, comp)
 	if err != nil {
 		return nil, err
@@ -147,10 +160,37 @@ func (s *service) DeleteRegistration(
 }
 
 func (s *service) mapToResponse(c *Company) *CompanyResponse {
-	return &CompanyResponse{
+	resp := &CompanyResponse{
 		ID:       c.ID.String(),
 		Name:     c.Name,
 		Email:    c.Email,
 		IsActive: c.IsActive,
 	}
+	if c.Logo != nil {
+		resp.Logo = *c.Logo
+	}
+	return resp
+}
+
+// maxLogoSize membatasi ukuran logo setelah di-decode agar payslip tetap ringan.
+const maxLogoSize = 256 << 10
+
+// validateLogo memastikan logo berupa data URI base64 PNG atau JPEG.
+func validateLogo(logo string) error {
+	var encoded string
+	switch {
+	case strings.HasPrefix(logo, "data:image/png;base64,"):
+		encoded = strings.TrimPrefix(logo, "data:image/png;base64,")
+	case strings.HasPrefix(logo, "data:image/jpeg;base64,"):
+		encoded = strings.TrimPrefix(logo, "data:image/jpeg;base64,")
+	default:
+		return companyerrors.ErrInvalidLogo
+	}
+	if base64.StdEncoding.DecodedLen(len(encoded)) > maxLogoSize {
+		return companyerrors.ErrInvalidLogo
+	}
+	if _, err := base64.StdEncoding.DecodeString(encoded); err != nil {
+		return companyerrors.ErrInvalidLogo
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"go-hris/internal/company"
+	companyerrors "go-hris/internal/company/errors"
 	companyMock "go-hris/internal/company/mock"
 	"testing"
 
@@ -77,6 +78,16 @@ func TestCompanyService_Update(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "New Name", resp.Name)
 	})
+
+	t.Run("Invalid Logo", func(t *testing.T) {
+		id := uuid.New()
+		mockRepo.EXPECT().GetByID(ctx, id).Return(&company.Company{ID: id, Name: "Test Company"}, nil)
+
+		logo := "https://example.com/logo.png"
+		_, err := service.Update(ctx, id.String(), company.UpdateCompanyRequest{Logo: &logo})
+
+		assert.ErrorIs(t, err, companyerrors.ErrInvalidLogo)
+	})
 }
 
 func TestCompanyService_UpsertRegistration(t *testing.T) {
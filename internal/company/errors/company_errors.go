@@ -42,6 +42,12 @@ var (
 		http.StatusConflict,
 	)
 
+	ErrInvalidLogo = apperror.New(
+		apperror.CodeInvalidInput,
+		"Logo must be a base64 PNG or JPEG data URI up to 256 KB",
+		http.StatusBadRequest,
+	)
+
 	ErrMissingRequiredFields = apperror.New(
 		apperror.CodeInvalidInput,
 		"Missing required fields",
@@ -1,38 +1,107 @@
 package employee
 
+import (
+	"time"
+
+	"go-hris/internal/shared/pagination"
+)
+
+// GetEmployeesFilterRequest adalah query string list karyawan. sort_by: name (default), email,
+// employee_number, hire_date, created_at; q mencari nama, email dan nomor karyawan.
+type GetEmployeesFilterRequest struct {
+	pagination.Request
+	DepartmentID string `form:"department_id"`
+	PositionID   string `form:"position_id"`
+	ManagerID    string `form:"manager_id"` // Bawahan langsung dari karyawan ini
+	Status       string `form:"status"`
+	HireDateFrom string `form:"hire_date_from"` // YYYY-MM-DD
+	HireDateTo   string `form:"hire_date_to"`   // YYYY-MM-DD
+}
+
+type EmployeeQueryFilter struct {
+	DepartmentID *string
+	PositionID   *string
+	ManagerID    *string
+	Status       *string
+	HireDateFrom *time.Time
+	HireDateTo   *time.Time
+	Page         pagination.Query
+}
+
 type CreateEmployeeRequest struct {
 	FullName         string `json:"full_name" binding:"required"`
 	Email            string `json:"email" binding:"required,email"`
 	EmployeeNumber   string `json:"employee_number"`
 	Phone            string `json:"phone"`
 	HireDate         string `json:"hire_date" binding:"required"`
+	BirthDate        string `json:"birth_date"`       // Opsional, format YYYY-MM-DD
+	TerminationDate  string `json:"termination_date"` // Opsional, hari kerja terakhir format YYYY-MM-DD
 	EmploymentStatus string `json:"employment_status" binding:"required"`
 	PositionID       string `json:"position_id" binding:"required,uuid"`
+	ManagerID        string `json:"manager_id"`  // Opsional, atasan langsung
+	PTKPStatus       string `json:"ptkp_status"` // Opsional, default TK/0
+
+	// Opsional: rekening tujuan transfer gaji
+	BankCode        string `json:"bank_code"`
+	BankAccountNo   string `json:"bank_account_number"`
+	BankAccountName string `json:"bank_account_name"`
 }
 
 type UpdateEmployeeRequest struct {
-	FullName         string `json:"full_name" binding:"required"`
-	Email            string `json:"email" binding:"required,email"`
-	EmployeeNumber   string `json:"employee_number" binding:"required"`
-	Phone            string `json:"phone"`
-	HireDate         string `json:"hire_date" binding:"required"`
-	EmploymentStatus string `json:"employment_status" binding:"required"`
-	PositionID       string `json:"position_id" binding:"required,uuid"`
+	FullName         string  `json:"full_name" binding:"required"`
+	Email            string  `json:"email" binding:"required,email"`
+	EmployeeNumber   string  `json:"employee_number" binding:"required"`
+	Phone            string  `json:"phone"`
+	HireDate         string  `json:"hire_date" binding:"required"`
+	BirthDate        string  `json:"birth_date"`       // Opsional, format YYYY-MM-DD
+	TerminationDate  *string `json:"termination_date"` // Opsional, hari kerja terakhir YYYY-MM-DD: tidak dikirim = tetap, "" = hapus
+	EmploymentStatus string  `json:"employment_status" binding:"required"`
+	PositionID       string  `json:"position_id" binding:"required,uuid"`
+	ManagerID        *string `json:"manager_id"`  // Opsional: tidak dikirim = tetap, "" = hapus atasan
+	PTKPStatus       string  `json:"ptkp_status"` // Opsional, default TK/0
+
+	// Opsional: rekening tujuan transfer gaji
+	BankCode        string `json:"bank_code"`
+	BankAccountNo   string `json:"bank_account_number"`
+	BankAccountName string `json:"bank_account_name"`
+}
+
+type TerminateEmployeeRequest struct {
+	TerminationDate string `json:"termination_date" binding:"required"` // Hari kerja terakhir, format YYYY-MM-DD
+	Reason          string `json:"reason" binding:"required"`           // RESIGNATION, DISMISSAL, CONTRACT_END
+	Note            string `json:"note"`
+	RehireEligible  *bool  `json:"rehire_eligible" binding:"required"`
+}
+
+type RehireEmployeeRequest struct {
+	HireDate         string `json:"hire_date" binding:"required"` // Tanggal mulai kerja kembali, format YYYY-MM-DD
+	EmploymentStatus string `json:"employment_status"`            // Opsional, default active
 }
 
 type EmployeeResponse struct {
-	ID               string                      `json:"id"`
-	FullName         string                      `json:"full_name"`
-	Email            string                      `json:"email"`
-	EmployeeNumber   string                      `json:"employee_number"`
-	Phone            string                      `json:"phone,omitempty"`
-	HireDate         string                      `json:"hire_date,omitempty"`
-	EmploymentStatus string                      `json:"employment_status,omitempty"`
-	CompanyID        string                      `json:"company_id,omitempty"`
-	DepartmentID     string                      `json:"department_id,omitempty"`
-	PositionID       string                      `json:"position_id,omitempty"`
-	Department       *EmployeeDepartmentResponse `json:"department,omitempty"`
-	Position         *EmployeePositionResponse   `json:"position,omitempty"`
+	ID                string                      `json:"id"`
+	FullName          string                      `json:"full_name"`
+	Email             string                      `json:"email"`
+	EmployeeNumber    string                      `json:"employee_number"`
+	Phone             string                      `json:"phone,omitempty"`
+	HireDate          string                      `json:"hire_date,omitempty"`
+	BirthDate         string                      `json:"birth_date,omitempty"`
+	TerminationDate   string                      `json:"termination_date,omitempty"`
+	EmploymentStatus  string                      `json:"employment_status,omitempty"`
+	PTKPStatus        string                      `json:"ptkp_status,omitempty"`
+	BankCode          string                      `json:"bank_code,omitempty"`
+	BankAccountNo     string                      `json:"bank_account_number,omitempty"`
+	BankAccountName   string                      `json:"bank_account_name,omitempty"`
+	TerminationReason string                      `json:"termination_reason,omitempty"`
+	TerminationNote   string                      `json:"termination_note,omitempty"`
+	RehireEligible    *bool                       `json:"rehire_eligible,omitempty"`
+	TerminatedAt      string                      `json:"terminated_at,omitempty"`
+	CompanyID         string                      `json:"company_id,omitempty"`
+	DepartmentID      string                      `json:"department_id,omitempty"`
+	PositionID        string                      `json:"position_id,omitempty"`
+	ManagerID         string                      `json:"manager_id,omitempty"`
+	Department        *EmployeeDepartmentResponse `json:"department,omitempty"`
+	Position          *EmployeePositionResponse   `json:"position,omitempty"`
 }
 
 type EmployeeDepartmentResponse struct {
@@ -44,3 +113,53 @@ type EmployeePositionResponse struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+// OrgChartNode adalah satu karyawan di org chart beserta bawahan langsungnya.
+type OrgChartNode struct {
+	ID               string         `json:"id"`
+	EmployeeNumber   string         `json:"employee_number"`
+	FullName         string         `json:"full_name"`
+	Email            string         `json:"email"`
+	EmploymentStatus string         `json:"employment_status,omitempty"`
+	ManagerID        string         `json:"manager_id,omitempty"`
+	PositionName     string         `json:"position_name,omitempty"`
+	DepartmentName   string         `json:"department_name,omitempty"`
+	TotalReports     int            `json:"total_reports"` // Bawahan langsung dan tidak langsung
+	Reports          []OrgChartNode `json:"reports"`
+}
+
+// EmployeeImportRowResult adalah hasil satu baris file import. Row mengikuti nomor baris
+// di file (baris 1 adalah judul kolom).
+type EmployeeImportRowResult struct {
+	Row      int      `json:"row"`
+	FullName string   `json:"full_name,omitempty"`
+	Email    string   `json:"email,omitempty"`
+	Position string   `json:"position,omitempty"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+type EmployeeImportReport struct {
+	FileName    string                    `json:"file_name"`
+	TotalRows   int                       `json:"total_rows"`
+	ValidRows   int                       `json:"valid_rows"`
+	InvalidRows int                       `json:"invalid_rows"`
+	Rows        []EmployeeImportRowResult `json:"rows"`
+}
+
+type EmployeeImportResponse struct {
+	ID              string                    `json:"id"`
+	FileName        string                    `json:"file_name"`
+	Status          string                    `json:"status"`
+	TotalRows       int                       `json:"total_rows"` // Baris valid yang diantrikan
+	ProcessedRows   int                       `json:"processed_rows"`
+	CreatedRows     int                       `json:"created_rows"`
+	FailedRows      int                       `json:"failed_rows"`
+	SkippedRows     int                       `json:"skipped_rows"` // Baris yang gagal validasi
+	ProgressPercent int                       `json:"progress_percent"`
+	Failures        []EmployeeImportRowResult `json:"failures,omitempty"`
+	RequestedBy     string                    `json:"requested_by,omitempty"`
+	StartedAt       string                    `json:"started_at,omitempty"`
+	CompletedAt     string                    `json:"completed_at,omitempty"`
+	CreatedAt       string                    `json:"created_at"`
+}
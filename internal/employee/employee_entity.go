@@ -8,21 +8,59 @@ import (
 )
 
 type Employee struct {
-	ID               uuid.UUID           `gorm:"column:id;type:uuid;primaryKey"`
-	CompanyID        uuid.UUID           `gorm:"column:company_id;type:uuid;index"`
-	DepartmentID     *uuid.UUID          `gorm:"column:department_id;type:uuid"`
-	PositionID       *uuid.UUID          `gorm:"column:position_id;type:uuid"`
-	EmployeeNumber   string              `gorm:"column:employee_number"`
-	FullName         string              `gorm:"column:full_name"`
-	Email            string              `gorm:"column:email;uniqueIndex"`
-	Phone            string              `gorm:"column:phone"`
-	HireDate         time.Time           `gorm:"column:hire_date;type:date"`
-	EmploymentStatus string              `gorm:"column:employment_status"`
-	CreatedAt        time.Time           `gorm:"column:created_at"`
-	UpdatedAt        time.Time           `gorm:"column:updated_at"`
-	DeletedAt        gorm.DeletedAt      `gorm:"column:deleted_at;index"`
-	Department       *EmployeeDepartment `gorm:"foreignKey:DepartmentID;references:ID"`
-	Position         *EmployeePosition   `gorm:"foreignKey:PositionID;references:ID"`
+	ID                uuid.UUID           `gorm:"column:id;type:uuid;primaryKey"`
+	CompanyID         uuid.UUID           `gorm:"column:company_id;type:uuid;index"`
+	DepartmentID      *uuid.UUID          `gorm:"column:department_id;type:uuid"`
+	PositionID        *uuid.UUID          `gorm:"column:position_id;type:uuid"`
+	ManagerID         *uuid.UUID          `gorm:"column:manager_id;type:uuid"` // Atasan langsung, dipakai approval dan org chart
+	EmployeeNumber    string              `gorm:"column:employee_number"`
+	FullName          string              `gorm:"column:full_name"`
+	Email             string              `gorm:"column:email;uniqueIndex"`
+	Phone             string              `gorm:"column:phone"`
+	HireDate          time.Time           `gorm:"column:hire_date;type:date"`
+	BirthDate         *time.Time          `gorm:"column:birth_date;type:date"`       // Dipakai sebagai bagian password payslip
+	TerminationDate   *time.Time          `gorm:"column:termination_date;type:date"` // Hari kerja terakhir, dipakai untuk prorata payroll
+	EmploymentStatus  string              `gorm:"column:employment_status"`
+	PTKPStatus        string              `gorm:"column:ptkp_status;default:TK/0"` // Status PTKP untuk PPh 21, mis. TK/0, K/1
+	BankCode          *string             `gorm:"column:bank_code"`                // Kode bank tujuan transfer gaji, mis. BCA, MANDIRI, BNI
+	BankAccountNo     *string             `gorm:"column:bank_account_number"`
+	BankAccountName   *string             `gorm:"column:bank_account_name"`
+	TerminationReason *string             `gorm:"column:termination_reason"` // RESIGNATION, DISMISSAL, CONTRACT_END
+	TerminationNote   *string             `gorm:"column:termination_note"`
+	RehireEligible    *bool               `gorm:"column:rehire_eligible"`
+	TerminatedBy      *uuid.UUID          `gorm:"column:terminated_by;type:uuid"`
+	TerminatedAt      *time.Time          `gorm:"column:terminated_at"` // Waktu terminasi dicatat, bukan hari terakhir
+	CreatedAt         time.Time           `gorm:"column:created_at"`
+	UpdatedAt         time.Time           `gorm:"column:updated_at"`
+	DeletedAt         gorm.DeletedAt      `gorm:"column:deleted_at;index"`
+	Department        *EmployeeDepartment `gorm:"foreignKey:DepartmentID;references:ID"`
+	Position          *EmployeePosition   `gorm:"foreignKey:PositionID;references:ID"`
+}
+
+// IsTerminated menandakan karyawan sudah melalui alur terminasi. Datanya tetap ada
+// (tidak di-soft-delete) agar bisa dipakai payroll dan laporan.
+func (e Employee) IsTerminated() bool {
+	return e.TerminationReason != nil
+}
+
+// EmploymentStint adalah masa kerja sebelumnya yang disimpan saat karyawan di-rehire, agar
+// payroll dan laporan periode lama tetap memakai hire_date dan termination_date yang benar.
+type EmploymentStint struct {
+	ID                uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	CompanyID         uuid.UUID  `gorm:"column:company_id;type:uuid"`
+	EmployeeID        uuid.UUID  `gorm:"column:employee_id;type:uuid"`
+	HireDate          time.Time  `gorm:"column:hire_date;type:date"`
+	TerminationDate   *time.Time `gorm:"column:termination_date;type:date"`
+	TerminationReason *string    `gorm:"column:termination_reason"`
+	TerminationNote   *string    `gorm:"column:termination_note"`
+	RehireEligible    *bool      `gorm:"column:rehire_eligible"`
+	TerminatedBy      *uuid.UUID `gorm:"column:terminated_by;type:uuid"`
+	TerminatedAt      *time.Time `gorm:"column:terminated_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+}
+
+func (EmploymentStint) TableName() string {
+	return "employee_employment_stints"
 }
 
 type EmployeeDepartment struct {
@@ -42,3 +80,17 @@ type EmployeePosition struct {
 func (EmployeePosition) TableName() string {
 	return "positions"
 }
+
+// OrgChartEntry adalah satu baris hasil traversal org chart. Depth dihitung dari root
+// traversal (0), bukan dari puncak company.
+type OrgChartEntry struct {
+	ID               uuid.UUID  `gorm:"column:id"`
+	ManagerID        *uuid.UUID `gorm:"column:manager_id"`
+	EmployeeNumber   string     `gorm:"column:employee_number"`
+	FullName         string     `gorm:"column:full_name"`
+	Email            string     `gorm:"column:email"`
+	EmploymentStatus string     `gorm:"column:employment_status"`
+	PositionName     *string    `gorm:"column:position_name"`
+	DepartmentName   *string    `gorm:"column:department_name"`
+	Depth            int        `gorm:"column:depth"`
+}
@@ -29,6 +29,12 @@ func mapRepositoryError(err error) error {
 				return employeeerrors.ErrEmployeeAlreadyExists
 			}
 		}
+		switch pgErr.ConstraintName {
+		case "fk_employees_manager":
+			return employeeerrors.ErrInvalidManager
+		case "chk_employees_manager_not_self":
+			return employeeerrors.ErrManagerCycle
+		}
 	}
 
 	errMsg := strings.ToLower(err.Error())
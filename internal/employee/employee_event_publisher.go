@@ -9,12 +9,12 @@ import (
 )
 
 type EventPublisher interface {
-	PublishEmployeeCreated(ctx context.Context, event events.EmployeeCreatedEvent) error
+	PublishEmployeeLifecycle(ctx context.Context, event events.EmployeeLifecycleEvent) error
 }
 
 type noopEventPublisher struct{}
 
-func (noopEventPublisher) PublishEmployeeCreated(context.Context, events.EmployeeCreatedEvent) error {
+func (noopEventPublisher) PublishEmployeeLifecycle(context.Context, events.EmployeeLifecycleEvent) error {
 	return nil
 }
 
@@ -26,9 +26,9 @@ func NewKafkaEventPublisher(writer *kafka.Writer) EventPublisher {
 	return &kafkaEventPublisher{writer: writer}
 }
 
-func (p *kafkaEventPublisher) PublishEmployeeCreated(
+func (p *kafkaEventPublisher) PublishEmployeeLifecycle(
 	ctx context.Context,
-	event events.EmployeeCreatedEvent,
+	event events.EmployeeLifecycleEvent,
 ) error {
 	payload, err := json.Marshal(event)
 	if err != nil {
@@ -36,8 +36,11 @@ func (p *kafkaEventPublisher) PublishEmployeeCreated(
 	}
 
 	return p.writer.WriteMessages(ctx, kafka.Message{
-		Topic: events.EmployeeCreatedTopic,
+		Topic: events.EmployeeLifecycleTopic,
 		Key:   []byte(event.EmployeeID),
 		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "event_type", Value: []byte(event.EventType)},
+		},
 	})
 }
@@ -3,8 +3,8 @@ package employee
 import (
 	"go-hris/internal/shared/apperror"
 	"go-hris/internal/shared/response"
+	"io"
 	"net/http"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -61,65 +61,21 @@ func (h *Handler) GetAll(c *gin.Context) {
 	companyID := c.GetString("company_id")
 	h.logger.Debug("http get all employees", zap.String("company_id", companyID))
 
-	resp, err := h.service.GetAll(ctx, companyID)
-	if err != nil {
-		h.writeServiceError(c, err)
+	var filterReq GetEmployeesFilterRequest
+	if err := c.ShouldBindQuery(&filterReq); err != nil {
+		h.logger.Warn("http get all employees validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
 		return
 	}
 
-	q := strings.TrimSpace(strings.ToLower(c.Query("q")))
-	if q != "" {
-		filtered := make([]EmployeeResponse, 0, len(resp))
-		for _, e := range resp {
-			if strings.Contains(strings.ToLower(e.FullName), q) || strings.Contains(strings.ToLower(e.Email), q) {
-				filtered = append(filtered, e)
-			}
-		}
-		resp = filtered
-	}
-
-	sortBy := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort_by", "name")))
-	sortDir := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort_dir", "asc")))
-	if sortDir != "desc" {
-		sortDir = "asc"
-	}
-	sort.Slice(resp, func(i, j int) bool {
-		var less bool
-		switch sortBy {
-		case "email":
-			less = strings.ToLower(resp[i].Email) < strings.ToLower(resp[j].Email)
-		case "id":
-			less = resp[i].ID < resp[j].ID
-		default:
-			less = strings.ToLower(resp[i].FullName) < strings.ToLower(resp[j].FullName)
-		}
-		if sortDir == "desc" {
-			return !less
-		}
-		return less
-	})
-
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	total := int64(len(resp))
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > len(resp) {
-		start = len(resp)
-	}
-	if end > len(resp) {
-		end = len(resp)
+	resp, total, err := h.service.GetAll(ctx, companyID, filterReq)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
 	}
 
-	meta := response.NewPaginationMeta(total, page, pageSize)
-	response.Success(c, http.StatusOK, resp[start:end], &meta)
+	meta := filterReq.Meta(total)
+	response.Success(c, http.StatusOK, resp, &meta)
 }
 
 func (h *Handler) GetOptions(c *gin.Context) {
@@ -211,3 +167,152 @@ func (h *Handler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, gin.H{"deleted": true}, nil)
 }
+
+func (h *Handler) Terminate(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := c.GetString("employee_id")
+	if actorID == "" {
+		actorID = c.GetString("user_id")
+	}
+	h.logger.Debug("http terminate employee",
+		zap.String("company_id", companyID),
+		zap.String("employee_id", id),
+	)
+	var req TerminateEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("http terminate employee validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.Terminate(ctx, companyID, actorID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) Rehire(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	h.logger.Debug("http rehire employee",
+		zap.String("company_id", companyID),
+		zap.String("employee_id", id),
+	)
+	var req RehireEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("http rehire employee validation failed", zap.Error(err))
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.Rehire(ctx, companyID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+const maxImportFileSize = 5 << 20
+
+// Import menerima file CSV/XLSX (field multipart "file"). Dengan dry_run=true hanya laporan
+// validasi per baris yang dikembalikan; tanpa itu job import diantrikan dan diproses async.
+func (h *Handler) Import(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := c.GetString("employee_id")
+	if actorID == "" {
+		actorID = c.GetString("user_id")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", "file too large")
+		return
+	}
+	dryRun := false
+	if v := c.DefaultPostForm("dry_run", c.Query("dry_run")); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", "dry_run must be a boolean")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxImportFileSize))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	h.logger.Debug("http import employees",
+		zap.String("company_id", companyID),
+		zap.String("file_name", fileHeader.Filename),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	if dryRun {
+		report, err := h.service.PreviewImport(ctx, companyID, fileHeader.Filename, content)
+		if err != nil {
+			h.writeServiceError(c, err)
+			return
+		}
+		response.Success(c, http.StatusOK, report, nil)
+		return
+	}
+
+	resp, err := h.service.StartImport(ctx, companyID, actorID, fileHeader.Filename, content)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, resp, nil)
+}
+
+func (h *Handler) GetImport(c *gin.Context) {
+	companyID := c.GetString("company_id")
+	id := c.Param("id")
+
+	resp, err := h.service.GetImport(c.Request.Context(), companyID, id)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetOrgChart(c *gin.Context) {
+	companyID := c.GetString("company_id")
+	rootID := c.Query("root_id")
+	h.logger.Debug("http get org chart",
+		zap.String("company_id", companyID),
+		zap.String("root_id", rootID),
+	)
+
+	resp, err := h.service.GetOrgChart(c.Request.Context(), companyID, rootID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
@@ -1,11 +1,13 @@
 package employee_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"go-hris/internal/employee"
 	employeeerrors "go-hris/internal/employee/errors"
 	"go-hris/internal/shared/apperror"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -19,18 +21,29 @@ import (
 
 type fakeEmployeeService struct {
 	CreateFn     func(ctx context.Context, companyID string, req employee.CreateEmployeeRequest) (employee.EmployeeResponse, error)
-	GetAllFn     func(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error)
+	GetAllFn     func(ctx context.Context, companyID string, filterReq employee.GetEmployeesFilterRequest) ([]employee.EmployeeResponse, int64, error)
 	GetOptionsFn func(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error)
 	GetByIDFn    func(ctx context.Context, companyID, id string) (employee.EmployeeResponse, error)
 	UpdateFn     func(ctx context.Context, companyID, id string, req employee.UpdateEmployeeRequest) (employee.EmployeeResponse, error)
 	DeleteFn     func(ctx context.Context, companyID, id string) error
+	TerminateFn  func(ctx context.Context, companyID, actorID, id string, req employee.TerminateEmployeeRequest) (employee.EmployeeResponse, error)
+	RehireFn     func(ctx context.Context, companyID, id string, req employee.RehireEmployeeRequest) (employee.EmployeeResponse, error)
+
+	PreviewImportFn func(ctx context.Context, companyID, fileName string, content []byte) (employee.EmployeeImportReport, error)
+	StartImportFn   func(ctx context.Context, companyID, actorID, fileName string, content []byte) (employee.EmployeeImportResponse, error)
+	GetImportFn     func(ctx context.Context, companyID, id string) (employee.EmployeeImportResponse, error)
+	ProcessImportFn func(ctx context.Context, companyID, id string) error
+
+	GetOrgChartFn       func(ctx context.Context, companyID, rootID string) ([]employee.OrgChartNode, error)
+	GetReportIDsFn      func(ctx context.Context, companyID, managerID string) ([]string, error)
+	IsInReportingLineFn func(ctx context.Context, companyID, managerID, employeeID string) (bool, error)
 }
 
 func (f *fakeEmployeeService) Create(ctx context.Context, companyID string, req employee.CreateEmployeeRequest) (employee.EmployeeResponse, error) {
 	return f.CreateFn(ctx, companyID, req)
 }
-func (f *fakeEmployeeService) GetAll(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error) {
-	return f.GetAllFn(ctx, companyID)
+func (f *fakeEmployeeService) GetAll(ctx context.Context, companyID string, filterReq employee.GetEmployeesFilterRequest) ([]employee.EmployeeResponse, int64, error) {
+	return f.GetAllFn(ctx, companyID, filterReq)
 }
 func (f *fakeEmployeeService) GetOptions(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error) {
 	return f.GetOptionsFn(ctx, companyID)
@@ -45,6 +58,42 @@ func (f *fakeEmployeeService) Delete(ctx context.Context, companyID, id string)
 	return f.DeleteFn(ctx, companyID, id)
 }
 
+func (f *fakeEmployeeService) Terminate(ctx context.Context, companyID, actorID, id string, req employee.TerminateEmployeeRequest) (employee.EmployeeResponse, error) {
+	return f.TerminateFn(ctx, companyID, actorID, id, req)
+}
+
+func (f *fakeEmployeeService) Rehire(ctx context.Context, companyID, id string, req employee.RehireEmployeeRequest) (employee.EmployeeResponse, error) {
+	return f.RehireFn(ctx, companyID, id, req)
+}
+
+func (f *fakeEmployeeService) PreviewImport(ctx context.Context, companyID, fileName string, content []byte) (employee.EmployeeImportReport, error) {
+	return f.PreviewImportFn(ctx, companyID, fileName, content)
+}
+
+func (f *fakeEmployeeService) StartImport(ctx context.Context, companyID, actorID, fileName string, content []byte) (employee.EmployeeImportResponse, error) {
+	return f.StartImportFn(ctx, companyID, actorID, fileName, content)
+}
+
+func (f *fakeEmployeeService) GetImport(ctx context.Context, companyID, id string) (employee.EmployeeImportResponse, error) {
+	return f.GetImportFn(ctx, companyID, id)
+}
+
+func (f *fakeEmployeeService) ProcessImport(ctx context.Context, companyID, id string) error {
+	return f.ProcessImportFn(ctx, companyID, id)
+}
+
+func (f *fakeEmployeeService) GetOrgChart(ctx context.Context, companyID, rootID string) ([]employee.OrgChartNode, error) {
+	return f.GetOrgChartFn(ctx, companyID, rootID)
+}
+
+func (f *fakeEmployeeService) GetReportIDs(ctx context.Context, companyID, managerID string) ([]string, error) {
+	return f.GetReportIDsFn(ctx, companyID, managerID)
+}
+
+func (f *fakeEmployeeService) IsInReportingLine(ctx context.Context, companyID, managerID, employeeID string) (bool, error) {
+	return f.IsInReportingLineFn(ctx, companyID, managerID, employeeID)
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -198,14 +247,21 @@ func TestEmployeeHandler_GetAll(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		companyID := uuid.New().String()
+		departmentID := uuid.New().String()
 
 		svc := &fakeEmployeeService{
-			GetAllFn: func(ctx context.Context, cid string) ([]employee.EmployeeResponse, error) {
+			GetAllFn: func(ctx context.Context, cid string, filterReq employee.GetEmployeesFilterRequest) ([]employee.EmployeeResponse, int64, error) {
 				assert.Equal(t, companyID, cid)
+				assert.Equal(t, 2, filterReq.Page)
+				assert.Equal(t, 2, filterReq.PageSize)
+				assert.Equal(t, "hire_date", filterReq.SortBy)
+				assert.Equal(t, "doe", filterReq.Search)
+				assert.Equal(t, departmentID, filterReq.DepartmentID)
+				assert.Equal(t, "2024-01-01", filterReq.HireDateFrom)
 				return []employee.EmployeeResponse{
 					{ID: uuid.New().String(), FullName: "John Doe", Email: "john@example.com"},
 					{ID: uuid.New().String(), FullName: "Jane Doe", Email: "jane@example.com"},
-				}, nil
+				}, 5, nil
 			},
 		}
 
@@ -214,7 +270,7 @@ func TestEmployeeHandler_GetAll(t *testing.T) {
 		c, _ := gin.CreateTestContext(w)
 
 		// Setup Request & Context
-		req := httptest.NewRequest(http.MethodGet, "/employees", nil)
+		req := httptest.NewRequest(http.MethodGet, "/employees?page=2&page_size=2&sort_by=hire_date&q=doe&department_id="+departmentID+"&hire_date_from=2024-01-01", nil)
 		c.Request = req
 
 		// Simulasi data yang biasanya diset oleh middleware
@@ -226,12 +282,26 @@ func TestEmployeeHandler_GetAll(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "John Doe")
 		assert.Contains(t, w.Body.String(), "Jane Doe")
+		assert.Contains(t, w.Body.String(), `"meta":{"total":5,"totalPages":3,"page":2,"pageSize":2}`)
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		h := employee.NewHandler(&fakeEmployeeService{})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest(http.MethodGet, "/employees?page=abc", nil)
+		c.Set("company_id", uuid.New().String())
+
+		h.GetAll(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("service error", func(t *testing.T) {
 		svc := &fakeEmployeeService{
-			GetAllFn: func(ctx context.Context, cid string) ([]employee.EmployeeResponse, error) {
-				return nil, errors.New("database error")
+			GetAllFn: func(ctx context.Context, cid string, filterReq employee.GetEmployeesFilterRequest) ([]employee.EmployeeResponse, int64, error) {
+				return nil, 0, errors.New("database error")
 			},
 		}
 
@@ -543,3 +613,285 @@ func TestDeleteEmployeeHandler(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
 }
+
+func TestEmployeeHandler_Terminate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		companyID := uuid.New().String()
+		employeeID := uuid.New().String()
+		actorID := uuid.New().String()
+
+		svc := &fakeEmployeeService{
+			TerminateFn: func(ctx context.Context, cid, aid, id string, req employee.TerminateEmployeeRequest) (employee.EmployeeResponse, error) {
+				assert.Equal(t, companyID, cid)
+				assert.Equal(t, actorID, aid)
+				assert.Equal(t, employeeID, id)
+				assert.Equal(t, "RESIGNATION", req.Reason)
+				assert.False(t, *req.RehireEligible)
+				return employee.EmployeeResponse{ID: id, EmploymentStatus: "terminated", TerminationReason: req.Reason}, nil
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/:id/terminate", withCompany(companyID), withUser(actorID), employee.NewHandler(svc).Terminate)
+
+		body := `{"termination_date":"2026-01-31","reason":"RESIGNATION","rehire_eligible":false}`
+		req := httptest.NewRequest(http.MethodPost, "/employees/"+employeeID+"/terminate", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "terminated")
+	})
+
+	t.Run("rehire eligibility required", func(t *testing.T) {
+		r := setupRouter()
+		r.POST("/employees/:id/terminate", employee.NewHandler(&fakeEmployeeService{}).Terminate)
+
+		body := `{"termination_date":"2026-01-31","reason":"RESIGNATION"}`
+		req := httptest.NewRequest(http.MethodPost, "/employees/"+uuid.New().String()+"/terminate", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("already terminated", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			TerminateFn: func(ctx context.Context, cid, aid, id string, req employee.TerminateEmployeeRequest) (employee.EmployeeResponse, error) {
+				return employee.EmployeeResponse{}, employeeerrors.ErrEmployeeAlreadyTerminated
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/:id/terminate", employee.NewHandler(svc).Terminate)
+
+		body := `{"termination_date":"2026-01-31","reason":"DISMISSAL","rehire_eligible":false}`
+		req := httptest.NewRequest(http.MethodPost, "/employees/"+uuid.New().String()+"/terminate", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestEmployeeHandler_Rehire(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		companyID := uuid.New().String()
+		employeeID := uuid.New().String()
+
+		svc := &fakeEmployeeService{
+			RehireFn: func(ctx context.Context, cid, id string, req employee.RehireEmployeeRequest) (employee.EmployeeResponse, error) {
+				assert.Equal(t, companyID, cid)
+				assert.Equal(t, employeeID, id)
+				assert.Equal(t, "2026-06-01", req.HireDate)
+				return employee.EmployeeResponse{ID: id, HireDate: req.HireDate, EmploymentStatus: "active"}, nil
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/:id/rehire", withCompany(companyID), employee.NewHandler(svc).Rehire)
+
+		body := `{"hire_date":"2026-06-01"}`
+		req := httptest.NewRequest(http.MethodPost, "/employees/"+employeeID+"/rehire", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "2026-06-01")
+	})
+
+	t.Run("not eligible", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			RehireFn: func(ctx context.Context, cid, id string, req employee.RehireEmployeeRequest) (employee.EmployeeResponse, error) {
+				return employee.EmployeeResponse{}, employeeerrors.ErrEmployeeNotRehireEligible
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/:id/rehire", employee.NewHandler(svc).Rehire)
+
+		body := `{"hire_date":"2026-06-01"}`
+		req := httptest.NewRequest(http.MethodPost, "/employees/"+uuid.New().String()+"/rehire", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func newImportRequest(t *testing.T, target, fileName, content string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for k, v := range fields {
+		assert.NoError(t, writer.WriteField(k, v))
+	}
+	part, err := writer.CreateFormFile("file", fileName)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestEmployeeHandler_Import(t *testing.T) {
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	csv := "full_name,email,hire_date,position\nBudi,budi@example.com,2026-01-05,Staff\n"
+
+	t.Run("dry run returns validation report", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			PreviewImportFn: func(ctx context.Context, cid, fileName string, content []byte) (employee.EmployeeImportReport, error) {
+				assert.Equal(t, companyID, cid)
+				assert.Equal(t, "karyawan.csv", fileName)
+				assert.Equal(t, csv, string(content))
+				return employee.EmployeeImportReport{FileName: fileName, TotalRows: 1, ValidRows: 1}, nil
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/imports", withCompany(companyID), employee.NewHandler(svc).Import)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newImportRequest(t, "/employees/imports", "karyawan.csv", csv, map[string]string{"dry_run": "true"}))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"valid_rows":1`)
+	})
+
+	t.Run("commit queues import", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			StartImportFn: func(ctx context.Context, cid, aid, fileName string, content []byte) (employee.EmployeeImportResponse, error) {
+				assert.Equal(t, actorID, aid)
+				return employee.EmployeeImportResponse{ID: uuid.New().String(), Status: employee.ImportStatusPending, TotalRows: 1}, nil
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/imports", withCompany(companyID), withUser(actorID), employee.NewHandler(svc).Import)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newImportRequest(t, "/employees/imports", "karyawan.csv", csv, nil))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), employee.ImportStatusPending)
+	})
+
+	t.Run("file required", func(t *testing.T) {
+		r := setupRouter()
+		r.POST("/employees/imports", employee.NewHandler(&fakeEmployeeService{}).Import)
+
+		req := httptest.NewRequest(http.MethodPost, "/employees/imports", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			StartImportFn: func(ctx context.Context, cid, aid, fileName string, content []byte) (employee.EmployeeImportResponse, error) {
+				return employee.EmployeeImportResponse{}, employeeerrors.ErrImportMissingColumns
+			},
+		}
+
+		r := setupRouter()
+		r.POST("/employees/imports", employee.NewHandler(svc).Import)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, newImportRequest(t, "/employees/imports", "karyawan.csv", "nama\nBudi\n", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestEmployeeHandler_GetImport(t *testing.T) {
+	companyID := uuid.New().String()
+	importID := uuid.New().String()
+
+	svc := &fakeEmployeeService{
+		GetImportFn: func(ctx context.Context, cid, id string) (employee.EmployeeImportResponse, error) {
+			assert.Equal(t, importID, id)
+			return employee.EmployeeImportResponse{ID: id, Status: employee.ImportStatusProcessing, ProgressPercent: 40}, nil
+		},
+		GetByIDFn: func(ctx context.Context, cid, id string) (employee.EmployeeResponse, error) {
+			t.Fatal("import status must not be routed to GetById")
+			return employee.EmployeeResponse{}, nil
+		},
+	}
+
+	h := employee.NewHandler(svc)
+	r := setupRouter()
+	r.GET("/employees/:id", withCompany(companyID), h.GetById)
+	r.GET("/employees/imports/:id", withCompany(companyID), h.GetImport)
+
+	req := httptest.NewRequest(http.MethodGet, "/employees/imports/"+importID, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"progress_percent":40`)
+}
+
+func TestEmployeeHandler_GetOrgChart(t *testing.T) {
+	companyID := uuid.New().String()
+	rootID := uuid.New().String()
+
+	t.Run("subtree by root_id", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			GetOrgChartFn: func(ctx context.Context, cid, root string) ([]employee.OrgChartNode, error) {
+				assert.Equal(t, companyID, cid)
+				assert.Equal(t, rootID, root)
+				return []employee.OrgChartNode{{
+					ID:           root,
+					FullName:     "Head",
+					TotalReports: 1,
+					Reports:      []employee.OrgChartNode{{ID: uuid.New().String(), FullName: "Staff", Reports: []employee.OrgChartNode{}}},
+				}}, nil
+			},
+			GetByIDFn: func(ctx context.Context, cid, id string) (employee.EmployeeResponse, error) {
+				t.Fatal("org chart must not be routed to GetById")
+				return employee.EmployeeResponse{}, nil
+			},
+		}
+
+		h := employee.NewHandler(svc)
+		r := setupRouter()
+		r.GET("/employees/:id", withCompany(companyID), h.GetById)
+		r.GET("/employees/org-chart", withCompany(companyID), h.GetOrgChart)
+
+		req := httptest.NewRequest(http.MethodGet, "/employees/org-chart?root_id="+rootID, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"total_reports":1`)
+		assert.Contains(t, w.Body.String(), `"full_name":"Staff"`)
+	})
+
+	t.Run("root not found", func(t *testing.T) {
+		svc := &fakeEmployeeService{
+			GetOrgChartFn: func(ctx context.Context, cid, root string) ([]employee.OrgChartNode, error) {
+				return nil, employeeerrors.ErrEmployeeNotFound
+			},
+		}
+
+		h := employee.NewHandler(svc)
+		r := setupRouter()
+		r.GET("/employees/org-chart", withCompany(companyID), h.GetOrgChart)
+
+		req := httptest.NewRequest(http.MethodGet, "/employees/org-chart?root_id="+rootID, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,187 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	employeeerrors "go-hris/internal/employee/errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Hierarchy adalah garis pelaporan karyawan. Dipakai modul lain (mis. leave dan RBAC) untuk
+// approval ke atasan langsung dan visibilitas data bawahan tanpa mengakses tabel employees.
+type Hierarchy interface {
+	GetReportIDs(ctx context.Context, companyID, managerID string) ([]string, error)
+	IsInReportingLine(ctx context.Context, companyID, managerID, employeeID string) (bool, error)
+}
+
+// GetOrgChart mengembalikan org chart seluruh company (satu tree per karyawan puncak) atau,
+// jika rootID diisi, subtree mulai dari karyawan tersebut.
+func (s *service) GetOrgChart(ctx context.Context, companyID, rootID string) ([]OrgChartNode, error) {
+	rootID = strings.TrimSpace(rootID)
+	if rootID != "" {
+		if _, err := uuid.Parse(rootID); err != nil {
+			return nil, employeeerrors.ErrInvalidEmployeeID
+		}
+	}
+
+	entries, err := s.repo.FindOrgChart(ctx, companyID, rootID)
+	if err != nil {
+		s.logger.Error("get org chart failed",
+			zap.String("company_id", companyID),
+			zap.String("root_id", rootID),
+			zap.Error(err),
+		)
+		return nil, mapRepositoryError(err)
+	}
+	if rootID != "" && len(entries) == 0 {
+		return nil, employeeerrors.ErrEmployeeNotFound
+	}
+
+	return buildOrgChart(entries), nil
+}
+
+// GetReportIDs mengembalikan ID seluruh bawahan managerID, langsung maupun tidak langsung.
+func (s *service) GetReportIDs(ctx context.Context, companyID, managerID string) ([]string, error) {
+	if _, err := uuid.Parse(managerID); err != nil {
+		return nil, employeeerrors.ErrInvalidEmployeeID
+	}
+
+	ids, err := s.repo.FindReportIDs(ctx, companyID, managerID)
+	if err != nil {
+		s.logger.Error("get report ids failed",
+			zap.String("company_id", companyID),
+			zap.String("manager_id", managerID),
+			zap.Error(err),
+		)
+		return nil, mapRepositoryError(err)
+	}
+	return ids, nil
+}
+
+// IsInReportingLine mengecek apakah managerID adalah atasan employeeID, langsung maupun
+// tidak langsung. Karyawan tidak dianggap atasan dirinya sendiri.
+func (s *service) IsInReportingLine(ctx context.Context, companyID, managerID, employeeID string) (bool, error) {
+	if _, err := uuid.Parse(managerID); err != nil {
+		return false, employeeerrors.ErrInvalidEmployeeID
+	}
+	if _, err := uuid.Parse(employeeID); err != nil {
+		return false, employeeerrors.ErrInvalidEmployeeID
+	}
+	if managerID == employeeID {
+		return false, nil
+	}
+
+	found, err := s.repo.IsInReportingLine(ctx, companyID, managerID, employeeID)
+	if err != nil {
+		s.logger.Error("check reporting line failed",
+			zap.String("company_id", companyID),
+			zap.String("manager_id", managerID),
+			zap.String("employee_id", employeeID),
+			zap.Error(err),
+		)
+		return false, mapRepositoryError(err)
+	}
+	return found, nil
+}
+
+// resolveManager memvalidasi atasan langsung: harus karyawan aktif di company yang sama dan
+// bukan karyawan itu sendiri atau salah satu bawahannya. employeeID nil untuk karyawan baru
+// yang belum punya bawahan, sehingga pengecekan siklus dan lock hierarki dilewati. repo harus
+// repo transaksi caller agar lock bertahan sampai update atasan di-commit.
+func resolveManager(ctx context.Context, repo Repository, companyID string, employeeID *uuid.UUID, managerID string) (*uuid.UUID, error) {
+	managerID = strings.TrimSpace(managerID)
+	if managerID == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(managerID)
+	if err != nil {
+		return nil, employeeerrors.ErrInvalidManagerID
+	}
+	if employeeID != nil && id == *employeeID {
+		return nil, employeeerrors.ErrManagerCycle
+	}
+
+	manager, err := repo.FindByIDAndCompany(ctx, companyID, id.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, employeeerrors.ErrInvalidManager
+	}
+	if err != nil {
+		return nil, err
+	}
+	if manager.IsTerminated() {
+		return nil, employeeerrors.ErrInvalidManager
+	}
+
+	if employeeID != nil {
+		// Lock diambil sebelum pengecekan agar perubahan atasan lain di company yang sama
+		// menunggu sampai transaksi ini selesai.
+		if err := repo.LockHierarchy(ctx, companyID); err != nil {
+			return nil, err
+		}
+		// Siklus terjadi jika karyawan ini sudah berada di rantai atasan calon manager.
+		cycle, err := repo.IsInReportingLine(ctx, companyID, employeeID.String(), id.String())
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			return nil, employeeerrors.ErrManagerCycle
+		}
+	}
+	return &id, nil
+}
+
+// buildOrgChart menyusun hasil traversal (urut depth lalu nama) menjadi tree. Karyawan yang
+// sudah dimasukkan dilewati agar data siklus lama tidak membuat rekursi tanpa akhir.
+func buildOrgChart(entries []OrgChartEntry) []OrgChartNode {
+	children := make(map[uuid.UUID][]OrgChartEntry)
+	var roots []OrgChartEntry
+	for _, e := range entries {
+		if e.Depth == 0 {
+			roots = append(roots, e)
+			continue
+		}
+		if e.ManagerID != nil {
+			children[*e.ManagerID] = append(children[*e.ManagerID], e)
+		}
+	}
+
+	visited := make(map[uuid.UUID]bool, len(entries))
+	var build func(e OrgChartEntry) OrgChartNode
+	build = func(e OrgChartEntry) OrgChartNode {
+		visited[e.ID] = true
+		node := OrgChartNode{
+			ID:               e.ID.String(),
+			EmployeeNumber:   e.EmployeeNumber,
+			FullName:         e.FullName,
+			Email:            e.Email,
+			EmploymentStatus: e.EmploymentStatus,
+			ManagerID:        uuidToString(e.ManagerID),
+			PositionName:     stringValue(e.PositionName),
+			DepartmentName:   stringValue(e.DepartmentName),
+			Reports:          []OrgChartNode{},
+		}
+		for _, child := range children[e.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			report := build(child)
+			node.TotalReports += 1 + report.TotalReports
+			node.Reports = append(node.Reports, report)
+		}
+		return node
+	}
+
+	nodes := make([]OrgChartNode, 0, len(roots))
+	for _, root := range roots {
+		if visited[root.ID] {
+			continue
+		}
+		nodes = append(nodes, build(root))
+	}
+	return nodes
+}
@@ -0,0 +1,304 @@
+package employee_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-hris/internal/employee"
+	employeeerrors "go-hris/internal/employee/errors"
+	"go-hris/internal/events"
+	"go-hris/internal/messaging/kafka"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func strPtr(v string) *string {
+	return &v
+}
+
+func TestEmployeeService_GetOrgChart(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	head, lead, staff, analyst := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("builds tree from traversal rows", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.EXPECT().FindOrgChart(ctx, companyID, "").Return([]employee.OrgChartEntry{
+			{ID: head, FullName: "Head", PositionName: strPtr("Director"), Depth: 0},
+			{ID: analyst, ManagerID: &head, FullName: "Analyst", Depth: 1},
+			{ID: lead, ManagerID: &head, FullName: "Lead", DepartmentName: strPtr("Engineering"), Depth: 1},
+			{ID: staff, ManagerID: &lead, FullName: "Staff", Depth: 2},
+		}, nil)
+
+		chart, err := deps.service.GetOrgChart(ctx, companyID, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, chart, 1)
+		root := chart[0]
+		assert.Equal(t, "Director", root.PositionName)
+		assert.Equal(t, 3, root.TotalReports)
+		assert.Len(t, root.Reports, 2)
+		assert.Equal(t, "Analyst", root.Reports[0].FullName)
+		assert.Empty(t, root.Reports[0].Reports)
+		assert.Equal(t, "Lead", root.Reports[1].FullName)
+		assert.Equal(t, 1, root.Reports[1].TotalReports)
+		assert.Equal(t, staff.String(), root.Reports[1].Reports[0].ID)
+		assert.Equal(t, lead.String(), root.Reports[1].Reports[0].ManagerID)
+	})
+
+	t.Run("subtree root inside legacy cycle", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		// lead dan staff saling menjadi atasan (data lama sebelum validasi siklus)
+		deps.repo.EXPECT().FindOrgChart(ctx, companyID, lead.String()).Return([]employee.OrgChartEntry{
+			{ID: lead, ManagerID: &staff, FullName: "Lead", Depth: 0},
+			{ID: staff, ManagerID: &lead, FullName: "Staff", Depth: 1},
+		}, nil)
+
+		chart, err := deps.service.GetOrgChart(ctx, companyID, lead.String())
+
+		assert.NoError(t, err)
+		assert.Len(t, chart, 1)
+		assert.Equal(t, 1, chart[0].TotalReports)
+		assert.Empty(t, chart[0].Reports[0].Reports)
+	})
+
+	t.Run("root not found", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.EXPECT().FindOrgChart(ctx, companyID, head.String()).Return(nil, nil)
+
+		_, err := deps.service.GetOrgChart(ctx, companyID, head.String())
+		assert.ErrorIs(t, err, employeeerrors.ErrEmployeeNotFound)
+	})
+
+	t.Run("invalid root id", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.GetOrgChart(ctx, companyID, "not-a-uuid")
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidEmployeeID)
+	})
+}
+
+func TestEmployeeService_IsInReportingLine(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	managerID, employeeID := uuid.New().String(), uuid.New().String()
+
+	deps := setupServiceTest(t)
+	defer deps.db.Close()
+
+	deps.repo.EXPECT().IsInReportingLine(ctx, companyID, managerID, employeeID).Return(true, nil)
+
+	found, err := deps.service.IsInReportingLine(ctx, companyID, managerID, employeeID)
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	// Karyawan bukan atasan dirinya sendiri, tanpa query ke repo
+	found, err = deps.service.IsInReportingLine(ctx, companyID, managerID, managerID)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestEmployeeService_Create_InvalidManager(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	positionID := uuid.New().String()
+	managerID := uuid.New().String()
+	reason := "RESIGNATION"
+
+	deps := setupServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, false)
+	deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+	deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID, positionID).Return(uuid.New().String(), nil)
+	deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID, managerID).
+		Return(&employee.Employee{ID: uuid.MustParse(managerID), TerminationReason: &reason}, nil)
+
+	_, err := deps.service.Create(ctx, companyID, employee.CreateEmployeeRequest{
+		FullName:         "Staff",
+		Email:            "staff@example.com",
+		HireDate:         "2025-01-01",
+		EmploymentStatus: "active",
+		PositionID:       positionID,
+		ManagerID:        managerID,
+	})
+
+	assert.ErrorIs(t, err, employeeerrors.ErrInvalidManager)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestEmployeeService_Update_Manager(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+	managerID := uuid.New()
+	positionID := uuid.New()
+	departmentID := uuid.New()
+
+	existing := func() *employee.Employee {
+		return &employee.Employee{
+			ID:               targetID,
+			CompanyID:        companyID,
+			PositionID:       &positionID,
+			DepartmentID:     &departmentID,
+			FullName:         "Staff",
+			Email:            "staff@example.com",
+			EmployeeNumber:   "EMP-300",
+			HireDate:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EmploymentStatus: employee.EmploymentStatusActive,
+			PTKPStatus:       "TK/0",
+		}
+	}
+	request := func(manager *string) employee.UpdateEmployeeRequest {
+		return employee.UpdateEmployeeRequest{
+			FullName:         "Staff",
+			Email:            "staff@example.com",
+			EmployeeNumber:   "EMP-300",
+			HireDate:         "2025-01-01",
+			EmploymentStatus: "active",
+			PositionID:       positionID.String(),
+			ManagerID:        manager,
+		}
+	}
+	expectLoad := func(deps *serviceDeps, commit bool) {
+		expectTx(t, deps.sqlMock, commit)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID.String(), positionID.String()).Return(departmentID.String(), nil)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(existing(), nil)
+	}
+
+	t.Run("success publishes manager change", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectLoad(deps, true)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), managerID.String()).
+			Return(&employee.Employee{ID: managerID, CompanyID: companyID}, nil)
+		deps.repo.EXPECT().LockHierarchy(ctx, companyID.String()).Return(nil)
+		deps.repo.EXPECT().IsInReportingLine(ctx, companyID.String(), targetID.String(), managerID.String()).Return(false, nil)
+		deps.repo.EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, emp *employee.Employee) error {
+				assert.Equal(t, managerID, *emp.ManagerID)
+				return nil
+			})
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				var envelope events.EmployeeLifecycleEvent
+				assert.NoError(t, json.Unmarshal(event.Payload, &envelope))
+				var data events.EmployeeUpdatedData
+				assert.NoError(t, envelope.DecodeData(&data))
+				assert.Equal(t, []events.EmployeeFieldChange{
+					{Field: "manager_id", To: managerID.String()},
+				}, data.Changes)
+				return nil
+			})
+		deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID.String())).SetVal(1)
+
+		resp, err := deps.service.Update(ctx, companyID.String(), targetID.String(), request(strPtr(managerID.String())))
+
+		assert.NoError(t, err)
+		assert.Equal(t, managerID.String(), resp.ManagerID)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("report as manager is rejected", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectLoad(deps, false)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), managerID.String()).
+			Return(&employee.Employee{ID: managerID, CompanyID: companyID}, nil)
+		deps.repo.EXPECT().LockHierarchy(ctx, companyID.String()).Return(nil)
+		deps.repo.EXPECT().IsInReportingLine(ctx, companyID.String(), targetID.String(), managerID.String()).Return(true, nil)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), request(strPtr(managerID.String())))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrManagerCycle)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("self as manager is rejected", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectLoad(deps, false)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), request(strPtr(targetID.String())))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrManagerCycle)
+	})
+
+	t.Run("manager outside company is rejected", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectLoad(deps, false)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), managerID.String()).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), request(strPtr(managerID.String())))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidManager)
+	})
+
+	t.Run("invalid manager id", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectLoad(deps, false)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), request(strPtr("not-a-uuid")))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidManagerID)
+	})
+}
+
+// Perubahan atasan mengambil advisory lock per company di transaksi update sebelum
+// pengecekan siklus, sehingga update bersilangan A ke B dan B ke A diserialisasi.
+func TestEmployeeRepository_LockHierarchyBeforeCycleCheck(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID, managerID := uuid.New().String(), uuid.New().String()
+
+	db, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(`SELECT pg_advisory_xact_lock\(hashtext\('employee_hierarchy:' \|\| \$1\)\)`).
+		WithArgs(companyID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery(`WITH RECURSIVE chain`).
+		WithArgs(companyID, managerID, employeeID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	sqlMock.ExpectCommit()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	repo := employee.NewRepository(gormDB).WithTx(tx)
+
+	assert.NoError(t, repo.LockHierarchy(ctx, companyID))
+	cycle, err := repo.IsInReportingLine(ctx, companyID, employeeID, managerID)
+	assert.NoError(t, err)
+	assert.False(t, cycle)
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,59 @@
+package employee
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status job import karyawan.
+const (
+	ImportStatusPending    = "PENDING"
+	ImportStatusProcessing = "PROCESSING"
+	ImportStatusCompleted  = "COMPLETED"
+	ImportStatusFailed     = "FAILED"
+)
+
+// EmployeeImport adalah satu job import massal. Baris valid disimpan di ImportRows
+// (JSON []EmployeeImportRow) dan dibuat satu per satu oleh consumer; TotalRows hanya menghitung
+// baris valid dan ProcessedRows menjadi titik lanjut jika job terputus di tengah jalan.
+// Baris yang gagal validasi tidak diantrikan dan dihitung di SkippedRows.
+type EmployeeImport struct {
+	ID            uuid.UUID  `gorm:"column:id;type:uuid;primaryKey"`
+	CompanyID     uuid.UUID  `gorm:"column:company_id;type:uuid;index"`
+	FileName      string     `gorm:"column:file_name"`
+	Status        string     `gorm:"column:status"`
+	TotalRows     int        `gorm:"column:total_rows"`
+	ProcessedRows int        `gorm:"column:processed_rows"`
+	CreatedRows   int        `gorm:"column:created_rows"`
+	FailedRows    int        `gorm:"column:failed_rows"`
+	SkippedRows   int        `gorm:"column:skipped_rows"`
+	ImportRows    string     `gorm:"column:import_rows;type:jsonb"`
+	Results       string     `gorm:"column:results;type:jsonb"` // JSON []EmployeeImportRowResult, hanya baris gagal
+	RequestID     *string    `gorm:"column:request_id"`
+	RequestedBy   *uuid.UUID `gorm:"column:requested_by;type:uuid"`
+	StartedAt     *time.Time `gorm:"column:started_at"`
+	CompletedAt   *time.Time `gorm:"column:completed_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
+
+func (EmployeeImport) TableName() string {
+	return "employee_imports"
+}
+
+// ImportPosition adalah posisi company beserta departemennya, dipakai me-resolve nama
+// posisi di file import ke ID.
+type ImportPosition struct {
+	ID             uuid.UUID `gorm:"column:id"`
+	Name           string    `gorm:"column:name"`
+	DepartmentID   uuid.UUID `gorm:"column:department_id"`
+	DepartmentName string    `gorm:"column:department_name"`
+}
+
+// EmployeeImportRow adalah satu baris file yang lolos validasi, sudah dipetakan ke request
+// Create dengan posisi yang sudah di-resolve.
+type EmployeeImportRow struct {
+	Row     int                   `json:"row"`
+	Request CreateEmployeeRequest `json:"request"`
+}
@@ -0,0 +1,322 @@
+package employee
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	employeeerrors "go-hris/internal/employee/errors"
+)
+
+const (
+	maxImportRows = 2000
+
+	// Batas ukuran satu part XLSX setelah didekompresi, mencegah zip bomb.
+	maxXLSXPartSize = 32 << 20
+)
+
+// Alias judul kolom file import. Judul dibandingkan setelah lower-case dan "_" diganti spasi,
+// sehingga nama field JSON CreateEmployeeRequest (mis. full_name) selalu dikenali.
+var importColumnAliases = map[string][]string{
+	"full_name":           {"full name", "name", "nama", "nama lengkap"},
+	"email":               {"email", "e-mail"},
+	"phone":               {"phone", "phone number", "no hp", "telepon"},
+	"hire_date":           {"hire date", "join date", "tanggal masuk"},
+	"birth_date":          {"birth date", "tanggal lahir"},
+	"employment_status":   {"employment status", "status"},
+	"position":            {"position", "position name", "jabatan"},
+	"department":          {"department", "department name", "departemen"},
+	"ptkp_status":         {"ptkp status", "ptkp"},
+	"bank_code":           {"bank code", "bank"},
+	"bank_account_number": {"bank account number", "bank account no", "account number", "no rekening"},
+	"bank_account_name":   {"bank account name", "account name", "nama rekening"},
+}
+
+var requiredImportColumns = []string{"full_name", "email", "hire_date", "position"}
+
+// parseImportFile membaca file import menjadi baris sel, baris pertama adalah judul kolom.
+// XLSX dikenali dari signature zip, selain itu dibaca sebagai CSV (koma atau titik koma).
+func parseImportFile(content []byte) ([][]string, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		records, err = parseXLSX(content)
+	} else {
+		records, err = parseImportCSV(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, employeeerrors.ErrInvalidImportFile
+	}
+	return records, nil
+}
+
+func parseImportCSV(content []byte) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	if len(bytes.TrimSpace(content)) == 0 {
+		return nil, employeeerrors.ErrInvalidImportFile
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, employeeerrors.ErrInvalidImportFile
+		}
+		// Baris kosong dilewati csv.Reader; isi slot kosong agar nomor baris tetap sesuai file.
+		line, _ := reader.FieldPos(0)
+		if line > maxImportRows+1 {
+			return nil, employeeerrors.ErrImportTooManyRows
+		}
+		for len(records) < line-1 {
+			records = append(records, nil)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				Text string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// parseXLSX membaca sheet pertama workbook. Nomor baris Excel dipertahankan (baris kosong
+// diisi slice kosong) agar laporan validasi menunjuk baris yang sama dengan di Excel.
+func parseXLSX(content []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, employeeerrors.ErrInvalidImportFile
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst xlsxSharedStrings
+		if err := decodeXLSXPart(f, &sst); err != nil {
+			return nil, err
+		}
+		shared = make([]string, 0, len(sst.Items))
+		for _, item := range sst.Items {
+			text := item.Text
+			for _, run := range item.Runs {
+				text += run.Text
+			}
+			shared = append(shared, text)
+		}
+	}
+
+	sheet, ok := files[firstSheetPath(files)]
+	if !ok {
+		return nil, employeeerrors.ErrInvalidImportFile
+	}
+	var ws xlsxWorksheet
+	if err := decodeXLSXPart(sheet, &ws); err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for _, row := range ws.Rows {
+		number := row.Number
+		if number == 0 {
+			number = len(records) + 1
+		}
+		if number > maxImportRows+1 {
+			return nil, employeeerrors.ErrImportTooManyRows
+		}
+		for len(records) < number {
+			records = append(records, nil)
+		}
+
+		var record []string
+		for i, cell := range row.Cells {
+			col := xlsxColumnIndex(cell.Ref)
+			if col < 0 {
+				col = i
+			}
+			for len(record) <= col {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, employeeerrors.ErrInvalidImportFile
+				}
+				record[col] = shared[idx]
+			case "inlineStr":
+				record[col] = cell.Inline.Text
+			case "b":
+				record[col] = strings.ToUpper(strconv.FormatBool(cell.Value == "1"))
+			case "e":
+				record[col] = ""
+			default:
+				record[col] = cell.Value
+			}
+		}
+		records[number-1] = record
+	}
+	return records, nil
+}
+
+// firstSheetPath mencari part sheet pertama lewat workbook.xml dan relasinya, dengan
+// fallback ke lokasi default yang dipakai Excel dan LibreOffice.
+func firstSheetPath(files map[string]*zip.File) string {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var wb xlsxWorkbook
+	var rels xlsxRelationships
+	wbFile, ok := files["xl/workbook.xml"]
+	relFile, relOK := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relOK || decodeXLSXPart(wbFile, &wb) != nil || decodeXLSXPart(relFile, &rels) != nil || len(wb.Sheets) == 0 {
+		return fallback
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return fallback
+}
+
+func decodeXLSXPart(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return employeeerrors.ErrInvalidImportFile
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXPartSize)).Decode(v); err != nil {
+		return employeeerrors.ErrInvalidImportFile
+	}
+	return nil
+}
+
+// xlsxColumnIndex mengubah referensi sel (mis. "AB12") menjadi indeks kolom berbasis nol.
+func xlsxColumnIndex(ref string) int {
+	col := 0
+	n := 0
+	for _, r := range strings.ToUpper(ref) {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		n++
+	}
+	if n == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+// importColumns memetakan judul kolom ke key field import. Kolom yang tidak dikenal diabaikan.
+func importColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, h := range header {
+		h = strings.Join(strings.Fields(strings.ReplaceAll(strings.ToLower(h), "_", " ")), " ")
+		for key, names := range importColumnAliases {
+			if _, found := columns[key]; found {
+				continue
+			}
+			if h == strings.ReplaceAll(key, "_", " ") || containsString(names, h) {
+				columns[key] = i
+			}
+		}
+	}
+	for _, key := range requiredImportColumns {
+		if _, ok := columns[key]; !ok {
+			return nil, employeeerrors.ErrImportMissingColumns
+		}
+	}
+	return columns, nil
+}
+
+func importValue(record []string, columns map[string]int, key string) string {
+	i, ok := columns[key]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseImportDate menerima YYYY-MM-DD atau nomor seri tanggal Excel (sel bertipe tanggal
+// di XLSX disimpan sebagai angka).
+func parseImportDate(v string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, nil
+	}
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil || serial < 1 || serial > 2958465 {
+		return time.Time{}, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+	return time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+}
@@ -0,0 +1,473 @@
+package employee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	employeeerrors "go-hris/internal/employee/errors"
+	"go-hris/internal/events"
+	"go-hris/internal/messaging/kafka"
+	"go-hris/internal/shared/apperror"
+	"go-hris/internal/shared/contextutil"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PreviewImport menjalankan dry run import: file dibaca dan setiap baris divalidasi tanpa
+// menyimpan apa pun.
+func (s *service) PreviewImport(ctx context.Context, companyID, fileName string, content []byte) (EmployeeImportReport, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return EmployeeImportReport{}, employeeerrors.ErrInvalidCompanyID
+	}
+	report, _, err := s.validateImport(ctx, companyID, fileName, content)
+	return report, err
+}
+
+// StartImport memvalidasi file lalu menyimpan baris yang valid sebagai job import dan
+// mengantrikan employee_import_requested lewat outbox. Baris yang gagal validasi dilewati
+// dan tercatat di hasil job; karyawan dibuat async oleh consumer lewat ProcessImport.
+func (s *service) StartImport(ctx context.Context, companyID, actorID, fileName string, content []byte) (EmployeeImportResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return EmployeeImportResponse{}, employeeerrors.ErrInvalidCompanyID
+	}
+
+	report, rows, err := s.validateImport(ctx, companyID, fileName, content)
+	if err != nil {
+		return EmployeeImportResponse{}, err
+	}
+	if len(rows) == 0 {
+		return EmployeeImportResponse{}, employeeerrors.ErrImportNoValidRows
+	}
+
+	skipped := make([]EmployeeImportRowResult, 0, report.InvalidRows)
+	for _, r := range report.Rows {
+		if !r.Valid {
+			skipped = append(skipped, r)
+		}
+	}
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return EmployeeImportResponse{}, err
+	}
+	resultsJSON, err := json.Marshal(skipped)
+	if err != nil {
+		return EmployeeImportResponse{}, err
+	}
+
+	now := time.Now().UTC()
+	job := &EmployeeImport{
+		ID:          uuid.New(),
+		CompanyID:   companyUUID,
+		FileName:    fileName,
+		Status:      ImportStatusPending,
+		TotalRows:   len(rows),
+		SkippedRows: len(skipped),
+		ImportRows:  string(rowsJSON),
+		Results:     string(resultsJSON),
+		RequestedBy: uuidPtr(actorID),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if rid := contextutil.GetRequestID(ctx); rid != "" {
+		job.RequestID = &rid
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("start employee import begin tx failed", zap.Error(err))
+		return EmployeeImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	if err := s.repo.WithTx(tx).CreateImport(ctx, job); err != nil {
+		s.logger.Error("start employee import persist failed", zap.Error(err))
+		return EmployeeImportResponse{}, err
+	}
+
+	if s.outbox != nil {
+		event := events.EmployeeImportRequestedEvent{
+			EventType:   events.EmployeeImportRequestedEventType,
+			ImportID:    job.ID.String(),
+			CompanyID:   companyID,
+			RequestedBy: actorID,
+			OccurredAt:  now,
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return EmployeeImportResponse{}, err
+		}
+		if err := s.outbox.WithTx(tx).Create(ctx, kafka.OutboxEvent{
+			ID:            uuid.NewString(),
+			RequestID:     contextutil.GetRequestID(ctx),
+			AggregateType: "employee_import",
+			AggregateID:   job.ID.String(),
+			EventType:     event.EventType,
+			Topic:         events.EmployeeImportRequestedTopic,
+			Payload:       payload,
+			Status:        kafka.OutboxStatusPending,
+		}); err != nil {
+			s.logger.Error("start employee import outbox persist failed", zap.Error(err))
+			return EmployeeImportResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("start employee import commit failed", zap.Error(err))
+		return EmployeeImportResponse{}, err
+	}
+
+	s.logger.Info("employee import queued",
+		zap.String("import_id", job.ID.String()),
+		zap.String("company_id", companyID),
+		zap.Int("rows", job.TotalRows),
+		zap.Int("skipped_rows", job.SkippedRows),
+	)
+
+	return mapToImportResponse(*job), nil
+}
+
+func (s *service) GetImport(ctx context.Context, companyID, id string) (EmployeeImportResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return EmployeeImportResponse{}, employeeerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return EmployeeImportResponse{}, employeeerrors.ErrEmployeeImportNotFound
+	}
+
+	job, err := s.repo.FindImportByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		return EmployeeImportResponse{}, mapImportError(err)
+	}
+	return mapToImportResponse(*job), nil
+}
+
+// ProcessImport membuat karyawan dari job import satu per satu lewat Create, sehingga nomor
+// karyawan diambil dari counter dan setiap karyawan mengantrikan employee_created seperti
+// biasa. Progress disimpan setiap baris; event yang dikirim ulang melanjutkan dari
+// ProcessedRows dan job yang sudah selesai diabaikan.
+func (s *service) ProcessImport(ctx context.Context, companyID, id string) error {
+	job, err := s.repo.FindImportByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		return mapImportError(err)
+	}
+	if job.Status == ImportStatusCompleted || job.Status == ImportStatusFailed {
+		return nil
+	}
+
+	var rows []EmployeeImportRow
+	var results []EmployeeImportRowResult
+	if err := json.Unmarshal([]byte(job.ImportRows), &rows); err != nil {
+		s.logger.Error("decode employee import rows failed", zap.String("import_id", id), zap.Error(err))
+		return s.finishImport(ctx, job, ImportStatusFailed)
+	}
+	if job.Results != "" {
+		if err := json.Unmarshal([]byte(job.Results), &results); err != nil {
+			return err
+		}
+	}
+
+	if job.Status == ImportStatusPending {
+		now := time.Now().UTC()
+		job.Status = ImportStatusProcessing
+		job.StartedAt = &now
+		job.UpdatedAt = now
+		if err := s.repo.UpdateImport(ctx, job); err != nil {
+			return err
+		}
+	}
+	if job.RequestID != nil {
+		ctx = contextutil.WithRequestID(ctx, *job.RequestID)
+	}
+
+	for i := job.ProcessedRows; i < len(rows); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		row := rows[i]
+		if _, err := s.Create(ctx, companyID, row.Request); err != nil {
+			s.logger.Warn("employee import row failed",
+				zap.String("import_id", id),
+				zap.Int("row", row.Row),
+				zap.Error(err),
+			)
+			job.FailedRows++
+			results = append(results, EmployeeImportRowResult{
+				Row:      row.Row,
+				FullName: row.Request.FullName,
+				Email:    row.Request.Email,
+				Errors:   []string{importErrorMessage(err)},
+			})
+			resultsJSON, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+			job.Results = string(resultsJSON)
+		} else {
+			job.CreatedRows++
+		}
+
+		job.ProcessedRows = i + 1
+		job.UpdatedAt = time.Now().UTC()
+		if err := s.repo.UpdateImport(ctx, job); err != nil {
+			s.logger.Error("update employee import progress failed", zap.String("import_id", id), zap.Error(err))
+			return err
+		}
+	}
+
+	return s.finishImport(ctx, job, ImportStatusCompleted)
+}
+
+func (s *service) finishImport(ctx context.Context, job *EmployeeImport, status string) error {
+	now := time.Now().UTC()
+	job.Status = status
+	job.CompletedAt = &now
+	job.UpdatedAt = now
+	if err := s.repo.UpdateImport(ctx, job); err != nil {
+		return err
+	}
+
+	s.logger.Info("employee import finished",
+		zap.String("import_id", job.ID.String()),
+		zap.String("status", status),
+		zap.Int("created_rows", job.CreatedRows),
+		zap.Int("failed_rows", job.FailedRows),
+	)
+	return nil
+}
+
+// validateImport membaca file, me-resolve posisi dan memvalidasi setiap baris. Baris yang
+// valid dikembalikan sudah dalam bentuk CreateEmployeeRequest.
+func (s *service) validateImport(ctx context.Context, companyID, fileName string, content []byte) (EmployeeImportReport, []EmployeeImportRow, error) {
+	records, err := parseImportFile(content)
+	if err != nil {
+		return EmployeeImportReport{}, nil, err
+	}
+	columns, err := importColumns(records[0])
+	if err != nil {
+		return EmployeeImportReport{}, nil, err
+	}
+
+	type dataRow struct {
+		number int
+		record []string
+	}
+	var data []dataRow
+	emails := make([]string, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		data = append(data, dataRow{number: i + 2, record: record})
+		if email := strings.ToLower(importValue(record, columns, "email")); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(data) == 0 {
+		return EmployeeImportReport{}, nil, employeeerrors.ErrInvalidImportFile
+	}
+
+	positions, err := s.repo.FindImportPositions(ctx, companyID)
+	if err != nil {
+		s.logger.Error("employee import load positions failed", zap.Error(err))
+		return EmployeeImportReport{}, nil, err
+	}
+	existing, err := s.repo.FindExistingEmails(ctx, companyID, emails)
+	if err != nil {
+		s.logger.Error("employee import load existing emails failed", zap.Error(err))
+		return EmployeeImportReport{}, nil, err
+	}
+	existingEmails := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		existingEmails[strings.ToLower(e)] = true
+	}
+
+	report := EmployeeImportReport{
+		FileName:  fileName,
+		TotalRows: len(data),
+		Rows:      make([]EmployeeImportRowResult, 0, len(data)),
+	}
+	var rows []EmployeeImportRow
+	firstRowByEmail := make(map[string]int, len(data))
+	for _, d := range data {
+		req, errs := buildImportRequest(d.record, columns, positions)
+
+		email := strings.ToLower(req.Email)
+		if email != "" {
+			if first, ok := firstRowByEmail[email]; ok {
+				errs = append(errs, fmt.Sprintf("duplicate email, already used on row %d", first))
+			} else {
+				firstRowByEmail[email] = d.number
+				if existingEmails[email] {
+					errs = append(errs, "email already used by an existing employee")
+				}
+			}
+		}
+
+		result := EmployeeImportRowResult{
+			Row:      d.number,
+			FullName: req.FullName,
+			Email:    req.Email,
+			Position: importValue(d.record, columns, "position"),
+			Valid:    len(errs) == 0,
+			Errors:   errs,
+		}
+		report.Rows = append(report.Rows, result)
+		if result.Valid {
+			report.ValidRows++
+			rows = append(rows, EmployeeImportRow{Row: d.number, Request: req})
+		} else {
+			report.InvalidRows++
+		}
+	}
+
+	return report, rows, nil
+}
+
+// buildImportRequest memetakan satu baris file ke CreateEmployeeRequest dan mengumpulkan
+// seluruh pesan validasinya. employee_number tidak diambil dari file; Create mengisinya
+// dari counter.
+func buildImportRequest(record []string, columns map[string]int, positions []ImportPosition) (CreateEmployeeRequest, []string) {
+	var errs []string
+	req := CreateEmployeeRequest{
+		FullName:        importValue(record, columns, "full_name"),
+		Email:           importValue(record, columns, "email"),
+		Phone:           importValue(record, columns, "phone"),
+		BankCode:        importValue(record, columns, "bank_code"),
+		BankAccountNo:   importValue(record, columns, "bank_account_number"),
+		BankAccountName: importValue(record, columns, "bank_account_name"),
+	}
+
+	if req.FullName == "" {
+		errs = append(errs, "full_name is required")
+	}
+	if req.Email == "" {
+		errs = append(errs, "email is required")
+	} else if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email {
+		errs = append(errs, "invalid email")
+	}
+
+	hireDate := importValue(record, columns, "hire_date")
+	if hireDate == "" {
+		errs = append(errs, "hire_date is required")
+	} else if t, err := parseImportDate(hireDate); err != nil {
+		errs = append(errs, "invalid hire_date format, expected YYYY-MM-DD")
+	} else {
+		req.HireDate = t.Format("2006-01-02")
+	}
+	if birthDate := importValue(record, columns, "birth_date"); birthDate != "" {
+		if t, err := parseImportDate(birthDate); err != nil {
+			errs = append(errs, "invalid birth_date format, expected YYYY-MM-DD")
+		} else {
+			req.BirthDate = t.Format("2006-01-02")
+		}
+	}
+
+	if status, err := normalizeEmploymentStatus(importValue(record, columns, "employment_status")); err != nil {
+		errs = append(errs, importErrorMessage(err))
+	} else {
+		req.EmploymentStatus = status
+	}
+	if ptkp, err := normalizePTKPStatus(importValue(record, columns, "ptkp_status"), DefaultPTKPStatus); err != nil {
+		errs = append(errs, importErrorMessage(err))
+	} else {
+		req.PTKPStatus = ptkp
+	}
+	if err := applyBankAccount(&Employee{FullName: req.FullName}, req.BankCode, req.BankAccountNo, req.BankAccountName); err != nil {
+		errs = append(errs, importErrorMessage(err))
+	}
+
+	positionID, err := resolveImportPosition(
+		positions,
+		importValue(record, columns, "position"),
+		importValue(record, columns, "department"),
+	)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		req.PositionID = positionID
+	}
+
+	return req, errs
+}
+
+// resolveImportPosition mencari posisi berdasarkan nama (tidak case-sensitive). Nama posisi
+// yang sama bisa ada di beberapa departemen, sehingga kolom department wajib diisi jika ambigu.
+func resolveImportPosition(positions []ImportPosition, name, department string) (string, error) {
+	if name == "" {
+		return "", errors.New("position is required")
+	}
+
+	var matches []ImportPosition
+	for _, p := range positions {
+		if !strings.EqualFold(strings.TrimSpace(p.Name), name) {
+			continue
+		}
+		if department != "" && !strings.EqualFold(strings.TrimSpace(p.DepartmentName), department) {
+			continue
+		}
+		matches = append(matches, p)
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0].ID.String(), nil
+	case len(matches) > 1:
+		return "", fmt.Errorf("position %q exists in several departments, fill the department column", name)
+	case department != "":
+		return "", fmt.Errorf("unknown position %q in department %q", name, department)
+	default:
+		return "", fmt.Errorf("unknown position %q", name)
+	}
+}
+
+func importErrorMessage(err error) string {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+func mapImportError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return employeeerrors.ErrEmployeeImportNotFound
+	}
+	return err
+}
+
+func mapToImportResponse(job EmployeeImport) EmployeeImportResponse {
+	resp := EmployeeImportResponse{
+		ID:            job.ID.String(),
+		FileName:      job.FileName,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		CreatedRows:   job.CreatedRows,
+		FailedRows:    job.FailedRows,
+		SkippedRows:   job.SkippedRows,
+		RequestedBy:   uuidToString(job.RequestedBy),
+		CreatedAt:     job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.TotalRows > 0 {
+		resp.ProgressPercent = job.ProcessedRows * 100 / job.TotalRows
+	}
+	if job.Results != "" {
+		_ = json.Unmarshal([]byte(job.Results), &resp.Failures)
+	}
+	if job.StartedAt != nil {
+		resp.StartedAt = job.StartedAt.Format(time.RFC3339)
+	}
+	if job.CompletedAt != nil {
+		resp.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+	return resp
+}
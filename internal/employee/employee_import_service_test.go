@@ -0,0 +1,343 @@
+package employee_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go-hris/internal/employee"
+	employeeerrors "go-hris/internal/employee/errors"
+	"go-hris/internal/events"
+	"go-hris/internal/messaging/kafka"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func importPositions() (staff, sales, ops employee.ImportPosition) {
+	staff = employee.ImportPosition{ID: uuid.New(), Name: "Staff", DepartmentID: uuid.New(), DepartmentName: "Finance"}
+	sales = employee.ImportPosition{ID: uuid.New(), Name: "Supervisor", DepartmentID: uuid.New(), DepartmentName: "Sales"}
+	ops = employee.ImportPosition{ID: uuid.New(), Name: "Supervisor", DepartmentID: uuid.New(), DepartmentName: "Operations"}
+	return staff, sales, ops
+}
+
+func TestEmployeeService_PreviewImport(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	staff, sales, ops := importPositions()
+
+	t.Run("row by row validation report", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		csv := strings.Join([]string{
+			"Full Name,Email,Hire Date,Position,Department,PTKP Status",
+			"Budi,budi@example.com,2026-01-05,staff,,K/1",
+			"Sari,BUDI@example.com,2026-01-06,Staff,,",
+			"Andi,andi@example.com,05-01-2026,Staff,,",
+			"Rina,rina@example.com,2026-01-05,Manager,,",
+			"",
+			"Dewi,dewi@example.com,2026-01-05,Supervisor,,",
+			"Tono,tono@example.com,2026-01-05,Supervisor,sales,",
+			"Lama,lama@example.com,2026-01-05,Staff,,",
+		}, "\n")
+
+		deps.repo.EXPECT().FindImportPositions(ctx, companyID).Return([]employee.ImportPosition{staff, sales, ops}, nil)
+		deps.repo.EXPECT().
+			FindExistingEmails(ctx, companyID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, cid string, emails []string) ([]string, error) {
+				assert.Contains(t, emails, "budi@example.com")
+				return []string{"lama@example.com"}, nil
+			})
+
+		report, err := deps.service.PreviewImport(ctx, companyID, "karyawan.csv", []byte(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, report.TotalRows)
+		assert.Equal(t, 2, report.ValidRows)
+		assert.Equal(t, 5, report.InvalidRows)
+		if assert.Len(t, report.Rows, 7) {
+			assert.True(t, report.Rows[0].Valid)
+			assert.Equal(t, 2, report.Rows[0].Row)
+			assert.Equal(t, []string{"duplicate email, already used on row 2"}, report.Rows[1].Errors)
+			assert.Equal(t, []string{"invalid hire_date format, expected YYYY-MM-DD"}, report.Rows[2].Errors)
+			assert.Equal(t, []string{`unknown position "Manager"`}, report.Rows[3].Errors)
+			// Baris kosong dilewati tapi nomor baris tetap sesuai file.
+			assert.Equal(t, 7, report.Rows[4].Row)
+			assert.Contains(t, report.Rows[4].Errors[0], "several departments")
+			assert.True(t, report.Rows[5].Valid)
+			assert.Equal(t, []string{"email already used by an existing employee"}, report.Rows[6].Errors)
+		}
+	})
+
+	t.Run("missing required columns", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.PreviewImport(ctx, companyID, "karyawan.csv", []byte("full_name;email\nBudi;budi@example.com\n"))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrImportMissingColumns)
+	})
+
+	t.Run("xlsx with shared strings and date serial", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.EXPECT().FindImportPositions(ctx, companyID).Return([]employee.ImportPosition{staff}, nil)
+		deps.repo.EXPECT().FindExistingEmails(ctx, companyID, []string{"budi@example.com"}).Return(nil, nil)
+
+		report, err := deps.service.PreviewImport(ctx, companyID, "karyawan.xlsx", buildTestXLSX(t))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, report.ValidRows)
+		if assert.Len(t, report.Rows, 1) {
+			assert.Equal(t, 3, report.Rows[0].Row)
+			assert.Equal(t, "Budi Santoso", report.Rows[0].FullName)
+			assert.Equal(t, "Staff", report.Rows[0].Position)
+		}
+	})
+}
+
+func TestEmployeeService_StartImport(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	staff, _, _ := importPositions()
+
+	t.Run("valid rows queued, invalid rows skipped", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		csv := "full_name,email,hire_date,position\nBudi,budi@example.com,2026-01-05,Staff\nSari,,2026-01-05,Staff\n"
+		deps.repo.EXPECT().FindImportPositions(ctx, companyID).Return([]employee.ImportPosition{staff}, nil)
+		deps.repo.EXPECT().FindExistingEmails(ctx, companyID, gomock.Any()).Return(nil, nil)
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().
+			CreateImport(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, job *employee.EmployeeImport) error {
+				assert.Equal(t, employee.ImportStatusPending, job.Status)
+				assert.Equal(t, 1, job.TotalRows)
+				assert.Equal(t, 1, job.SkippedRows)
+				var rows []employee.EmployeeImportRow
+				assert.NoError(t, json.Unmarshal([]byte(job.ImportRows), &rows))
+				if assert.Len(t, rows, 1) {
+					assert.Equal(t, staff.ID.String(), rows[0].Request.PositionID)
+					assert.Equal(t, employee.EmploymentStatusActive, rows[0].Request.EmploymentStatus)
+					assert.Empty(t, rows[0].Request.EmployeeNumber)
+				}
+				return nil
+			})
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				assert.Equal(t, events.EmployeeImportRequestedTopic, event.Topic)
+				assert.Equal(t, events.EmployeeImportRequestedEventType, event.EventType)
+				return nil
+			})
+
+		resp, err := deps.service.StartImport(ctx, companyID, actorID, "karyawan.csv", []byte(csv))
+
+		assert.NoError(t, err)
+		assert.Equal(t, employee.ImportStatusPending, resp.Status)
+		assert.Equal(t, actorID, resp.RequestedBy)
+		if assert.Len(t, resp.Failures, 1) {
+			assert.Equal(t, 3, resp.Failures[0].Row)
+			assert.Equal(t, []string{"email is required"}, resp.Failures[0].Errors)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("no valid rows", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.EXPECT().FindImportPositions(ctx, companyID).Return(nil, nil)
+		deps.repo.EXPECT().FindExistingEmails(ctx, companyID, gomock.Any()).Return(nil, nil)
+
+		_, err := deps.service.StartImport(ctx, companyID, actorID, "karyawan.csv",
+			[]byte("full_name,email,hire_date,position\nBudi,budi@example.com,2026-01-05,Staff\n"))
+
+		assert.ErrorIs(t, err, employeeerrors.ErrImportNoValidRows)
+	})
+}
+
+func TestEmployeeService_GetImport(t *testing.T) {
+	deps := setupServiceTest(t)
+	defer deps.db.Close()
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	t.Run("malformed id is not found", func(t *testing.T) {
+		_, err := deps.service.GetImport(ctx, companyID, "not-a-uuid")
+		assert.ErrorIs(t, err, employeeerrors.ErrEmployeeImportNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		importID := uuid.New()
+		deps.repo.EXPECT().
+			FindImportByIDAndCompany(ctx, companyID, importID.String()).
+			Return(&employee.EmployeeImport{ID: importID, CompanyID: uuid.MustParse(companyID), Status: employee.ImportStatusPending, ImportRows: "[]", Results: "[]"}, nil)
+
+		resp, err := deps.service.GetImport(ctx, companyID, importID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, importID.String(), resp.ID)
+	})
+}
+
+func TestEmployeeService_ProcessImport(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	importID := uuid.New()
+	positionID := uuid.New().String()
+	departmentID := uuid.New().String()
+
+	newJob := func(t *testing.T, processed int) *employee.EmployeeImport {
+		rows, err := json.Marshal([]employee.EmployeeImportRow{
+			{Row: 2, Request: employee.CreateEmployeeRequest{FullName: "Budi", Email: "budi@example.com", HireDate: "2026-01-05", EmploymentStatus: "active", PositionID: positionID}},
+			{Row: 3, Request: employee.CreateEmployeeRequest{FullName: "Sari", Email: "sari@example.com", HireDate: "2026-01-05", EmploymentStatus: "active", PositionID: positionID}},
+		})
+		assert.NoError(t, err)
+		status := employee.ImportStatusPending
+		if processed > 0 {
+			status = employee.ImportStatusProcessing
+		}
+		return &employee.EmployeeImport{
+			ID:            importID,
+			CompanyID:     uuid.MustParse(companyID),
+			Status:        status,
+			TotalRows:     2,
+			ProcessedRows: processed,
+			ImportRows:    string(rows),
+			Results:       "[]",
+		}
+	}
+
+	t.Run("creates employees through create flow and tracks progress", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		job := newJob(t, 0)
+		deps.repo.EXPECT().FindImportByIDAndCompany(ctx, companyID, importID.String()).Return(job, nil)
+
+		var progress []int
+		deps.repo.EXPECT().
+			UpdateImport(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, j *employee.EmployeeImport) error {
+				progress = append(progress, j.ProcessedRows)
+				return nil
+			}).
+			Times(4)
+
+		// Baris pertama berhasil dengan nomor dari counter dan event employee_created.
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo).Times(2)
+		deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID, positionID).Return(departmentID, nil).Times(2)
+		deps.counter.EXPECT().GetNextValue(ctx, companyID, "employee_number").Return(int64(7), nil)
+		deps.counter.EXPECT().GetNextValue(ctx, companyID, "employee_number").Return(int64(8), nil)
+		gomock.InOrder(
+			deps.repo.EXPECT().
+				Create(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, e *employee.Employee) error {
+					assert.Equal(t, "EMP-000007", e.EmployeeNumber)
+					return nil
+				}),
+			deps.repo.EXPECT().
+				Create(ctx, gomock.Any()).
+				Return(&pgconn.PgError{Code: "23505", ConstraintName: "uq_employee_email"}),
+		)
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				assert.Equal(t, events.EmployeeCreatedEventType, event.EventType)
+				return nil
+			})
+		deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID)).SetVal(1)
+
+		// Baris kedua gagal karena email dipakai sejak dry run.
+		expectTx(t, deps.sqlMock, false)
+
+		err := deps.service.ProcessImport(ctx, companyID, importID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 2}, progress)
+		assert.Equal(t, employee.ImportStatusCompleted, job.Status)
+		assert.Equal(t, 1, job.CreatedRows)
+		assert.Equal(t, 1, job.FailedRows)
+		assert.NotNil(t, job.CompletedAt)
+
+		assert.Contains(t, job.Results, "Employee with the same email already exists")
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("redelivered event resumes from processed rows", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		job := newJob(t, 2)
+		deps.repo.EXPECT().FindImportByIDAndCompany(ctx, companyID, importID.String()).Return(job, nil)
+		deps.repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+		deps.repo.EXPECT().UpdateImport(ctx, gomock.Any()).Return(nil).Times(1)
+
+		err := deps.service.ProcessImport(ctx, companyID, importID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, employee.ImportStatusCompleted, job.Status)
+	})
+
+	t.Run("completed job is ignored", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		job := newJob(t, 2)
+		job.Status = employee.ImportStatusCompleted
+		deps.repo.EXPECT().FindImportByIDAndCompany(ctx, companyID, importID.String()).Return(job, nil)
+		deps.repo.EXPECT().UpdateImport(gomock.Any(), gomock.Any()).Times(0)
+
+		assert.NoError(t, deps.service.ProcessImport(ctx, companyID, importID.String()))
+	})
+}
+
+// buildTestXLSX membuat workbook minimal: baris 1 judul kolom, baris 2 kosong, baris 3 data
+// dengan hire_date sebagai nomor seri tanggal Excel (46027 = 2026-01-05).
+func buildTestXLSX(t *testing.T) []byte {
+	t.Helper()
+
+	parts := map[string]string{
+		"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Karyawan" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/data.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>Nama Lengkap</t></si><si><t>Email</t></si><si><t>Tanggal Masuk</t></si><si><t>Jabatan</t></si>
+<si><r><t>Budi </t></r><r><t>Santoso</t></r></si><si><t>budi@example.com</t></si></sst>`,
+		"xl/worksheets/data.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" t="s"><v>3</v></c></row>
+<row r="3"><c r="A3" t="s"><v>4</v></c><c r="B3" t="s"><v>5</v></c><c r="C3"><v>46027</v></c><c r="D3" t="inlineStr"><is><t>Staff</t></is></c></row>
+</sheetData></worksheet>`,
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
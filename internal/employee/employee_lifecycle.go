@@ -0,0 +1,102 @@
+package employee
+
+import (
+	"time"
+
+	"go-hris/internal/events"
+
+	"github.com/google/uuid"
+)
+
+// buildUpdateEvents membandingkan data karyawan sebelum dan sesudah Update. Perubahan
+// data umum dikirim sebagai employee_updated, sedangkan mutasi posisi/departemen dan
+// perubahan status kepegawaian dikirim sebagai event tersendiri.
+func buildUpdateEvents(requestID, companyID string, before, after Employee) ([]events.EmployeeLifecycleEvent, error) {
+	employeeID := after.ID.String()
+	var out []events.EmployeeLifecycleEvent
+
+	if changes := diffEmployeeFields(before, after); len(changes) > 0 {
+		event, err := events.NewEmployeeLifecycleEvent(
+			events.EmployeeUpdatedEventType, requestID, companyID, employeeID,
+			events.EmployeeUpdatedData{Changes: changes},
+		)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, event)
+	}
+
+	if !sameUUID(before.PositionID, after.PositionID) || !sameUUID(before.DepartmentID, after.DepartmentID) {
+		event, err := events.NewEmployeeLifecycleEvent(
+			events.EmployeeTransferredEventType, requestID, companyID, employeeID,
+			events.EmployeeTransferredData{
+				FromPositionID:   uuidToString(before.PositionID),
+				ToPositionID:     uuidToString(after.PositionID),
+				FromDepartmentID: uuidToString(before.DepartmentID),
+				ToDepartmentID:   uuidToString(after.DepartmentID),
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, event)
+	}
+
+	if before.EmploymentStatus != after.EmploymentStatus {
+		event, err := events.NewEmployeeLifecycleEvent(
+			events.EmployeeStatusChangedEventType, requestID, companyID, employeeID,
+			events.EmployeeStatusChangedData{
+				FromStatus: before.EmploymentStatus,
+				ToStatus:   after.EmploymentStatus,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, event)
+	}
+
+	return out, nil
+}
+
+// diffEmployeeFields mengembalikan field yang berubah selain posisi, departemen dan status
+// kepegawaian. Nomor rekening tidak pernah dikirim nilainya.
+func diffEmployeeFields(before, after Employee) []events.EmployeeFieldChange {
+	var changes []events.EmployeeFieldChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, events.EmployeeFieldChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("full_name", before.FullName, after.FullName)
+	add("email", before.Email, after.Email)
+	add("employee_number", before.EmployeeNumber, after.EmployeeNumber)
+	add("phone", before.Phone, after.Phone)
+	add("hire_date", formatDate(&before.HireDate), formatDate(&after.HireDate))
+	add("birth_date", formatDate(before.BirthDate), formatDate(after.BirthDate))
+	add("termination_date", formatDate(before.TerminationDate), formatDate(after.TerminationDate))
+	add("manager_id", uuidToString(before.ManagerID), uuidToString(after.ManagerID))
+	add("ptkp_status", before.PTKPStatus, after.PTKPStatus)
+	add("bank_code", stringValue(before.BankCode), stringValue(after.BankCode))
+	if stringValue(before.BankAccountNo) != stringValue(after.BankAccountNo) {
+		changes = append(changes, events.EmployeeFieldChange{Field: "bank_account_number", Redacted: true})
+	}
+	add("bank_account_name", stringValue(before.BankAccountName), stringValue(after.BankAccountName))
+
+	return changes
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func formatDate(v *time.Time) string {
+	if v == nil || v.IsZero() {
+		return ""
+	}
+	return v.Format("2006-01-02")
+}
@@ -3,6 +3,7 @@ package employee
 import (
 	"context"
 	"database/sql"
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/tenant"
 	"time"
 
@@ -13,12 +14,25 @@ import (
 type Repository interface {
 	WithTx(tx *sql.Tx) Repository
 	Create(ctx context.Context, emp *Employee) error
-	FindAllByCompany(ctx context.Context, companyID string) ([]Employee, error)
+	FindPageByCompany(ctx context.Context, companyID string, filter EmployeeQueryFilter) ([]Employee, int64, error)
 	FindOptionsByCompany(ctx context.Context, companyID string) ([]Employee, error)
 	FindByIDAndCompany(ctx context.Context, companyID string, id string) (*Employee, error)
 	GetDepartmentIDByPosition(ctx context.Context, companyID, positionID string) (string, error)
 	Update(ctx context.Context, emp *Employee) error
 	Delete(ctx context.Context, companyID string, id string) error
+	DeactivateUser(ctx context.Context, companyID string, employeeID string) error
+	ActivateUser(ctx context.Context, companyID string, employeeID string) error
+	CreateEmploymentStint(ctx context.Context, stint *EmploymentStint) error
+	DeactivateTerminatedUsers(ctx context.Context, asOf time.Time) (int64, error)
+	FindImportPositions(ctx context.Context, companyID string) ([]ImportPosition, error)
+	FindExistingEmails(ctx context.Context, companyID string, emails []string) ([]string, error)
+	CreateImport(ctx context.Context, job *EmployeeImport) error
+	FindImportByIDAndCompany(ctx context.Context, companyID string, id string) (*EmployeeImport, error)
+	UpdateImport(ctx context.Context, job *EmployeeImport) error
+	FindOrgChart(ctx context.Context, companyID string, rootID string) ([]OrgChartEntry, error)
+	FindReportIDs(ctx context.Context, companyID string, managerID string) ([]string, error)
+	IsInReportingLine(ctx context.Context, companyID string, managerID string, employeeID string) (bool, error)
+	LockHierarchy(ctx context.Context, companyID string) error
 }
 
 type repository struct {
@@ -45,15 +59,22 @@ INSERT INTO employees (
 	company_id,
 	department_id,
 	position_id,
+	manager_id,
 	employee_number,
 	full_name,
 	email,
 	phone,
 	hire_date,
+	birth_date,
+	termination_date,
 	employment_status,
+	ptkp_status,
+	bank_code,
+	bank_account_number,
+	bank_account_name,
 	created_at,
 	updated_at
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 `
 		now := time.Now().UTC()
 		if emp.CreatedAt.IsZero() {
@@ -67,12 +88,19 @@ INSERT INTO employees (
 			emp.CompanyID,
 			emp.DepartmentID,
 			emp.PositionID,
+			emp.ManagerID,
 			emp.EmployeeNumber,
 			emp.FullName,
 			emp.Email,
 			emp.Phone,
 			emp.HireDate,
+			emp.BirthDate,
+			emp.TerminationDate,
 			emp.EmploymentStatus,
+			emp.PTKPStatus,
+			emp.BankCode,
+			emp.BankAccountNo,
+			emp.BankAccountName,
 			emp.CreatedAt,
 			emp.UpdatedAt,
 		)
@@ -81,12 +109,56 @@ INSERT INTO employees (
 	return r.db.WithContext(ctx).Create(emp).Error
 }
 
-func (r *repository) FindAllByCompany(ctx context.Context, companyID string) ([]Employee, error) {
+var employeeListSort = pagination.Sort{
+	Columns: map[string]string{
+		"name":            "employees.full_name",
+		"email":           "employees.email",
+		"employee_number": "employees.employee_number",
+		"hire_date":       "employees.hire_date",
+		"created_at":      "employees.created_at",
+		"id":              "employees.id",
+	},
+	Default:    "name",
+	DefaultDir: "asc",
+	TieBreaker: "employees.id",
+}
+
+// FindPageByCompany mengembalikan satu halaman karyawan sesuai filter beserta total seluruh
+// data yang cocok.
+func (r *repository) FindPageByCompany(ctx context.Context, companyID string, filter EmployeeQueryFilter) ([]Employee, int64, error) {
+	db := r.db.WithContext(ctx).
+		Model(&Employee{}).
+		Where("employees.company_id = ?", companyID).
+		Scopes(pagination.Search(filter.Page.Search, "employees.full_name", "employees.email", "employees.employee_number"))
+
+	if filter.DepartmentID != nil {
+		db = db.Where("employees.department_id = ?", *filter.DepartmentID)
+	}
+	if filter.PositionID != nil {
+		db = db.Where("employees.position_id = ?", *filter.PositionID)
+	}
+	if filter.ManagerID != nil {
+		db = db.Where("employees.manager_id = ?", *filter.ManagerID)
+	}
+	if filter.Status != nil {
+		db = db.Where("employees.employment_status = ?", *filter.Status)
+	}
+	if filter.HireDateFrom != nil {
+		db = db.Where("employees.hire_date >= ?", *filter.HireDateFrom)
+	}
+	if filter.HireDateTo != nil {
+		db = db.Where("employees.hire_date <= ?", *filter.HireDateTo)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var emps []Employee
-	err := r.db.WithContext(ctx).
-		Scopes(tenant.Scope(companyID)).
-		Find(&emps).Error
-	return emps, err
+	err := db.Scopes(pagination.Paginate(filter.Page)).Find(&emps).Error
+	return emps, total, err
 }
 
 func (r *repository) FindOptionsByCompany(ctx context.Context, companyID string) ([]Employee, error) {
@@ -139,6 +211,64 @@ LIMIT 1
 }
 
 func (r *repository) Update(ctx context.Context, emp *Employee) error {
+	if r.tx != nil {
+		query := `
+UPDATE employees SET
+	department_id = $1,
+	position_id = $2,
+	manager_id = $3,
+	employee_number = $4,
+	full_name = $5,
+	email = $6,
+	phone = $7,
+	hire_date = $8,
+	birth_date = $9,
+	termination_date = $10,
+	employment_status = $11,
+	ptkp_status = $12,
+	bank_code = $13,
+	bank_account_number = $14,
+	bank_account_name = $15,
+	termination_reason = $16,
+	termination_note = $17,
+	rehire_eligible = $18,
+	terminated_by = $19,
+	terminated_at = $20,
+	updated_at = $21
+WHERE id = $22
+  AND company_id = $23
+  AND deleted_at IS NULL
+`
+		emp.UpdatedAt = time.Now().UTC()
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			emp.DepartmentID,
+			emp.PositionID,
+			emp.ManagerID,
+			emp.EmployeeNumber,
+			emp.FullName,
+			emp.Email,
+			emp.Phone,
+			emp.HireDate,
+			emp.BirthDate,
+			emp.TerminationDate,
+			emp.EmploymentStatus,
+			emp.PTKPStatus,
+			emp.BankCode,
+			emp.BankAccountNo,
+			emp.BankAccountName,
+			emp.TerminationReason,
+			emp.TerminationNote,
+			emp.RehireEligible,
+			emp.TerminatedBy,
+			emp.TerminatedAt,
+			emp.UpdatedAt,
+			emp.ID,
+			emp.CompanyID,
+		)
+		return err
+	}
 	return r.db.WithContext(ctx).Save(emp).Error
 }
 
@@ -147,3 +277,318 @@ func (r *repository) Delete(ctx context.Context, companyID string, id string) er
 		Scopes(tenant.Scope(companyID)).
 		Delete(&Employee{}, "id = ?", id).Error
 }
+
+// DeactivateUser menonaktifkan akun login yang terhubung ke karyawan.
+func (r *repository) DeactivateUser(ctx context.Context, companyID string, employeeID string) error {
+	if r.tx != nil {
+		return r.setUserActiveTx(ctx, companyID, employeeID, false)
+	}
+	return r.db.WithContext(ctx).
+		Table("users").
+		Scopes(tenant.Scope(companyID)).
+		Where("employee_id = ?", employeeID).
+		Where("is_active = ?", true).
+		Updates(map[string]interface{}{
+			"is_active":  false,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// ActivateUser mengaktifkan kembali akun login karyawan, dipakai saat rehire.
+func (r *repository) ActivateUser(ctx context.Context, companyID string, employeeID string) error {
+	if r.tx != nil {
+		return r.setUserActiveTx(ctx, companyID, employeeID, true)
+	}
+	return r.db.WithContext(ctx).
+		Table("users").
+		Scopes(tenant.Scope(companyID)).
+		Where("employee_id = ?", employeeID).
+		Where("is_active = ?", false).
+		Updates(map[string]interface{}{
+			"is_active":  true,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+// CreateEmploymentStint menyimpan masa kerja sebelumnya sebelum data employees ditimpa rehire.
+func (r *repository) CreateEmploymentStint(ctx context.Context, stint *EmploymentStint) error {
+	if stint.CreatedAt.IsZero() {
+		stint.CreatedAt = time.Now().UTC()
+	}
+	if r.tx != nil {
+		query := `
+INSERT INTO employee_employment_stints (
+	id,
+	company_id,
+	employee_id,
+	hire_date,
+	termination_date,
+	termination_reason,
+	termination_note,
+	rehire_eligible,
+	terminated_by,
+	terminated_at,
+	created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			stint.ID,
+			stint.CompanyID,
+			stint.EmployeeID,
+			stint.HireDate,
+			stint.TerminationDate,
+			stint.TerminationReason,
+			stint.TerminationNote,
+			stint.RehireEligible,
+			stint.TerminatedBy,
+			stint.TerminatedAt,
+			stint.CreatedAt,
+		)
+		return err
+	}
+	return r.db.WithContext(ctx).Create(stint).Error
+}
+
+func (r *repository) setUserActiveTx(ctx context.Context, companyID string, employeeID string, active bool) error {
+	query := `
+UPDATE users
+SET is_active = $1,
+	updated_at = $2
+WHERE company_id = $3
+  AND employee_id = $4
+  AND is_active = $5
+  AND deleted_at IS NULL
+`
+	_, err := r.tx.ExecContext(ctx, query, active, time.Now().UTC(), companyID, employeeID, !active)
+	return err
+}
+
+// DeactivateTerminatedUsers menonaktifkan akun login seluruh karyawan terminasi yang hari
+// terakhirnya sudah tiba per asOf. Dipanggil berkala oleh worker.
+func (r *repository) DeactivateTerminatedUsers(ctx context.Context, asOf time.Time) (int64, error) {
+	query := `
+UPDATE users
+SET is_active = FALSE,
+	updated_at = NOW()
+FROM employees
+WHERE employees.id = users.employee_id
+  AND employees.company_id = users.company_id
+  AND employees.termination_reason IS NOT NULL
+  AND employees.termination_date <= ?
+  AND users.is_active = TRUE
+  AND users.deleted_at IS NULL
+`
+	res := r.db.WithContext(ctx).Exec(query, asOf)
+	return res.RowsAffected, res.Error
+}
+
+// FindImportPositions mengembalikan seluruh posisi company beserta nama departemennya untuk
+// me-resolve kolom position/department file import.
+func (r *repository) FindImportPositions(ctx context.Context, companyID string) ([]ImportPosition, error) {
+	var positions []ImportPosition
+	err := r.db.WithContext(ctx).
+		Table("positions AS p").
+		Select("p.id, p.name, p.department_id, d.name AS department_name").
+		Joins("JOIN departments d ON d.id = p.department_id AND d.deleted_at IS NULL").
+		Where("p.company_id = ?", companyID).
+		Where("p.deleted_at IS NULL").
+		Scan(&positions).Error
+	return positions, err
+}
+
+// FindExistingEmails mengembalikan email (lower-case) yang sudah dipakai di company, termasuk
+// karyawan yang sudah di-soft-delete karena constraint uq_employee_email tetap berlaku.
+func (r *repository) FindExistingEmails(ctx context.Context, companyID string, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var existing []string
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&Employee{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("LOWER(email) IN ?", emails).
+		Pluck("LOWER(email)", &existing).Error
+	return existing, err
+}
+
+func (r *repository) CreateImport(ctx context.Context, job *EmployeeImport) error {
+	if r.tx != nil {
+		query := `
+INSERT INTO employee_imports (
+	id,
+	company_id,
+	file_name,
+	status,
+	total_rows,
+	processed_rows,
+	created_rows,
+	failed_rows,
+	skipped_rows,
+	import_rows,
+	results,
+	request_id,
+	requested_by,
+	created_at,
+	updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+`
+		_, err := r.tx.ExecContext(
+			ctx,
+			query,
+			job.ID,
+			job.CompanyID,
+			job.FileName,
+			job.Status,
+			job.TotalRows,
+			job.ProcessedRows,
+			job.CreatedRows,
+			job.FailedRows,
+			job.SkippedRows,
+			job.ImportRows,
+			job.Results,
+			job.RequestID,
+			job.RequestedBy,
+			job.CreatedAt,
+			job.UpdatedAt,
+		)
+		return err
+	}
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *repository) FindImportByIDAndCompany(ctx context.Context, companyID string, id string) (*EmployeeImport, error) {
+	var job EmployeeImport
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		First(&job, "id = ?", id).Error
+	return &job, err
+}
+
+func (r *repository) UpdateImport(ctx context.Context, job *EmployeeImport) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// Root org chart tanpa rootID: karyawan tanpa atasan, atau yang atasannya sudah dihapus
+// sehingga tidak tercecer dari tree.
+const orgChartCompanyRoots = `e.manager_id IS NULL
+	   OR NOT EXISTS (
+		SELECT 1 FROM employees m
+		WHERE m.id = e.manager_id
+		  AND m.company_id = e.company_id
+		  AND m.deleted_at IS NULL
+	   )`
+
+// FindOrgChart menelusuri garis pelaporan secara rekursif, dari rootID atau dari seluruh root
+// company jika rootID kosong. path mencegah loop jika data lama terlanjur membentuk siklus.
+func (r *repository) FindOrgChart(ctx context.Context, companyID string, rootID string) ([]OrgChartEntry, error) {
+	rootFilter := "(" + orgChartCompanyRoots + ")"
+	args := []interface{}{companyID}
+	if rootID != "" {
+		rootFilter = "e.id = ?"
+		args = append(args, rootID)
+	}
+	args = append(args, companyID)
+
+	query := `
+WITH RECURSIVE chart AS (
+	SELECT e.id, 0 AS depth, ARRAY[e.id] AS path
+	FROM employees e
+	WHERE e.company_id = ?
+	  AND e.deleted_at IS NULL
+	  AND ` + rootFilter + `
+	UNION ALL
+	SELECT c.id, chart.depth + 1, chart.path || c.id
+	FROM employees c
+	JOIN chart ON c.manager_id = chart.id
+	WHERE c.company_id = ?
+	  AND c.deleted_at IS NULL
+	  AND NOT c.id = ANY(chart.path)
+)
+SELECT
+	e.id,
+	e.manager_id,
+	e.employee_number,
+	e.full_name,
+	e.email,
+	e.employment_status,
+	p.name AS position_name,
+	d.name AS department_name,
+	chart.depth
+FROM chart
+JOIN employees e ON e.id = chart.id
+LEFT JOIN positions p ON p.id = e.position_id
+LEFT JOIN departments d ON d.id = e.department_id
+ORDER BY chart.depth, e.full_name
+`
+	var entries []OrgChartEntry
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&entries).Error
+	return entries, err
+}
+
+// FindReportIDs mengembalikan seluruh bawahan managerID, langsung maupun tidak langsung.
+// Dipakai modul lain untuk approval dan visibilitas data berbasis atasan.
+func (r *repository) FindReportIDs(ctx context.Context, companyID string, managerID string) ([]string, error) {
+	query := `
+WITH RECURSIVE reports AS (
+	SELECT e.id, ARRAY[e.manager_id, e.id] AS path
+	FROM employees e
+	WHERE e.company_id = ?
+	  AND e.manager_id = ?
+	  AND e.deleted_at IS NULL
+	UNION ALL
+	SELECT c.id, reports.path || c.id
+	FROM employees c
+	JOIN reports ON c.manager_id = reports.id
+	WHERE c.company_id = ?
+	  AND c.deleted_at IS NULL
+	  AND NOT c.id = ANY(reports.path)
+)
+SELECT id::text FROM reports
+`
+	var ids []string
+	err := r.db.WithContext(ctx).Raw(query, companyID, managerID, companyID).Scan(&ids).Error
+	return ids, err
+}
+
+// IsInReportingLine mengecek apakah managerID berada di rantai atasan employeeID (atasan
+// langsung maupun di atasnya), dengan menelusuri manager_id dari employeeID ke atas.
+func (r *repository) IsInReportingLine(ctx context.Context, companyID string, managerID string, employeeID string) (bool, error) {
+	query := `
+WITH RECURSIVE chain AS (
+	SELECT e.manager_id, ARRAY[e.id] AS path
+	FROM employees e
+	WHERE e.company_id = $1
+	  AND e.id = $2
+	  AND e.deleted_at IS NULL
+	UNION ALL
+	SELECT m.manager_id, chain.path || m.id
+	FROM employees m
+	JOIN chain ON m.id = chain.manager_id
+	WHERE m.company_id = $1
+	  AND m.deleted_at IS NULL
+	  AND NOT m.id = ANY(chain.path)
+)
+SELECT EXISTS (SELECT 1 FROM chain WHERE manager_id = $3)
+`
+	var found bool
+	if r.tx != nil {
+		err := r.tx.QueryRowContext(ctx, query, companyID, employeeID, managerID).Scan(&found)
+		return found, err
+	}
+	err := r.db.WithContext(ctx).Raw(query, companyID, employeeID, managerID).Scan(&found).Error
+	return found, err
+}
+
+// LockHierarchy mengambil advisory lock transaksi per company agar perubahan atasan
+// diserialisasi: dua update bersilangan (A ke B dan B ke A) tidak bisa sama-sama lolos
+// pengecekan siklus. Lock dilepas saat commit/rollback; tanpa transaksi tidak berefek.
+func (r *repository) LockHierarchy(ctx context.Context, companyID string) error {
+	if r.tx == nil {
+		return nil
+	}
+	_, err := r.tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('employee_hierarchy:' || $1))`, companyID)
+	return err
+}
@@ -30,6 +30,13 @@ func RegisterRoutes(
 			handler.GetOptions,
 		)
 
+		// Org chart seluruh company, atau subtree jika root_id diisi
+		employees.GET("/org-chart",
+			middleware.RateLimitByUser(1, 5),
+			middleware.RBACAuthorize(rbacService, "employee", "read"),
+			handler.GetOrgChart,
+		)
+
 		employees.GET("/:id",
 			middleware.RateLimitByUser(3, 10),
 			middleware.RBACAuthorize(rbacService, "employee", "read"),
@@ -48,6 +55,32 @@ func RegisterRoutes(
 			handler.Update,
 		)
 
+		// Terminasi menggantikan delete untuk offboarding: data tetap ada untuk payroll dan laporan
+		employees.POST("/:id/terminate",
+			middleware.RateLimitByUser(0.05, 1),
+			middleware.RBACAuthorize(rbacService, "employee", "delete"),
+			handler.Terminate,
+		)
+
+		// Import massal CSV/XLSX: dry_run mengembalikan laporan validasi, commit diproses async
+		employees.POST("/imports",
+			middleware.RateLimitByUser(0.05, 2),
+			middleware.RBACAuthorize(rbacService, "employee", "create"),
+			handler.Import,
+		)
+
+		employees.GET("/imports/:id",
+			middleware.RateLimitByUser(3, 10),
+			middleware.RBACAuthorize(rbacService, "employee", "read"),
+			handler.GetImport,
+		)
+
+		employees.POST("/:id/rehire",
+			middleware.RateLimitByUser(0.05, 1),
+			middleware.RBACAuthorize(rbacService, "employee", "create"),
+			handler.Rehire,
+		)
+
 		employees.DELETE("/:id",
 			middleware.RateLimitByUser(0.05, 1),
 			middleware.RBACAuthorize(rbacService, "employee", "delete"),
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	employeeerrors "go-hris/internal/employee/errors"
 	"go-hris/internal/events"
 	"go-hris/internal/messaging/kafka"
 	"go-hris/internal/shared/contextutil"
 	"go-hris/internal/shared/counter"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,6 +22,40 @@ import (
 
 const EmployeeOptionsKeyPrefix = "employees:options:v2:"
 
+// DefaultPTKPStatus dipakai jika status PTKP karyawan tidak diisi (tidak kawin, tanpa tanggungan).
+const DefaultPTKPStatus = "TK/0"
+
+var validPTKPStatuses = []string{"TK/0", "TK/1", "TK/2", "TK/3", "K/0", "K/1", "K/2", "K/3"}
+
+// Status kepegawaian. EmploymentStatusTerminated hanya diisi lewat alur terminasi.
+const (
+	EmploymentStatusActive     = "active"
+	EmploymentStatusProbation  = "probation"
+	EmploymentStatusContract   = "contract"
+	EmploymentStatusInactive   = "inactive"
+	EmploymentStatusTerminated = "terminated"
+)
+
+var validEmploymentStatuses = []string{
+	EmploymentStatusActive,
+	EmploymentStatusProbation,
+	EmploymentStatusContract,
+	EmploymentStatusInactive,
+}
+
+// Alasan terminasi karyawan.
+const (
+	TerminationReasonResignation = "RESIGNATION"
+	TerminationReasonDismissal   = "DISMISSAL"
+	TerminationReasonContractEnd = "CONTRACT_END"
+)
+
+var validTerminationReasons = []string{
+	TerminationReasonResignation,
+	TerminationReasonDismissal,
+	TerminationReasonContractEnd,
+}
+
 func GetEmployeeOptionsKey(companyID string) string {
 	return EmployeeOptionsKeyPrefix + companyID
 }
@@ -27,11 +63,19 @@ func GetEmployeeOptionsKey(companyID string) string {
 //go:generate mockgen -source=employee_service.go -destination=mock/employee_service_mock.go -package=mock
 type Service interface {
 	Create(ctx context.Context, companyID string, req CreateEmployeeRequest) (EmployeeResponse, error)
-	GetAll(ctx context.Context, companyID string) ([]EmployeeResponse, error)
+	GetAll(ctx context.Context, companyID string, filterReq GetEmployeesFilterRequest) ([]EmployeeResponse, int64, error)
 	GetOptions(ctx context.Context, companyID string) ([]EmployeeResponse, error)
 	GetByID(ctx context.Context, companyID, id string) (EmployeeResponse, error)
 	Update(ctx context.Context, companyID, id string, req UpdateEmployeeRequest) (EmployeeResponse, error)
 	Delete(ctx context.Context, companyID, id string) error
+	Terminate(ctx context.Context, companyID, actorID, id string, req TerminateEmployeeRequest) (EmployeeResponse, error)
+	Rehire(ctx context.Context, companyID, id string, req RehireEmployeeRequest) (EmployeeResponse, error)
+	PreviewImport(ctx context.Context, companyID, fileName string, content []byte) (EmployeeImportReport, error)
+	StartImport(ctx context.Context, companyID, actorID, fileName string, content []byte) (EmployeeImportResponse, error)
+	GetImport(ctx context.Context, companyID, id string) (EmployeeImportResponse, error)
+	ProcessImport(ctx context.Context, companyID, id string) error
+	GetOrgChart(ctx context.Context, companyID, rootID string) ([]OrgChartNode, error)
+	Hierarchy
 }
 
 type service struct {
@@ -112,6 +156,41 @@ func (s *service) Create(
 		)
 		return EmployeeResponse{}, errors.New("invalid hire_date format, expected YYYY-MM-DD")
 	}
+	birthDate, err := parseOptionalDate(req.BirthDate)
+	if err != nil {
+		s.logger.Warn("create employee invalid birth_date",
+			zap.String("birth_date", req.BirthDate),
+			zap.Error(err),
+		)
+		return EmployeeResponse{}, errors.New("invalid birth_date format, expected YYYY-MM-DD")
+	}
+	terminationDate, err := parseOptionalDate(req.TerminationDate)
+	if err != nil {
+		s.logger.Warn("create employee invalid termination_date",
+			zap.String("termination_date", req.TerminationDate),
+			zap.Error(err),
+		)
+		return EmployeeResponse{}, errors.New("invalid termination_date format, expected YYYY-MM-DD")
+	}
+	if terminationDate != nil && terminationDate.Before(hireDate) {
+		return EmployeeResponse{}, errors.New("termination_date must be on or after hire_date")
+	}
+	ptkpStatus, err := normalizePTKPStatus(req.PTKPStatus, DefaultPTKPStatus)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	employmentStatus, err := normalizeEmploymentStatus(req.EmploymentStatus)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	managerID, err := resolveManager(ctx, qtx, companyID, nil, req.ManagerID)
+	if err != nil {
+		s.logger.Warn("create employee invalid manager",
+			zap.String("manager_id", req.ManagerID),
+			zap.Error(err),
+		)
+		return EmployeeResponse{}, err
+	}
 
 	if req.EmployeeNumber == "" {
 		nextVal, err := s.counter.GetNextValue(ctx, companyID, "employee_number")
@@ -130,10 +209,17 @@ func (s *service) Create(
 		CompanyID:        uuid.MustParse(companyID),
 		PositionID:       uuidPtr(req.PositionID),
 		DepartmentID:     uuidPtr(departmentID),
+		ManagerID:        managerID,
 		EmployeeNumber:   req.EmployeeNumber,
 		Phone:            req.Phone,
 		HireDate:         hireDate,
-		EmploymentStatus: req.EmploymentStatus,
+		BirthDate:        birthDate,
+		TerminationDate:  terminationDate,
+		EmploymentStatus: employmentStatus,
+		PTKPStatus:       ptkpStatus,
+	}
+	if err := applyBankAccount(empl, req.BankCode, req.BankAccountNo, req.BankAccountName); err != nil {
+		return EmployeeResponse{}, err
 	}
 
 	if err := qtx.Create(ctx, empl); err != nil {
@@ -141,37 +227,25 @@ func (s *service) Create(
 		return EmployeeResponse{}, mapRepositoryError(err)
 	}
 
-	event := events.EmployeeCreatedEvent{
-		EventType:  "employee_created",
-		RequestID:  contextutil.GetRequestID(ctx), // Propagasi ke async events
-		EmployeeID: empl.ID.String(),
-		CompanyID:  companyID,
-		OccurredAt: time.Now().UTC(),
+	event, err := events.NewEmployeeLifecycleEvent(
+		events.EmployeeCreatedEventType,
+		contextutil.GetRequestID(ctx), // Propagasi ke async events
+		companyID,
+		empl.ID.String(),
+		events.EmployeeCreatedData{
+			EmployeeNumber:   empl.EmployeeNumber,
+			HireDate:         empl.HireDate.Format("2006-01-02"),
+			EmploymentStatus: empl.EmploymentStatus,
+			PositionID:       uuidToString(empl.PositionID),
+			DepartmentID:     uuidToString(empl.DepartmentID),
+		},
+	)
+	if err != nil {
+		l.Error("build employee_created event failed", zap.Error(err))
+		return EmployeeResponse{}, err
 	}
-	if s.outbox != nil {
-		payload, err := json.Marshal(event)
-		if err != nil {
-			l.Error("marshal event failed", zap.Error(err))
-			return EmployeeResponse{}, err
-		}
-
-		outboxRepo := s.outbox.WithTx(tx)
-		if err := outboxRepo.Create(ctx, kafka.OutboxEvent{
-			ID:            uuid.NewString(),
-			RequestID:     contextutil.GetRequestID(ctx),
-			AggregateType: "employee",
-			AggregateID:   empl.ID.String(),
-			EventType:     event.EventType,
-			Topic:         events.EmployeeCreatedTopic,
-			Payload:       payload,
-			Status:        kafka.OutboxStatusPending,
-		}); err != nil {
-			s.logger.Error("create employee outbox persist failed",
-				zap.String("employee_id", empl.ID.String()),
-				zap.Error(err),
-			)
-			return EmployeeResponse{}, err
-		}
+	if err := s.enqueueEvents(ctx, tx, event); err != nil {
+		return EmployeeResponse{}, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -205,15 +279,54 @@ func (s *service) Create(
 func (s *service) GetAll(
 	ctx context.Context,
 	companyID string,
-) ([]EmployeeResponse, error) {
+	filterReq GetEmployeesFilterRequest,
+) ([]EmployeeResponse, int64, error) {
 	s.logger.Debug("get all employees requested", zap.String("company_id", companyID))
-	depts, err := s.repo.FindAllByCompany(ctx, companyID)
+	filter, err := buildListFilter(filterReq)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	depts, total, err := s.repo.FindPageByCompany(ctx, companyID, filter)
 	if err != nil {
 		s.logger.Error("get all employees failed", zap.Error(err))
-		return nil, mapRepositoryError(err)
+		return nil, 0, mapRepositoryError(err)
+	}
+
+	return mapToListResponse(depts), total, nil
+}
+
+func buildListFilter(req GetEmployeesFilterRequest) (EmployeeQueryFilter, error) {
+	filter := EmployeeQueryFilter{Page: req.Query(employeeListSort)}
+
+	var err error
+	if filter.DepartmentID, err = optionalUUIDFilter(req.DepartmentID); err != nil {
+		return EmployeeQueryFilter{}, err
+	}
+	if filter.PositionID, err = optionalUUIDFilter(req.PositionID); err != nil {
+		return EmployeeQueryFilter{}, err
+	}
+	if filter.ManagerID, err = optionalUUIDFilter(req.ManagerID); err != nil {
+		return EmployeeQueryFilter{}, err
+	}
+
+	if status := strings.ToLower(strings.TrimSpace(req.Status)); status != "" {
+		if status != EmploymentStatusTerminated && !containsString(validEmploymentStatuses, status) {
+			return EmployeeQueryFilter{}, employeeerrors.ErrInvalidEmployeeFilter
+		}
+		filter.Status = &status
 	}
 
-	return mapToListResponse(depts), nil
+	if filter.HireDateFrom, err = parseOptionalDate(req.HireDateFrom); err != nil {
+		return EmployeeQueryFilter{}, employeeerrors.ErrInvalidEmployeeFilter
+	}
+	if filter.HireDateTo, err = parseOptionalDate(req.HireDateTo); err != nil {
+		return EmployeeQueryFilter{}, employeeerrors.ErrInvalidEmployeeFilter
+	}
+	if filter.HireDateFrom != nil && filter.HireDateTo != nil && filter.HireDateTo.Before(*filter.HireDateFrom) {
+		return EmployeeQueryFilter{}, employeeerrors.ErrInvalidEmployeeFilter
+	}
+	return filter, nil
 }
 
 func (s *service) GetOptions(ctx context.Context, companyID string) ([]EmployeeResponse, error) {
@@ -311,12 +424,35 @@ func (s *service) Update(
 		)
 		return EmployeeResponse{}, errors.New("invalid hire_date format, expected YYYY-MM-DD")
 	}
+	birthDate, err := parseOptionalDate(req.BirthDate)
+	if err != nil {
+		s.logger.Warn("update employee invalid birth_date",
+			zap.String("birth_date", req.BirthDate),
+			zap.Error(err),
+		)
+		return EmployeeResponse{}, errors.New("invalid birth_date format, expected YYYY-MM-DD")
+	}
+	var terminationDate *time.Time
+	if req.TerminationDate != nil {
+		terminationDate, err = parseOptionalDate(*req.TerminationDate)
+		if err != nil {
+			s.logger.Warn("update employee invalid termination_date",
+				zap.String("termination_date", *req.TerminationDate),
+				zap.Error(err),
+			)
+			return EmployeeResponse{}, errors.New("invalid termination_date format, expected YYYY-MM-DD")
+		}
+	}
+	if terminationDate != nil && terminationDate.Before(hireDate) {
+		return EmployeeResponse{}, errors.New("termination_date must be on or after hire_date")
+	}
 
 	empl, err := qtx.FindByIDAndCompany(ctx, companyID, id)
 	if err != nil {
 		s.logger.Error("update employee fetch existing failed", zap.Error(err))
 		return EmployeeResponse{}, mapRepositoryError(err)
 	}
+	before := *empl
 
 	empl.FullName = req.FullName
 	empl.Email = req.Email
@@ -325,13 +461,60 @@ func (s *service) Update(
 	empl.EmployeeNumber = req.EmployeeNumber
 	empl.Phone = req.Phone
 	empl.HireDate = hireDate
-	empl.EmploymentStatus = req.EmploymentStatus
+	if birthDate != nil {
+		empl.BirthDate = birthDate
+	}
+	if req.TerminationDate != nil {
+		// Tanggal keluar karyawan yang sudah diterminasi hanya bisa dihapus lewat rehire.
+		if terminationDate == nil && empl.IsTerminated() {
+			return EmployeeResponse{}, employeeerrors.ErrTerminationDateManaged
+		}
+		empl.TerminationDate = terminationDate
+	}
+	employmentStatus, err := normalizeEmploymentStatus(req.EmploymentStatus)
+	if empl.IsTerminated() {
+		// Karyawan terminasi tetap boleh dikoreksi datanya selama statusnya tidak diubah.
+		if !strings.EqualFold(strings.TrimSpace(req.EmploymentStatus), EmploymentStatusTerminated) {
+			return EmployeeResponse{}, employeeerrors.ErrTerminationManagedStatus
+		}
+		employmentStatus, err = EmploymentStatusTerminated, nil
+	}
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	empl.EmploymentStatus = employmentStatus
+	if empl.PTKPStatus, err = normalizePTKPStatus(req.PTKPStatus, empl.PTKPStatus); err != nil {
+		return EmployeeResponse{}, err
+	}
+	if err := applyBankAccount(empl, req.BankCode, req.BankAccountNo, req.BankAccountName); err != nil {
+		return EmployeeResponse{}, err
+	}
+	// Atasan hanya divalidasi ulang jika diubah, agar karyawan yang atasannya sudah terminasi
+	// tetap bisa diperbarui data lainnya.
+	if req.ManagerID != nil && strings.TrimSpace(*req.ManagerID) != uuidToString(empl.ManagerID) {
+		if empl.ManagerID, err = resolveManager(ctx, qtx, companyID, &empl.ID, *req.ManagerID); err != nil {
+			s.logger.Warn("update employee invalid manager",
+				zap.String("employee_id", id),
+				zap.String("manager_id", *req.ManagerID),
+				zap.Error(err),
+			)
+			return EmployeeResponse{}, err
+		}
+	}
 
 	if err := qtx.Update(ctx, empl); err != nil {
 		s.logger.Error("update employee persist failed", zap.Error(err))
 		return EmployeeResponse{}, mapRepositoryError(err)
 	}
 
+	updateEvents, err := buildUpdateEvents(contextutil.GetRequestID(ctx), companyID, before, *empl)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	if err := s.enqueueEvents(ctx, tx, updateEvents...); err != nil {
+		return EmployeeResponse{}, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		s.logger.Error("update employee commit failed", zap.Error(err))
 		return EmployeeResponse{}, err
@@ -394,18 +577,302 @@ func (s *service) Delete(
 	return nil
 }
 
+// Terminate mencatat terminasi karyawan (hari terakhir, alasan, kelayakan rehire) tanpa
+// menghapus datanya. Akun login dinonaktifkan langsung jika hari terakhir sudah tiba; jika
+// masih di masa depan, sweep worker yang menonaktifkannya. Event employee_terminated
+// diantrikan lewat outbox dalam transaksi yang sama.
+func (s *service) Terminate(
+	ctx context.Context,
+	companyID, actorID, id string,
+	req TerminateEmployeeRequest,
+) (EmployeeResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidCompanyID
+	}
+	reason := strings.ToUpper(strings.TrimSpace(req.Reason))
+	if !containsString(validTerminationReasons, reason) {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidTerminationReason
+	}
+	terminationDate, err := time.Parse("2006-01-02", strings.TrimSpace(req.TerminationDate))
+	if err != nil {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidTerminationDate
+	}
+	rehireEligible := req.RehireEligible != nil && *req.RehireEligible
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("terminate employee begin tx failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	empl, err := qtx.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		s.logger.Error("terminate employee fetch existing failed", zap.Error(err))
+		return EmployeeResponse{}, mapRepositoryError(err)
+	}
+	if empl.IsTerminated() {
+		return EmployeeResponse{}, employeeerrors.ErrEmployeeAlreadyTerminated
+	}
+	if terminationDate.Before(empl.HireDate) {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidTerminationDate
+	}
+
+	now := time.Now().UTC()
+	empl.TerminationDate = &terminationDate
+	empl.TerminationReason = &reason
+	empl.RehireEligible = &rehireEligible
+	empl.TerminatedBy = uuidPtr(actorID)
+	empl.TerminatedAt = &now
+	empl.TerminationNote = nil
+	if note := strings.TrimSpace(req.Note); note != "" {
+		empl.TerminationNote = &note
+	}
+	empl.EmploymentStatus = EmploymentStatusTerminated
+
+	if err := qtx.Update(ctx, empl); err != nil {
+		s.logger.Error("terminate employee persist failed", zap.Error(err))
+		return EmployeeResponse{}, mapRepositoryError(err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !terminationDate.After(today) {
+		if err := qtx.DeactivateUser(ctx, companyID, id); err != nil {
+			s.logger.Error("terminate employee deactivate user failed", zap.Error(err))
+			return EmployeeResponse{}, err
+		}
+	}
+
+	event, err := events.NewEmployeeLifecycleEvent(
+		events.EmployeeTerminatedEventType,
+		contextutil.GetRequestID(ctx),
+		companyID,
+		empl.ID.String(),
+		events.EmployeeTerminatedData{
+			TerminationDate: terminationDate.Format("2006-01-02"),
+			Reason:          reason,
+			RehireEligible:  rehireEligible,
+		},
+	)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	if err := s.enqueueEvents(ctx, tx, event); err != nil {
+		return EmployeeResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("terminate employee commit failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+
+	if s.rdb != nil {
+		cacheKey := GetEmployeeOptionsKey(companyID)
+		if err := s.rdb.Del(ctx, cacheKey).Err(); err != nil {
+			s.logger.Error("failed to invalidate employee options cache",
+				zap.Error(err),
+				zap.String("key", cacheKey),
+			)
+		}
+	}
+
+	s.logger.Info("terminate employee success",
+		zap.String("employee_id", id),
+		zap.String("reason", reason),
+		zap.String("termination_date", terminationDate.Format("2006-01-02")),
+	)
+
+	return mapToResponse(*empl), nil
+}
+
+// Rehire mempekerjakan kembali karyawan terminasi yang layak rehire: masa kerja sebelumnya
+// disimpan ke employee_employment_stints, lalu data terminasi dibersihkan, hire_date diganti
+// tanggal mulai baru dan akun login diaktifkan kembali.
+func (s *service) Rehire(
+	ctx context.Context,
+	companyID, id string,
+	req RehireEmployeeRequest,
+) (EmployeeResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidCompanyID
+	}
+	hireDate, err := time.Parse("2006-01-02", strings.TrimSpace(req.HireDate))
+	if err != nil {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidRehireDate
+	}
+	employmentStatus, err := normalizeEmploymentStatus(req.EmploymentStatus)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		s.logger.Error("rehire employee begin tx failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	empl, err := qtx.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		s.logger.Error("rehire employee fetch existing failed", zap.Error(err))
+		return EmployeeResponse{}, mapRepositoryError(err)
+	}
+	if !empl.IsTerminated() {
+		return EmployeeResponse{}, employeeerrors.ErrEmployeeNotTerminated
+	}
+	if empl.RehireEligible == nil || !*empl.RehireEligible {
+		return EmployeeResponse{}, employeeerrors.ErrEmployeeNotRehireEligible
+	}
+	previousTerminationDate := formatDate(empl.TerminationDate)
+	if empl.TerminationDate != nil && !hireDate.After(*empl.TerminationDate) {
+		return EmployeeResponse{}, employeeerrors.ErrInvalidRehireDate
+	}
+
+	stint := &EmploymentStint{
+		ID:                uuid.New(),
+		CompanyID:         empl.CompanyID,
+		EmployeeID:        empl.ID,
+		HireDate:          empl.HireDate,
+		TerminationDate:   empl.TerminationDate,
+		TerminationReason: empl.TerminationReason,
+		TerminationNote:   empl.TerminationNote,
+		RehireEligible:    empl.RehireEligible,
+		TerminatedBy:      empl.TerminatedBy,
+		TerminatedAt:      empl.TerminatedAt,
+	}
+	if err := qtx.CreateEmploymentStint(ctx, stint); err != nil {
+		s.logger.Error("rehire employee persist previous stint failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+
+	empl.HireDate = hireDate
+	empl.EmploymentStatus = employmentStatus
+	empl.TerminationDate = nil
+	empl.TerminationReason = nil
+	empl.TerminationNote = nil
+	empl.RehireEligible = nil
+	empl.TerminatedBy = nil
+	empl.TerminatedAt = nil
+
+	if err := qtx.Update(ctx, empl); err != nil {
+		s.logger.Error("rehire employee persist failed", zap.Error(err))
+		return EmployeeResponse{}, mapRepositoryError(err)
+	}
+	if err := qtx.ActivateUser(ctx, companyID, id); err != nil {
+		s.logger.Error("rehire employee activate user failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+
+	event, err := events.NewEmployeeLifecycleEvent(
+		events.EmployeeRehiredEventType,
+		contextutil.GetRequestID(ctx),
+		companyID,
+		empl.ID.String(),
+		events.EmployeeRehiredData{
+			HireDate:                hireDate.Format("2006-01-02"),
+			PreviousTerminationDate: previousTerminationDate,
+			EmploymentStatus:        employmentStatus,
+		},
+	)
+	if err != nil {
+		return EmployeeResponse{}, err
+	}
+	if err := s.enqueueEvents(ctx, tx, event); err != nil {
+		return EmployeeResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.logger.Error("rehire employee commit failed", zap.Error(err))
+		return EmployeeResponse{}, err
+	}
+
+	if s.rdb != nil {
+		cacheKey := GetEmployeeOptionsKey(companyID)
+		if err := s.rdb.Del(ctx, cacheKey).Err(); err != nil {
+			s.logger.Error("failed to invalidate employee options cache",
+				zap.Error(err),
+				zap.String("key", cacheKey),
+			)
+		}
+	}
+
+	s.logger.Info("rehire employee success",
+		zap.String("employee_id", id),
+		zap.String("hire_date", hireDate.Format("2006-01-02")),
+	)
+
+	return mapToResponse(*empl), nil
+}
+
+// enqueueEvents menyimpan event lifecycle karyawan ke outbox di dalam transaksi caller,
+// sesuai urutan kejadian. Tanpa outbox (mis. di test), event dilewati.
+func (s *service) enqueueEvents(ctx context.Context, tx *sql.Tx, evts ...events.EmployeeLifecycleEvent) error {
+	if s.outbox == nil || len(evts) == 0 {
+		return nil
+	}
+
+	outboxRepo := s.outbox.WithTx(tx)
+	for _, event := range evts {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			s.logger.Error("marshal event failed", zap.String("event_type", event.EventType), zap.Error(err))
+			return err
+		}
+
+		if err := outboxRepo.Create(ctx, kafka.OutboxEvent{
+			ID:            event.EventID,
+			RequestID:     event.RequestID,
+			AggregateType: "employee",
+			AggregateID:   event.EmployeeID,
+			EventType:     event.EventType,
+			Topic:         events.EmployeeLifecycleTopic,
+			Payload:       payload,
+			Status:        kafka.OutboxStatusPending,
+		}); err != nil {
+			s.logger.Error("employee outbox persist failed",
+				zap.String("employee_id", event.EmployeeID),
+				zap.String("event_type", event.EventType),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+	return nil
+}
+
 func mapToResponse(empl Employee) EmployeeResponse {
 	resp := EmployeeResponse{
-		ID:               empl.ID.String(),
-		FullName:         empl.FullName,
-		Email:            empl.Email,
-		EmployeeNumber:   empl.EmployeeNumber,
-		Phone:            empl.Phone,
-		HireDate:         empl.HireDate.Format("2006-01-02"),
-		EmploymentStatus: empl.EmploymentStatus,
-		CompanyID:        empl.CompanyID.String(),
-		DepartmentID:     uuidToString(empl.DepartmentID),
-		PositionID:       uuidToString(empl.PositionID),
+		ID:                empl.ID.String(),
+		FullName:          empl.FullName,
+		Email:             empl.Email,
+		EmployeeNumber:    empl.EmployeeNumber,
+		Phone:             empl.Phone,
+		HireDate:          empl.HireDate.Format("2006-01-02"),
+		EmploymentStatus:  empl.EmploymentStatus,
+		PTKPStatus:        empl.PTKPStatus,
+		BankCode:          stringValue(empl.BankCode),
+		BankAccountNo:     stringValue(empl.BankAccountNo),
+		BankAccountName:   stringValue(empl.BankAccountName),
+		TerminationReason: stringValue(empl.TerminationReason),
+		TerminationNote:   stringValue(empl.TerminationNote),
+		RehireEligible:    empl.RehireEligible,
+		CompanyID:         empl.CompanyID.String(),
+		DepartmentID:      uuidToString(empl.DepartmentID),
+		PositionID:        uuidToString(empl.PositionID),
+		ManagerID:         uuidToString(empl.ManagerID),
+	}
+	if empl.BirthDate != nil {
+		resp.BirthDate = empl.BirthDate.Format("2006-01-02")
+	}
+	if empl.TerminationDate != nil {
+		resp.TerminationDate = empl.TerminationDate.Format("2006-01-02")
+	}
+	if empl.TerminatedAt != nil {
+		resp.TerminatedAt = empl.TerminatedAt.Format(time.RFC3339)
 	}
 	if empl.Department != nil {
 		resp.Department = &EmployeeDepartmentResponse{
@@ -443,6 +910,105 @@ func mapToOptionListResponse(emps []Employee) []EmployeeResponse {
 	return res
 }
 
+// optionalUUIDFilter mengembalikan nil untuk filter kosong.
+func optionalUUIDFilter(v string) (*string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	if _, err := uuid.Parse(v); err != nil {
+		return nil, employeeerrors.ErrInvalidEmployeeFilter
+	}
+	return &v, nil
+}
+
+// normalizePTKPStatus memvalidasi status PTKP karyawan. Nilai kosong memakai fallback.
+func normalizePTKPStatus(v, fallback string) (string, error) {
+	if v == "" {
+		if fallback == "" {
+			return DefaultPTKPStatus, nil
+		}
+		return fallback, nil
+	}
+	for _, status := range validPTKPStatuses {
+		if v == status {
+			return v, nil
+		}
+	}
+	return "", employeeerrors.ErrInvalidPTKPStatus
+}
+
+// normalizeEmploymentStatus memvalidasi status kepegawaian (case-insensitive, default active).
+// Status terminated ditolak karena hanya boleh diisi oleh Terminate.
+func normalizeEmploymentStatus(v string) (string, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return EmploymentStatusActive, nil
+	}
+	if v == EmploymentStatusTerminated {
+		return "", employeeerrors.ErrTerminationManagedStatus
+	}
+	if !containsString(validEmploymentStatuses, v) {
+		return "", employeeerrors.ErrInvalidEmploymentStatus
+	}
+	return v, nil
+}
+
+func containsString(values []string, v string) bool {
+	for _, item := range values {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBankAccount mengisi rekening tujuan transfer gaji. Jika semua field kosong,
+// rekening yang sudah tersimpan dipertahankan. Nama pemilik rekening default ke nama karyawan.
+func applyBankAccount(empl *Employee, code, number, name string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	number = strings.NewReplacer(" ", "", "-", "", ".", "").Replace(number)
+	name = strings.TrimSpace(name)
+	if code == "" && number == "" && name == "" {
+		return nil
+	}
+	if code == "" || len(number) < 5 || len(number) > 34 {
+		return employeeerrors.ErrInvalidBankAccount
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return employeeerrors.ErrInvalidBankAccount
+		}
+	}
+	if name == "" {
+		name = empl.FullName
+	}
+	empl.BankCode = &code
+	empl.BankAccountNo = &number
+	empl.BankAccountName = &name
+	return nil
+}
+
+// parseOptionalDate mengembalikan nil untuk input kosong.
+func parseOptionalDate(v string) (*time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
 func uuidPtr(v string) *uuid.UUID {
 	id, err := uuid.Parse(v)
 	if err != nil {
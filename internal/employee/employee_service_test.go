@@ -13,6 +13,7 @@ import (
 	"go-hris/internal/events"
 	"go-hris/internal/shared/apperror"
 	"go-hris/internal/shared/contextutil"
+	"go-hris/internal/shared/pagination"
 
 	employeeMock "go-hris/internal/employee/mock"
 	"go-hris/internal/messaging/kafka"
@@ -25,6 +26,8 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 type serviceDeps struct {
@@ -110,6 +113,7 @@ func TestEmployeeService_Create(t *testing.T) {
 				assert.Equal(t, "EMP-000123", d.EmployeeNumber)
 				assert.Equal(t, companyID, d.CompanyID.String())
 				assert.Equal(t, req.Email, d.Email)
+				assert.Equal(t, employee.DefaultPTKPStatus, d.PTKPStatus)
 				d.ID = deptID
 				return nil
 			})
@@ -205,6 +209,42 @@ func TestEmployeeService_Create(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("invalid ptkp status", func(t *testing.T) {
+		req := employee.CreateEmployeeRequest{FullName: "HR", Email: "hr@example.com", EmployeeNumber: "EMP-105", HireDate: "2026-01-01", EmploymentStatus: "active", PositionID: uuid.New().String(), PTKPStatus: "K/4"}
+
+		expectTx(t, deps.sqlMock, false)
+
+		deps.repo.EXPECT().
+			WithTx(gomock.Any()).
+			Return(deps.repo)
+
+		deps.repo.EXPECT().
+			GetDepartmentIDByPosition(ctx, companyID, req.PositionID).
+			Return(uuid.New().String(), nil)
+
+		_, err := deps.service.Create(ctx, companyID, req)
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidPTKPStatus)
+	})
+
+	t.Run("invalid bank account", func(t *testing.T) {
+		req := employee.CreateEmployeeRequest{FullName: "HR", Email: "hr@example.com", EmployeeNumber: "EMP-106", HireDate: "2026-01-01", EmploymentStatus: "active", PositionID: uuid.New().String(), BankCode: "BCA", BankAccountNo: "12AB"}
+
+		expectTx(t, deps.sqlMock, false)
+
+		deps.repo.EXPECT().
+			WithTx(gomock.Any()).
+			Return(deps.repo)
+
+		deps.repo.EXPECT().
+			GetDepartmentIDByPosition(ctx, companyID, req.PositionID).
+			Return(uuid.New().String(), nil)
+
+		_, err := deps.service.Create(ctx, companyID, req)
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidBankAccount)
+	})
+
 	t.Run("duplicate employee number -> conflict error", func(t *testing.T) {
 		req := employee.CreateEmployeeRequest{FullName: "HR", Email: "hr@example.com", EmployeeNumber: "EMP-100", Phone: "0812", HireDate: "2026-01-01", EmploymentStatus: "active", PositionID: uuid.New().String()}
 		departmentID := uuid.New().String()
@@ -238,29 +278,56 @@ func TestEmployeeService_GetAll(t *testing.T) {
 	companyID := uuid.New().String()
 
 	t.Run("success", func(t *testing.T) {
+		departmentID := uuid.New().String()
 		mockEmployees := []employee.Employee{
 			{ID: uuid.New(), FullName: "Andi", Email: "andi@comp.com"},
 			{ID: uuid.New(), FullName: "Budi", Email: "budi@comp.com"},
 		}
 
 		deps.repo.EXPECT().
-			FindAllByCompany(ctx, companyID).
-			Return(mockEmployees, nil).
+			FindPageByCompany(ctx, companyID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, cid string, filter employee.EmployeeQueryFilter) ([]employee.Employee, int64, error) {
+				assert.Equal(t, departmentID, *filter.DepartmentID)
+				assert.Equal(t, employee.EmploymentStatusProbation, *filter.Status)
+				assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filter.HireDateFrom)
+				assert.Nil(t, filter.HireDateTo)
+				assert.Equal(t, "employees.hire_date DESC, employees.id DESC", filter.Page.OrderBy)
+				assert.Equal(t, 20, filter.Page.Offset())
+				return mockEmployees, 42, nil
+			}).
 			Times(1)
 
-		resp, err := deps.service.GetAll(ctx, companyID)
+		resp, total, err := deps.service.GetAll(ctx, companyID, employee.GetEmployeesFilterRequest{
+			Request:      pagination.Request{Page: 3, SortBy: "hire_date", SortDir: "desc"},
+			DepartmentID: departmentID,
+			Status:       "Probation",
+			HireDateFrom: "2024-01-01",
+		})
 
 		assert.NoError(t, err)
 		assert.Len(t, resp, 2)
+		assert.Equal(t, int64(42), total)
 		assert.Equal(t, "Andi", resp[0].FullName)
 	})
 
+	t.Run("invalid filter", func(t *testing.T) {
+		for _, req := range []employee.GetEmployeesFilterRequest{
+			{PositionID: "not-a-uuid"},
+			{Status: "retired"},
+			{HireDateFrom: "01-01-2024"},
+			{HireDateFrom: "2024-02-01", HireDateTo: "2024-01-01"},
+		} {
+			_, _, err := deps.service.GetAll(ctx, companyID, req)
+			assert.ErrorIs(t, err, employeeerrors.ErrInvalidEmployeeFilter)
+		}
+	})
+
 	t.Run("error repository", func(t *testing.T) {
 		deps.repo.EXPECT().
-			FindAllByCompany(ctx, companyID).
-			Return(nil, errors.New("db error"))
+			FindPageByCompany(ctx, companyID, gomock.Any()).
+			Return(nil, int64(0), errors.New("db error"))
 
-		resp, err := deps.service.GetAll(ctx, companyID)
+		resp, _, err := deps.service.GetAll(ctx, companyID, employee.GetEmployeesFilterRequest{})
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
@@ -433,12 +500,30 @@ func TestEmployeeService_Update(t *testing.T) {
 				return nil
 			})
 
+		// Data umum, posisi dan status berubah sekaligus -> tiga event lifecycle
+		var eventTypes []string
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				assert.Equal(t, events.EmployeeLifecycleTopic, event.Topic)
+				assert.Equal(t, targetID.String(), event.AggregateID)
+				eventTypes = append(eventTypes, event.EventType)
+				return nil
+			}).
+			Times(3)
+
 		deps.sqlMock.ExpectCommit()
 
 		resp, err := deps.service.Update(ctx, companyID.String(), targetID.String(), req)
 
 		assert.NoError(t, err)
 		assert.Equal(t, req.FullName, resp.FullName)
+		assert.Equal(t, []string{
+			events.EmployeeUpdatedEventType,
+			events.EmployeeTransferredEventType,
+			events.EmployeeStatusChangedEventType,
+		}, eventTypes)
 	})
 
 	t.Run("error - employee not found", func(t *testing.T) {
@@ -491,6 +576,55 @@ func TestEmployeeService_Update(t *testing.T) {
 
 		assert.Error(t, err)
 	})
+
+	t.Run("success - empty termination_date clears it", func(t *testing.T) {
+		clear := ""
+		req := employee.UpdateEmployeeRequest{FullName: "HR Updated", Email: "hr.updated@example.com", EmployeeNumber: "EMP-105", HireDate: "2026-01-05", TerminationDate: &clear, EmploymentStatus: "active", PositionID: uuid.New().String()}
+		plannedLastDay := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+		deps.sqlMock.ExpectBegin()
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().
+			GetDepartmentIDByPosition(ctx, companyID.String(), req.PositionID).
+			Return(uuid.New().String(), nil)
+		deps.repo.EXPECT().
+			FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+			Return(&employee.Employee{ID: targetID, CompanyID: companyID, TerminationDate: &plannedLastDay}, nil)
+		deps.repo.EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, d *employee.Employee) error {
+				assert.Nil(t, d.TerminationDate)
+				return nil
+			})
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().Create(ctx, gomock.Any()).Return(nil).AnyTimes()
+		deps.sqlMock.ExpectCommit()
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("error - cannot clear termination_date of terminated employee", func(t *testing.T) {
+		clear := ""
+		req := employee.UpdateEmployeeRequest{FullName: "HR Updated", Email: "hr.updated@example.com", EmployeeNumber: "EMP-106", HireDate: "2024-01-05", TerminationDate: &clear, EmploymentStatus: "terminated", PositionID: uuid.New().String()}
+		lastDay := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+		reason := employee.TerminationReasonResignation
+
+		deps.sqlMock.ExpectBegin()
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().
+			GetDepartmentIDByPosition(ctx, companyID.String(), req.PositionID).
+			Return(uuid.New().String(), nil)
+		deps.repo.EXPECT().
+			FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+			Return(&employee.Employee{ID: targetID, CompanyID: companyID, TerminationDate: &lastDay, TerminationReason: &reason}, nil)
+		deps.sqlMock.ExpectRollback()
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), req)
+
+		assert.ErrorIs(t, err, employeeerrors.ErrTerminationDateManaged)
+	})
 }
 
 func TestEmployeeService_Delete(t *testing.T) {
@@ -534,6 +668,481 @@ func TestEmployeeService_Delete(t *testing.T) {
 	})
 }
 
+func TestEmployeeService_Terminate(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+	actorID := uuid.New().String()
+	eligible := true
+
+	existing := func() *employee.Employee {
+		return &employee.Employee{
+			ID:               targetID,
+			CompanyID:        companyID,
+			FullName:         "Leaving Staff",
+			HireDate:         time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			EmploymentStatus: employee.EmploymentStatusActive,
+		}
+	}
+
+	t.Run("success - past last day deactivates user and queues event", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(existing(), nil)
+		deps.repo.EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, e *employee.Employee) error {
+				assert.Equal(t, employee.EmploymentStatusTerminated, e.EmploymentStatus)
+				assert.Equal(t, "2026-01-31", e.TerminationDate.Format("2006-01-02"))
+				assert.Equal(t, employee.TerminationReasonResignation, *e.TerminationReason)
+				assert.True(t, *e.RehireEligible)
+				assert.Equal(t, actorID, e.TerminatedBy.String())
+				return nil
+			})
+		deps.repo.EXPECT().DeactivateUser(ctx, companyID.String(), targetID.String()).Return(nil)
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				assert.Equal(t, events.EmployeeTerminatedEventType, event.EventType)
+				assert.Equal(t, events.EmployeeLifecycleTopic, event.Topic)
+				var envelope events.EmployeeLifecycleEvent
+				assert.NoError(t, json.Unmarshal(event.Payload, &envelope))
+				assert.Equal(t, events.EmployeeLifecycleSchemaVersion, envelope.SchemaVersion)
+				assert.Equal(t, event.ID, envelope.EventID)
+				var data events.EmployeeTerminatedData
+				assert.NoError(t, envelope.DecodeData(&data))
+				assert.Equal(t, "2026-01-31", data.TerminationDate)
+				assert.Equal(t, employee.TerminationReasonResignation, data.Reason)
+				return nil
+			})
+		deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID.String())).SetVal(1)
+
+		resp, err := deps.service.Terminate(ctx, companyID.String(), actorID, targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: "2026-01-31",
+			Reason:          "resignation",
+			Note:            " pindah kota ",
+			RehireEligible:  &eligible,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, employee.EmploymentStatusTerminated, resp.EmploymentStatus)
+		assert.Equal(t, "pindah kota", resp.TerminationNote)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("future last day leaves user active for the sweep", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		lastDay := time.Now().UTC().AddDate(0, 1, 0).Format("2006-01-02")
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(existing(), nil)
+		deps.repo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+		deps.repo.EXPECT().DeactivateUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID.String())).SetVal(1)
+
+		resp, err := deps.service.Terminate(ctx, companyID.String(), actorID, targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: lastDay,
+			Reason:          employee.TerminationReasonContractEnd,
+			RehireEligible:  &eligible,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, lastDay, resp.TerminationDate)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("already terminated", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		reason := employee.TerminationReasonDismissal
+		terminated := existing()
+		terminated.TerminationReason = &reason
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(terminated, nil)
+
+		_, err := deps.service.Terminate(ctx, companyID.String(), actorID, targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: "2026-01-31",
+			Reason:          employee.TerminationReasonResignation,
+			RehireEligible:  &eligible,
+		})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrEmployeeAlreadyTerminated)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("last day before hire date", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(existing(), nil)
+
+		_, err := deps.service.Terminate(ctx, companyID.String(), actorID, targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: "2024-02-28",
+			Reason:          employee.TerminationReasonResignation,
+			RehireEligible:  &eligible,
+		})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidTerminationDate)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid reason", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.Terminate(ctx, companyID.String(), actorID, targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: "2026-01-31",
+			Reason:          "RETIRED",
+			RehireEligible:  &eligible,
+		})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidTerminationReason)
+	})
+}
+
+// setupRealRepoTest memakai repository dan outbox asli di atas sqlmock, untuk memastikan
+// seluruh write berjalan di transaksi yang sama dengan insert outbox.
+func setupRealRepoTest(t *testing.T) (*sql.DB, sqlmock.Sqlmock, employee.Service) {
+	t.Helper()
+	db, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	svc := employee.NewServiceWithOutbox(db, employee.NewRepository(gormDB), nil, kafka.NewOutboxRepository(db), nil)
+	return db, sqlMock, svc
+}
+
+func expectEmployeeRow(sqlMock sqlmock.Sqlmock, companyID, targetID uuid.UUID, extra func(*sqlmock.Rows)) {
+	rows := sqlmock.NewRows([]string{"id", "company_id", "full_name", "email", "employee_number", "hire_date", "employment_status", "ptkp_status", "termination_date", "termination_reason", "rehire_eligible"})
+	if extra != nil {
+		extra(rows)
+	} else {
+		rows.AddRow(targetID, companyID, "Staff", "staff@example.com", "EMP-400", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), employee.EmploymentStatusActive, "TK/0", nil, nil, nil)
+	}
+	sqlMock.ExpectQuery(`SELECT \* FROM "employees"`).WillReturnRows(rows)
+}
+
+// Gagal insert outbox harus me-rollback perubahan karyawan dan akun login, bukan hanya
+// event-nya, agar consumer tidak tertinggal dari state karyawan.
+func TestEmployeeService_OutboxFailureRollsBack(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+
+	t.Run("terminate", func(t *testing.T) {
+		db, sqlMock, svc := setupRealRepoTest(t)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		expectEmployeeRow(sqlMock, companyID, targetID, nil)
+		sqlMock.ExpectExec(`UPDATE employees SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`UPDATE users`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`INSERT INTO outbox_events`).WillReturnError(errors.New("outbox unavailable"))
+		sqlMock.ExpectRollback()
+
+		_, err := svc.Terminate(ctx, companyID.String(), uuid.New().String(), targetID.String(), employee.TerminateEmployeeRequest{
+			TerminationDate: "2026-01-31",
+			Reason:          "RESIGNATION",
+		})
+
+		assert.Error(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		db, sqlMock, svc := setupRealRepoTest(t)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectQuery(`SELECT department_id::text`).WillReturnRows(sqlmock.NewRows([]string{"department_id"}).AddRow(uuid.New().String()))
+		expectEmployeeRow(sqlMock, companyID, targetID, nil)
+		sqlMock.ExpectExec(`UPDATE employees SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`INSERT INTO outbox_events`).WillReturnError(errors.New("outbox unavailable"))
+		sqlMock.ExpectRollback()
+
+		_, err := svc.Update(ctx, companyID.String(), targetID.String(), employee.UpdateEmployeeRequest{
+			FullName:         "Staff Renamed",
+			Email:            "staff@example.com",
+			EmployeeNumber:   "EMP-400",
+			HireDate:         "2024-03-01",
+			EmploymentStatus: "active",
+			PositionID:       uuid.New().String(),
+		})
+
+		assert.Error(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("rehire", func(t *testing.T) {
+		db, sqlMock, svc := setupRealRepoTest(t)
+		defer db.Close()
+
+		sqlMock.ExpectBegin()
+		expectEmployeeRow(sqlMock, companyID, targetID, func(rows *sqlmock.Rows) {
+			rows.AddRow(targetID, companyID, "Staff", "staff@example.com", "EMP-400", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				employee.EmploymentStatusTerminated, "TK/0", time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), "RESIGNATION", true)
+		})
+		sqlMock.ExpectExec(`INSERT INTO employee_employment_stints`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`UPDATE employees SET`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`UPDATE users`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`INSERT INTO outbox_events`).WillReturnError(errors.New("outbox unavailable"))
+		sqlMock.ExpectRollback()
+
+		_, err := svc.Rehire(ctx, companyID.String(), targetID.String(), employee.RehireEmployeeRequest{
+			HireDate:         "2026-01-05",
+			EmploymentStatus: "active",
+		})
+
+		assert.Error(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestEmployeeService_Update_TerminatedStatus(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+
+	req := employee.UpdateEmployeeRequest{FullName: "HR", Email: "hr@example.com", EmployeeNumber: "EMP-107", HireDate: "2026-01-01", EmploymentStatus: "terminated", PositionID: uuid.New().String()}
+
+	t.Run("cannot set terminated through update", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID.String(), req.PositionID).Return(uuid.New().String(), nil)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+			Return(&employee.Employee{ID: targetID, CompanyID: companyID}, nil)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), req)
+
+		assert.ErrorIs(t, err, employeeerrors.ErrTerminationManagedStatus)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("cannot reactivate terminated employee through update", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		reason := employee.TerminationReasonResignation
+		active := req
+		active.EmploymentStatus = "active"
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID.String(), req.PositionID).Return(uuid.New().String(), nil)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+			Return(&employee.Employee{ID: targetID, CompanyID: companyID, TerminationReason: &reason}, nil)
+
+		_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), active)
+
+		assert.ErrorIs(t, err, employeeerrors.ErrTerminationManagedStatus)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestEmployeeService_Update_LifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+	positionID := uuid.New()
+	departmentID := uuid.New()
+	bankCode, accountNo, accountName := "BCA", "1234567890", "Staff"
+
+	deps := setupServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, true)
+	deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+	deps.repo.EXPECT().GetDepartmentIDByPosition(ctx, companyID.String(), positionID.String()).Return(departmentID.String(), nil)
+	deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+		Return(&employee.Employee{
+			ID:               targetID,
+			CompanyID:        companyID,
+			PositionID:       &positionID,
+			DepartmentID:     &departmentID,
+			FullName:         "Staff",
+			Email:            "staff@example.com",
+			EmployeeNumber:   "EMP-200",
+			Phone:            "0811",
+			HireDate:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			EmploymentStatus: employee.EmploymentStatusActive,
+			PTKPStatus:       "TK/0",
+			BankCode:         &bankCode,
+			BankAccountNo:    &accountNo,
+			BankAccountName:  &accountName,
+		}, nil)
+	deps.repo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+	deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+	deps.outbox.EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+			// Hanya telepon dan rekening yang berubah: tidak ada event mutasi/status.
+			assert.Equal(t, events.EmployeeUpdatedEventType, event.EventType)
+			var envelope events.EmployeeLifecycleEvent
+			assert.NoError(t, json.Unmarshal(event.Payload, &envelope))
+			var data events.EmployeeUpdatedData
+			assert.NoError(t, envelope.DecodeData(&data))
+			assert.Equal(t, []events.EmployeeFieldChange{
+				{Field: "phone", From: "0811", To: "0819"},
+				{Field: "bank_account_number", Redacted: true},
+			}, data.Changes)
+			assert.NotContains(t, string(event.Payload), "9876543210")
+			return nil
+		})
+	deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID.String())).SetVal(1)
+
+	_, err := deps.service.Update(ctx, companyID.String(), targetID.String(), employee.UpdateEmployeeRequest{
+		FullName:         "Staff",
+		Email:            "staff@example.com",
+		EmployeeNumber:   "EMP-200",
+		Phone:            "0819",
+		HireDate:         "2025-01-01",
+		EmploymentStatus: "active",
+		PositionID:       positionID.String(),
+		BankCode:         "BCA",
+		BankAccountNo:    "9876543210",
+		BankAccountName:  "Staff",
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestEmployeeService_Rehire(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New()
+	targetID := uuid.New()
+
+	terminated := func(eligible bool) *employee.Employee {
+		reason := employee.TerminationReasonContractEnd
+		lastDay := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+		actor := uuid.New()
+		return &employee.Employee{
+			ID:                targetID,
+			CompanyID:         companyID,
+			HireDate:          time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			EmploymentStatus:  employee.EmploymentStatusTerminated,
+			TerminationDate:   &lastDay,
+			TerminationReason: &reason,
+			RehireEligible:    &eligible,
+			TerminatedBy:      &actor,
+			TerminatedAt:      &lastDay,
+		}
+	}
+
+	t.Run("success - clears termination, reactivates user and queues event", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(terminated(true), nil)
+		deps.repo.EXPECT().
+			CreateEmploymentStint(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, stint *employee.EmploymentStint) error {
+				// Masa kerja lama tetap tersimpan untuk payroll dan laporan periode sebelumnya
+				assert.Equal(t, targetID, stint.EmployeeID)
+				assert.Equal(t, "2025-02-01", stint.HireDate.Format("2006-01-02"))
+				assert.Equal(t, "2026-01-31", stint.TerminationDate.Format("2006-01-02"))
+				assert.Equal(t, employee.TerminationReasonContractEnd, *stint.TerminationReason)
+				return nil
+			})
+		deps.repo.EXPECT().
+			Update(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, e *employee.Employee) error {
+				assert.False(t, e.IsTerminated())
+				assert.Nil(t, e.TerminationDate)
+				assert.Nil(t, e.RehireEligible)
+				assert.Equal(t, employee.EmploymentStatusContract, e.EmploymentStatus)
+				assert.Equal(t, "2026-06-01", e.HireDate.Format("2006-01-02"))
+				return nil
+			})
+		deps.repo.EXPECT().ActivateUser(ctx, companyID.String(), targetID.String()).Return(nil)
+		deps.outbox.EXPECT().WithTx(gomock.Any()).Return(deps.outbox)
+		deps.outbox.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event kafka.OutboxEvent) error {
+				assert.Equal(t, events.EmployeeRehiredEventType, event.EventType)
+				var envelope events.EmployeeLifecycleEvent
+				assert.NoError(t, json.Unmarshal(event.Payload, &envelope))
+				var data events.EmployeeRehiredData
+				assert.NoError(t, envelope.DecodeData(&data))
+				assert.Equal(t, "2026-01-31", data.PreviousTerminationDate)
+				assert.Equal(t, "2026-06-01", data.HireDate)
+				return nil
+			})
+		deps.redismock.ExpectDel(employee.GetEmployeeOptionsKey(companyID.String())).SetVal(1)
+
+		resp, err := deps.service.Rehire(ctx, companyID.String(), targetID.String(), employee.RehireEmployeeRequest{
+			HireDate:         "2026-06-01",
+			EmploymentStatus: "contract",
+		})
+
+		assert.NoError(t, err)
+		assert.Empty(t, resp.TerminationReason)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("not rehire eligible", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(terminated(false), nil)
+
+		_, err := deps.service.Rehire(ctx, companyID.String(), targetID.String(), employee.RehireEmployeeRequest{HireDate: "2026-06-01"})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrEmployeeNotRehireEligible)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("active employee cannot be rehired", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).
+			Return(&employee.Employee{ID: targetID, CompanyID: companyID}, nil)
+
+		_, err := deps.service.Rehire(ctx, companyID.String(), targetID.String(), employee.RehireEmployeeRequest{HireDate: "2026-06-01"})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrEmployeeNotTerminated)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("hire date must be after last termination date", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.EXPECT().WithTx(gomock.Any()).Return(deps.repo)
+		deps.repo.EXPECT().FindByIDAndCompany(ctx, companyID.String(), targetID.String()).Return(terminated(true), nil)
+
+		_, err := deps.service.Rehire(ctx, companyID.String(), targetID.String(), employee.RehireEmployeeRequest{HireDate: "2026-01-31"})
+
+		assert.ErrorIs(t, err, employeeerrors.ErrInvalidRehireDate)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
 // Helper
 type outboxRequestIDMatcher struct {
 	expectedRID string
@@ -551,7 +1160,7 @@ func (m outboxRequestIDMatcher) Matches(x any) bool {
 	}
 
 	// Cek RequestID di dalam JSON Payload
-	var payload events.EmployeeCreatedEvent
+	var payload events.EmployeeLifecycleEvent
 	if err := json.Unmarshal(event.Payload, &payload); err != nil {
 		return false
 	}
@@ -0,0 +1,44 @@
+package employee
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RunTerminationSweep menonaktifkan akun login karyawan yang hari terakhirnya sudah tiba,
+// untuk terminasi yang dicatat dengan tanggal di masa depan.
+func RunTerminationSweep(
+	ctx context.Context,
+	repo Repository,
+	logger *zap.Logger,
+	interval time.Duration,
+) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	log := logger.Named("employee.termination_sweep")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Info("termination sweep started", zap.Duration("interval", interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("termination sweep stopped")
+			return
+		case <-ticker.C:
+			deactivated, err := repo.DeactivateTerminatedUsers(ctx, time.Now().UTC())
+			if err != nil {
+				log.Error("deactivate terminated users failed", zap.Error(err))
+				continue
+			}
+			if deactivated > 0 {
+				log.Info("terminated users deactivated", zap.Int64("count", deactivated))
+			}
+		}
+	}
+}
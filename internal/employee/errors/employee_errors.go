@@ -41,4 +41,104 @@ var (
 		"Missing required fields",
 		http.StatusBadRequest,
 	)
+	ErrInvalidPTKPStatus = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid PTKP status, expected TK/0-TK/3 or K/0-K/3",
+		http.StatusBadRequest,
+	)
+	ErrInvalidBankAccount = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid bank account, bank_code is required and account number must be 5-34 digits",
+		http.StatusBadRequest,
+	)
+	ErrInvalidEmploymentStatus = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid employment status, expected active, probation, contract or inactive",
+		http.StatusBadRequest,
+	)
+	ErrTerminationManagedStatus = apperror.New(
+		apperror.CodeInvalidInput,
+		"Terminated status can only be changed through the termination endpoint",
+		http.StatusBadRequest,
+	)
+	ErrTerminationDateManaged = apperror.New(
+		apperror.CodeInvalidInput,
+		"Termination date of a terminated employee can only be cleared through the rehire endpoint",
+		http.StatusBadRequest,
+	)
+	ErrInvalidTerminationReason = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid termination reason, expected RESIGNATION, DISMISSAL or CONTRACT_END",
+		http.StatusBadRequest,
+	)
+	ErrInvalidTerminationDate = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid termination_date, expected YYYY-MM-DD on or after hire_date",
+		http.StatusBadRequest,
+	)
+	ErrEmployeeAlreadyTerminated = apperror.New(
+		apperror.CodeInvalidState,
+		"Employee is already terminated",
+		http.StatusConflict,
+	)
+	ErrEmployeeNotTerminated = apperror.New(
+		apperror.CodeInvalidState,
+		"Only terminated employees can be rehired",
+		http.StatusConflict,
+	)
+	ErrEmployeeNotRehireEligible = apperror.New(
+		apperror.CodeInvalidState,
+		"Employee is not eligible for rehire",
+		http.StatusConflict,
+	)
+	ErrInvalidRehireDate = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid hire_date, expected YYYY-MM-DD after the last termination_date",
+		http.StatusBadRequest,
+	)
+	ErrInvalidImportFile = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid import file, expected CSV or XLSX with a header row and at least one data row",
+		http.StatusBadRequest,
+	)
+	ErrImportMissingColumns = apperror.New(
+		apperror.CodeInvalidInput,
+		"Import file must have full_name, email, hire_date and position columns",
+		http.StatusBadRequest,
+	)
+	ErrImportTooManyRows = apperror.New(
+		apperror.CodeInvalidInput,
+		"Import file exceeds the maximum of 2000 rows",
+		http.StatusBadRequest,
+	)
+	ErrImportNoValidRows = apperror.New(
+		apperror.CodeInvalidInput,
+		"Import file has no valid rows, run a dry run to see the validation report",
+		http.StatusBadRequest,
+	)
+	ErrEmployeeImportNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"Employee import not found",
+		http.StatusNotFound,
+	)
+	ErrInvalidManagerID = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid manager_id",
+		http.StatusBadRequest,
+	)
+	ErrInvalidManager = apperror.New(
+		apperror.CodeInvalidInput,
+		"Manager must be an active employee in the same company",
+		http.StatusBadRequest,
+	)
+	ErrManagerCycle = apperror.New(
+		apperror.CodeInvalidInput,
+		"Manager cannot be the employee itself or one of their direct or indirect reports",
+		http.StatusBadRequest,
+	)
+	ErrInvalidEmployeeFilter = apperror.New(
+		apperror.CodeInvalidInput,
+		"Invalid filter, expected UUID department_id, position_id and manager_id, a known status and YYYY-MM-DD hire dates",
+		http.StatusBadRequest,
+	)
 )
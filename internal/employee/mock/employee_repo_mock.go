@@ -14,6 +14,7 @@ import (
 	sql "database/sql"
 	employee "go-hris/internal/employee"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,6 +43,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ActivateUser mocks base method.
+func (m *MockRepository) ActivateUser(ctx context.Context, companyID, employeeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateUser", ctx, companyID, employeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateUser indicates an expected call of ActivateUser.
+func (mr *MockRepositoryMockRecorder) ActivateUser(ctx, companyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateUser", reflect.TypeOf((*MockRepository)(nil).ActivateUser), ctx, companyID, employeeID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, emp *employee.Employee) error {
 	m.ctrl.T.Helper()
@@ -56,33 +71,75 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, emp any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, emp)
 }
 
-// Delete mocks base method.
-func (m *MockRepository) Delete(ctx context.Context, companyID, id string) error {
+// CreateEmploymentStint mocks base method.
+func (m *MockRepository) CreateEmploymentStint(ctx context.Context, stint *employee.EmploymentStint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, companyID, id)
+	ret := m.ctrl.Call(m, "CreateEmploymentStint", ctx, stint)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
+// CreateEmploymentStint indicates an expected call of CreateEmploymentStint.
+func (mr *MockRepositoryMockRecorder) CreateEmploymentStint(ctx, stint any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, companyID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmploymentStint", reflect.TypeOf((*MockRepository)(nil).CreateEmploymentStint), ctx, stint)
 }
 
-// FindAllByCompany mocks base method.
-func (m *MockRepository) FindAllByCompany(ctx context.Context, companyID string) ([]employee.Employee, error) {
+// CreateImport mocks base method.
+func (m *MockRepository) CreateImport(ctx context.Context, job *employee.EmployeeImport) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByCompany", ctx, companyID)
-	ret0, _ := ret[0].([]employee.Employee)
+	ret := m.ctrl.Call(m, "CreateImport", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImport indicates an expected call of CreateImport.
+func (mr *MockRepositoryMockRecorder) CreateImport(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImport", reflect.TypeOf((*MockRepository)(nil).CreateImport), ctx, job)
+}
+
+// DeactivateTerminatedUsers mocks base method.
+func (m *MockRepository) DeactivateTerminatedUsers(ctx context.Context, asOf time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTerminatedUsers", ctx, asOf)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByCompany indicates an expected call of FindAllByCompany.
-func (mr *MockRepositoryMockRecorder) FindAllByCompany(ctx, companyID any) *gomock.Call {
+// DeactivateTerminatedUsers indicates an expected call of DeactivateTerminatedUsers.
+func (mr *MockRepositoryMockRecorder) DeactivateTerminatedUsers(ctx, asOf any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTerminatedUsers", reflect.TypeOf((*MockRepository)(nil).DeactivateTerminatedUsers), ctx, asOf)
+}
+
+// DeactivateUser mocks base method.
+func (m *MockRepository) DeactivateUser(ctx context.Context, companyID, employeeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, companyID, employeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockRepositoryMockRecorder) DeactivateUser(ctx, companyID, employeeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompany", reflect.TypeOf((*MockRepository)(nil).FindAllByCompany), ctx, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockRepository)(nil).DeactivateUser), ctx, companyID, employeeID)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, companyID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, companyID, id)
 }
 
 // FindByIDAndCompany mocks base method.
@@ -100,6 +157,51 @@ func (mr *MockRepositoryMockRecorder) FindByIDAndCompany(ctx, companyID, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindByIDAndCompany), ctx, companyID, id)
 }
 
+// FindExistingEmails mocks base method.
+func (m *MockRepository) FindExistingEmails(ctx context.Context, companyID string, emails []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExistingEmails", ctx, companyID, emails)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExistingEmails indicates an expected call of FindExistingEmails.
+func (mr *MockRepositoryMockRecorder) FindExistingEmails(ctx, companyID, emails any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExistingEmails", reflect.TypeOf((*MockRepository)(nil).FindExistingEmails), ctx, companyID, emails)
+}
+
+// FindImportByIDAndCompany mocks base method.
+func (m *MockRepository) FindImportByIDAndCompany(ctx context.Context, companyID, id string) (*employee.EmployeeImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindImportByIDAndCompany", ctx, companyID, id)
+	ret0, _ := ret[0].(*employee.EmployeeImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindImportByIDAndCompany indicates an expected call of FindImportByIDAndCompany.
+func (mr *MockRepositoryMockRecorder) FindImportByIDAndCompany(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImportByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindImportByIDAndCompany), ctx, companyID, id)
+}
+
+// FindImportPositions mocks base method.
+func (m *MockRepository) FindImportPositions(ctx context.Context, companyID string) ([]employee.ImportPosition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindImportPositions", ctx, companyID)
+	ret0, _ := ret[0].([]employee.ImportPosition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindImportPositions indicates an expected call of FindImportPositions.
+func (mr *MockRepositoryMockRecorder) FindImportPositions(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImportPositions", reflect.TypeOf((*MockRepository)(nil).FindImportPositions), ctx, companyID)
+}
+
 // FindOptionsByCompany mocks base method.
 func (m *MockRepository) FindOptionsByCompany(ctx context.Context, companyID string) ([]employee.Employee, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +217,52 @@ func (mr *MockRepositoryMockRecorder) FindOptionsByCompany(ctx, companyID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOptionsByCompany", reflect.TypeOf((*MockRepository)(nil).FindOptionsByCompany), ctx, companyID)
 }
 
+// FindOrgChart mocks base method.
+func (m *MockRepository) FindOrgChart(ctx context.Context, companyID, rootID string) ([]employee.OrgChartEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrgChart", ctx, companyID, rootID)
+	ret0, _ := ret[0].([]employee.OrgChartEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrgChart indicates an expected call of FindOrgChart.
+func (mr *MockRepositoryMockRecorder) FindOrgChart(ctx, companyID, rootID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrgChart", reflect.TypeOf((*MockRepository)(nil).FindOrgChart), ctx, companyID, rootID)
+}
+
+// FindPageByCompany mocks base method.
+func (m *MockRepository) FindPageByCompany(ctx context.Context, companyID string, filter employee.EmployeeQueryFilter) ([]employee.Employee, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCompany", ctx, companyID, filter)
+	ret0, _ := ret[0].([]employee.Employee)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCompany indicates an expected call of FindPageByCompany.
+func (mr *MockRepositoryMockRecorder) FindPageByCompany(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCompany", reflect.TypeOf((*MockRepository)(nil).FindPageByCompany), ctx, companyID, filter)
+}
+
+// FindReportIDs mocks base method.
+func (m *MockRepository) FindReportIDs(ctx context.Context, companyID, managerID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReportIDs", ctx, companyID, managerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReportIDs indicates an expected call of FindReportIDs.
+func (mr *MockRepositoryMockRecorder) FindReportIDs(ctx, companyID, managerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReportIDs", reflect.TypeOf((*MockRepository)(nil).FindReportIDs), ctx, companyID, managerID)
+}
+
 // GetDepartmentIDByPosition mocks base method.
 func (m *MockRepository) GetDepartmentIDByPosition(ctx context.Context, companyID, positionID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +278,35 @@ func (mr *MockRepositoryMockRecorder) GetDepartmentIDByPosition(ctx, companyID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepartmentIDByPosition", reflect.TypeOf((*MockRepository)(nil).GetDepartmentIDByPosition), ctx, companyID, positionID)
 }
 
+// IsInReportingLine mocks base method.
+func (m *MockRepository) IsInReportingLine(ctx context.Context, companyID, managerID, employeeID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInReportingLine", ctx, companyID, managerID, employeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInReportingLine indicates an expected call of IsInReportingLine.
+func (mr *MockRepositoryMockRecorder) IsInReportingLine(ctx, companyID, managerID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInReportingLine", reflect.TypeOf((*MockRepository)(nil).IsInReportingLine), ctx, companyID, managerID, employeeID)
+}
+
+// LockHierarchy mocks base method.
+func (m *MockRepository) LockHierarchy(ctx context.Context, companyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockHierarchy", ctx, companyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockHierarchy indicates an expected call of LockHierarchy.
+func (mr *MockRepositoryMockRecorder) LockHierarchy(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockHierarchy", reflect.TypeOf((*MockRepository)(nil).LockHierarchy), ctx, companyID)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, emp *employee.Employee) error {
 	m.ctrl.T.Helper()
@@ -144,6 +321,20 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, emp any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, emp)
 }
 
+// UpdateImport mocks base method.
+func (m *MockRepository) UpdateImport(ctx context.Context, job *employee.EmployeeImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateImport", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateImport indicates an expected call of UpdateImport.
+func (mr *MockRepositoryMockRecorder) UpdateImport(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateImport", reflect.TypeOf((*MockRepository)(nil).UpdateImport), ctx, job)
+}
+
 // WithTx mocks base method.
 func (m *MockRepository) WithTx(tx *sql.Tx) employee.Repository {
 	m.ctrl.T.Helper()
@@ -71,18 +71,19 @@ func (mr *MockServiceMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error) {
+func (m *MockService) GetAll(ctx context.Context, companyID string, filterReq employee.GetEmployeesFilterRequest) ([]employee.EmployeeResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, companyID)
+	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, filterReq)
 	ret0, _ := ret[0].([]employee.EmployeeResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, companyID any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, filterReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, filterReq)
 }
 
 // GetByID mocks base method.
@@ -100,6 +101,21 @@ func (mr *MockServiceMockRecorder) GetByID(ctx, companyID, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, companyID, id)
 }
 
+// GetImport mocks base method.
+func (m *MockService) GetImport(ctx context.Context, companyID, id string) (employee.EmployeeImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", ctx, companyID, id)
+	ret0, _ := ret[0].(employee.EmployeeImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockServiceMockRecorder) GetImport(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockService)(nil).GetImport), ctx, companyID, id)
+}
+
 // GetOptions mocks base method.
 func (m *MockService) GetOptions(ctx context.Context, companyID string) ([]employee.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
@@ -115,6 +131,125 @@ func (mr *MockServiceMockRecorder) GetOptions(ctx, companyID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockService)(nil).GetOptions), ctx, companyID)
 }
 
+// GetOrgChart mocks base method.
+func (m *MockService) GetOrgChart(ctx context.Context, companyID, rootID string) ([]employee.OrgChartNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrgChart", ctx, companyID, rootID)
+	ret0, _ := ret[0].([]employee.OrgChartNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrgChart indicates an expected call of GetOrgChart.
+func (mr *MockServiceMockRecorder) GetOrgChart(ctx, companyID, rootID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrgChart", reflect.TypeOf((*MockService)(nil).GetOrgChart), ctx, companyID, rootID)
+}
+
+// GetReportIDs mocks base method.
+func (m *MockService) GetReportIDs(ctx context.Context, companyID, managerID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportIDs", ctx, companyID, managerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportIDs indicates an expected call of GetReportIDs.
+func (mr *MockServiceMockRecorder) GetReportIDs(ctx, companyID, managerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportIDs", reflect.TypeOf((*MockService)(nil).GetReportIDs), ctx, companyID, managerID)
+}
+
+// IsInReportingLine mocks base method.
+func (m *MockService) IsInReportingLine(ctx context.Context, companyID, managerID, employeeID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInReportingLine", ctx, companyID, managerID, employeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInReportingLine indicates an expected call of IsInReportingLine.
+func (mr *MockServiceMockRecorder) IsInReportingLine(ctx, companyID, managerID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInReportingLine", reflect.TypeOf((*MockService)(nil).IsInReportingLine), ctx, companyID, managerID, employeeID)
+}
+
+// PreviewImport mocks base method.
+func (m *MockService) PreviewImport(ctx context.Context, companyID, fileName string, content []byte) (employee.EmployeeImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewImport", ctx, companyID, fileName, content)
+	ret0, _ := ret[0].(employee.EmployeeImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewImport indicates an expected call of PreviewImport.
+func (mr *MockServiceMockRecorder) PreviewImport(ctx, companyID, fileName, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewImport", reflect.TypeOf((*MockService)(nil).PreviewImport), ctx, companyID, fileName, content)
+}
+
+// ProcessImport mocks base method.
+func (m *MockService) ProcessImport(ctx context.Context, companyID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessImport", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessImport indicates an expected call of ProcessImport.
+func (mr *MockServiceMockRecorder) ProcessImport(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessImport", reflect.TypeOf((*MockService)(nil).ProcessImport), ctx, companyID, id)
+}
+
+// Rehire mocks base method.
+func (m *MockService) Rehire(ctx context.Context, companyID, id string, req employee.RehireEmployeeRequest) (employee.EmployeeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rehire", ctx, companyID, id, req)
+	ret0, _ := ret[0].(employee.EmployeeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rehire indicates an expected call of Rehire.
+func (mr *MockServiceMockRecorder) Rehire(ctx, companyID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rehire", reflect.TypeOf((*MockService)(nil).Rehire), ctx, companyID, id, req)
+}
+
+// StartImport mocks base method.
+func (m *MockService) StartImport(ctx context.Context, companyID, actorID, fileName string, content []byte) (employee.EmployeeImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", ctx, companyID, actorID, fileName, content)
+	ret0, _ := ret[0].(employee.EmployeeImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockServiceMockRecorder) StartImport(ctx, companyID, actorID, fileName, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockService)(nil).StartImport), ctx, companyID, actorID, fileName, content)
+}
+
+// Terminate mocks base method.
+func (m *MockService) Terminate(ctx context.Context, companyID, actorID, id string, req employee.TerminateEmployeeRequest) (employee.EmployeeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Terminate", ctx, companyID, actorID, id, req)
+	ret0, _ := ret[0].(employee.EmployeeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Terminate indicates an expected call of Terminate.
+func (mr *MockServiceMockRecorder) Terminate(ctx, companyID, actorID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Terminate", reflect.TypeOf((*MockService)(nil).Terminate), ctx, companyID, actorID, id, req)
+}
+
 // Update mocks base method.
 func (m *MockService) Update(ctx context.Context, companyID, id string, req employee.UpdateEmployeeRequest) (employee.EmployeeResponse, error) {
 	m.ctrl.T.Helper()
@@ -13,6 +13,33 @@ import (
 	"go.uber.org/zap"
 )
 
+// LifecycleAction adalah tindakan consumer gaji default untuk satu event lifecycle karyawan.
+type LifecycleAction int
+
+const (
+	LifecycleCreateSalary LifecycleAction = iota
+	LifecycleSkip
+	LifecycleHalt
+)
+
+// ClassifyLifecycleEvent menentukan tindakan consumer gaji default, dipakai bersama oleh
+// semua consumer lifecycle agar aturannya tidak terduplikasi.
+//
+// Event dengan schema_version lebih baru menghasilkan LifecycleHalt: consumer berhenti tanpa
+// commit sehingga offset tetap di event tersebut sampai consumer di-upgrade. Melewatinya bisa
+// menghilangkan employee_created dan merusak urutan event per karyawan.
+// Gaji default hanya dibuat untuk karyawan baru; event lain (termasuk rehire, yang tetap
+// memakai riwayat gaji lama) cukup di-commit.
+func ClassifyLifecycleEvent(event events.EmployeeLifecycleEvent) LifecycleAction {
+	if !event.Supported() {
+		return LifecycleHalt
+	}
+	if !event.IsCreated() {
+		return LifecycleSkip
+	}
+	return LifecycleCreateSalary
+}
+
 type EmployeeCreatedConsumer struct {
 	reader  *kafka.Reader
 	service Service
@@ -33,7 +60,7 @@ func NewEmployeeCreatedConsumer(
 	return &EmployeeCreatedConsumer{
 		reader: kafka.NewReader(kafka.ReaderConfig{
 			Brokers:        []string{broker},
-			Topic:          events.EmployeeCreatedTopic,
+			Topic:          events.EmployeeLifecycleTopic,
 			GroupID:        groupID,
 			CommitInterval: time.Second,
 			StartOffset:    kafka.FirstOffset,
@@ -55,7 +82,7 @@ func (c *EmployeeCreatedConsumer) Start(ctx context.Context) {
 				continue
 			}
 
-			var event events.EmployeeCreatedEvent
+			var event events.EmployeeLifecycleEvent
 			if err := json.Unmarshal(msg.Value, &event); err != nil {
 				c.logger.Error("decode employee_created event failed", zap.Error(err))
 				if commitErr := c.reader.CommitMessages(ctx, msg); commitErr != nil {
@@ -64,6 +91,20 @@ func (c *EmployeeCreatedConsumer) Start(ctx context.Context) {
 				continue
 			}
 
+			switch ClassifyLifecycleEvent(event) {
+			case LifecycleHalt:
+				c.logger.Error("unsupported employee lifecycle schema version, consumer stopped without commit",
+					zap.Int("schema_version", event.SchemaVersion),
+					zap.String("event_id", event.EventID),
+				)
+				return
+			case LifecycleSkip:
+				if commitErr := c.reader.CommitMessages(ctx, msg); commitErr != nil {
+					c.logger.Error("commit skipped employee lifecycle event failed", zap.Error(commitErr))
+				}
+				continue
+			}
+
 			effectiveDate := time.Now().UTC().Format("2006-01-02")
 			_, err = c.service.Create(ctx, event.CompanyID, CreateEmployeeSalaryRequest{
 				EmployeeID:    event.EmployeeID,
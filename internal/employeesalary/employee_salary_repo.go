@@ -3,6 +3,7 @@ package employeesalary
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -14,6 +15,7 @@ type Repository interface {
 	FindAllByCompany(ctx context.Context, companyID string) ([]EmployeeSalary, error)
 	FindByIDAndCompany(ctx context.Context, companyID string, id string) (*EmployeeSalary, error)
 	Delete(ctx context.Context, companyID string, id string) error
+	HasClosedPayrollPeriodFrom(ctx context.Context, companyID string, effectiveDate time.Time) (bool, error)
 }
 
 type repository struct {
@@ -75,3 +77,16 @@ func (r *repository) Delete(ctx context.Context, companyID string, id string) er
 		Where("employees.company_id = ?", companyID).
 		Delete(&EmployeeSalary{}).Error
 }
+
+// HasClosedPayrollPeriodFrom mengecek apakah ada periode payroll CLOSED yang ikut terdampak
+// gaji dengan tanggal berlaku effectiveDate (periode yang berakhir pada atau setelah tanggal tersebut).
+func (r *repository) HasClosedPayrollPeriodFrom(ctx context.Context, companyID string, effectiveDate time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("payroll_periods").
+		Where("company_id = ?", companyID).
+		Where("status = ?", "CLOSED").
+		Where("period_end >= ?", effectiveDate).
+		Count(&count).Error
+	return count > 0, err
+}
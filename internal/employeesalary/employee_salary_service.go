@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"time"
 
+	employeesalaryerrors "go-hris/internal/employeesalary/errors"
+
 	"github.com/google/uuid"
 )
 
@@ -48,6 +50,9 @@ func (s *service) Create(
 	if err != nil {
 		return EmployeeSalaryResponse{}, err
 	}
+	if err := ensurePayrollPeriodOpen(ctx, qtx, companyID, effectiveDate); err != nil {
+		return EmployeeSalaryResponse{}, err
+	}
 
 	salary := &EmployeeSalary{
 		ID:            uuid.New(),
@@ -123,6 +128,9 @@ func (s *service) Update(
 	if err != nil {
 		return EmployeeSalaryResponse{}, err
 	}
+	if err := ensurePayrollPeriodOpen(ctx, qtx, companyID, effectiveDate); err != nil {
+		return EmployeeSalaryResponse{}, err
+	}
 
 	newSalary := &EmployeeSalary{
 		ID:            uuid.New(),
@@ -154,6 +162,14 @@ func (s *service) Delete(
 
 	qtx := s.repo.WithTx(tx)
 
+	salary, err := qtx.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+	if err := ensurePayrollPeriodOpen(ctx, qtx, companyID, salary.EffectiveDate); err != nil {
+		return err
+	}
+
 	if err := qtx.Delete(ctx, companyID, id); err != nil {
 		return mapRepositoryError(err)
 	}
@@ -161,6 +177,19 @@ func (s *service) Delete(
 	return tx.Commit()
 }
 
+// ensurePayrollPeriodOpen menolak perubahan gaji berlaku surut yang jatuh di periode payroll
+// yang sudah ditutup.
+func ensurePayrollPeriodOpen(ctx context.Context, repo Repository, companyID string, effectiveDate time.Time) error {
+	closed, err := repo.HasClosedPayrollPeriodFrom(ctx, companyID, effectiveDate)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return employeesalaryerrors.ErrSalaryPeriodClosed
+	}
+	return nil
+}
+
 func mapToResponse(salary EmployeeSalary) EmployeeSalaryResponse {
 	return EmployeeSalaryResponse{
 		ID:            salary.ID.String(),
@@ -9,6 +9,7 @@ import (
 
 	"go-hris/internal/employeesalary"
 	employeesalaryerrors "go-hris/internal/employeesalary/errors"
+	"go-hris/internal/events"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -22,6 +23,7 @@ type fakeSalaryRepository struct {
 	findAllByCompanyFn   func(ctx context.Context, companyID string) ([]employeesalary.EmployeeSalary, error)
 	findByIDAndCompanyFn func(ctx context.Context, companyID string, id string) (*employeesalary.EmployeeSalary, error)
 	deleteFn             func(ctx context.Context, companyID string, id string) error
+	hasClosedPeriodFn    func(ctx context.Context, companyID string, effectiveDate time.Time) (bool, error)
 }
 
 func (f *fakeSalaryRepository) WithTx(tx *sql.Tx) employeesalary.Repository {
@@ -59,6 +61,13 @@ func (f *fakeSalaryRepository) Delete(ctx context.Context, companyID string, id
 	return nil
 }
 
+func (f *fakeSalaryRepository) HasClosedPayrollPeriodFrom(ctx context.Context, companyID string, effectiveDate time.Time) (bool, error) {
+	if f.hasClosedPeriodFn != nil {
+		return f.hasClosedPeriodFn(ctx, companyID, effectiveDate)
+	}
+	return false, nil
+}
+
 type serviceDeps struct {
 	db      *sql.DB
 	sqlMock sqlmock.Sqlmock
@@ -305,6 +314,13 @@ func TestEmployeeSalaryService_Delete(t *testing.T) {
 	companyID := uuid.New().String()
 	salaryID := uuid.New().String()
 
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*employeesalary.EmployeeSalary, error) {
+		return &employeesalary.EmployeeSalary{
+			ID:            uuid.MustParse(id),
+			EffectiveDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}, nil
+	}
+
 	t.Run("success", func(t *testing.T) {
 		expectTx(t, deps.sqlMock, true)
 
@@ -333,3 +349,108 @@ func TestEmployeeSalaryService_Delete(t *testing.T) {
 		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
 	})
 }
+
+func TestEmployeeSalaryService_ClosedPayrollPeriod(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID := uuid.New()
+
+	closedFrom := func(t *testing.T, deps *serviceDeps) {
+		deps.repo.hasClosedPeriodFn = func(ctx context.Context, cid string, effectiveDate time.Time) (bool, error) {
+			assert.Equal(t, companyID, cid)
+			return !effectiveDate.After(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)), nil
+		}
+		deps.repo.createFn = func(ctx context.Context, salary *employeesalary.EmployeeSalary) error {
+			t.Fatal("salary must not be created in a closed period")
+			return nil
+		}
+	}
+
+	t.Run("create back-dated into closed period", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+		closedFrom(t, deps)
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Create(ctx, companyID, employeesalary.CreateEmployeeSalaryRequest{
+			EmployeeID:    employeeID.String(),
+			BaseSalary:    9000000,
+			EffectiveDate: "2026-01-15",
+		})
+
+		assert.ErrorIs(t, err, employeesalaryerrors.ErrSalaryPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("update back-dated into closed period", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+		closedFrom(t, deps)
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*employeesalary.EmployeeSalary, error) {
+			return &employeesalary.EmployeeSalary{ID: uuid.MustParse(id), EmployeeID: employeeID}, nil
+		}
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Update(ctx, companyID, uuid.New().String(), employeesalary.UpdateEmployeeSalaryRequest{
+			EmployeeID:    employeeID.String(),
+			BaseSalary:    9000000,
+			EffectiveDate: "2026-01-01",
+		})
+
+		assert.ErrorIs(t, err, employeesalaryerrors.ErrSalaryPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("delete salary effective in closed period", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+		closedFrom(t, deps)
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*employeesalary.EmployeeSalary, error) {
+			return &employeesalary.EmployeeSalary{
+				ID:            uuid.MustParse(id),
+				EffectiveDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			}, nil
+		}
+		deps.repo.deleteFn = func(ctx context.Context, cid string, id string) error {
+			t.Fatal("salary must not be deleted in a closed period")
+			return nil
+		}
+		expectTx(t, deps.sqlMock, false)
+
+		err := deps.service.Delete(ctx, companyID, uuid.New().String())
+
+		assert.ErrorIs(t, err, employeesalaryerrors.ErrSalaryPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("future-dated change allowed", func(t *testing.T) {
+		deps := setupServiceTest(t)
+		defer deps.db.Close()
+		closedFrom(t, deps)
+		deps.repo.createFn = nil
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*employeesalary.EmployeeSalary, error) {
+			return &employeesalary.EmployeeSalary{ID: uuid.MustParse(id), EmployeeID: employeeID}, nil
+		}
+		expectTx(t, deps.sqlMock, true)
+
+		_, err := deps.service.Create(ctx, companyID, employeesalary.CreateEmployeeSalaryRequest{
+			EmployeeID:    employeeID.String(),
+			BaseSalary:    9000000,
+			EffectiveDate: "2026-02-01",
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestClassifyLifecycleEvent(t *testing.T) {
+	current := events.EmployeeLifecycleSchemaVersion
+
+	assert.Equal(t, employeesalary.LifecycleCreateSalary, employeesalary.ClassifyLifecycleEvent(events.EmployeeLifecycleEvent{SchemaVersion: current, EventType: events.EmployeeCreatedEventType}))
+	// Event lama tanpa event_type hanya berasal dari Create
+	assert.Equal(t, employeesalary.LifecycleCreateSalary, employeesalary.ClassifyLifecycleEvent(events.EmployeeLifecycleEvent{}))
+	assert.Equal(t, employeesalary.LifecycleSkip, employeesalary.ClassifyLifecycleEvent(events.EmployeeLifecycleEvent{SchemaVersion: current, EventType: events.EmployeeRehiredEventType}))
+	// Versi skema lebih baru tidak boleh di-commit lalu dibuang
+	assert.Equal(t, employeesalary.LifecycleHalt, employeesalary.ClassifyLifecycleEvent(events.EmployeeLifecycleEvent{SchemaVersion: current + 1, EventType: events.EmployeeCreatedEventType}))
+}
@@ -11,4 +11,9 @@ var (
 		"Salary for this employee and effective date already exists",
 		http.StatusConflict,
 	)
+	ErrSalaryPeriodClosed = apperror.New(
+		apperror.CodeInvalidState,
+		"Salary change affects a closed payroll period",
+		http.StatusConflict,
+	)
 )
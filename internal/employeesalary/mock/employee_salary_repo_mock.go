@@ -14,6 +14,7 @@ import (
 	sql "database/sql"
 	employeesalary "go-hris/internal/employeesalary"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -100,6 +101,21 @@ func (mr *MockRepositoryMockRecorder) FindByIDAndCompany(ctx, companyID, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindByIDAndCompany), ctx, companyID, id)
 }
 
+// HasClosedPayrollPeriodFrom mocks base method.
+func (m *MockRepository) HasClosedPayrollPeriodFrom(ctx context.Context, companyID string, effectiveDate time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasClosedPayrollPeriodFrom", ctx, companyID, effectiveDate)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasClosedPayrollPeriodFrom indicates an expected call of HasClosedPayrollPeriodFrom.
+func (mr *MockRepositoryMockRecorder) HasClosedPayrollPeriodFrom(ctx, companyID, effectiveDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasClosedPayrollPeriodFrom", reflect.TypeOf((*MockRepository)(nil).HasClosedPayrollPeriodFrom), ctx, companyID, effectiveDate)
+}
+
 // WithTx mocks base method.
 func (m *MockRepository) WithTx(tx *sql.Tx) employeesalary.Repository {
 	m.ctrl.T.Helper()
@@ -0,0 +1,15 @@
+package events
+
+import "time"
+
+const EmployeeImportRequestedTopic = "hr.employee.import.requested.v1"
+
+const EmployeeImportRequestedEventType = "employee_import_requested"
+
+type EmployeeImportRequestedEvent struct {
+	EventType   string    `json:"event_type"`
+	ImportID    string    `json:"import_id"`
+	CompanyID   string    `json:"company_id"`
+	RequestedBy string    `json:"requested_by"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const EmployeeLifecycleTopic = "hr.employee.lifecycle.v1"
+
+// EmployeeLifecycleSchemaVersion dinaikkan jika envelope atau data event berubah secara
+// tidak kompatibel. Event lama tanpa schema_version dianggap versi 1.
+const EmployeeLifecycleSchemaVersion = 1
+
+const (
+	EmployeeCreatedEventType       = "employee_created"
+	EmployeeUpdatedEventType       = "employee_updated"
+	EmployeeTransferredEventType   = "employee_transferred"
+	EmployeeStatusChangedEventType = "employee_status_changed"
+	EmployeeTerminatedEventType    = "employee_terminated"
+	EmployeeRehiredEventType       = "employee_rehired"
+)
+
+// EmployeeLifecycleEvent adalah envelope bersama seluruh event di topic lifecycle karyawan.
+// Field level atas sama dengan event employee_created lama sehingga consumer lama tetap bisa
+// membacanya; detail per jenis event ada di Data.
+type EmployeeLifecycleEvent struct {
+	SchemaVersion int             `json:"schema_version"`
+	EventID       string          `json:"event_id"`
+	RequestID     string          `json:"request_id"`
+	EventType     string          `json:"event_type"`
+	EmployeeID    string          `json:"employee_id"`
+	CompanyID     string          `json:"company_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+func NewEmployeeLifecycleEvent(eventType, requestID, companyID, employeeID string, data interface{}) (EmployeeLifecycleEvent, error) {
+	event := EmployeeLifecycleEvent{
+		SchemaVersion: EmployeeLifecycleSchemaVersion,
+		EventID:       uuid.NewString(),
+		RequestID:     requestID,
+		EventType:     eventType,
+		EmployeeID:    employeeID,
+		CompanyID:     companyID,
+		OccurredAt:    time.Now().UTC(),
+	}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return EmployeeLifecycleEvent{}, err
+		}
+		event.Data = raw
+	}
+	return event, nil
+}
+
+// Supported menandakan consumer ini memahami versi skema event.
+func (e EmployeeLifecycleEvent) Supported() bool {
+	return e.SchemaVersion <= EmployeeLifecycleSchemaVersion
+}
+
+// IsCreated menandakan event employee_created. Event lama yang belum membawa event_type
+// hanya mungkin berasal dari Create.
+func (e EmployeeLifecycleEvent) IsCreated() bool {
+	return e.EventType == "" || e.EventType == EmployeeCreatedEventType
+}
+
+// DecodeData membaca Data ke struct data sesuai EventType.
+func (e EmployeeLifecycleEvent) DecodeData(v interface{}) error {
+	if len(e.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+type EmployeeCreatedData struct {
+	EmployeeNumber   string `json:"employee_number"`
+	HireDate         string `json:"hire_date"`
+	EmploymentStatus string `json:"employment_status"`
+	PositionID       string `json:"position_id,omitempty"`
+	DepartmentID     string `json:"department_id,omitempty"`
+}
+
+// EmployeeFieldChange mencatat satu field yang berubah. Field sensitif (nomor rekening)
+// dikirim dengan Redacted=true tanpa nilai.
+type EmployeeFieldChange struct {
+	Field    string `json:"field"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
+	Redacted bool   `json:"redacted,omitempty"`
+}
+
+type EmployeeUpdatedData struct {
+	Changes []EmployeeFieldChange `json:"changes"`
+}
+
+type EmployeeTransferredData struct {
+	FromPositionID   string `json:"from_position_id,omitempty"`
+	ToPositionID     string `json:"to_position_id,omitempty"`
+	FromDepartmentID string `json:"from_department_id,omitempty"`
+	ToDepartmentID   string `json:"to_department_id,omitempty"`
+}
+
+type EmployeeStatusChangedData struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+}
+
+type EmployeeTerminatedData struct {
+	TerminationDate string `json:"termination_date"` // Hari kerja terakhir, YYYY-MM-DD
+	Reason          string `json:"reason"`
+	RehireEligible  bool   `json:"rehire_eligible"`
+}
+
+type EmployeeRehiredData struct {
+	HireDate                string `json:"hire_date"`
+	PreviousTerminationDate string `json:"previous_termination_date,omitempty"`
+	EmploymentStatus        string `json:"employment_status"`
+}
@@ -1,5 +1,28 @@
 package leave
 
+import "go-hris/internal/shared/pagination"
+
+// GetLeavesFilterRequest adalah query string list cuti. sort_by: start_date (default, terbaru
+// dulu), end_date, created_at, status, employee_name; q mencari nama karyawan. Periode
+// date_from/date_to mengambil cuti yang beririsan.
+type GetLeavesFilterRequest struct {
+	pagination.Request
+	EmployeeID string `form:"employee_id" binding:"omitempty,uuid"` // Hanya berlaku untuk akses read all
+	Status     string `form:"status" binding:"omitempty,oneof=PENDING SUBMITTED APPROVED REJECTED CANCELLED"`
+	LeaveType  string `form:"leave_type" binding:"omitempty,oneof=ANNUAL SICK UNPAID"`
+	DateFrom   string `form:"date_from"` // YYYY-MM-DD
+	DateTo     string `form:"date_to"`   // YYYY-MM-DD
+}
+
+type LeaveQueryFilter struct {
+	EmployeeID *string
+	Status     *string
+	LeaveType  *string
+	DateFrom   *string
+	DateTo     *string
+	Page       pagination.Query
+}
+
 type CreateLeaveRequest struct {
 	EmployeeID string `json:"employee_id" binding:"required,uuid"`
 	LeaveType  string `json:"leave_type" binding:"required,oneof=ANNUAL SICK UNPAID"`
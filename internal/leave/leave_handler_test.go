@@ -38,7 +38,7 @@ func decodeEnvelope(t *testing.T, body []byte) apiEnvelope {
 
 type fakeLeaveService struct {
 	createFn  func(ctx context.Context, companyID, actorID string, req leave.CreateLeaveRequest) (leave.LeaveResponse, error)
-	getAllFn  func(ctx context.Context, companyID, actorID string, canReadAll bool) ([]leave.LeaveResponse, error)
+	getAllFn  func(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq leave.GetLeavesFilterRequest) ([]leave.LeaveResponse, int64, error)
 	getByIDFn func(ctx context.Context, companyID, id string) (leave.LeaveResponse, error)
 	updateFn  func(ctx context.Context, companyID, actorID, id string, req leave.UpdateLeaveRequest) (leave.LeaveResponse, error)
 	submitFn  func(ctx context.Context, companyID, actorID, id string) (leave.LeaveResponse, error)
@@ -50,8 +50,8 @@ type fakeLeaveService struct {
 func (f *fakeLeaveService) Create(ctx context.Context, companyID, actorID string, req leave.CreateLeaveRequest) (leave.LeaveResponse, error) {
 	return f.createFn(ctx, companyID, actorID, req)
 }
-func (f *fakeLeaveService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]leave.LeaveResponse, error) {
-	return f.getAllFn(ctx, companyID, actorID, canReadAll)
+func (f *fakeLeaveService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq leave.GetLeavesFilterRequest) ([]leave.LeaveResponse, int64, error) {
+	return f.getAllFn(ctx, companyID, actorID, canReadAll, filterReq)
 }
 func (f *fakeLeaveService) GetByID(ctx context.Context, companyID, id string) (leave.LeaveResponse, error) {
 	return f.getByIDFn(ctx, companyID, id)
@@ -193,20 +193,22 @@ func TestLeaveHandler_GetAll(t *testing.T) {
 		companyID := uuid.New().String()
 		actorID := uuid.New().String()
 		svc := &fakeLeaveService{
-			getAllFn: func(ctx context.Context, cid, aid string, canReadAll bool) ([]leave.LeaveResponse, error) {
+			getAllFn: func(ctx context.Context, cid, aid string, canReadAll bool, filterReq leave.GetLeavesFilterRequest) ([]leave.LeaveResponse, int64, error) {
 				assert.Equal(t, companyID, cid)
 				assert.Equal(t, actorID, aid)
 				assert.False(t, canReadAll)
+				assert.Equal(t, "SICK", filterReq.LeaveType)
+				assert.Equal(t, "budi", filterReq.Search)
 				return []leave.LeaveResponse{
 					{ID: uuid.New().String(), CompanyID: cid, LeaveType: "SICK", Status: leave.StatusPending},
-				}, nil
+				}, 1, nil
 			},
 		}
 
 		h := leave.NewHandler(svc)
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Request = httptest.NewRequest(http.MethodGet, "/leaves", nil)
+		c.Request = httptest.NewRequest(http.MethodGet, "/leaves?leave_type=SICK&q=budi", nil)
 		c.Set("company_id", companyID)
 		c.Set("employee_id", actorID)
 		c.Set("role", "EMPLOYEE")
@@ -227,8 +229,8 @@ func TestLeaveHandler_GetAll(t *testing.T) {
 
 	t.Run("negative service error", func(t *testing.T) {
 		svc := &fakeLeaveService{
-			getAllFn: func(ctx context.Context, cid, aid string, canReadAll bool) ([]leave.LeaveResponse, error) {
-				return nil, errors.New("db error")
+			getAllFn: func(ctx context.Context, cid, aid string, canReadAll bool, filterReq leave.GetLeavesFilterRequest) ([]leave.LeaveResponse, int64, error) {
+				return nil, 0, errors.New("db error")
 			},
 		}
 		h := leave.NewHandler(svc)
@@ -247,6 +249,18 @@ func TestLeaveHandler_GetAll(t *testing.T) {
 		assert.Equal(t, "INTERNAL_ERROR", env.Error.Code)
 		assert.Equal(t, "Internal server error", env.Error.Message)
 	})
+
+	t.Run("negative invalid status filter", func(t *testing.T) {
+		h := leave.NewHandler(&fakeLeaveService{})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/leaves?status=UNKNOWN", nil)
+		c.Set("company_id", uuid.New().String())
+		c.Set("employee_id", uuid.New().String())
+
+		h.GetAll(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestLeaveHandler_GetByID(t *testing.T) {
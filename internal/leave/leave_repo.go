@@ -3,6 +3,7 @@ package leave
 import (
 	"context"
 	"database/sql"
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/tenant"
 	"time"
 
@@ -13,13 +14,13 @@ import (
 type Repository interface {
 	WithTx(tx *sql.Tx) Repository
 	Create(ctx context.Context, l *Leave) error
-	FindAllByCompany(ctx context.Context, companyID string) ([]Leave, error)
-	FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]Leave, error)
+	FindPageByCompany(ctx context.Context, companyID string, filter LeaveQueryFilter) ([]Leave, int64, error)
 	FindByIDAndCompany(ctx context.Context, companyID, id string) (*Leave, error)
 	Update(ctx context.Context, l *Leave) error
 	Delete(ctx context.Context, companyID, id string) error
 	EmployeeBelongsToCompany(ctx context.Context, companyID, employeeID string) (bool, error)
 	HasOverlappingPeriod(ctx context.Context, companyID, employeeID string, startDate, endDate time.Time, excludeID *string) (bool, error)
+	MarkPayrollsStale(ctx context.Context, companyID, leaveID string) error
 }
 
 type repository struct {
@@ -39,25 +40,55 @@ func (r *repository) Create(ctx context.Context, l *Leave) error {
 	return r.db.WithContext(ctx).Create(l).Error
 }
 
-func (r *repository) FindAllByCompany(ctx context.Context, companyID string) ([]Leave, error) {
-	var leaves []Leave
-	err := r.db.WithContext(ctx).
-		Preload("Employee").
-		Scopes(tenant.Scope(companyID)).
-		Order("start_date DESC").
-		Find(&leaves).Error
-	return leaves, err
+var leaveListSort = pagination.Sort{
+	Columns: map[string]string{
+		"start_date":    "leaves.start_date",
+		"end_date":      "leaves.end_date",
+		"created_at":    "leaves.created_at",
+		"status":        "leaves.status",
+		"employee_name": "employees.full_name",
+	},
+	Default:    "start_date",
+	DefaultDir: "desc",
+	TieBreaker: "leaves.id",
 }
 
-func (r *repository) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]Leave, error) {
+// FindPageByCompany mengembalikan satu halaman cuti sesuai filter beserta total seluruh data
+// yang cocok. Join employees dipakai untuk search dan sort nama karyawan.
+func (r *repository) FindPageByCompany(ctx context.Context, companyID string, filter LeaveQueryFilter) ([]Leave, int64, error) {
+	db := r.db.WithContext(ctx).
+		Model(&Leave{}).
+		Joins("LEFT JOIN employees ON employees.id = leaves.employee_id").
+		Where("leaves.company_id = ?", companyID).
+		Scopes(pagination.Search(filter.Page.Search, "employees.full_name"))
+
+	if filter.EmployeeID != nil {
+		db = db.Where("leaves.employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != nil {
+		db = db.Where("leaves.status = ?", *filter.Status)
+	}
+	if filter.LeaveType != nil {
+		db = db.Where("leaves.leave_type = ?", *filter.LeaveType)
+	}
+	if filter.DateFrom != nil {
+		db = db.Where("leaves.end_date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		db = db.Where("leaves.start_date <= ?", *filter.DateTo)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var leaves []Leave
-	err := r.db.WithContext(ctx).
-		Preload("Employee").
-		Scopes(tenant.Scope(companyID)).
-		Where("employee_id = ?", employeeID).
-		Order("start_date DESC").
+	err := db.Preload("Employee").
+		Scopes(pagination.Paginate(filter.Page)).
 		Find(&leaves).Error
-	return leaves, err
+	return leaves, total, err
 }
 
 func (r *repository) FindByIDAndCompany(ctx context.Context, companyID, id string) (*Leave, error) {
@@ -107,3 +138,23 @@ func (r *repository) HasOverlappingPeriod(ctx context.Context, companyID, employ
 	err := db.Count(&count).Error
 	return count > 0, err
 }
+
+// MarkPayrollsStale menandai payroll DRAFT karyawan yang periodenya beririsan dengan
+// cuti APPROVED ini, agar HR me-regenerate payroll setelah cuti berubah.
+func (r *repository) MarkPayrollsStale(ctx context.Context, companyID, leaveID string) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE payrolls p
+		SET is_stale = TRUE,
+			stale_reason = 'leave ' || l.id::text || ' changed',
+			updated_at = now()
+		FROM leaves l
+		WHERE l.id = ?
+			AND l.company_id = ?
+			AND l.status = ?
+			AND p.company_id = l.company_id
+			AND p.employee_id = l.employee_id
+			AND p.status = 'DRAFT'
+			AND p.deleted_at IS NULL
+			AND NOT (p.period_end < l.start_date OR p.period_start > l.end_date)
+	`, leaveID, companyID, StatusApproved).Error
+}
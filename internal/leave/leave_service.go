@@ -23,7 +23,7 @@ const (
 //go:generate mockgen -source=leave_service.go -destination=mock/leave_service_mock.go -package=mock
 type Service interface {
 	Create(ctx context.Context, companyID, actorID string, req CreateLeaveRequest) (LeaveResponse, error)
-	GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]LeaveResponse, error)
+	GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq GetLeavesFilterRequest) ([]LeaveResponse, int64, error)
 	GetByID(ctx context.Context, companyID, id string) (LeaveResponse, error)
 	Update(ctx context.Context, companyID, actorID, id string, req UpdateLeaveRequest) (LeaveResponse, error)
 	Submit(ctx context.Context, companyID, actorID, id string) (LeaveResponse, error)
@@ -126,23 +126,62 @@ func (s *service) Create(ctx context.Context, companyID, actorID string, req Cre
 	return mapToResponse(*l), nil
 }
 
-func (s *service) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]LeaveResponse, error) {
-	var (
-		leaves []Leave
-		err    error
-	)
-	if canReadAll {
-		leaves, err = s.repo.FindAllByCompany(ctx, companyID)
-	} else {
+func (s *service) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq GetLeavesFilterRequest) ([]LeaveResponse, int64, error) {
+	filter, err := buildListFilter(filterReq)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canReadAll {
+		// Tanpa akses read all, list selalu dibatasi ke cuti milik actor sendiri.
 		if _, parseErr := uuid.Parse(actorID); parseErr != nil {
-			return nil, leaveerrors.ErrInvalidActorID
+			return nil, 0, leaveerrors.ErrInvalidActorID
 		}
-		leaves, err = s.repo.FindAllByCompanyAndEmployee(ctx, companyID, actorID)
+		filter.EmployeeID = &actorID
 	}
+
+	leaves, total, err := s.repo.FindPageByCompany(ctx, companyID, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return mapToListResponse(leaves), nil
+	return mapToListResponse(leaves), total, nil
+}
+
+func buildListFilter(req GetLeavesFilterRequest) (LeaveQueryFilter, error) {
+	filter := LeaveQueryFilter{Page: req.Query(leaveListSort)}
+	if req.EmployeeID != "" {
+		if _, err := uuid.Parse(req.EmployeeID); err != nil {
+			return LeaveQueryFilter{}, leaveerrors.ErrInvalidEmployeeID
+		}
+		filter.EmployeeID = &req.EmployeeID
+	}
+	if req.Status != "" {
+		filter.Status = &req.Status
+	}
+	if req.LeaveType != "" {
+		filter.LeaveType = &req.LeaveType
+	}
+
+	var from, to time.Time
+	if req.DateFrom != "" {
+		d, err := parseDate(req.DateFrom)
+		if err != nil {
+			return LeaveQueryFilter{}, err
+		}
+		from = d
+		filter.DateFrom = &req.DateFrom
+	}
+	if req.DateTo != "" {
+		d, err := parseDate(req.DateTo)
+		if err != nil {
+			return LeaveQueryFilter{}, err
+		}
+		to = d
+		filter.DateTo = &req.DateTo
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return LeaveQueryFilter{}, leaveerrors.ErrInvalidDateRange
+	}
+	return filter, nil
 }
 
 func (s *service) GetByID(ctx context.Context, companyID, id string) (LeaveResponse, error) {
@@ -267,6 +306,10 @@ func (s *service) Update(ctx context.Context, companyID, actorID, id string, req
 		l.RejectionReason = nil
 	}
 
+	// Tandai payroll berdasarkan kondisi cuti sebelum dan sesudah perubahan
+	if err := qtx.MarkPayrollsStale(ctx, companyID, id); err != nil {
+		return LeaveResponse{}, err
+	}
 	if err := qtx.Update(ctx, l); err != nil {
 		s.logger.Error("update leave persist failed",
 			zap.String("leave_id", id),
@@ -274,6 +317,9 @@ func (s *service) Update(ctx context.Context, companyID, actorID, id string, req
 		)
 		return LeaveResponse{}, err
 	}
+	if err := qtx.MarkPayrollsStale(ctx, companyID, id); err != nil {
+		return LeaveResponse{}, err
+	}
 
 	if err := tx.Commit(); err != nil {
 		s.logger.Error("update leave commit failed",
@@ -386,6 +432,9 @@ func (s *service) transitionLeaveStatus(ctx context.Context, companyID, actorID,
 		)
 		return LeaveResponse{}, err
 	}
+	if err := qtx.MarkPayrollsStale(ctx, companyID, id); err != nil {
+		return LeaveResponse{}, err
+	}
 	if err := tx.Commit(); err != nil {
 		s.logger.Error("transition leave status commit failed",
 			zap.String("leave_id", id),
@@ -408,6 +457,9 @@ func (s *service) Delete(ctx context.Context, companyID, id string) error {
 	defer tx.Rollback()
 
 	qtx := s.repo.WithTx(tx)
+	if err := qtx.MarkPayrollsStale(ctx, companyID, id); err != nil {
+		return err
+	}
 	if err := qtx.Delete(ctx, companyID, id); err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go-hris/internal/leave"
+	leaveerrors "go-hris/internal/leave/errors"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -17,13 +18,13 @@ import (
 type fakeLeaveRepository struct {
 	withTxFn                 func(tx *sql.Tx) leave.Repository
 	createFn                 func(ctx context.Context, l *leave.Leave) error
-	findAllByCompanyFn       func(ctx context.Context, companyID string) ([]leave.Leave, error)
-	findAllByCompanyEmpFn    func(ctx context.Context, companyID, employeeID string) ([]leave.Leave, error)
+	findPageByCompanyFn      func(ctx context.Context, companyID string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error)
 	findByIDAndCompanyFn     func(ctx context.Context, companyID, id string) (*leave.Leave, error)
 	updateFn                 func(ctx context.Context, l *leave.Leave) error
 	deleteFn                 func(ctx context.Context, companyID, id string) error
 	employeeBelongsToCompany func(ctx context.Context, companyID, employeeID string) (bool, error)
 	hasOverlappingPeriodFn   func(ctx context.Context, companyID, employeeID string, startDate, endDate time.Time, excludeID *string) (bool, error)
+	markPayrollsStaleFn      func(ctx context.Context, companyID, leaveID string) error
 }
 
 func (f *fakeLeaveRepository) WithTx(tx *sql.Tx) leave.Repository {
@@ -40,18 +41,11 @@ func (f *fakeLeaveRepository) Create(ctx context.Context, l *leave.Leave) error
 	return nil
 }
 
-func (f *fakeLeaveRepository) FindAllByCompany(ctx context.Context, companyID string) ([]leave.Leave, error) {
-	if f.findAllByCompanyFn != nil {
-		return f.findAllByCompanyFn(ctx, companyID)
+func (f *fakeLeaveRepository) FindPageByCompany(ctx context.Context, companyID string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error) {
+	if f.findPageByCompanyFn != nil {
+		return f.findPageByCompanyFn(ctx, companyID, filter)
 	}
-	return nil, nil
-}
-
-func (f *fakeLeaveRepository) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]leave.Leave, error) {
-	if f.findAllByCompanyEmpFn != nil {
-		return f.findAllByCompanyEmpFn(ctx, companyID, employeeID)
-	}
-	return nil, nil
+	return nil, 0, nil
 }
 
 func (f *fakeLeaveRepository) FindByIDAndCompany(ctx context.Context, companyID, id string) (*leave.Leave, error) {
@@ -89,6 +83,13 @@ func (f *fakeLeaveRepository) HasOverlappingPeriod(ctx context.Context, companyI
 	return false, nil
 }
 
+func (f *fakeLeaveRepository) MarkPayrollsStale(ctx context.Context, companyID, leaveID string) error {
+	if f.markPayrollsStaleFn != nil {
+		return f.markPayrollsStaleFn(ctx, companyID, leaveID)
+	}
+	return nil
+}
+
 type leaveServiceDeps struct {
 	db      *sql.DB
 	sqlMock sqlmock.Sqlmock
@@ -211,8 +212,13 @@ func TestLeaveService_GetAll(t *testing.T) {
 		defer deps.db.Close()
 
 		employeeID := uuid.New()
-		deps.repo.findAllByCompanyFn = func(ctx context.Context, cid string) ([]leave.Leave, error) {
+		deps.repo.findPageByCompanyFn = func(ctx context.Context, cid string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error) {
 			assert.Equal(t, companyID, cid)
+			assert.Equal(t, employeeID.String(), *filter.EmployeeID)
+			assert.Equal(t, leave.StatusPending, *filter.Status)
+			assert.Equal(t, "2026-04-01", *filter.DateFrom)
+			assert.Nil(t, filter.DateTo)
+			assert.Equal(t, "leaves.start_date DESC, leaves.id DESC", filter.Page.OrderBy)
 			return []leave.Leave{
 				{
 					ID:         uuid.New(),
@@ -225,26 +231,65 @@ func TestLeaveService_GetAll(t *testing.T) {
 					Status:     leave.StatusPending,
 					CreatedBy:  uuid.New(),
 				},
-			}, nil
+			}, 11, nil
 		}
 
-		resp, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), true)
+		resp, total, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), true, leave.GetLeavesFilterRequest{
+			EmployeeID: employeeID.String(),
+			Status:     leave.StatusPending,
+			DateFrom:   "2026-04-01",
+		})
 
 		assert.NoError(t, err)
 		assert.Len(t, resp, 1)
+		assert.Equal(t, int64(11), total)
 		assert.Equal(t, employeeID.String(), resp[0].EmployeeID)
 		assert.Equal(t, 2, resp[0].TotalDays)
 	})
 
+	t.Run("without read all only own leaves", func(t *testing.T) {
+		deps := setupLeaveServiceTest(t)
+		defer deps.db.Close()
+
+		actorID := uuid.New().String()
+		deps.repo.findPageByCompanyFn = func(ctx context.Context, cid string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error) {
+			assert.Equal(t, actorID, *filter.EmployeeID)
+			return nil, 0, nil
+		}
+
+		_, _, err := deps.service.GetAll(ctx, companyID, actorID, false, leave.GetLeavesFilterRequest{EmployeeID: uuid.New().String()})
+		assert.NoError(t, err)
+	})
+
+	t.Run("negative invalid date range", func(t *testing.T) {
+		deps := setupLeaveServiceTest(t)
+		defer deps.db.Close()
+
+		_, _, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), true, leave.GetLeavesFilterRequest{
+			DateFrom: "2026-05-01",
+			DateTo:   "2026-04-01",
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "start_date")
+	})
+
+	t.Run("negative invalid employee filter", func(t *testing.T) {
+		deps := setupLeaveServiceTest(t)
+		defer deps.db.Close()
+
+		_, _, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), true, leave.GetLeavesFilterRequest{EmployeeID: "not-a-uuid"})
+		assert.ErrorIs(t, err, leaveerrors.ErrInvalidEmployeeID)
+	})
+
 	t.Run("negative repo error", func(t *testing.T) {
 		deps := setupLeaveServiceTest(t)
 		defer deps.db.Close()
 
-		deps.repo.findAllByCompanyEmpFn = func(ctx context.Context, cid, employeeID string) ([]leave.Leave, error) {
-			return nil, errors.New("db error")
+		deps.repo.findPageByCompanyFn = func(ctx context.Context, cid string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error) {
+			return nil, 0, errors.New("db error")
 		}
 
-		resp, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), false)
+		resp, _, err := deps.service.GetAll(ctx, companyID, uuid.New().String(), false, leave.GetLeavesFilterRequest{})
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
@@ -656,3 +701,61 @@ func TestLeaveService_SubmitApproveReject(t *testing.T) {
 		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
 	})
 }
+
+func TestLeaveService_Approve_MarksPayrollsStale(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	id := uuid.New().String()
+
+	deps := setupLeaveServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, true)
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, targetID string) (*leave.Leave, error) {
+		return &leave.Leave{
+			ID:        uuid.MustParse(targetID),
+			CompanyID: uuid.MustParse(cid),
+			LeaveType: "UNPAID",
+			Status:    leave.StatusSubmitted,
+		}, nil
+	}
+	updated := false
+	marked := false
+	deps.repo.updateFn = func(ctx context.Context, l *leave.Leave) error {
+		updated = true
+		return nil
+	}
+	deps.repo.markPayrollsStaleFn = func(ctx context.Context, cid, leaveID string) error {
+		assert.True(t, updated, "payroll harus ditandai setelah status cuti tersimpan")
+		assert.Equal(t, companyID, cid)
+		assert.Equal(t, id, leaveID)
+		marked = true
+		return nil
+	}
+
+	_, err := deps.service.Approve(ctx, companyID, actorID, id)
+
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestLeaveService_Delete_MarkStaleError(t *testing.T) {
+	deps := setupLeaveServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, false)
+	deps.repo.markPayrollsStaleFn = func(ctx context.Context, cid, leaveID string) error {
+		return errors.New("update failed")
+	}
+	deps.repo.deleteFn = func(ctx context.Context, cid, targetID string) error {
+		t.Fatal("delete should not run when marking payroll stale fails")
+		return nil
+	}
+
+	err := deps.service.Delete(context.Background(), uuid.New().String(), uuid.New().String())
+
+	assert.Error(t, err)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
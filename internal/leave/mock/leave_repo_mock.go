@@ -86,36 +86,6 @@ func (mr *MockRepositoryMockRecorder) EmployeeBelongsToCompany(ctx, companyID, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmployeeBelongsToCompany", reflect.TypeOf((*MockRepository)(nil).EmployeeBelongsToCompany), ctx, companyID, employeeID)
 }
 
-// FindAllByCompany mocks base method.
-func (m *MockRepository) FindAllByCompany(ctx context.Context, companyID string) ([]leave.Leave, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByCompany", ctx, companyID)
-	ret0, _ := ret[0].([]leave.Leave)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByCompany indicates an expected call of FindAllByCompany.
-func (mr *MockRepositoryMockRecorder) FindAllByCompany(ctx, companyID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompany", reflect.TypeOf((*MockRepository)(nil).FindAllByCompany), ctx, companyID)
-}
-
-// FindAllByCompanyAndEmployee mocks base method.
-func (m *MockRepository) FindAllByCompanyAndEmployee(ctx context.Context, companyID, employeeID string) ([]leave.Leave, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByCompanyAndEmployee", ctx, companyID, employeeID)
-	ret0, _ := ret[0].([]leave.Leave)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAllByCompanyAndEmployee indicates an expected call of FindAllByCompanyAndEmployee.
-func (mr *MockRepositoryMockRecorder) FindAllByCompanyAndEmployee(ctx, companyID, employeeID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompanyAndEmployee", reflect.TypeOf((*MockRepository)(nil).FindAllByCompanyAndEmployee), ctx, companyID, employeeID)
-}
-
 // FindByIDAndCompany mocks base method.
 func (m *MockRepository) FindByIDAndCompany(ctx context.Context, companyID, id string) (*leave.Leave, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +101,22 @@ func (mr *MockRepositoryMockRecorder) FindByIDAndCompany(ctx, companyID, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindByIDAndCompany), ctx, companyID, id)
 }
 
+// FindPageByCompany mocks base method.
+func (m *MockRepository) FindPageByCompany(ctx context.Context, companyID string, filter leave.LeaveQueryFilter) ([]leave.Leave, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCompany", ctx, companyID, filter)
+	ret0, _ := ret[0].([]leave.Leave)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCompany indicates an expected call of FindPageByCompany.
+func (mr *MockRepositoryMockRecorder) FindPageByCompany(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCompany", reflect.TypeOf((*MockRepository)(nil).FindPageByCompany), ctx, companyID, filter)
+}
+
 // HasOverlappingPeriod mocks base method.
 func (m *MockRepository) HasOverlappingPeriod(ctx context.Context, companyID, employeeID string, startDate, endDate time.Time, excludeID *string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -146,6 +132,20 @@ func (mr *MockRepositoryMockRecorder) HasOverlappingPeriod(ctx, companyID, emplo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlappingPeriod", reflect.TypeOf((*MockRepository)(nil).HasOverlappingPeriod), ctx, companyID, employeeID, startDate, endDate, excludeID)
 }
 
+// MarkPayrollsStale mocks base method.
+func (m *MockRepository) MarkPayrollsStale(ctx context.Context, companyID, leaveID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayrollsStale", ctx, companyID, leaveID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPayrollsStale indicates an expected call of MarkPayrollsStale.
+func (mr *MockRepositoryMockRecorder) MarkPayrollsStale(ctx, companyID, leaveID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayrollsStale", reflect.TypeOf((*MockRepository)(nil).MarkPayrollsStale), ctx, companyID, leaveID)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, l *leave.Leave) error {
 	m.ctrl.T.Helper()
@@ -86,18 +86,19 @@ func (mr *MockServiceMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool) ([]leave.LeaveResponse, error) {
+func (m *MockService) GetAll(ctx context.Context, companyID, actorID string, canReadAll bool, filterReq leave.GetLeavesFilterRequest) ([]leave.LeaveResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, actorID, canReadAll)
+	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, actorID, canReadAll, filterReq)
 	ret0, _ := ret[0].([]leave.LeaveResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, actorID, canReadAll any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, actorID, canReadAll, filterReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, actorID, canReadAll)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, actorID, canReadAll, filterReq)
 }
 
 // GetByID mocks base method.
@@ -34,9 +34,24 @@ func ConsumeEmployeeLifecycle(
 			continue
 		}
 
-		var event events.EmployeeCreatedEvent
+		var event events.EmployeeLifecycleEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			log.Error("decode employee_created event failed", zap.Error(err))
+			log.Error("decode employee lifecycle event failed", zap.Error(err))
+			_ = reader.CommitMessages(ctx, msg)
+			continue
+		}
+		switch employeesalary.ClassifyLifecycleEvent(event) {
+		case employeesalary.LifecycleHalt:
+			log.Error("unsupported employee lifecycle schema version, consumer stopped without commit",
+				zap.Int("schema_version", event.SchemaVersion),
+				zap.String("event_id", event.EventID),
+			)
+			return
+		case employeesalary.LifecycleSkip:
+			log.Debug("employee lifecycle event skipped",
+				zap.String("event_type", event.EventType),
+				zap.String("employee_id", event.EmployeeID),
+			)
 			_ = reader.CommitMessages(ctx, msg)
 			continue
 		}
@@ -0,0 +1,59 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"go-hris/internal/employee"
+	"go-hris/internal/events"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+func ConsumeEmployeeImportRequested(
+	ctx context.Context,
+	reader *kafkago.Reader,
+	employeeService employee.Service,
+	logger *zap.Logger,
+) {
+	log := logger.Named("kafka.consumer.employee_import")
+	log.Info("employee import consumer started")
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Info("employee import consumer stopped")
+				return
+			}
+			log.Error("fetch employee import message failed", zap.Error(err))
+			continue
+		}
+
+		var event events.EmployeeImportRequestedEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			log.Error("decode employee import event failed", zap.Error(err))
+			_ = reader.CommitMessages(ctx, msg)
+			continue
+		}
+
+		if err := employeeService.ProcessImport(ctx, event.CompanyID, event.ImportID); err != nil {
+			log.Error("process employee import failed",
+				zap.String("import_id", event.ImportID),
+				zap.String("company_id", event.CompanyID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			log.Error("commit employee import message failed", zap.Error(err))
+			continue
+		}
+
+		log.Info("employee import processed",
+			zap.String("import_id", event.ImportID),
+			zap.String("company_id", event.CompanyID),
+		)
+	}
+}
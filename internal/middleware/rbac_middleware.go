@@ -58,3 +58,43 @@ func RBACAuthorize(service RBACService, resource, action string) gin.HandlerFunc
 		c.Next()
 	}
 }
+
+// RBACCheck mengecek permission tanpa menolak request, untuk endpoint yang juga boleh
+// diakses pemilik data (mis. karyawan mengunduh payslip sendiri). Handler membaca
+// hasilnya dengan HasPermission.
+func RBACCheck(service RBACService, resource, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		employeeID, ok1 := c.Get(string(ContextEmployeeID))
+		companyID, ok2 := c.Get(string(ContextCompanyID))
+
+		if !ok1 || !ok2 {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing auth context"})
+			c.Abort()
+			return
+		}
+
+		allowed, err := service.Enforce(domain.EnforceRequest{
+			EmployeeID: employeeID.(string),
+			CompanyID:  companyID.(string),
+			Resource:   resource,
+			Action:     action,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Set(permissionKey(resource, action), allowed)
+		c.Next()
+	}
+}
+
+// HasPermission mengembalikan hasil RBACCheck untuk resource dan action tersebut.
+func HasPermission(c *gin.Context, resource, action string) bool {
+	return c.GetBool(permissionKey(resource, action))
+}
+
+func permissionKey(resource, action string) string {
+	return "rbac_permission:" + resource + ":" + action
+}
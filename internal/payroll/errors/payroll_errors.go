@@ -52,6 +52,26 @@ var (
 		"payroll already exists in overlapping period",
 		http.StatusConflict,
 	)
+	ErrInvalidPayrollType = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid payroll_type, expected REGULAR, THR, BONUS or CORRECTION (payroll runs support REGULAR and THR)",
+		http.StatusBadRequest,
+	)
+	ErrInvalidOffCyclePayroll = apperror.New(
+		apperror.CodeInvalidInput,
+		"BONUS payroll requires a positive allowance and CORRECTION payroll requires at least one amount",
+		http.StatusBadRequest,
+	)
+	ErrTHRNotEligible = apperror.New(
+		apperror.CodeInvalidState,
+		"employee is not eligible for THR: service length must be at least 1 full month before reference_date",
+		http.StatusBadRequest,
+	)
+	ErrInvalidSimulation = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid payroll simulation: employee_id and department_id cannot be combined, base_salary requires employee_id, base_salary_increase_bps must be 0-10000 and cannot be combined with base_salary",
+		http.StatusBadRequest,
+	)
 	ErrPayrollNotFound = apperror.New(
 		apperror.CodeNotFound,
 		"payroll not found",
@@ -92,4 +112,234 @@ var (
 		"payslip is not generated yet",
 		http.StatusNotFound,
 	)
+	ErrPayslipForbidden = apperror.New(
+		apperror.CodeForbidden,
+		"payslip belongs to another employee",
+		http.StatusForbidden,
+	)
+	ErrPayslipStorageNotConfigured = apperror.New(
+		apperror.CodeInternalError,
+		"payslip storage is not configured",
+		http.StatusInternalServerError,
+	)
+	ErrPayslipPasswordUnavailable = apperror.New(
+		apperror.CodeInvalidState,
+		"employee data required for payslip password is missing (birth_date or employee_number)",
+		http.StatusBadRequest,
+	)
+	ErrPayrollRunNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"payroll run not found",
+		http.StatusNotFound,
+	)
+	ErrPayrollRunNoEmployees = apperror.New(
+		apperror.CodeInvalidState,
+		"no active employees found for payroll run",
+		http.StatusBadRequest,
+	)
+	ErrEmployeeSalaryNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"employee has no effective base salary for this period",
+		http.StatusNotFound,
+	)
+	ErrEmployeeNotEmployedInPeriod = apperror.New(
+		apperror.CodeInvalidState,
+		"employee is not employed in this period (hire_date after period_end or termination_date before period_start)",
+		http.StatusBadRequest,
+	)
+	ErrPayrollRunHasNoDraft = apperror.New(
+		apperror.CodeInvalidState,
+		"payroll run has no DRAFT payroll to approve",
+		http.StatusBadRequest,
+	)
+	ErrInvalidPayrollSetting = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid payroll setting: work_hours_per_day must be 1-24, work_days_per_week 5-7, amounts cannot be negative, tax_calculator must be supported, BPJS rates 0-10000 bps, debit_account_number must be 5-34 digits, payslip_password_mode must be NONE, BIRTH_DATE_EMPLOYEE_NUMBER, EMPLOYEE_NUMBER or BIRTH_DATE, proration_method must be WORKING_DAYS or CALENDAR_DAYS",
+		http.StatusBadRequest,
+	)
+	ErrPayrollStale = apperror.New(
+		apperror.CodeInvalidState,
+		"payroll is stale because its source data changed, regenerate before approving",
+		http.StatusBadRequest,
+	)
+	ErrInvalidApprovalChain = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid approval chain: at most 10 steps, each step needs a name (max 100 characters) and role (max 50 characters), min_net_total cannot be negative, and at least one step must have min_net_total 0",
+		http.StatusBadRequest,
+	)
+	ErrPayrollApprovedThroughRun = apperror.New(
+		apperror.CodeInvalidState,
+		"payroll belongs to a payroll run, approve the run instead",
+		http.StatusConflict,
+	)
+	ErrApprovalRoleNotAllowed = apperror.New(
+		apperror.CodeForbidden,
+		"current approval step must be approved by another role",
+		http.StatusForbidden,
+	)
+	ErrApprovalActorAlreadyApproved = apperror.New(
+		apperror.CodeConflict,
+		"actor already approved a previous step of this payroll",
+		http.StatusConflict,
+	)
+	ErrPayrollPeriodClosed = apperror.New(
+		apperror.CodeInvalidState,
+		"payroll period is closed, reopen it before changing payroll or back-dated salary",
+		http.StatusConflict,
+	)
+	ErrInvalidPeriodTransition = apperror.New(
+		apperror.CodeInvalidState,
+		"invalid payroll period status transition",
+		http.StatusBadRequest,
+	)
+	ErrPeriodHasDraftPayroll = apperror.New(
+		apperror.CodeInvalidState,
+		"payroll period still has DRAFT payroll, approve or delete it before closing",
+		http.StatusBadRequest,
+	)
+	ErrPeriodReopenReasonRequired = apperror.New(
+		apperror.CodeInvalidInput,
+		"reason is required to reopen a closed payroll period",
+		http.StatusBadRequest,
+	)
+	ErrCancelOnlyApproved = apperror.New(
+		apperror.CodeInvalidState,
+		"only APPROVED payroll can be cancelled, use delete for DRAFT or reverse for PAID",
+		http.StatusBadRequest,
+	)
+	ErrReverseOnlyPaid = apperror.New(
+		apperror.CodeInvalidState,
+		"only PAID payroll can be reversed",
+		http.StatusBadRequest,
+	)
+	ErrPayrollIsReversal = apperror.New(
+		apperror.CodeInvalidState,
+		"reversal adjustment payroll cannot be cancelled, reversed, regenerated or deleted",
+		http.StatusBadRequest,
+	)
+	ErrCancelReasonRequired = apperror.New(
+		apperror.CodeInvalidInput,
+		"reason is required",
+		http.StatusBadRequest,
+	)
+	ErrInvalidTaxStatus = apperror.New(
+		apperror.CodeInvalidInput,
+		"employee tax status is not supported by the tax calculator",
+		http.StatusBadRequest,
+	)
+	ErrInvalidVarianceRequest = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid variance report request: exactly one of period or run_id is required, compare_period must be YYYY-MM and differ from the compared period, threshold_bps and threshold_amount cannot be negative",
+		http.StatusBadRequest,
+	)
+	ErrInvalidBankFormat = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid bank file format, expected BCA, MANDIRI, BNI or PAIN001",
+		http.StatusBadRequest,
+	)
+	ErrDebitAccountNotConfigured = apperror.New(
+		apperror.CodeInvalidState,
+		"debit account is not configured in payroll settings",
+		http.StatusBadRequest,
+	)
+	ErrBankExportNotApproved = apperror.New(
+		apperror.CodeInvalidState,
+		"only APPROVED payroll with positive net salary can be exported to bank file",
+		http.StatusBadRequest,
+	)
+	ErrBankExportAlreadyExported = apperror.New(
+		apperror.CodeConflict,
+		"payroll already exported to a bank file that is not settled yet, set reexport to export it again",
+		http.StatusConflict,
+	)
+	ErrEmployeeBankAccountMissing = apperror.New(
+		apperror.CodeInvalidState,
+		"employee bank account is not set",
+		http.StatusBadRequest,
+	)
+	ErrComponentTemplateNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"payroll component template not found",
+		http.StatusNotFound,
+	)
+	ErrInvalidComponentTemplate = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid component template: code max 50 characters, component_type must be ALLOWANCE or DEDUCTION, calculation_type FIXED, PERCENT_OF_BASE or PER_ATTENDANCE_DAY, amount must be positive for FIXED and PER_ATTENDANCE_DAY, rate_bps 1-10000 for PERCENT_OF_BASE",
+		http.StatusBadRequest,
+	)
+	ErrComponentTemplateCodeExists = apperror.New(
+		apperror.CodeConflict,
+		"component template code already exists in this company",
+		http.StatusConflict,
+	)
+	ErrComponentAssignmentNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"component assignment not found",
+		http.StatusNotFound,
+	)
+	ErrInvalidComponentAssignment = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid component assignment: amount cannot be negative, rate_bps must be 0-10000, effective_to must be on or after effective_from",
+		http.StatusBadRequest,
+	)
+	ErrComponentAssignmentOverlap = apperror.New(
+		apperror.CodeConflict,
+		"employee already has this component template in an overlapping effective period",
+		http.StatusConflict,
+	)
+	ErrLoanNotFound = apperror.New(
+		apperror.CodeNotFound,
+		"employee loan not found",
+		http.StatusNotFound,
+	)
+	ErrInvalidLoan = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid loan: loan_type must be LOAN or SALARY_ADVANCE, principal must be positive, installment_count 1-120 and not greater than principal, start_period must be YYYY-MM",
+		http.StatusBadRequest,
+	)
+	ErrInvalidLoanStatusFilter = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid loan status filter, expected ACTIVE or PAID_OFF",
+		http.StatusBadRequest,
+	)
+	ErrLoanAlreadyPaidOff = apperror.New(
+		apperror.CodeInvalidState,
+		"loan is already paid off",
+		http.StatusBadRequest,
+	)
+	ErrInvalidAccountMapping = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid account mapping: component_type must be BASE_SALARY, OVERTIME, ALLOWANCE, DEDUCTION, EMPLOYER_CONTRIBUTION or NET_PAYABLE, source_type and component_name only for ALLOWANCE, DEDUCTION and EMPLOYER_CONTRIBUTION, account_code max 50 characters, EMPLOYER_CONTRIBUTION requires contra_account_code, and each combination can only be mapped once",
+		http.StatusBadRequest,
+	)
+	ErrInvalidJournalFormat = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid journal format, expected CSV, ACCURATE or JURNAL",
+		http.StatusBadRequest,
+	)
+	ErrInvalidJournalScope = apperror.New(
+		apperror.CodeInvalidInput,
+		"journal export requires exactly one of run_id or period",
+		http.StatusBadRequest,
+	)
+	ErrJournalRunNotApproved = apperror.New(
+		apperror.CodeInvalidState,
+		"only APPROVED or PAID payroll run can be exported to journal",
+		http.StatusBadRequest,
+	)
+	ErrJournalNoPayroll = apperror.New(
+		apperror.CodeInvalidState,
+		"no approved payroll found for journal export",
+		http.StatusBadRequest,
+	)
+	ErrJournalUnbalanced = apperror.New(
+		apperror.CodeInternalError,
+		"payroll journal is not balanced",
+		http.StatusInternalServerError,
+	)
+	ErrInvalidBankResultFile = apperror.New(
+		apperror.CodeInvalidInput,
+		"invalid bank result file, expected CSV with reference and status columns or pain.002 XML",
+		http.StatusBadRequest,
+	)
 )
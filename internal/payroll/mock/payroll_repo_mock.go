@@ -16,6 +16,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,6 +44,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ComponentTemplateCodeExists mocks base method.
+func (m *MockRepository) ComponentTemplateCodeExists(ctx context.Context, companyID, code string, excludeID *string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComponentTemplateCodeExists", ctx, companyID, code, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComponentTemplateCodeExists indicates an expected call of ComponentTemplateCodeExists.
+func (mr *MockRepositoryMockRecorder) ComponentTemplateCodeExists(ctx, companyID, code, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComponentTemplateCodeExists", reflect.TypeOf((*MockRepository)(nil).ComponentTemplateCodeExists), ctx, companyID, code, excludeID)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, arg1 *payroll.Payroll) error {
 	m.ctrl.T.Helper()
@@ -57,6 +73,118 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, arg1)
 }
 
+// CreateApproval mocks base method.
+func (m *MockRepository) CreateApproval(ctx context.Context, approval *payroll.PayrollApproval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApproval", ctx, approval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApproval indicates an expected call of CreateApproval.
+func (mr *MockRepositoryMockRecorder) CreateApproval(ctx, approval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApproval", reflect.TypeOf((*MockRepository)(nil).CreateApproval), ctx, approval)
+}
+
+// CreateComponentAssignment mocks base method.
+func (m *MockRepository) CreateComponentAssignment(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponentAssignment", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComponentAssignment indicates an expected call of CreateComponentAssignment.
+func (mr *MockRepositoryMockRecorder) CreateComponentAssignment(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponentAssignment", reflect.TypeOf((*MockRepository)(nil).CreateComponentAssignment), ctx, assignment)
+}
+
+// CreateComponentTemplate mocks base method.
+func (m *MockRepository) CreateComponentTemplate(ctx context.Context, template *payroll.ComponentTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponentTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComponentTemplate indicates an expected call of CreateComponentTemplate.
+func (mr *MockRepositoryMockRecorder) CreateComponentTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponentTemplate", reflect.TypeOf((*MockRepository)(nil).CreateComponentTemplate), ctx, template)
+}
+
+// CreateLoan mocks base method.
+func (m *MockRepository) CreateLoan(ctx context.Context, loan *payroll.EmployeeLoan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoan", ctx, loan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockRepositoryMockRecorder) CreateLoan(ctx, loan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockRepository)(nil).CreateLoan), ctx, loan)
+}
+
+// CreateLoanRepayment mocks base method.
+func (m *MockRepository) CreateLoanRepayment(ctx context.Context, repayment *payroll.LoanRepayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoanRepayment", ctx, repayment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoanRepayment indicates an expected call of CreateLoanRepayment.
+func (mr *MockRepositoryMockRecorder) CreateLoanRepayment(ctx, repayment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoanRepayment", reflect.TypeOf((*MockRepository)(nil).CreateLoanRepayment), ctx, repayment)
+}
+
+// CreatePeriodAudit mocks base method.
+func (m *MockRepository) CreatePeriodAudit(ctx context.Context, audit *payroll.PayrollPeriodAudit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePeriodAudit", ctx, audit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePeriodAudit indicates an expected call of CreatePeriodAudit.
+func (mr *MockRepositoryMockRecorder) CreatePeriodAudit(ctx, audit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeriodAudit", reflect.TypeOf((*MockRepository)(nil).CreatePeriodAudit), ctx, audit)
+}
+
+// CreateRun mocks base method.
+func (m *MockRepository) CreateRun(ctx context.Context, run *payroll.PayrollRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRepositoryMockRecorder) CreateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRepository)(nil).CreateRun), ctx, run)
+}
+
+// CreateRunFailures mocks base method.
+func (m *MockRepository) CreateRunFailures(ctx context.Context, failures []payroll.PayrollRunFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRunFailures", ctx, failures)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRunFailures indicates an expected call of CreateRunFailures.
+func (mr *MockRepositoryMockRecorder) CreateRunFailures(ctx, failures any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRunFailures", reflect.TypeOf((*MockRepository)(nil).CreateRunFailures), ctx, failures)
+}
+
 // Delete mocks base method.
 func (m *MockRepository) Delete(ctx context.Context, companyID, id string) error {
 	m.ctrl.T.Helper()
@@ -71,6 +199,20 @@ func (mr *MockRepositoryMockRecorder) Delete(ctx, companyID, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, companyID, id)
 }
 
+// DeleteComponentAssignment mocks base method.
+func (m *MockRepository) DeleteComponentAssignment(ctx context.Context, companyID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComponentAssignment", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComponentAssignment indicates an expected call of DeleteComponentAssignment.
+func (mr *MockRepositoryMockRecorder) DeleteComponentAssignment(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComponentAssignment", reflect.TypeOf((*MockRepository)(nil).DeleteComponentAssignment), ctx, companyID, id)
+}
+
 // EmployeeBelongsToCompany mocks base method.
 func (m *MockRepository) EmployeeBelongsToCompany(ctx context.Context, companyID, employeeID string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +228,36 @@ func (mr *MockRepositoryMockRecorder) EmployeeBelongsToCompany(ctx, companyID, e
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmployeeBelongsToCompany", reflect.TypeOf((*MockRepository)(nil).EmployeeBelongsToCompany), ctx, companyID, employeeID)
 }
 
+// FindAccountMappings mocks base method.
+func (m *MockRepository) FindAccountMappings(ctx context.Context, companyID string) ([]payroll.AccountMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAccountMappings", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.AccountMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAccountMappings indicates an expected call of FindAccountMappings.
+func (mr *MockRepositoryMockRecorder) FindAccountMappings(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAccountMappings", reflect.TypeOf((*MockRepository)(nil).FindAccountMappings), ctx, companyID)
+}
+
+// FindActiveLoans mocks base method.
+func (m *MockRepository) FindActiveLoans(ctx context.Context, companyID, employeeID string, periodEnd time.Time) ([]payroll.EmployeeLoan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActiveLoans", ctx, companyID, employeeID, periodEnd)
+	ret0, _ := ret[0].([]payroll.EmployeeLoan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActiveLoans indicates an expected call of FindActiveLoans.
+func (mr *MockRepositoryMockRecorder) FindActiveLoans(ctx, companyID, employeeID, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveLoans", reflect.TypeOf((*MockRepository)(nil).FindActiveLoans), ctx, companyID, employeeID, periodEnd)
+}
+
 // FindAllByCompany mocks base method.
 func (m *MockRepository) FindAllByCompany(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +273,81 @@ func (mr *MockRepositoryMockRecorder) FindAllByCompany(ctx, companyID, filter an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCompany", reflect.TypeOf((*MockRepository)(nil).FindAllByCompany), ctx, companyID, filter)
 }
 
+// FindApprovalSteps mocks base method.
+func (m *MockRepository) FindApprovalSteps(ctx context.Context, companyID string) ([]payroll.ApprovalStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApprovalSteps", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.ApprovalStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApprovalSteps indicates an expected call of FindApprovalSteps.
+func (mr *MockRepositoryMockRecorder) FindApprovalSteps(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApprovalSteps", reflect.TypeOf((*MockRepository)(nil).FindApprovalSteps), ctx, companyID)
+}
+
+// FindApprovedLeaves mocks base method.
+func (m *MockRepository) FindApprovedLeaves(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time) ([]payroll.LeaveRange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApprovedLeaves", ctx, companyID, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].([]payroll.LeaveRange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApprovedLeaves indicates an expected call of FindApprovedLeaves.
+func (mr *MockRepositoryMockRecorder) FindApprovedLeaves(ctx, companyID, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApprovedLeaves", reflect.TypeOf((*MockRepository)(nil).FindApprovedLeaves), ctx, companyID, employeeID, periodStart, periodEnd)
+}
+
+// FindAttendanceRecords mocks base method.
+func (m *MockRepository) FindAttendanceRecords(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time) ([]payroll.AttendanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAttendanceRecords", ctx, companyID, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].([]payroll.AttendanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAttendanceRecords indicates an expected call of FindAttendanceRecords.
+func (mr *MockRepositoryMockRecorder) FindAttendanceRecords(ctx, companyID, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAttendanceRecords", reflect.TypeOf((*MockRepository)(nil).FindAttendanceRecords), ctx, companyID, employeeID, periodStart, periodEnd)
+}
+
+// FindBPJSContributions mocks base method.
+func (m *MockRepository) FindBPJSContributions(ctx context.Context, companyID string, periodStart, periodEnd time.Time) ([]payroll.BPJSContributionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBPJSContributions", ctx, companyID, periodStart, periodEnd)
+	ret0, _ := ret[0].([]payroll.BPJSContributionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBPJSContributions indicates an expected call of FindBPJSContributions.
+func (mr *MockRepositoryMockRecorder) FindBPJSContributions(ctx, companyID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBPJSContributions", reflect.TypeOf((*MockRepository)(nil).FindBPJSContributions), ctx, companyID, periodStart, periodEnd)
+}
+
+// FindBankTransferRows mocks base method.
+func (m *MockRepository) FindBankTransferRows(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.BankTransferRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBankTransferRows", ctx, companyID, payrollIDs)
+	ret0, _ := ret[0].([]payroll.BankTransferRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBankTransferRows indicates an expected call of FindBankTransferRows.
+func (mr *MockRepositoryMockRecorder) FindBankTransferRows(ctx, companyID, payrollIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBankTransferRows", reflect.TypeOf((*MockRepository)(nil).FindBankTransferRows), ctx, companyID, payrollIDs)
+}
+
 // FindByIDAndCompany mocks base method.
 func (m *MockRepository) FindByIDAndCompany(ctx context.Context, companyID, id string) (*payroll.Payroll, error) {
 	m.ctrl.T.Helper()
@@ -116,19 +363,510 @@ func (mr *MockRepositoryMockRecorder) FindByIDAndCompany(ctx, companyID, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindByIDAndCompany), ctx, companyID, id)
 }
 
+// FindByRun mocks base method.
+func (m *MockRepository) FindByRun(ctx context.Context, companyID, runID string) ([]payroll.Payroll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRun", ctx, companyID, runID)
+	ret0, _ := ret[0].([]payroll.Payroll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRun indicates an expected call of FindByRun.
+func (mr *MockRepositoryMockRecorder) FindByRun(ctx, companyID, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRun", reflect.TypeOf((*MockRepository)(nil).FindByRun), ctx, companyID, runID)
+}
+
+// FindComponentAssignmentByID mocks base method.
+func (m *MockRepository) FindComponentAssignmentByID(ctx context.Context, companyID, id string) (*payroll.EmployeeComponentAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComponentAssignmentByID", ctx, companyID, id)
+	ret0, _ := ret[0].(*payroll.EmployeeComponentAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComponentAssignmentByID indicates an expected call of FindComponentAssignmentByID.
+func (mr *MockRepositoryMockRecorder) FindComponentAssignmentByID(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComponentAssignmentByID", reflect.TypeOf((*MockRepository)(nil).FindComponentAssignmentByID), ctx, companyID, id)
+}
+
+// FindComponentAssignments mocks base method.
+func (m *MockRepository) FindComponentAssignments(ctx context.Context, companyID, employeeID string) ([]payroll.EmployeeComponentAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComponentAssignments", ctx, companyID, employeeID)
+	ret0, _ := ret[0].([]payroll.EmployeeComponentAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComponentAssignments indicates an expected call of FindComponentAssignments.
+func (mr *MockRepositoryMockRecorder) FindComponentAssignments(ctx, companyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComponentAssignments", reflect.TypeOf((*MockRepository)(nil).FindComponentAssignments), ctx, companyID, employeeID)
+}
+
+// FindComponentTemplateByID mocks base method.
+func (m *MockRepository) FindComponentTemplateByID(ctx context.Context, companyID, id string) (*payroll.ComponentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComponentTemplateByID", ctx, companyID, id)
+	ret0, _ := ret[0].(*payroll.ComponentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComponentTemplateByID indicates an expected call of FindComponentTemplateByID.
+func (mr *MockRepositoryMockRecorder) FindComponentTemplateByID(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComponentTemplateByID", reflect.TypeOf((*MockRepository)(nil).FindComponentTemplateByID), ctx, companyID, id)
+}
+
+// FindComponentTemplates mocks base method.
+func (m *MockRepository) FindComponentTemplates(ctx context.Context, companyID string) ([]payroll.ComponentTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComponentTemplates", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.ComponentTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComponentTemplates indicates an expected call of FindComponentTemplates.
+func (mr *MockRepositoryMockRecorder) FindComponentTemplates(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComponentTemplates", reflect.TypeOf((*MockRepository)(nil).FindComponentTemplates), ctx, companyID)
+}
+
+// FindComponentsByPayrollIDs mocks base method.
+func (m *MockRepository) FindComponentsByPayrollIDs(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.PayrollComponent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindComponentsByPayrollIDs", ctx, companyID, payrollIDs)
+	ret0, _ := ret[0].([]payroll.PayrollComponent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindComponentsByPayrollIDs indicates an expected call of FindComponentsByPayrollIDs.
+func (mr *MockRepositoryMockRecorder) FindComponentsByPayrollIDs(ctx, companyID, payrollIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindComponentsByPayrollIDs", reflect.TypeOf((*MockRepository)(nil).FindComponentsByPayrollIDs), ctx, companyID, payrollIDs)
+}
+
+// FindEffectiveComponentAssignments mocks base method.
+func (m *MockRepository) FindEffectiveComponentAssignments(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time) ([]payroll.EmployeeComponentAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEffectiveComponentAssignments", ctx, companyID, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].([]payroll.EmployeeComponentAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEffectiveComponentAssignments indicates an expected call of FindEffectiveComponentAssignments.
+func (mr *MockRepositoryMockRecorder) FindEffectiveComponentAssignments(ctx, companyID, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEffectiveComponentAssignments", reflect.TypeOf((*MockRepository)(nil).FindEffectiveComponentAssignments), ctx, companyID, employeeID, periodStart, periodEnd)
+}
+
+// FindEmployeeTaxStatus mocks base method.
+func (m *MockRepository) FindEmployeeTaxStatus(ctx context.Context, companyID, employeeID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmployeeTaxStatus", ctx, companyID, employeeID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmployeeTaxStatus indicates an expected call of FindEmployeeTaxStatus.
+func (mr *MockRepositoryMockRecorder) FindEmployeeTaxStatus(ctx, companyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmployeeTaxStatus", reflect.TypeOf((*MockRepository)(nil).FindEmployeeTaxStatus), ctx, companyID, employeeID)
+}
+
+// FindEmployeesForRun mocks base method.
+func (m *MockRepository) FindEmployeesForRun(ctx context.Context, companyID string, periodStart, periodEnd time.Time, departmentID *string) ([]payroll.RunEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmployeesForRun", ctx, companyID, periodStart, periodEnd, departmentID)
+	ret0, _ := ret[0].([]payroll.RunEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmployeesForRun indicates an expected call of FindEmployeesForRun.
+func (mr *MockRepositoryMockRecorder) FindEmployeesForRun(ctx, companyID, periodStart, periodEnd, departmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmployeesForRun", reflect.TypeOf((*MockRepository)(nil).FindEmployeesForRun), ctx, companyID, periodStart, periodEnd, departmentID)
+}
+
+// FindEmploymentPeriod mocks base method.
+func (m *MockRepository) FindEmploymentPeriod(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time) (payroll.EmploymentPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmploymentPeriod", ctx, companyID, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].(payroll.EmploymentPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmploymentPeriod indicates an expected call of FindEmploymentPeriod.
+func (mr *MockRepositoryMockRecorder) FindEmploymentPeriod(ctx, companyID, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmploymentPeriod", reflect.TypeOf((*MockRepository)(nil).FindEmploymentPeriod), ctx, companyID, employeeID, periodStart, periodEnd)
+}
+
+// FindJournalPayrolls mocks base method.
+func (m *MockRepository) FindJournalPayrolls(ctx context.Context, companyID string, filter payroll.JournalQueryFilter) ([]payroll.JournalPayrollRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindJournalPayrolls", ctx, companyID, filter)
+	ret0, _ := ret[0].([]payroll.JournalPayrollRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindJournalPayrolls indicates an expected call of FindJournalPayrolls.
+func (mr *MockRepositoryMockRecorder) FindJournalPayrolls(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindJournalPayrolls", reflect.TypeOf((*MockRepository)(nil).FindJournalPayrolls), ctx, companyID, filter)
+}
+
+// FindLoanByID mocks base method.
+func (m *MockRepository) FindLoanByID(ctx context.Context, companyID, id string) (*payroll.EmployeeLoan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLoanByID", ctx, companyID, id)
+	ret0, _ := ret[0].(*payroll.EmployeeLoan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLoanByID indicates an expected call of FindLoanByID.
+func (mr *MockRepositoryMockRecorder) FindLoanByID(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLoanByID", reflect.TypeOf((*MockRepository)(nil).FindLoanByID), ctx, companyID, id)
+}
+
+// FindLoanRepaymentsByPayroll mocks base method.
+func (m *MockRepository) FindLoanRepaymentsByPayroll(ctx context.Context, companyID, payrollID string) ([]payroll.LoanRepayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLoanRepaymentsByPayroll", ctx, companyID, payrollID)
+	ret0, _ := ret[0].([]payroll.LoanRepayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLoanRepaymentsByPayroll indicates an expected call of FindLoanRepaymentsByPayroll.
+func (mr *MockRepositoryMockRecorder) FindLoanRepaymentsByPayroll(ctx, companyID, payrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLoanRepaymentsByPayroll", reflect.TypeOf((*MockRepository)(nil).FindLoanRepaymentsByPayroll), ctx, companyID, payrollID)
+}
+
+// FindLoans mocks base method.
+func (m *MockRepository) FindLoans(ctx context.Context, companyID string, filter payroll.LoanQueryFilter) ([]payroll.EmployeeLoan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLoans", ctx, companyID, filter)
+	ret0, _ := ret[0].([]payroll.EmployeeLoan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLoans indicates an expected call of FindLoans.
+func (mr *MockRepositoryMockRecorder) FindLoans(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLoans", reflect.TypeOf((*MockRepository)(nil).FindLoans), ctx, companyID, filter)
+}
+
+// FindPageByCompany mocks base method.
+func (m *MockRepository) FindPageByCompany(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCompany", ctx, companyID, filter)
+	ret0, _ := ret[0].([]payroll.Payroll)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCompany indicates an expected call of FindPageByCompany.
+func (mr *MockRepositoryMockRecorder) FindPageByCompany(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCompany", reflect.TypeOf((*MockRepository)(nil).FindPageByCompany), ctx, companyID, filter)
+}
+
+// FindPayslipProfile mocks base method.
+func (m *MockRepository) FindPayslipProfile(ctx context.Context, companyID, employeeID string) (payroll.PayslipProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayslipProfile", ctx, companyID, employeeID)
+	ret0, _ := ret[0].(payroll.PayslipProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayslipProfile indicates an expected call of FindPayslipProfile.
+func (mr *MockRepositoryMockRecorder) FindPayslipProfile(ctx, companyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayslipProfile", reflect.TypeOf((*MockRepository)(nil).FindPayslipProfile), ctx, companyID, employeeID)
+}
+
+// FindPayslipYearToDate mocks base method.
+func (m *MockRepository) FindPayslipYearToDate(ctx context.Context, companyID, employeeID string, yearStart, periodEnd time.Time, excludePayrollID string) (payroll.PayslipYearToDate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPayslipYearToDate", ctx, companyID, employeeID, yearStart, periodEnd, excludePayrollID)
+	ret0, _ := ret[0].(payroll.PayslipYearToDate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPayslipYearToDate indicates an expected call of FindPayslipYearToDate.
+func (mr *MockRepositoryMockRecorder) FindPayslipYearToDate(ctx, companyID, employeeID, yearStart, periodEnd, excludePayrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPayslipYearToDate", reflect.TypeOf((*MockRepository)(nil).FindPayslipYearToDate), ctx, companyID, employeeID, yearStart, periodEnd, excludePayrollID)
+}
+
+// FindPeriodByStart mocks base method.
+func (m *MockRepository) FindPeriodByStart(ctx context.Context, companyID string, periodStart time.Time) (*payroll.PayrollPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPeriodByStart", ctx, companyID, periodStart)
+	ret0, _ := ret[0].(*payroll.PayrollPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPeriodByStart indicates an expected call of FindPeriodByStart.
+func (mr *MockRepositoryMockRecorder) FindPeriodByStart(ctx, companyID, periodStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPeriodByStart", reflect.TypeOf((*MockRepository)(nil).FindPeriodByStart), ctx, companyID, periodStart)
+}
+
+// FindPeriods mocks base method.
+func (m *MockRepository) FindPeriods(ctx context.Context, companyID string, from, to time.Time) ([]payroll.PayrollPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPeriods", ctx, companyID, from, to)
+	ret0, _ := ret[0].([]payroll.PayrollPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPeriods indicates an expected call of FindPeriods.
+func (mr *MockRepositoryMockRecorder) FindPeriods(ctx, companyID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPeriods", reflect.TypeOf((*MockRepository)(nil).FindPeriods), ctx, companyID, from, to)
+}
+
+// FindRegularPayrollIDInPeriod mocks base method.
+func (m *MockRepository) FindRegularPayrollIDInPeriod(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRegularPayrollIDInPeriod", ctx, companyID, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRegularPayrollIDInPeriod indicates an expected call of FindRegularPayrollIDInPeriod.
+func (mr *MockRepositoryMockRecorder) FindRegularPayrollIDInPeriod(ctx, companyID, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRegularPayrollIDInPeriod", reflect.TypeOf((*MockRepository)(nil).FindRegularPayrollIDInPeriod), ctx, companyID, employeeID, periodStart, periodEnd)
+}
+
+// FindRunByIDAndCompany mocks base method.
+func (m *MockRepository) FindRunByIDAndCompany(ctx context.Context, companyID, id string) (*payroll.PayrollRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunByIDAndCompany", ctx, companyID, id)
+	ret0, _ := ret[0].(*payroll.PayrollRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunByIDAndCompany indicates an expected call of FindRunByIDAndCompany.
+func (mr *MockRepositoryMockRecorder) FindRunByIDAndCompany(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunByIDAndCompany", reflect.TypeOf((*MockRepository)(nil).FindRunByIDAndCompany), ctx, companyID, id)
+}
+
+// FindRunsByCompany mocks base method.
+func (m *MockRepository) FindRunsByCompany(ctx context.Context, companyID string) ([]payroll.PayrollRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunsByCompany", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.PayrollRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunsByCompany indicates an expected call of FindRunsByCompany.
+func (mr *MockRepositoryMockRecorder) FindRunsByCompany(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunsByCompany", reflect.TypeOf((*MockRepository)(nil).FindRunsByCompany), ctx, companyID)
+}
+
+// FindSalaryHistory mocks base method.
+func (m *MockRepository) FindSalaryHistory(ctx context.Context, employeeID string, periodStart, periodEnd time.Time) ([]payroll.SalaryHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSalaryHistory", ctx, employeeID, periodStart, periodEnd)
+	ret0, _ := ret[0].([]payroll.SalaryHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSalaryHistory indicates an expected call of FindSalaryHistory.
+func (mr *MockRepositoryMockRecorder) FindSalaryHistory(ctx, employeeID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSalaryHistory", reflect.TypeOf((*MockRepository)(nil).FindSalaryHistory), ctx, employeeID, periodStart, periodEnd)
+}
+
+// FindSetting mocks base method.
+func (m *MockRepository) FindSetting(ctx context.Context, companyID string) (*payroll.PayrollSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSetting", ctx, companyID)
+	ret0, _ := ret[0].(*payroll.PayrollSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSetting indicates an expected call of FindSetting.
+func (mr *MockRepositoryMockRecorder) FindSetting(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSetting", reflect.TypeOf((*MockRepository)(nil).FindSetting), ctx, companyID)
+}
+
+// FindTaxSamePeriod mocks base method.
+func (m *MockRepository) FindTaxSamePeriod(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time, excludePayrollID *string) (payroll.TaxYearToDate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTaxSamePeriod", ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+	ret0, _ := ret[0].(payroll.TaxYearToDate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTaxSamePeriod indicates an expected call of FindTaxSamePeriod.
+func (mr *MockRepositoryMockRecorder) FindTaxSamePeriod(ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTaxSamePeriod", reflect.TypeOf((*MockRepository)(nil).FindTaxSamePeriod), ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+}
+
+// FindTaxYearToDate mocks base method.
+func (m *MockRepository) FindTaxYearToDate(ctx context.Context, companyID, employeeID string, yearStart, before time.Time) (payroll.TaxYearToDate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTaxYearToDate", ctx, companyID, employeeID, yearStart, before)
+	ret0, _ := ret[0].(payroll.TaxYearToDate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTaxYearToDate indicates an expected call of FindTaxYearToDate.
+func (mr *MockRepositoryMockRecorder) FindTaxYearToDate(ctx, companyID, employeeID, yearStart, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTaxYearToDate", reflect.TypeOf((*MockRepository)(nil).FindTaxYearToDate), ctx, companyID, employeeID, yearStart, before)
+}
+
+// HasClosedPeriod mocks base method.
+func (m *MockRepository) HasClosedPeriod(ctx context.Context, companyID string, periodStart, periodEnd time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasClosedPeriod", ctx, companyID, periodStart, periodEnd)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasClosedPeriod indicates an expected call of HasClosedPeriod.
+func (mr *MockRepositoryMockRecorder) HasClosedPeriod(ctx, companyID, periodStart, periodEnd any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasClosedPeriod", reflect.TypeOf((*MockRepository)(nil).HasClosedPeriod), ctx, companyID, periodStart, periodEnd)
+}
+
+// HasOverlappingComponentAssignment mocks base method.
+func (m *MockRepository) HasOverlappingComponentAssignment(ctx context.Context, companyID, employeeID, templateID string, effectiveFrom time.Time, effectiveTo *time.Time, excludeID *string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOverlappingComponentAssignment", ctx, companyID, employeeID, templateID, effectiveFrom, effectiveTo, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOverlappingComponentAssignment indicates an expected call of HasOverlappingComponentAssignment.
+func (mr *MockRepositoryMockRecorder) HasOverlappingComponentAssignment(ctx, companyID, employeeID, templateID, effectiveFrom, effectiveTo, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlappingComponentAssignment", reflect.TypeOf((*MockRepository)(nil).HasOverlappingComponentAssignment), ctx, companyID, employeeID, templateID, effectiveFrom, effectiveTo, excludeID)
+}
+
 // HasOverlappingPeriod mocks base method.
-func (m *MockRepository) HasOverlappingPeriod(ctx context.Context, companyID, employeeID string, periodStart, periodEnd time.Time, excludePayrollID *string) (bool, error) {
+func (m *MockRepository) HasOverlappingPeriod(ctx context.Context, companyID, employeeID, payrollType string, periodStart, periodEnd time.Time, excludePayrollID *string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasOverlappingPeriod", ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+	ret := m.ctrl.Call(m, "HasOverlappingPeriod", ctx, companyID, employeeID, payrollType, periodStart, periodEnd, excludePayrollID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HasOverlappingPeriod indicates an expected call of HasOverlappingPeriod.
-func (mr *MockRepositoryMockRecorder) HasOverlappingPeriod(ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) HasOverlappingPeriod(ctx, companyID, employeeID, payrollType, periodStart, periodEnd, excludePayrollID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlappingPeriod", reflect.TypeOf((*MockRepository)(nil).HasOverlappingPeriod), ctx, companyID, employeeID, payrollType, periodStart, periodEnd, excludePayrollID)
+}
+
+// MarkBankExported mocks base method.
+func (m *MockRepository) MarkBankExported(ctx context.Context, companyID string, payrollIDs []string, batchID string, exportedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBankExported", ctx, companyID, payrollIDs, batchID, exportedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkBankExported indicates an expected call of MarkBankExported.
+func (mr *MockRepositoryMockRecorder) MarkBankExported(ctx, companyID, payrollIDs, batchID, exportedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBankExported", reflect.TypeOf((*MockRepository)(nil).MarkBankExported), ctx, companyID, payrollIDs, batchID, exportedAt)
+}
+
+// MarkPayrollsStaleByEmployee mocks base method.
+func (m *MockRepository) MarkPayrollsStaleByEmployee(ctx context.Context, companyID, employeeID string, from time.Time, to *time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayrollsStaleByEmployee", ctx, companyID, employeeID, from, to, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPayrollsStaleByEmployee indicates an expected call of MarkPayrollsStaleByEmployee.
+func (mr *MockRepositoryMockRecorder) MarkPayrollsStaleByEmployee(ctx, companyID, employeeID, from, to, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayrollsStaleByEmployee", reflect.TypeOf((*MockRepository)(nil).MarkPayrollsStaleByEmployee), ctx, companyID, employeeID, from, to, reason)
+}
+
+// MarkPayrollsStaleByTemplate mocks base method.
+func (m *MockRepository) MarkPayrollsStaleByTemplate(ctx context.Context, companyID, templateID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayrollsStaleByTemplate", ctx, companyID, templateID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPayrollsStaleByTemplate indicates an expected call of MarkPayrollsStaleByTemplate.
+func (mr *MockRepositoryMockRecorder) MarkPayrollsStaleByTemplate(ctx, companyID, templateID, reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlappingPeriod", reflect.TypeOf((*MockRepository)(nil).HasOverlappingPeriod), ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayrollsStaleByTemplate", reflect.TypeOf((*MockRepository)(nil).MarkPayrollsStaleByTemplate), ctx, companyID, templateID, reason)
+}
+
+// ReplaceAccountMappings mocks base method.
+func (m *MockRepository) ReplaceAccountMappings(ctx context.Context, companyID string, mappings []payroll.AccountMapping) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceAccountMappings", ctx, companyID, mappings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceAccountMappings indicates an expected call of ReplaceAccountMappings.
+func (mr *MockRepositoryMockRecorder) ReplaceAccountMappings(ctx, companyID, mappings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceAccountMappings", reflect.TypeOf((*MockRepository)(nil).ReplaceAccountMappings), ctx, companyID, mappings)
+}
+
+// ReplaceApprovalSteps mocks base method.
+func (m *MockRepository) ReplaceApprovalSteps(ctx context.Context, companyID string, steps []payroll.ApprovalStep) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceApprovalSteps", ctx, companyID, steps)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceApprovalSteps indicates an expected call of ReplaceApprovalSteps.
+func (mr *MockRepositoryMockRecorder) ReplaceApprovalSteps(ctx, companyID, steps any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceApprovalSteps", reflect.TypeOf((*MockRepository)(nil).ReplaceApprovalSteps), ctx, companyID, steps)
 }
 
 // ReplaceComponents mocks base method.
@@ -145,6 +883,48 @@ func (mr *MockRepositoryMockRecorder) ReplaceComponents(ctx, companyID, payrollI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceComponents", reflect.TypeOf((*MockRepository)(nil).ReplaceComponents), ctx, companyID, payrollID, components)
 }
 
+// ResetApprovals mocks base method.
+func (m *MockRepository) ResetApprovals(ctx context.Context, companyID, payrollID string, runID *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetApprovals", ctx, companyID, payrollID, runID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetApprovals indicates an expected call of ResetApprovals.
+func (mr *MockRepositoryMockRecorder) ResetApprovals(ctx, companyID, payrollID, runID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetApprovals", reflect.TypeOf((*MockRepository)(nil).ResetApprovals), ctx, companyID, payrollID, runID)
+}
+
+// ResetPendingApprovals mocks base method.
+func (m *MockRepository) ResetPendingApprovals(ctx context.Context, companyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPendingApprovals", ctx, companyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPendingApprovals indicates an expected call of ResetPendingApprovals.
+func (mr *MockRepositoryMockRecorder) ResetPendingApprovals(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPendingApprovals", reflect.TypeOf((*MockRepository)(nil).ResetPendingApprovals), ctx, companyID)
+}
+
+// SavePeriod mocks base method.
+func (m *MockRepository) SavePeriod(ctx context.Context, period *payroll.PayrollPeriod) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePeriod", ctx, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePeriod indicates an expected call of SavePeriod.
+func (mr *MockRepositoryMockRecorder) SavePeriod(ctx, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePeriod", reflect.TypeOf((*MockRepository)(nil).SavePeriod), ctx, period)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, arg1 *payroll.Payroll) error {
 	m.ctrl.T.Helper()
@@ -159,6 +939,90 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, arg1)
 }
 
+// UpdateComponentAssignment mocks base method.
+func (m *MockRepository) UpdateComponentAssignment(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponentAssignment", ctx, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComponentAssignment indicates an expected call of UpdateComponentAssignment.
+func (mr *MockRepositoryMockRecorder) UpdateComponentAssignment(ctx, assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponentAssignment", reflect.TypeOf((*MockRepository)(nil).UpdateComponentAssignment), ctx, assignment)
+}
+
+// UpdateComponentTemplate mocks base method.
+func (m *MockRepository) UpdateComponentTemplate(ctx context.Context, template *payroll.ComponentTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponentTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComponentTemplate indicates an expected call of UpdateComponentTemplate.
+func (mr *MockRepositoryMockRecorder) UpdateComponentTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponentTemplate", reflect.TypeOf((*MockRepository)(nil).UpdateComponentTemplate), ctx, template)
+}
+
+// UpdateLoan mocks base method.
+func (m *MockRepository) UpdateLoan(ctx context.Context, loan *payroll.EmployeeLoan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoan", ctx, loan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoan indicates an expected call of UpdateLoan.
+func (mr *MockRepositoryMockRecorder) UpdateLoan(ctx, loan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoan", reflect.TypeOf((*MockRepository)(nil).UpdateLoan), ctx, loan)
+}
+
+// UpdateLoanRepayment mocks base method.
+func (m *MockRepository) UpdateLoanRepayment(ctx context.Context, repayment *payroll.LoanRepayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoanRepayment", ctx, repayment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoanRepayment indicates an expected call of UpdateLoanRepayment.
+func (mr *MockRepositoryMockRecorder) UpdateLoanRepayment(ctx, repayment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoanRepayment", reflect.TypeOf((*MockRepository)(nil).UpdateLoanRepayment), ctx, repayment)
+}
+
+// UpdateRun mocks base method.
+func (m *MockRepository) UpdateRun(ctx context.Context, run *payroll.PayrollRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockRepositoryMockRecorder) UpdateRun(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockRepository)(nil).UpdateRun), ctx, run)
+}
+
+// UpsertSetting mocks base method.
+func (m *MockRepository) UpsertSetting(ctx context.Context, setting *payroll.PayrollSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSetting", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSetting indicates an expected call of UpsertSetting.
+func (mr *MockRepositoryMockRecorder) UpsertSetting(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSetting", reflect.TypeOf((*MockRepository)(nil).UpsertSetting), ctx, setting)
+}
+
 // WithTx mocks base method.
 func (m *MockRepository) WithTx(tx *sql.Tx) payroll.Repository {
 	m.ctrl.T.Helper()
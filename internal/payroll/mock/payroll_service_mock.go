@@ -42,18 +42,63 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // Approve mocks base method.
-func (m *MockService) Approve(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error) {
+func (m *MockService) Approve(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Approve", ctx, companyID, actorID, id)
+	ret := m.ctrl.Call(m, "Approve", ctx, companyID, actorID, role, id, req)
 	ret0, _ := ret[0].(payroll.PayrollResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Approve indicates an expected call of Approve.
-func (mr *MockServiceMockRecorder) Approve(ctx, companyID, actorID, id any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Approve(ctx, companyID, actorID, role, id, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockService)(nil).Approve), ctx, companyID, actorID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockService)(nil).Approve), ctx, companyID, actorID, role, id, req)
+}
+
+// ApproveRun mocks base method.
+func (m *MockService) ApproveRun(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveRun", ctx, companyID, actorID, role, id, req)
+	ret0, _ := ret[0].(payroll.PayrollRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveRun indicates an expected call of ApproveRun.
+func (mr *MockServiceMockRecorder) ApproveRun(ctx, companyID, actorID, role, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveRun", reflect.TypeOf((*MockService)(nil).ApproveRun), ctx, companyID, actorID, role, id, req)
+}
+
+// Cancel mocks base method.
+func (m *MockService) Cancel(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, companyID, actorID, id, req)
+	ret0, _ := ret[0].(payroll.PayrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockServiceMockRecorder) Cancel(ctx, companyID, actorID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockService)(nil).Cancel), ctx, companyID, actorID, id, req)
+}
+
+// ClosePeriod mocks base method.
+func (m *MockService) ClosePeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePeriod", ctx, companyID, actorID, period, req)
+	ret0, _ := ret[0].(payroll.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePeriod indicates an expected call of ClosePeriod.
+func (mr *MockServiceMockRecorder) ClosePeriod(ctx, companyID, actorID, period, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePeriod", reflect.TypeOf((*MockService)(nil).ClosePeriod), ctx, companyID, actorID, period, req)
 }
 
 // Create mocks base method.
@@ -71,6 +116,66 @@ func (mr *MockServiceMockRecorder) Create(ctx, companyID, actorID, req any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, companyID, actorID, req)
 }
 
+// CreateComponentAssignment mocks base method.
+func (m *MockService) CreateComponentAssignment(ctx context.Context, companyID, actorID string, req payroll.CreateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponentAssignment", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.ComponentAssignmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComponentAssignment indicates an expected call of CreateComponentAssignment.
+func (mr *MockServiceMockRecorder) CreateComponentAssignment(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponentAssignment", reflect.TypeOf((*MockService)(nil).CreateComponentAssignment), ctx, companyID, actorID, req)
+}
+
+// CreateComponentTemplate mocks base method.
+func (m *MockService) CreateComponentTemplate(ctx context.Context, companyID, actorID string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponentTemplate", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.ComponentTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComponentTemplate indicates an expected call of CreateComponentTemplate.
+func (mr *MockServiceMockRecorder) CreateComponentTemplate(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponentTemplate", reflect.TypeOf((*MockService)(nil).CreateComponentTemplate), ctx, companyID, actorID, req)
+}
+
+// CreateLoan mocks base method.
+func (m *MockService) CreateLoan(ctx context.Context, companyID, actorID string, req payroll.CreateLoanRequest) (payroll.LoanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoan", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.LoanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockServiceMockRecorder) CreateLoan(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockService)(nil).CreateLoan), ctx, companyID, actorID, req)
+}
+
+// CreateRun mocks base method.
+func (m *MockService) CreateRun(ctx context.Context, companyID, actorID string, req payroll.CreatePayrollRunRequest) (payroll.PayrollRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.PayrollRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockServiceMockRecorder) CreateRun(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockService)(nil).CreateRun), ctx, companyID, actorID, req)
+}
+
 // Delete mocks base method.
 func (m *MockService) Delete(ctx context.Context, companyID, id string) error {
 	m.ctrl.T.Helper()
@@ -85,6 +190,50 @@ func (mr *MockServiceMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockService)(nil).Delete), ctx, companyID, id)
 }
 
+// DeleteComponentAssignment mocks base method.
+func (m *MockService) DeleteComponentAssignment(ctx context.Context, companyID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComponentAssignment", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComponentAssignment indicates an expected call of DeleteComponentAssignment.
+func (mr *MockServiceMockRecorder) DeleteComponentAssignment(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComponentAssignment", reflect.TypeOf((*MockService)(nil).DeleteComponentAssignment), ctx, companyID, id)
+}
+
+// ExportBankTransfer mocks base method.
+func (m *MockService) ExportBankTransfer(ctx context.Context, companyID string, req payroll.BankExportRequest) (payroll.BankExportFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBankTransfer", ctx, companyID, req)
+	ret0, _ := ret[0].(payroll.BankExportFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportBankTransfer indicates an expected call of ExportBankTransfer.
+func (mr *MockServiceMockRecorder) ExportBankTransfer(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBankTransfer", reflect.TypeOf((*MockService)(nil).ExportBankTransfer), ctx, companyID, req)
+}
+
+// ExportJournal mocks base method.
+func (m *MockService) ExportJournal(ctx context.Context, companyID string, req payroll.JournalExportRequest) (payroll.JournalExportFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportJournal", ctx, companyID, req)
+	ret0, _ := ret[0].(payroll.JournalExportFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportJournal indicates an expected call of ExportJournal.
+func (mr *MockServiceMockRecorder) ExportJournal(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportJournal", reflect.TypeOf((*MockService)(nil).ExportJournal), ctx, companyID, req)
+}
+
 // GeneratePayslip mocks base method.
 func (m *MockService) GeneratePayslip(ctx context.Context, companyID, id string) (payroll.PayrollResponse, error) {
 	m.ctrl.T.Helper()
@@ -100,13 +249,29 @@ func (mr *MockServiceMockRecorder) GeneratePayslip(ctx, companyID, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePayslip", reflect.TypeOf((*MockService)(nil).GeneratePayslip), ctx, companyID, id)
 }
 
+// GetAccountMappings mocks base method.
+func (m *MockService) GetAccountMappings(ctx context.Context, companyID string) ([]payroll.AccountMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMappings", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.AccountMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMappings indicates an expected call of GetAccountMappings.
+func (mr *MockServiceMockRecorder) GetAccountMappings(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMappings", reflect.TypeOf((*MockService)(nil).GetAccountMappings), ctx, companyID)
+}
+
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, companyID string, filterReq payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, error) {
+func (m *MockService) GetAll(ctx context.Context, companyID string, filterReq payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, filterReq)
 	ret0, _ := ret[0].([]payroll.PayrollResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
@@ -115,6 +280,36 @@ func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, filterReq any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, filterReq)
 }
 
+// GetApprovalChain mocks base method.
+func (m *MockService) GetApprovalChain(ctx context.Context, companyID string) (payroll.ApprovalChainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalChain", ctx, companyID)
+	ret0, _ := ret[0].(payroll.ApprovalChainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovalChain indicates an expected call of GetApprovalChain.
+func (mr *MockServiceMockRecorder) GetApprovalChain(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalChain", reflect.TypeOf((*MockService)(nil).GetApprovalChain), ctx, companyID)
+}
+
+// GetBPJSReport mocks base method.
+func (m *MockService) GetBPJSReport(ctx context.Context, companyID string, req payroll.BPJSReportFilterRequest) (payroll.BPJSReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBPJSReport", ctx, companyID, req)
+	ret0, _ := ret[0].(payroll.BPJSReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBPJSReport indicates an expected call of GetBPJSReport.
+func (mr *MockServiceMockRecorder) GetBPJSReport(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBPJSReport", reflect.TypeOf((*MockService)(nil).GetBPJSReport), ctx, companyID, req)
+}
+
 // GetBreakdown mocks base method.
 func (m *MockService) GetBreakdown(ctx context.Context, companyID, id string) (payroll.PayrollBreakdownResponse, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +340,186 @@ func (mr *MockServiceMockRecorder) GetByID(ctx, companyID, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, companyID, id)
 }
 
+// GetComponentAssignments mocks base method.
+func (m *MockService) GetComponentAssignments(ctx context.Context, companyID, employeeID string) ([]payroll.ComponentAssignmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentAssignments", ctx, companyID, employeeID)
+	ret0, _ := ret[0].([]payroll.ComponentAssignmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentAssignments indicates an expected call of GetComponentAssignments.
+func (mr *MockServiceMockRecorder) GetComponentAssignments(ctx, companyID, employeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentAssignments", reflect.TypeOf((*MockService)(nil).GetComponentAssignments), ctx, companyID, employeeID)
+}
+
+// GetComponentTemplates mocks base method.
+func (m *MockService) GetComponentTemplates(ctx context.Context, companyID string) ([]payroll.ComponentTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentTemplates", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.ComponentTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentTemplates indicates an expected call of GetComponentTemplates.
+func (mr *MockServiceMockRecorder) GetComponentTemplates(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentTemplates", reflect.TypeOf((*MockService)(nil).GetComponentTemplates), ctx, companyID)
+}
+
+// GetLoanByID mocks base method.
+func (m *MockService) GetLoanByID(ctx context.Context, companyID, id string) (payroll.LoanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanByID", ctx, companyID, id)
+	ret0, _ := ret[0].(payroll.LoanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanByID indicates an expected call of GetLoanByID.
+func (mr *MockServiceMockRecorder) GetLoanByID(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanByID", reflect.TypeOf((*MockService)(nil).GetLoanByID), ctx, companyID, id)
+}
+
+// GetLoans mocks base method.
+func (m *MockService) GetLoans(ctx context.Context, companyID string, req payroll.GetLoansFilterRequest) ([]payroll.LoanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoans", ctx, companyID, req)
+	ret0, _ := ret[0].([]payroll.LoanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoans indicates an expected call of GetLoans.
+func (mr *MockServiceMockRecorder) GetLoans(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoans", reflect.TypeOf((*MockService)(nil).GetLoans), ctx, companyID, req)
+}
+
+// GetPayslipDownload mocks base method.
+func (m *MockService) GetPayslipDownload(ctx context.Context, companyID, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayslipDownload", ctx, companyID, requesterEmployeeID, id, canReadAll)
+	ret0, _ := ret[0].(payroll.PayslipDownloadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayslipDownload indicates an expected call of GetPayslipDownload.
+func (mr *MockServiceMockRecorder) GetPayslipDownload(ctx, companyID, requesterEmployeeID, id, canReadAll any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayslipDownload", reflect.TypeOf((*MockService)(nil).GetPayslipDownload), ctx, companyID, requesterEmployeeID, id, canReadAll)
+}
+
+// GetPeriod mocks base method.
+func (m *MockService) GetPeriod(ctx context.Context, companyID, period string) (payroll.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeriod", ctx, companyID, period)
+	ret0, _ := ret[0].(payroll.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeriod indicates an expected call of GetPeriod.
+func (mr *MockServiceMockRecorder) GetPeriod(ctx, companyID, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeriod", reflect.TypeOf((*MockService)(nil).GetPeriod), ctx, companyID, period)
+}
+
+// GetPeriods mocks base method.
+func (m *MockService) GetPeriods(ctx context.Context, companyID string, req payroll.GetPayrollPeriodsRequest) ([]payroll.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeriods", ctx, companyID, req)
+	ret0, _ := ret[0].([]payroll.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeriods indicates an expected call of GetPeriods.
+func (mr *MockServiceMockRecorder) GetPeriods(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeriods", reflect.TypeOf((*MockService)(nil).GetPeriods), ctx, companyID, req)
+}
+
+// GetRunByID mocks base method.
+func (m *MockService) GetRunByID(ctx context.Context, companyID, id string) (payroll.PayrollRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunByID", ctx, companyID, id)
+	ret0, _ := ret[0].(payroll.PayrollRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunByID indicates an expected call of GetRunByID.
+func (mr *MockServiceMockRecorder) GetRunByID(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunByID", reflect.TypeOf((*MockService)(nil).GetRunByID), ctx, companyID, id)
+}
+
+// GetRuns mocks base method.
+func (m *MockService) GetRuns(ctx context.Context, companyID string) ([]payroll.PayrollRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, companyID)
+	ret0, _ := ret[0].([]payroll.PayrollRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockServiceMockRecorder) GetRuns(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*MockService)(nil).GetRuns), ctx, companyID)
+}
+
+// GetSetting mocks base method.
+func (m *MockService) GetSetting(ctx context.Context, companyID string) (payroll.PayrollSettingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetting", ctx, companyID)
+	ret0, _ := ret[0].(payroll.PayrollSettingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetting indicates an expected call of GetSetting.
+func (mr *MockServiceMockRecorder) GetSetting(ctx, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetting", reflect.TypeOf((*MockService)(nil).GetSetting), ctx, companyID)
+}
+
+// GetVarianceReport mocks base method.
+func (m *MockService) GetVarianceReport(ctx context.Context, companyID string, req payroll.PayrollVarianceFilterRequest) (payroll.PayrollVarianceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVarianceReport", ctx, companyID, req)
+	ret0, _ := ret[0].(payroll.PayrollVarianceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVarianceReport indicates an expected call of GetVarianceReport.
+func (mr *MockServiceMockRecorder) GetVarianceReport(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVarianceReport", reflect.TypeOf((*MockService)(nil).GetVarianceReport), ctx, companyID, req)
+}
+
+// ImportBankResult mocks base method.
+func (m *MockService) ImportBankResult(ctx context.Context, companyID, actorID string, content []byte) (payroll.BankResultResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankResult", ctx, companyID, actorID, content)
+	ret0, _ := ret[0].(payroll.BankResultResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankResult indicates an expected call of ImportBankResult.
+func (mr *MockServiceMockRecorder) ImportBankResult(ctx, companyID, actorID, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankResult", reflect.TypeOf((*MockService)(nil).ImportBankResult), ctx, companyID, actorID, content)
+}
+
 // MarkAsPaid mocks base method.
 func (m *MockService) MarkAsPaid(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +535,51 @@ func (mr *MockServiceMockRecorder) MarkAsPaid(ctx, companyID, actorID, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsPaid", reflect.TypeOf((*MockService)(nil).MarkAsPaid), ctx, companyID, actorID, id)
 }
 
+// MarkRunAsPaid mocks base method.
+func (m *MockService) MarkRunAsPaid(ctx context.Context, companyID, actorID, id string) (payroll.PayrollRunResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRunAsPaid", ctx, companyID, actorID, id)
+	ret0, _ := ret[0].(payroll.PayrollRunResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRunAsPaid indicates an expected call of MarkRunAsPaid.
+func (mr *MockServiceMockRecorder) MarkRunAsPaid(ctx, companyID, actorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRunAsPaid", reflect.TypeOf((*MockService)(nil).MarkRunAsPaid), ctx, companyID, actorID, id)
+}
+
+// PayoffLoan mocks base method.
+func (m *MockService) PayoffLoan(ctx context.Context, companyID, actorID, id string, req payroll.PayoffLoanRequest) (payroll.LoanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayoffLoan", ctx, companyID, actorID, id, req)
+	ret0, _ := ret[0].(payroll.LoanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayoffLoan indicates an expected call of PayoffLoan.
+func (mr *MockServiceMockRecorder) PayoffLoan(ctx, companyID, actorID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayoffLoan", reflect.TypeOf((*MockService)(nil).PayoffLoan), ctx, companyID, actorID, id, req)
+}
+
+// ProcessPeriod mocks base method.
+func (m *MockService) ProcessPeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPeriod", ctx, companyID, actorID, period, req)
+	ret0, _ := ret[0].(payroll.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPeriod indicates an expected call of ProcessPeriod.
+func (mr *MockServiceMockRecorder) ProcessPeriod(ctx, companyID, actorID, period, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPeriod", reflect.TypeOf((*MockService)(nil).ProcessPeriod), ctx, companyID, actorID, period, req)
+}
+
 // Regenerate mocks base method.
 func (m *MockService) Regenerate(ctx context.Context, companyID, actorID, id string, req payroll.RegeneratePayrollRequest) (payroll.PayrollResponse, error) {
 	m.ctrl.T.Helper()
@@ -174,3 +594,123 @@ func (mr *MockServiceMockRecorder) Regenerate(ctx, companyID, actorID, id, req a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Regenerate", reflect.TypeOf((*MockService)(nil).Regenerate), ctx, companyID, actorID, id, req)
 }
+
+// ReopenPeriod mocks base method.
+func (m *MockService) ReopenPeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPeriod", ctx, companyID, actorID, period, req)
+	ret0, _ := ret[0].(payroll.PayrollPeriodResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenPeriod indicates an expected call of ReopenPeriod.
+func (mr *MockServiceMockRecorder) ReopenPeriod(ctx, companyID, actorID, period, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPeriod", reflect.TypeOf((*MockService)(nil).ReopenPeriod), ctx, companyID, actorID, period, req)
+}
+
+// Reverse mocks base method.
+func (m *MockService) Reverse(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, companyID, actorID, id, req)
+	ret0, _ := ret[0].(payroll.PayrollResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockServiceMockRecorder) Reverse(ctx, companyID, actorID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockService)(nil).Reverse), ctx, companyID, actorID, id, req)
+}
+
+// Simulate mocks base method.
+func (m *MockService) Simulate(ctx context.Context, companyID string, req payroll.SimulatePayrollRequest) (payroll.PayrollSimulationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", ctx, companyID, req)
+	ret0, _ := ret[0].(payroll.PayrollSimulationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockServiceMockRecorder) Simulate(ctx, companyID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockService)(nil).Simulate), ctx, companyID, req)
+}
+
+// UpdateAccountMappings mocks base method.
+func (m *MockService) UpdateAccountMappings(ctx context.Context, companyID, actorID string, req payroll.UpdateAccountMappingsRequest) ([]payroll.AccountMappingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountMappings", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].([]payroll.AccountMappingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountMappings indicates an expected call of UpdateAccountMappings.
+func (mr *MockServiceMockRecorder) UpdateAccountMappings(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountMappings", reflect.TypeOf((*MockService)(nil).UpdateAccountMappings), ctx, companyID, actorID, req)
+}
+
+// UpdateApprovalChain mocks base method.
+func (m *MockService) UpdateApprovalChain(ctx context.Context, companyID, actorID string, req payroll.UpdateApprovalChainRequest) (payroll.ApprovalChainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApprovalChain", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.ApprovalChainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateApprovalChain indicates an expected call of UpdateApprovalChain.
+func (mr *MockServiceMockRecorder) UpdateApprovalChain(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApprovalChain", reflect.TypeOf((*MockService)(nil).UpdateApprovalChain), ctx, companyID, actorID, req)
+}
+
+// UpdateComponentAssignment mocks base method.
+func (m *MockService) UpdateComponentAssignment(ctx context.Context, companyID, id string, req payroll.UpdateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponentAssignment", ctx, companyID, id, req)
+	ret0, _ := ret[0].(payroll.ComponentAssignmentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComponentAssignment indicates an expected call of UpdateComponentAssignment.
+func (mr *MockServiceMockRecorder) UpdateComponentAssignment(ctx, companyID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponentAssignment", reflect.TypeOf((*MockService)(nil).UpdateComponentAssignment), ctx, companyID, id, req)
+}
+
+// UpdateComponentTemplate mocks base method.
+func (m *MockService) UpdateComponentTemplate(ctx context.Context, companyID, actorID, id string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponentTemplate", ctx, companyID, actorID, id, req)
+	ret0, _ := ret[0].(payroll.ComponentTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComponentTemplate indicates an expected call of UpdateComponentTemplate.
+func (mr *MockServiceMockRecorder) UpdateComponentTemplate(ctx, companyID, actorID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponentTemplate", reflect.TypeOf((*MockService)(nil).UpdateComponentTemplate), ctx, companyID, actorID, id, req)
+}
+
+// UpdateSetting mocks base method.
+func (m *MockService) UpdateSetting(ctx context.Context, companyID, actorID string, req payroll.UpdatePayrollSettingRequest) (payroll.PayrollSettingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSetting", ctx, companyID, actorID, req)
+	ret0, _ := ret[0].(payroll.PayrollSettingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSetting indicates an expected call of UpdateSetting.
+func (mr *MockServiceMockRecorder) UpdateSetting(ctx, companyID, actorID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSetting", reflect.TypeOf((*MockService)(nil).UpdateSetting), ctx, companyID, actorID, req)
+}
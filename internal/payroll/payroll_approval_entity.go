@@ -0,0 +1,43 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalStep adalah satu langkah rantai approval payroll company. Role dicocokkan dengan
+// role pada token aktor; MinNetTotal > 0 membuat langkah hanya wajib di atas total tersebut.
+type ApprovalStep struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	StepOrder   int       `gorm:"type:int;not null"`
+	Name        string    `gorm:"type:varchar(100);not null"`
+	Role        string    `gorm:"type:varchar(50);not null"`
+	MinNetTotal int64     `gorm:"type:bigint;not null;default:0"`
+	CreatedBy   uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (ApprovalStep) TableName() string {
+	return "payroll_approval_steps"
+}
+
+// PayrollApproval mencatat langkah approval yang sudah dilalui satu payroll atau payroll run.
+type PayrollApproval struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID uuid.UUID  `gorm:"type:uuid;not null"`
+	PayrollID *uuid.UUID `gorm:"type:uuid;index"`
+	RunID     *uuid.UUID `gorm:"type:uuid;index"`
+	StepOrder int        `gorm:"type:int;not null"`
+	StepName  string     `gorm:"type:varchar(100);not null"`
+	Role      string     `gorm:"type:varchar(50);not null"`
+	ActorID   uuid.UUID  `gorm:"type:uuid;not null"`
+	Comment   *string    `gorm:"type:text"`
+	CreatedAt time.Time
+}
+
+func (PayrollApproval) TableName() string {
+	return "payroll_approvals"
+}
@@ -0,0 +1,214 @@
+package payroll
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+const maxApprovalSteps = 10
+
+// defaultApprovalChain dipakai company yang belum mengatur rantai approval: satu langkah
+// yang boleh disetujui siapa pun pemegang payroll:approve, sama seperti sebelumnya.
+func defaultApprovalChain() []ApprovalStep {
+	return []ApprovalStep{{StepOrder: 1, Name: "Approval"}}
+}
+
+func (s *service) GetApprovalChain(ctx context.Context, companyID string) (ApprovalChainResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return ApprovalChainResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+
+	steps, err := s.repo.FindApprovalSteps(ctx, companyID)
+	if err != nil {
+		return ApprovalChainResponse{}, err
+	}
+	return mapToApprovalChainResponse(steps), nil
+}
+
+// UpdateApprovalChain mengganti seluruh langkah approval company. Approval payroll dan run
+// yang masih DRAFT dihapus sehingga rantai baru diulang dari langkah pertama.
+func (s *service) UpdateApprovalChain(
+	ctx context.Context,
+	companyID, actorID string,
+	req UpdateApprovalChainRequest,
+) (ApprovalChainResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return ApprovalChainResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return ApprovalChainResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	if len(req.Steps) > maxApprovalSteps {
+		return ApprovalChainResponse{}, payrollerrors.ErrInvalidApprovalChain
+	}
+
+	steps := make([]ApprovalStep, 0, len(req.Steps))
+	hasMandatory := false
+	for i, item := range req.Steps {
+		name := strings.TrimSpace(item.Name)
+		role := strings.TrimSpace(item.Role)
+		if name == "" || len(name) > 100 || role == "" || len(role) > 50 || item.MinNetTotal < 0 {
+			return ApprovalChainResponse{}, payrollerrors.ErrInvalidApprovalChain
+		}
+		if item.MinNetTotal == 0 {
+			hasMandatory = true
+		}
+		steps = append(steps, ApprovalStep{
+			ID:          uuid.New(),
+			CompanyID:   companyUUID,
+			StepOrder:   i + 1,
+			Name:        name,
+			Role:        role,
+			MinNetTotal: item.MinNetTotal,
+			CreatedBy:   actorUUID,
+		})
+	}
+	if len(steps) > 0 && !hasMandatory {
+		return ApprovalChainResponse{}, payrollerrors.ErrInvalidApprovalChain
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ApprovalChainResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+	if err := qtx.ReplaceApprovalSteps(ctx, companyID, steps); err != nil {
+		return ApprovalChainResponse{}, err
+	}
+	if err := qtx.ResetPendingApprovals(ctx, companyID); err != nil {
+		return ApprovalChainResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ApprovalChainResponse{}, err
+	}
+
+	return mapToApprovalChainResponse(steps), nil
+}
+
+// requiredApprovalSteps mengembalikan langkah rantai yang wajib untuk total net salary tersebut.
+func requiredApprovalSteps(ctx context.Context, repo Repository, companyID string, netTotal int64) ([]ApprovalStep, error) {
+	chain, err := repo.FindApprovalSteps(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		chain = defaultApprovalChain()
+	}
+
+	required := make([]ApprovalStep, 0, len(chain))
+	for _, step := range chain {
+		if step.MinNetTotal == 0 || netTotal >= step.MinNetTotal {
+			required = append(required, step)
+		}
+	}
+	return required, nil
+}
+
+// nextApprovalStep mengembalikan langkah wajib pertama yang belum disetujui, atau nil jika
+// seluruh langkah sudah dilalui.
+func nextApprovalStep(required []ApprovalStep, approvals []PayrollApproval) *ApprovalStep {
+	approved := make(map[int]struct{}, len(approvals))
+	for _, a := range approvals {
+		approved[a.StepOrder] = struct{}{}
+	}
+	for i := range required {
+		if _, ok := approved[required[i].StepOrder]; !ok {
+			return &required[i]
+		}
+	}
+	return nil
+}
+
+// recordApprovalStep mencatat persetujuan aktor pada langkah berikutnya. Satu aktor hanya
+// boleh menyetujui satu langkah per payroll/run. Nilai kembali true berarti langkah terakhir
+// sudah dilalui dan payroll boleh berpindah ke APPROVED.
+func recordApprovalStep(
+	ctx context.Context,
+	qtx Repository,
+	required []ApprovalStep,
+	approvals *[]PayrollApproval,
+	approval PayrollApproval,
+	role string,
+	comment string,
+	now time.Time,
+) (bool, error) {
+	step := nextApprovalStep(required, *approvals)
+	if step == nil {
+		// Rantai diubah setelah seluruh langkah yang kini wajib sudah disetujui.
+		return true, nil
+	}
+	role = strings.TrimSpace(role)
+	if step.Role != "" && !strings.EqualFold(step.Role, role) {
+		return false, payrollerrors.ErrApprovalRoleNotAllowed
+	}
+	for _, a := range *approvals {
+		if a.ActorID == approval.ActorID {
+			return false, payrollerrors.ErrApprovalActorAlreadyApproved
+		}
+	}
+
+	approval.ID = uuid.New()
+	approval.StepOrder = step.StepOrder
+	approval.StepName = step.Name
+	approval.Role = role
+	approval.CreatedAt = now
+	if v := strings.TrimSpace(comment); v != "" {
+		approval.Comment = &v
+	}
+	if err := qtx.CreateApproval(ctx, &approval); err != nil {
+		return false, err
+	}
+	*approvals = append(*approvals, approval)
+
+	return nextApprovalStep(required, *approvals) == nil, nil
+}
+
+func mapToApprovalChainResponse(steps []ApprovalStep) ApprovalChainResponse {
+	resp := ApprovalChainResponse{}
+	if len(steps) == 0 {
+		resp.IsDefault = true
+		steps = defaultApprovalChain()
+	}
+	resp.Steps = make([]ApprovalStepResponse, 0, len(steps))
+	for _, step := range steps {
+		resp.Steps = append(resp.Steps, mapToApprovalStepResponse(step))
+	}
+	return resp
+}
+
+func mapToApprovalStepResponse(step ApprovalStep) ApprovalStepResponse {
+	return ApprovalStepResponse{
+		StepOrder:   step.StepOrder,
+		Name:        step.Name,
+		Role:        step.Role,
+		MinNetTotal: step.MinNetTotal,
+	}
+}
+
+func mapToApprovalResponses(approvals []PayrollApproval) []PayrollApprovalResponse {
+	if len(approvals) == 0 {
+		return nil
+	}
+	resp := make([]PayrollApprovalResponse, 0, len(approvals))
+	for _, a := range approvals {
+		resp = append(resp, PayrollApprovalResponse{
+			StepOrder:  a.StepOrder,
+			StepName:   a.StepName,
+			Role:       a.Role,
+			ActorID:    a.ActorID.String(),
+			Comment:    a.Comment,
+			ApprovedAt: a.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
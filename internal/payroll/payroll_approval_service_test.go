@@ -0,0 +1,276 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+
+	"go-hris/internal/messaging/kafka"
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_ApproveChain(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	payrollID := uuid.New().String()
+	hrID, financeID, ownerID := uuid.New(), uuid.New(), uuid.New()
+
+	chain := []payroll.ApprovalStep{
+		{StepOrder: 1, Name: "HR Review", Role: "HR"},
+		{StepOrder: 2, Name: "Finance Approval", Role: "Finance"},
+		{StepOrder: 3, Name: "Owner Sign-off", Role: "Owner", MinNetTotal: 50000000},
+	}
+
+	// setup menyiapkan payroll DRAFT dengan approval yang sudah dilalui dan menghitung event outbox.
+	setup := func(t *testing.T, netSalary int64, approvals []payroll.PayrollApproval) (payroll.Service, sqlmock.Sqlmock, *int, *[]payroll.PayrollApproval) {
+		db, sqlMock, err := sqlmock.New()
+		assert.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		created := make([]payroll.PayrollApproval, 0)
+		repo := &fakePayrollRepository{
+			findByIDAndCompanyFn: func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+				return &payroll.Payroll{
+					ID:        uuid.MustParse(id),
+					CompanyID: uuid.MustParse(cid),
+					Status:    payroll.StatusDraft,
+					NetSalary: netSalary,
+					Approvals: approvals,
+				}, nil
+			},
+			findApprovalStepsFn: func(ctx context.Context, cid string) ([]payroll.ApprovalStep, error) {
+				return chain, nil
+			},
+			createApprovalFn: func(ctx context.Context, approval *payroll.PayrollApproval) error {
+				created = append(created, *approval)
+				return nil
+			},
+		}
+		queued := 0
+		outbox := &fakeOutboxRepository{
+			createFn: func(ctx context.Context, event kafka.OutboxEvent) error {
+				queued++
+				return nil
+			},
+		}
+		return payroll.NewServiceWithOutbox(db, repo, outbox), sqlMock, &queued, &created
+	}
+
+	t.Run("first step keeps payroll draft", func(t *testing.T) {
+		svc, sqlMock, queued, created := setup(t, 10000000, nil)
+		expectTx(t, sqlMock, true)
+
+		resp, err := svc.Approve(ctx, companyID, hrID.String(), "hr", payrollID, payroll.ApprovePayrollRequest{Comment: " sudah dicek "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.StatusDraft, resp.Status)
+		assert.Nil(t, resp.ApprovedBy)
+		assert.Equal(t, 0, *queued)
+		if assert.Len(t, *created, 1) {
+			assert.Equal(t, 1, (*created)[0].StepOrder)
+			assert.Equal(t, "HR Review", (*created)[0].StepName)
+			if assert.NotNil(t, (*created)[0].Comment) {
+				assert.Equal(t, "sudah dicek", *(*created)[0].Comment)
+			}
+		}
+		if assert.NotNil(t, resp.NextApprovalStep) {
+			assert.Equal(t, "Finance", resp.NextApprovalStep.Role)
+		}
+		assert.Len(t, resp.Approvals, 1)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("final step below threshold approves and queues payslip event", func(t *testing.T) {
+		svc, sqlMock, queued, _ := setup(t, 10000000, []payroll.PayrollApproval{
+			{StepOrder: 1, StepName: "HR Review", Role: "HR", ActorID: hrID},
+		})
+		expectTx(t, sqlMock, true)
+
+		resp, err := svc.Approve(ctx, companyID, financeID.String(), "Finance", payrollID, payroll.ApprovePayrollRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.StatusApproved, resp.Status)
+		assert.Nil(t, resp.NextApprovalStep)
+		assert.Equal(t, 1, *queued)
+		assert.Len(t, resp.Approvals, 2)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("owner sign-off required above threshold", func(t *testing.T) {
+		svc, sqlMock, queued, _ := setup(t, 60000000, []payroll.PayrollApproval{
+			{StepOrder: 1, StepName: "HR Review", Role: "HR", ActorID: hrID},
+		})
+		expectTx(t, sqlMock, true)
+
+		resp, err := svc.Approve(ctx, companyID, financeID.String(), "Finance", payrollID, payroll.ApprovePayrollRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.StatusDraft, resp.Status)
+		assert.Equal(t, 0, *queued)
+		if assert.NotNil(t, resp.NextApprovalStep) {
+			assert.Equal(t, "Owner", resp.NextApprovalStep.Role)
+		}
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("wrong role rejected", func(t *testing.T) {
+		svc, sqlMock, _, created := setup(t, 10000000, nil)
+		expectTx(t, sqlMock, false)
+
+		_, err := svc.Approve(ctx, companyID, ownerID.String(), "Owner", payrollID, payroll.ApprovePayrollRequest{})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrApprovalRoleNotAllowed)
+		assert.Empty(t, *created)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("same actor cannot approve two steps", func(t *testing.T) {
+		svc, sqlMock, _, _ := setup(t, 10000000, []payroll.PayrollApproval{
+			{StepOrder: 1, StepName: "HR Review", Role: "HR", ActorID: hrID},
+		})
+		expectTx(t, sqlMock, false)
+
+		_, err := svc.Approve(ctx, companyID, hrID.String(), "Finance", payrollID, payroll.ApprovePayrollRequest{})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrApprovalActorAlreadyApproved)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_Approve_RejectsRunPayroll(t *testing.T) {
+	ctx := context.Background()
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	expectTx(t, deps.sqlMock, false)
+
+	runID := uuid.New()
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+		return &payroll.Payroll{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft, NetSalary: 30000000, RunID: &runID}, nil
+	}
+	deps.repo.createApprovalFn = func(ctx context.Context, approval *payroll.PayrollApproval) error {
+		t.Fatal("run payroll must not be approved individually")
+		return nil
+	}
+
+	_, err := deps.service.Approve(ctx, uuid.New().String(), uuid.New().String(), "Finance", uuid.New().String(), payroll.ApprovePayrollRequest{})
+
+	assert.ErrorIs(t, err, payrollerrors.ErrPayrollApprovedThroughRun)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_ApproveRun_Threshold(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	runID := uuid.New()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.PayrollRun, error) {
+		return &payroll.PayrollRun{
+			ID:        runID,
+			CompanyID: uuid.MustParse(cid),
+			Status:    payroll.StatusDraft,
+			Approvals: []payroll.PayrollApproval{{StepOrder: 1, StepName: "Finance Approval", Role: "Finance", ActorID: uuid.New()}},
+		}, nil
+	}
+	deps.repo.findByRunFn = func(ctx context.Context, cid string, rid string) ([]payroll.Payroll, error) {
+		// Masing-masing di bawah threshold, tapi total run melewatinya.
+		return []payroll.Payroll{
+			{ID: uuid.New(), Status: payroll.StatusDraft, NetSalary: 30000000, RunID: &runID},
+			{ID: uuid.New(), Status: payroll.StatusDraft, NetSalary: 30000000, RunID: &runID},
+		}, nil
+	}
+	deps.repo.findApprovalStepsFn = func(ctx context.Context, cid string) ([]payroll.ApprovalStep, error) {
+		return []payroll.ApprovalStep{
+			{StepOrder: 1, Name: "Finance Approval", Role: "Finance"},
+			{StepOrder: 2, Name: "Owner Sign-off", Role: "Owner", MinNetTotal: 50000000},
+		}, nil
+	}
+	deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+		assert.Equal(t, payroll.StatusApproved, p.Status)
+		return nil
+	}
+
+	expectTx(t, deps.sqlMock, true)
+	resp, err := deps.service.ApproveRun(ctx, companyID, uuid.New().String(), "owner", runID.String(), payroll.ApprovePayrollRequest{Comment: "ok"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.StatusApproved, resp.Status)
+	if assert.Len(t, resp.Approvals, 2) {
+		assert.Equal(t, "Owner Sign-off", resp.Approvals[1].StepName)
+	}
+	for _, p := range resp.Payrolls {
+		assert.Equal(t, payroll.StatusApproved, p.Status)
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_UpdateApprovalChain(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("replace chain", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.replaceApprovalStepsFn = func(ctx context.Context, cid string, steps []payroll.ApprovalStep) error {
+			if assert.Len(t, steps, 3) {
+				assert.Equal(t, 3, steps[2].StepOrder)
+				assert.Equal(t, int64(50000000), steps[2].MinNetTotal)
+			}
+			return nil
+		}
+		reset := false
+		deps.repo.resetPendingApprovalsFn = func(ctx context.Context, cid string) error {
+			// Approval lama pada payroll/run DRAFT tidak boleh memenuhi langkah rantai baru
+			assert.Equal(t, companyID, cid)
+			reset = true
+			return nil
+		}
+
+		resp, err := deps.service.UpdateApprovalChain(ctx, companyID, actorID, payroll.UpdateApprovalChainRequest{
+			Steps: []payroll.ApprovalStepRequest{
+				{Name: "HR Review", Role: "HR"},
+				{Name: "Finance Approval", Role: "Finance"},
+				{Name: "Owner Sign-off", Role: "Owner", MinNetTotal: 50000000},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.IsDefault)
+		assert.Len(t, resp.Steps, 3)
+		assert.True(t, reset)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("default chain when not configured", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		resp, err := deps.service.GetApprovalChain(ctx, companyID)
+
+		assert.NoError(t, err)
+		assert.True(t, resp.IsDefault)
+		if assert.Len(t, resp.Steps, 1) {
+			assert.Empty(t, resp.Steps[0].Role)
+		}
+	})
+
+	t.Run("at least one mandatory step", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.UpdateApprovalChain(ctx, companyID, actorID, payroll.UpdateApprovalChainRequest{
+			Steps: []payroll.ApprovalStepRequest{{Name: "Owner Sign-off", Role: "Owner", MinNetTotal: 50000000}},
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidApprovalChain)
+	})
+}
@@ -0,0 +1,477 @@
+package payroll
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BankFormatBCA     = "BCA"
+	BankFormatMandiri = "MANDIRI"
+	BankFormatBNI     = "BNI"
+	BankFormatPain001 = "PAIN001" // ISO 20022 pain.001.001.03 (Customer Credit Transfer Initiation)
+
+	BankResultPaid    = "PAID"
+	BankResultFailed  = "FAILED"
+	BankResultSkipped = "SKIPPED"
+)
+
+// BankTransferRow adalah proyeksi payroll beserta rekening karyawan untuk file transfer.
+type BankTransferRow struct {
+	PayrollID         uuid.UUID
+	EmployeeID        uuid.UUID
+	EmployeeNumber    string
+	EmployeeName      string
+	Email             string
+	BankCode          *string
+	BankAccountNumber *string
+	BankAccountName   *string
+	Status            string
+	NetSalary         int64
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	ReversalOfID      *uuid.UUID
+	BankExportBatchID *string
+	BankExportedAt    *time.Time
+}
+
+type bankTransfer struct {
+	PayrollID     uuid.UUID
+	Reference     string
+	BankCode      string
+	AccountNumber string
+	AccountName   string
+	Email         string
+	Remark        string
+	Amount        int64
+}
+
+type bankTransferBatch struct {
+	BatchID            string
+	CreatedAt          time.Time
+	ExecutionDate      time.Time
+	DebitBankCode      string
+	DebitAccountNumber string
+	DebitAccountName   string
+	CompanyCode        string
+	Transfers          []bankTransfer
+	TotalAmount        int64
+}
+
+// isInHouse menandai transfer ke rekening di bank yang sama dengan rekening debit.
+func (b bankTransferBatch) isInHouse(t bankTransfer) bool {
+	return strings.EqualFold(t.BankCode, b.DebitBankCode)
+}
+
+type bankFileFormat struct {
+	Extension   string
+	ContentType string
+	Write       func(bankTransferBatch) ([]byte, error)
+}
+
+// Layout CSV mengikuti template bulk transfer masing-masing bank. Kolom referensi
+// berisi paymentReference sehingga file hasil dari bank bisa dicocokkan kembali.
+var bankFileFormats = map[string]bankFileFormat{
+	BankFormatBCA:     {Extension: "csv", ContentType: "text/csv", Write: writeBCAFile},
+	BankFormatMandiri: {Extension: "csv", ContentType: "text/csv", Write: writeMandiriFile},
+	BankFormatBNI:     {Extension: "csv", ContentType: "text/csv", Write: writeBNIFile},
+	BankFormatPain001: {Extension: "xml", ContentType: "application/xml", Write: writePain001File},
+}
+
+// paymentReference adalah ID payroll tanpa tanda hubung (32 karakter), muat di
+// field EndToEndId pain.001 (maks. 35) dan bisa di-parse ulang dengan uuid.Parse.
+func paymentReference(payrollID uuid.UUID) string {
+	return strings.ToUpper(strings.ReplaceAll(payrollID.String(), "-", ""))
+}
+
+// isAccountNumber memvalidasi nomor rekening: 5-34 digit angka.
+func isAccountNumber(v string) bool {
+	if len(v) < 5 || len(v) > 34 {
+		return false
+	}
+	for _, r := range v {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func writeCSV(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func amountString(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
+
+// writeBCAFile: baris header (H) berisi corporate ID dan rekening debit, diikuti baris detail (D).
+func writeBCAFile(batch bankTransferBatch) ([]byte, error) {
+	records := [][]string{{
+		"H",
+		batch.CompanyCode,
+		batch.DebitAccountNumber,
+		batch.ExecutionDate.Format("20060102"),
+		strconv.Itoa(len(batch.Transfers)),
+		amountString(batch.TotalAmount),
+		batch.BatchID,
+	}}
+	for _, t := range batch.Transfers {
+		transferType := "LLG"
+		if batch.isInHouse(t) {
+			transferType = "BCA"
+		}
+		records = append(records, []string{
+			"D",
+			transferType,
+			t.BankCode,
+			t.AccountNumber,
+			t.AccountName,
+			amountString(t.Amount),
+			t.Reference,
+			t.Remark,
+			t.Email,
+		})
+	}
+	return writeCSV(records)
+}
+
+// writeMandiriFile: baris pertama (P) berisi tanggal eksekusi dan rekening debit.
+func writeMandiriFile(batch bankTransferBatch) ([]byte, error) {
+	records := [][]string{{
+		"P",
+		batch.ExecutionDate.Format("20060102"),
+		batch.DebitAccountNumber,
+		strconv.Itoa(len(batch.Transfers)),
+		amountString(batch.TotalAmount),
+	}}
+	for _, t := range batch.Transfers {
+		transferType := "LBU"
+		if batch.isInHouse(t) {
+			transferType = "IBU"
+		}
+		records = append(records, []string{
+			t.AccountNumber,
+			t.AccountName,
+			"IDR",
+			amountString(t.Amount),
+			t.Reference,
+			t.Remark,
+			transferType,
+			t.BankCode,
+			t.Email,
+		})
+	}
+	return writeCSV(records)
+}
+
+// writeBNIFile: baris ringkasan, baris judul kolom, lalu satu baris per transfer.
+func writeBNIFile(batch bankTransferBatch) ([]byte, error) {
+	records := [][]string{
+		{
+			batch.ExecutionDate.Format("2006/01/02"),
+			strconv.Itoa(len(batch.Transfers)),
+			amountString(batch.TotalAmount),
+			batch.DebitAccountNumber,
+		},
+		{"No", "Account No", "Account Name", "Amount", "Currency", "Bank Code", "Reference", "Remark", "Email"},
+	}
+	for i, t := range batch.Transfers {
+		records = append(records, []string{
+			strconv.Itoa(i + 1),
+			t.AccountNumber,
+			t.AccountName,
+			amountString(t.Amount),
+			"IDR",
+			t.BankCode,
+			t.Reference,
+			t.Remark,
+			t.Email,
+		})
+	}
+	return writeCSV(records)
+}
+
+type pain001Document struct {
+	XMLName xml.Name          `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 Document"`
+	Init    pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	GroupHeader pain001GroupHeader `xml:"GrpHdr"`
+	PaymentInfo pain001PaymentInfo `xml:"PmtInf"`
+}
+
+type pain001GroupHeader struct {
+	MessageID      string      `xml:"MsgId"`
+	CreatedAt      string      `xml:"CreDtTm"`
+	NumberOfTxs    int         `xml:"NbOfTxs"`
+	ControlSum     string      `xml:"CtrlSum"`
+	InitiatingName pain001Name `xml:"InitgPty"`
+}
+
+type pain001Name struct {
+	Name string `xml:"Nm"`
+}
+
+type pain001Account struct {
+	ID string `xml:"Id>Othr>Id"`
+}
+
+type pain001Agent struct {
+	ID string `xml:"FinInstnId>Othr>Id"`
+}
+
+type pain001PaymentInfo struct {
+	PaymentInfoID string              `xml:"PmtInfId"`
+	Method        string              `xml:"PmtMtd"`
+	NumberOfTxs   int                 `xml:"NbOfTxs"`
+	ControlSum    string              `xml:"CtrlSum"`
+	CategoryCode  string              `xml:"PmtTpInf>CtgyPurp>Cd"`
+	ExecutionDate string              `xml:"ReqdExctnDt"`
+	Debtor        pain001Name         `xml:"Dbtr"`
+	DebtorAccount pain001Account      `xml:"DbtrAcct"`
+	DebtorAgent   pain001Agent        `xml:"DbtrAgt"`
+	Transfers     []pain001CreditItem `xml:"CdtTrfTxInf"`
+}
+
+type pain001Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type pain001CreditItem struct {
+	EndToEndID      string         `xml:"PmtId>EndToEndId"`
+	Amount          pain001Amount  `xml:"Amt>InstdAmt"`
+	CreditorAgent   pain001Agent   `xml:"CdtrAgt"`
+	Creditor        pain001Name    `xml:"Cdtr"`
+	CreditorAccount pain001Account `xml:"CdtrAcct"`
+	Remittance      string         `xml:"RmtInf>Ustrd"`
+}
+
+// pain001AmountString memformat nominal rupiah dengan dua digit desimal sesuai skema ISO 20022.
+func pain001AmountString(amount int64) string {
+	return amountString(amount) + ".00"
+}
+
+// writePain001File menghasilkan satu PmtInf bertipe SALA (salary) untuk seluruh transfer.
+func writePain001File(batch bankTransferBatch) ([]byte, error) {
+	total := pain001AmountString(batch.TotalAmount)
+	doc := pain001Document{Init: pain001Initiation{
+		GroupHeader: pain001GroupHeader{
+			MessageID:      batch.BatchID,
+			CreatedAt:      batch.CreatedAt.Format("2006-01-02T15:04:05"),
+			NumberOfTxs:    len(batch.Transfers),
+			ControlSum:     total,
+			InitiatingName: pain001Name{Name: batch.DebitAccountName},
+		},
+		PaymentInfo: pain001PaymentInfo{
+			PaymentInfoID: batch.BatchID,
+			Method:        "TRF",
+			NumberOfTxs:   len(batch.Transfers),
+			ControlSum:    total,
+			CategoryCode:  "SALA",
+			ExecutionDate: batch.ExecutionDate.Format("2006-01-02"),
+			Debtor:        pain001Name{Name: batch.DebitAccountName},
+			DebtorAccount: pain001Account{ID: batch.DebitAccountNumber},
+			DebtorAgent:   pain001Agent{ID: batch.DebitBankCode},
+		},
+	}}
+	for _, t := range batch.Transfers {
+		doc.Init.PaymentInfo.Transfers = append(doc.Init.PaymentInfo.Transfers, pain001CreditItem{
+			EndToEndID:      t.Reference,
+			Amount:          pain001Amount{Currency: "IDR", Value: pain001AmountString(t.Amount)},
+			CreditorAgent:   pain001Agent{ID: t.BankCode},
+			Creditor:        pain001Name{Name: t.AccountName},
+			CreditorAccount: pain001Account{ID: t.AccountNumber},
+			Remittance:      t.Remark,
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// bankResultEntry adalah satu baris file hasil transfer dari bank.
+type bankResultEntry struct {
+	Reference     string
+	Status        string
+	BankReference string
+	Reason        string
+}
+
+// Status transaksi berhasil pada file hasil CSV bank maupun pain.002
+// (ACSC = settlement completed, ACCC = credited to creditor account).
+var bankSuccessStatuses = map[string]bool{
+	"SUCCESS":  true,
+	"SUKSES":   true,
+	"BERHASIL": true,
+	"OK":       true,
+	"ACSC":     true,
+	"ACCC":     true,
+}
+
+func isBankSuccessStatus(status string) bool {
+	return bankSuccessStatuses[strings.ToUpper(strings.TrimSpace(status))]
+}
+
+// parseBankResult membaca file hasil transfer. File XML dibaca sebagai pain.002,
+// selain itu sebagai CSV dengan baris judul kolom.
+func parseBankResult(content []byte) ([]bankResultEntry, error) {
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf")))
+	if len(trimmed) == 0 {
+		return nil, payrollerrors.ErrInvalidBankResultFile
+	}
+	if trimmed[0] == '<' {
+		return parsePain002Result(trimmed)
+	}
+	return parseCSVBankResult(trimmed)
+}
+
+// Alias judul kolom yang dipakai file hasil BCA, Mandiri dan BNI.
+var (
+	resultReferenceColumns     = []string{"reference", "customer reference", "no referensi", "referensi", "remark reference"}
+	resultStatusColumns        = []string{"status", "transaction status", "status transaksi", "result"}
+	resultBankReferenceColumns = []string{"bank reference", "journal no", "transaction id", "no jurnal"}
+	resultReasonColumns        = []string{"reason", "description", "keterangan", "error message"}
+)
+
+func parseCSVBankResult(content []byte) ([]bankResultEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var (
+		entries []bankResultEntry
+		columns map[string]int
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, payrollerrors.ErrInvalidBankResultFile
+		}
+
+		// Baris sebelum judul kolom (mis. ringkasan batch) dilewati.
+		if columns == nil {
+			columns = resultColumns(record)
+			continue
+		}
+
+		reference := columnValue(record, columns, "reference")
+		if reference == "" {
+			continue
+		}
+		entries = append(entries, bankResultEntry{
+			Reference:     reference,
+			Status:        strings.ToUpper(columnValue(record, columns, "status")),
+			BankReference: columnValue(record, columns, "bank_reference"),
+			Reason:        columnValue(record, columns, "reason"),
+		})
+	}
+	if columns == nil || len(entries) == 0 {
+		return nil, payrollerrors.ErrInvalidBankResultFile
+	}
+	return entries, nil
+}
+
+// resultColumns mengembalikan posisi kolom jika record adalah baris judul kolom.
+func resultColumns(record []string) map[string]int {
+	aliases := map[string][]string{
+		"reference":      resultReferenceColumns,
+		"status":         resultStatusColumns,
+		"bank_reference": resultBankReferenceColumns,
+		"reason":         resultReasonColumns,
+	}
+	columns := make(map[string]int)
+	for i, header := range record {
+		header = strings.ToLower(strings.TrimSpace(header))
+		for key, names := range aliases {
+			if _, found := columns[key]; found {
+				continue
+			}
+			for _, name := range names {
+				if header == name {
+					columns[key] = i
+				}
+			}
+		}
+	}
+	_, hasReference := columns["reference"]
+	_, hasStatus := columns["status"]
+	if !hasReference || !hasStatus {
+		return nil
+	}
+	return columns
+}
+
+func columnValue(record []string, columns map[string]int, key string) string {
+	i, ok := columns[key]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+type pain002Document struct {
+	Report struct {
+		Payments []struct {
+			Transactions []struct {
+				EndToEndID   string   `xml:"OrgnlEndToEndId"`
+				Status       string   `xml:"TxSts"`
+				Reasons      []string `xml:"StsRsnInf>AddtlInf"`
+				ServicerRef  string   `xml:"AcctSvcrRef"`
+				OriginalTxID string   `xml:"OrgnlTxId"`
+			} `xml:"TxInfAndSts"`
+		} `xml:"OrgnlPmtInfAndSts"`
+	} `xml:"CstmrPmtStsRpt"`
+}
+
+func parsePain002Result(content []byte) ([]bankResultEntry, error) {
+	var doc pain002Document
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil, payrollerrors.ErrInvalidBankResultFile
+	}
+
+	var entries []bankResultEntry
+	for _, payment := range doc.Report.Payments {
+		for _, tx := range payment.Transactions {
+			bankReference := tx.ServicerRef
+			if bankReference == "" {
+				bankReference = tx.OriginalTxID
+			}
+			entries = append(entries, bankResultEntry{
+				Reference:     strings.TrimSpace(tx.EndToEndID),
+				Status:        strings.ToUpper(strings.TrimSpace(tx.Status)),
+				BankReference: strings.TrimSpace(bankReference),
+				Reason:        strings.TrimSpace(strings.Join(tx.Reasons, " ")),
+			})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, payrollerrors.ErrInvalidBankResultFile
+	}
+	return entries, nil
+}
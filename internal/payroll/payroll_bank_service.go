@@ -0,0 +1,303 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/apperror"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExportBankTransfer menyusun file bulk transfer gaji untuk sekumpulan payroll APPROVED.
+// Seluruh payroll harus valid; satu saja yang gagal validasi membatalkan export.
+// Batch export dicatat pada payroll agar payroll yang filenya belum diselesaikan lewat
+// ImportBankResult tidak ikut ditransfer dua kali, kecuali ekspor ulang diminta eksplisit.
+func (s *service) ExportBankTransfer(
+	ctx context.Context,
+	companyID string,
+	req BankExportRequest,
+) (BankExportFile, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return BankExportFile{}, payrollerrors.ErrInvalidCompanyID
+	}
+	formatCode := strings.ToUpper(strings.TrimSpace(req.Format))
+	format, ok := bankFileFormats[formatCode]
+	if !ok {
+		return BankExportFile{}, payrollerrors.ErrInvalidBankFormat
+	}
+
+	now := time.Now().UTC()
+	executionDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if strings.TrimSpace(req.ExecutionDate) != "" {
+		executionDate, err = time.Parse("2006-01-02", strings.TrimSpace(req.ExecutionDate))
+		if err != nil {
+			return BankExportFile{}, payrollerrors.ErrInvalidDateFormat
+		}
+	}
+
+	setting, err := s.loadSetting(ctx, s.repo, companyUUID)
+	if err != nil {
+		return BankExportFile{}, err
+	}
+	if setting.DebitAccountNumber == "" || setting.DebitBankCode == "" {
+		return BankExportFile{}, payrollerrors.ErrDebitAccountNotConfigured
+	}
+
+	payrollIDs := uniqueStrings(req.PayrollIDs)
+	rows, err := s.repo.FindBankTransferRows(ctx, companyID, payrollIDs)
+	if err != nil {
+		return BankExportFile{}, err
+	}
+	if len(rows) != len(payrollIDs) {
+		return BankExportFile{}, payrollerrors.ErrPayrollNotFound
+	}
+
+	batch := bankTransferBatch{
+		BatchID:            fmt.Sprintf("PAY%s%s", executionDate.Format("20060102"), strings.ToUpper(uuid.NewString()[:8])),
+		CreatedAt:          now,
+		ExecutionDate:      executionDate,
+		DebitBankCode:      setting.DebitBankCode,
+		DebitAccountNumber: setting.DebitAccountNumber,
+		DebitAccountName:   setting.DebitAccountName,
+		CompanyCode:        setting.BankCompanyCode,
+	}
+	// Semua payroll yang bermasalah dikumpulkan agar client bisa memperbaiki sekaligus.
+	notApproved := make([]string, 0)
+	alreadyExported := make([]string, 0)
+	missingAccount := make([]string, 0)
+	for _, row := range rows {
+		// Payroll penyesuaian reversal bernilai negatif, bukan untuk ditransfer.
+		if row.Status != StatusApproved || row.ReversalOfID != nil || row.NetSalary <= 0 {
+			notApproved = append(notApproved, row.PayrollID.String())
+		} else if row.BankExportedAt != nil && !req.Reexport {
+			alreadyExported = append(alreadyExported, row.PayrollID.String())
+		} else if row.BankCode == nil || *row.BankCode == "" || row.BankAccountNumber == nil || *row.BankAccountNumber == "" {
+			missingAccount = append(missingAccount, row.PayrollID.String())
+		}
+	}
+	if len(notApproved) > 0 {
+		return BankExportFile{}, apperror.WithDetails(payrollerrors.ErrBankExportNotApproved, map[string][]string{"payroll_ids": notApproved})
+	}
+	if len(alreadyExported) > 0 {
+		return BankExportFile{}, apperror.WithDetails(payrollerrors.ErrBankExportAlreadyExported, map[string][]string{"payroll_ids": alreadyExported})
+	}
+	if len(missingAccount) > 0 {
+		return BankExportFile{}, apperror.WithDetails(payrollerrors.ErrEmployeeBankAccountMissing, map[string][]string{"payroll_ids": missingAccount})
+	}
+
+	for _, row := range rows {
+		accountName := row.EmployeeName
+		if row.BankAccountName != nil && *row.BankAccountName != "" {
+			accountName = *row.BankAccountName
+		}
+		batch.Transfers = append(batch.Transfers, bankTransfer{
+			PayrollID:     row.PayrollID,
+			Reference:     paymentReference(row.PayrollID),
+			BankCode:      *row.BankCode,
+			AccountNumber: *row.BankAccountNumber,
+			AccountName:   accountName,
+			Email:         row.Email,
+			Remark:        "GAJI " + row.PeriodStart.Format("01/2006"),
+			Amount:        row.NetSalary,
+		})
+		batch.TotalAmount += row.NetSalary
+	}
+
+	content, err := format.Write(batch)
+	if err != nil {
+		return BankExportFile{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return BankExportFile{}, err
+	}
+	defer tx.Rollback()
+
+	if err := s.repo.WithTx(tx).MarkBankExported(ctx, companyID, payrollIDs, batch.BatchID, now); err != nil {
+		return BankExportFile{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return BankExportFile{}, err
+	}
+
+	return BankExportFile{
+		BatchID:       batch.BatchID,
+		FileName:      fmt.Sprintf("payroll-%s-%s.%s", strings.ToLower(formatCode), batch.BatchID, format.Extension),
+		ContentType:   format.ContentType,
+		Content:       content,
+		TransferCount: len(batch.Transfers),
+		TotalAmount:   batch.TotalAmount,
+	}, nil
+}
+
+// ImportBankResult membaca file hasil transfer dari bank dan menandai payroll yang
+// berhasil ditransfer sebagai PAID. Baris gagal dilaporkan tanpa mengubah payroll.
+func (s *service) ImportBankResult(
+	ctx context.Context,
+	companyID, actorID string,
+	content []byte,
+) (BankResultResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return BankResultResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(actorID); err != nil {
+		return BankResultResponse{}, payrollerrors.ErrInvalidActorID
+	}
+
+	entries, err := parseBankResult(content)
+	if err != nil {
+		return BankResultResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return BankResultResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	now := time.Now().UTC()
+	resp := BankResultResponse{TotalRows: len(entries)}
+	runIDs := make(map[uuid.UUID]struct{})
+	for _, entry := range entries {
+		item := BankResultItemResponse{
+			Reference:     entry.Reference,
+			BankStatus:    entry.Status,
+			BankReference: entry.BankReference,
+		}
+
+		payroll, err := findPayrollByReference(ctx, qtx, companyID, entry.Reference)
+		switch {
+		case errors.Is(err, payrollerrors.ErrPayrollNotFound):
+			item.Result = BankResultFailed
+			item.Message = "payroll not found for reference"
+		case err != nil:
+			return BankResultResponse{}, err
+		default:
+			payrollID := payroll.ID.String()
+			item.PayrollID = &payrollID
+
+			switch {
+			case !isBankSuccessStatus(entry.Status):
+				item.Result = BankResultFailed
+				item.Message = entry.Reason
+				// Transfer gagal, payroll boleh diekspor lagi tanpa reexport.
+				if payroll.Status == StatusApproved && payroll.BankExportedAt != nil {
+					payroll.BankExportBatchID = nil
+					payroll.BankExportedAt = nil
+					if err := qtx.Update(ctx, payroll); err != nil {
+						return BankResultResponse{}, err
+					}
+				}
+			case payroll.Status == StatusPaid:
+				item.Result = BankResultSkipped
+				item.Message = "payroll already paid"
+			case payroll.Status != StatusApproved:
+				item.Result = BankResultFailed
+				item.Message = fmt.Sprintf("payroll status is %s", payroll.Status)
+			default:
+				payroll.Status = StatusPaid
+				payroll.PaidAt = &now
+				if entry.BankReference != "" {
+					bankReference := entry.BankReference
+					payroll.PaymentReference = &bankReference
+				}
+				if err := qtx.Update(ctx, payroll); err != nil {
+					return BankResultResponse{}, err
+				}
+				if payroll.RunID != nil {
+					runIDs[*payroll.RunID] = struct{}{}
+				}
+				item.Result = BankResultPaid
+			}
+		}
+
+		switch item.Result {
+		case BankResultPaid:
+			resp.PaidCount++
+		case BankResultSkipped:
+			resp.SkippedCount++
+		default:
+			resp.FailedCount++
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	for runID := range runIDs {
+		if err := markRunPaidIfSettled(ctx, qtx, companyID, runID.String(), now); err != nil {
+			return BankResultResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return BankResultResponse{}, err
+	}
+
+	return resp, nil
+}
+
+func findPayrollByReference(ctx context.Context, repo Repository, companyID, reference string) (*Payroll, error) {
+	payrollID, err := uuid.Parse(reference)
+	if err != nil {
+		return nil, payrollerrors.ErrPayrollNotFound
+	}
+	payroll, err := repo.FindByIDAndCompany(ctx, companyID, payrollID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, payrollerrors.ErrPayrollNotFound
+		}
+		return nil, err
+	}
+	return payroll, nil
+}
+
+// markRunPaidIfSettled menandai payroll run PAID jika seluruh payroll aktifnya sudah dibayar.
+func markRunPaidIfSettled(ctx context.Context, repo Repository, companyID, runID string, paidAt time.Time) error {
+	run, err := repo.FindRunByIDAndCompany(ctx, companyID, runID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if run.Status != StatusApproved {
+		return nil
+	}
+
+	payrolls, err := repo.FindByRun(ctx, companyID, runID)
+	if err != nil {
+		return err
+	}
+	for _, p := range payrolls {
+		if p.Status != StatusPaid && p.Status != StatusCancelled {
+			return nil
+		}
+	}
+
+	run.Status = StatusPaid
+	run.PaidAt = &paidAt
+	return repo.UpdateRun(ctx, run)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
@@ -0,0 +1,332 @@
+package payroll_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/apperror"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func bankSetting(companyID string) *payroll.PayrollSetting {
+	return &payroll.PayrollSetting{
+		CompanyID:          uuid.MustParse(companyID),
+		WorkHoursPerDay:    8,
+		WorkDaysPerWeek:    5,
+		DebitBankCode:      "BCA",
+		DebitAccountNumber: "0123456789",
+		DebitAccountName:   "PT Maju Jaya",
+		BankCompanyCode:    "MAJUJAYA01",
+	}
+}
+
+func bankTransferRows() []payroll.BankTransferRow {
+	strPtr := func(v string) *string { return &v }
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []payroll.BankTransferRow{
+		{
+			PayrollID:         uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+			EmployeeName:      "Andi",
+			Email:             "andi@example.com",
+			BankCode:          strPtr("BCA"),
+			BankAccountNumber: strPtr("5550001111"),
+			BankAccountName:   strPtr("ANDI PRATAMA"),
+			Status:            payroll.StatusApproved,
+			NetSalary:         9500000,
+			PeriodStart:       periodStart,
+		},
+		{
+			PayrollID:         uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+			EmployeeName:      "Budi",
+			BankCode:          strPtr("MANDIRI"),
+			BankAccountNumber: strPtr("1230009999"),
+			Status:            payroll.StatusApproved,
+			NetSalary:         4500000,
+			PeriodStart:       periodStart,
+		},
+	}
+}
+
+func TestPayrollService_ExportBankTransfer(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	payrollIDs := []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"}
+
+	t.Run("bca csv", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return bankSetting(cid), nil
+		}
+		deps.repo.findBankTransferRowsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.BankTransferRow, error) {
+			assert.ElementsMatch(t, payrollIDs, ids)
+			return bankTransferRows(), nil
+		}
+		var exportedIDs []string
+		var exportedBatch string
+		deps.repo.markBankExportedFn = func(ctx context.Context, cid string, ids []string, batchID string, exportedAt time.Time) error {
+			exportedIDs, exportedBatch = ids, batchID
+			return nil
+		}
+
+		file, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{
+			Format:        "bca",
+			PayrollIDs:    append(payrollIDs, payrollIDs[0]),
+			ExecutionDate: "2026-03-25",
+		})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, payrollIDs, exportedIDs)
+		assert.Equal(t, file.BatchID, exportedBatch)
+		assert.True(t, strings.HasPrefix(file.BatchID, "PAY20260325"))
+		assert.Equal(t, 2, file.TransferCount)
+		assert.Equal(t, int64(14000000), file.TotalAmount)
+		assert.Equal(t, "text/csv", file.ContentType)
+		assert.True(t, strings.HasSuffix(file.FileName, ".csv"))
+
+		reader := csv.NewReader(bytes.NewReader(file.Content))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		assert.NoError(t, err)
+		if assert.Len(t, records, 3) {
+			assert.Equal(t, []string{"H", "MAJUJAYA01", "0123456789", "20260325", "2", "14000000"}, records[0][:6])
+			assert.Equal(t, []string{"D", "BCA", "BCA", "5550001111", "ANDI PRATAMA", "9500000", "11111111111111111111111111111111", "GAJI 03/2026", "andi@example.com"}, records[1])
+			// Rekening bank lain memakai transfer antarbank dan nama karyawan sebagai nama rekening
+			assert.Equal(t, []string{"LLG", "MANDIRI", "1230009999", "Budi"}, records[2][1:5])
+		}
+	})
+
+	t.Run("pain.001 xml", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return bankSetting(cid), nil
+		}
+		deps.repo.findBankTransferRowsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.BankTransferRow, error) {
+			return bankTransferRows(), nil
+		}
+
+		file, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{
+			Format:        payroll.BankFormatPain001,
+			PayrollIDs:    payrollIDs,
+			ExecutionDate: "2026-03-25",
+		})
+
+		assert.NoError(t, err)
+		content := string(file.Content)
+		assert.Contains(t, content, `xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"`)
+		assert.Contains(t, content, "<NbOfTxs>2</NbOfTxs>")
+		assert.Contains(t, content, "<CtrlSum>14000000.00</CtrlSum>")
+		assert.Contains(t, content, "<ReqdExctnDt>2026-03-25</ReqdExctnDt>")
+		assert.Contains(t, content, "<EndToEndId>22222222222222222222222222222222</EndToEndId>")
+		assert.Contains(t, content, `<InstdAmt Ccy="IDR">4500000.00</InstdAmt>`)
+	})
+
+	t.Run("rejects payroll that is not approved", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return bankSetting(cid), nil
+		}
+		deps.repo.findBankTransferRowsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.BankTransferRow, error) {
+			rows := bankTransferRows()
+			rows[1].Status = payroll.StatusDraft
+			return rows, nil
+		}
+
+		_, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: payroll.BankFormatBNI, PayrollIDs: payrollIDs})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrBankExportNotApproved)
+		assert.Equal(t, map[string][]string{"payroll_ids": {"22222222-2222-2222-2222-222222222222"}}, apperror.ToHTTP(err).Details)
+	})
+
+	t.Run("rejects payroll in an unsettled export unless reexport", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		exportedAt := time.Date(2026, 3, 24, 9, 0, 0, 0, time.UTC)
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return bankSetting(cid), nil
+		}
+		deps.repo.findBankTransferRowsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.BankTransferRow, error) {
+			rows := bankTransferRows()
+			rows[0].BankExportedAt = &exportedAt
+			return rows, nil
+		}
+		exports := 0
+		deps.repo.markBankExportedFn = func(ctx context.Context, cid string, ids []string, batchID string, exportedAt time.Time) error {
+			exports++
+			return nil
+		}
+
+		_, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: payroll.BankFormatBCA, PayrollIDs: payrollIDs})
+		assert.ErrorIs(t, err, payrollerrors.ErrBankExportAlreadyExported)
+		assert.Equal(t, map[string][]string{"payroll_ids": {payrollIDs[0]}}, apperror.ToHTTP(err).Details)
+		assert.Equal(t, 0, exports)
+
+		file, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: payroll.BankFormatBCA, PayrollIDs: payrollIDs, Reexport: true})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, file.TransferCount)
+		assert.Equal(t, 1, exports)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects employee without bank account", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return bankSetting(cid), nil
+		}
+		deps.repo.findBankTransferRowsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.BankTransferRow, error) {
+			rows := bankTransferRows()
+			rows[0].BankAccountNumber = nil
+			return rows, nil
+		}
+
+		_, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: payroll.BankFormatMandiri, PayrollIDs: payrollIDs})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrEmployeeBankAccountMissing)
+		assert.Equal(t, map[string][]string{"payroll_ids": {"11111111-1111-1111-1111-111111111111"}}, apperror.ToHTTP(err).Details)
+	})
+
+	t.Run("requires debit account", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: payroll.BankFormatBCA, PayrollIDs: payrollIDs})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrDebitAccountNotConfigured)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.ExportBankTransfer(ctx, companyID, payroll.BankExportRequest{Format: "BRI", PayrollIDs: payrollIDs})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidBankFormat)
+	})
+}
+
+func TestPayrollService_ImportBankResult(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("csv marks successful transfers paid and settles run", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		runID := uuid.New()
+		batchID := "PAY20260325ABCDEF12"
+		exportedAt := time.Date(2026, 3, 24, 9, 0, 0, 0, time.UTC)
+		paidRef := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+		failedRef := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+		payrolls := map[string]*payroll.Payroll{
+			paidRef.String():   {ID: paidRef, RunID: &runID, Status: payroll.StatusApproved},
+			failedRef.String(): {ID: failedRef, RunID: &runID, Status: payroll.StatusApproved, BankExportBatchID: &batchID, BankExportedAt: &exportedAt},
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return payrolls[id], nil
+		}
+		var updated []*payroll.Payroll
+		deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+			updated = append(updated, p)
+			return nil
+		}
+		deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.PayrollRun, error) {
+			return &payroll.PayrollRun{ID: runID, Status: payroll.StatusApproved}, nil
+		}
+		deps.repo.findByRunFn = func(ctx context.Context, cid, id string) ([]payroll.Payroll, error) {
+			return []payroll.Payroll{*payrolls[paidRef.String()], *payrolls[failedRef.String()]}, nil
+		}
+		deps.repo.updateRunFn = func(ctx context.Context, run *payroll.PayrollRun) error {
+			t.Fatal("run must stay APPROVED while a transfer failed")
+			return nil
+		}
+
+		content := "Reference;Status;Journal No;Keterangan\n" +
+			"11111111111111111111111111111111;BERHASIL;JRN-001;\n" +
+			"22222222222222222222222222222222;GAGAL;;Rekening tidak ditemukan\n"
+
+		resp, err := deps.service.ImportBankResult(ctx, companyID, actorID, []byte(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.TotalRows)
+		assert.Equal(t, 1, resp.PaidCount)
+		assert.Equal(t, 1, resp.FailedCount)
+		if assert.Len(t, resp.Items, 2) {
+			assert.Equal(t, payroll.BankResultPaid, resp.Items[0].Result)
+			assert.Equal(t, payroll.BankResultFailed, resp.Items[1].Result)
+			assert.Equal(t, "Rekening tidak ditemukan", resp.Items[1].Message)
+		}
+		// Transfer gagal melepas penanda export agar bisa diekspor ulang
+		if assert.Len(t, updated, 2) {
+			assert.Equal(t, failedRef, updated[1].ID)
+			assert.Nil(t, updated[1].BankExportBatchID)
+			assert.Nil(t, updated[1].BankExportedAt)
+			assert.Equal(t, payroll.StatusPaid, updated[0].Status)
+			assert.NotNil(t, updated[0].PaidAt)
+			if assert.NotNil(t, updated[0].PaymentReference) {
+				assert.Equal(t, "JRN-001", *updated[0].PaymentReference)
+			}
+		}
+		assert.Equal(t, payroll.StatusApproved, payrolls[failedRef.String()].Status)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("pain.002 result", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		paidRef := uuid.New()
+		existing := &payroll.Payroll{ID: paidRef, Status: payroll.StatusPaid}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return existing, nil
+		}
+
+		content := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.03">
+  <CstmrPmtStsRpt>
+    <OrgnlPmtInfAndSts>
+      <TxInfAndSts>
+        <OrgnlEndToEndId>` + strings.ReplaceAll(paidRef.String(), "-", "") + `</OrgnlEndToEndId>
+        <TxSts>ACSC</TxSts>
+      </TxInfAndSts>
+    </OrgnlPmtInfAndSts>
+  </CstmrPmtStsRpt>
+</Document>`
+
+		resp, err := deps.service.ImportBankResult(ctx, companyID, actorID, []byte(content))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.SkippedCount)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.ImportBankResult(ctx, companyID, actorID, []byte("foo,bar\n1,2\n"))
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidBankResultFile)
+	})
+}
@@ -0,0 +1,136 @@
+package payroll
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ComponentSourceBPJS = "BPJS"
+
+	// ComponentTypeEmployerContribution adalah beban perusahaan (mis. iuran BPJS
+	// bagian pemberi kerja). Tidak mengurangi maupun menambah net salary.
+	ComponentTypeEmployerContribution = "EMPLOYER_CONTRIBUTION"
+
+	BPJSProgramJHT       = "JHT"
+	BPJSProgramJP        = "JP"
+	BPJSProgramJKK       = "JKK"
+	BPJSProgramJKM       = "JKM"
+	BPJSProgramKesehatan = "KESEHATAN"
+)
+
+// bpjsProgram adalah tarif satu program BPJS untuk company. WageCap 0 berarti tanpa batas upah.
+type bpjsProgram struct {
+	Code        string
+	Name        string
+	EmployeeBps int64
+	EmployerBps int64
+	WageCap     int64
+
+	// TaxableBenefit: iuran pemberi kerja menjadi bagian penghasilan bruto PPh 21 (JKK, JKM, Kesehatan).
+	TaxableBenefit bool
+	// PensionDeductible: iuran karyawan mengurangi penghasilan neto PPh 21 (JHT, JP).
+	PensionDeductible bool
+}
+
+func bpjsPrograms(setting PayrollSetting) []bpjsProgram {
+	return []bpjsProgram{
+		{Code: BPJSProgramJHT, Name: "BPJS JHT", EmployeeBps: setting.JHTEmployeeRateBps, EmployerBps: setting.JHTEmployerRateBps, PensionDeductible: true},
+		{Code: BPJSProgramJP, Name: "BPJS JP", EmployeeBps: setting.JPEmployeeRateBps, EmployerBps: setting.JPEmployerRateBps, WageCap: setting.JPWageCap, PensionDeductible: true},
+		{Code: BPJSProgramJKK, Name: "BPJS JKK", EmployerBps: setting.JKKEmployerRateBps, TaxableBenefit: true},
+		{Code: BPJSProgramJKM, Name: "BPJS JKM", EmployerBps: setting.JKMEmployerRateBps, TaxableBenefit: true},
+		{Code: BPJSProgramKesehatan, Name: "BPJS Kesehatan", EmployeeBps: setting.KesehatanEmployeeRateBps, EmployerBps: setting.KesehatanEmployerRateBps, WageCap: setting.KesehatanWageCap, TaxableBenefit: true},
+	}
+}
+
+// BPJSContributionRow adalah satu komponen iuran BPJS untuk laporan bulanan.
+type BPJSContributionRow struct {
+	PayrollID     uuid.UUID
+	EmployeeID    uuid.UUID
+	EmployeeName  string
+	PayrollStatus string
+	BaseSalary    int64
+	ComponentType string
+	Program       string
+	TotalAmount   int64
+}
+
+// bpjsContributions adalah hasil perhitungan iuran BPJS satu payroll.
+type bpjsContributions struct {
+	EmployeeItems []PayrollComponent // DEDUCTION, mengurangi net salary
+	EmployerItems []PayrollComponent // EMPLOYER_CONTRIBUTION, beban perusahaan
+
+	EmployerTotal       int64
+	TaxableBenefit      int64
+	PensionContribution int64
+}
+
+// calculateBPJS menghitung iuran BPJS dari upah (gaji pokok) sesuai tarif company.
+// Program dengan tarif 0 dilewati.
+func calculateBPJS(companyID uuid.UUID, payrollID *uuid.UUID, wage int64, setting PayrollSetting) (bpjsContributions, error) {
+	var result bpjsContributions
+	if wage <= 0 {
+		return result, nil
+	}
+
+	payrollRef := uuid.Nil
+	if payrollID != nil {
+		payrollRef = *payrollID
+	}
+	source := ComponentSourceBPJS
+
+	for _, program := range bpjsPrograms(setting) {
+		base := wage
+		if program.WageCap > 0 && base > program.WageCap {
+			base = program.WageCap
+		}
+
+		employeeShare := base * program.EmployeeBps / 10_000
+		employerShare := base * program.EmployerBps / 10_000
+
+		metadata, err := json.Marshal(map[string]any{
+			"program":           program.Code,
+			"wage":              wage,
+			"wage_base":         base,
+			"wage_cap":          program.WageCap,
+			"employee_rate_bps": program.EmployeeBps,
+			"employer_rate_bps": program.EmployerBps,
+		})
+		if err != nil {
+			return bpjsContributions{}, err
+		}
+		raw := string(metadata)
+
+		newComponent := func(componentType, name string, amount int64) PayrollComponent {
+			return PayrollComponent{
+				ID:            uuid.New(),
+				PayrollID:     payrollRef,
+				CompanyID:     companyID,
+				ComponentType: componentType,
+				ComponentName: name,
+				Quantity:      1,
+				UnitAmount:    amount,
+				TotalAmount:   amount,
+				SourceType:    &source,
+				Metadata:      &raw,
+			}
+		}
+
+		if employeeShare > 0 {
+			result.EmployeeItems = append(result.EmployeeItems, newComponent(ComponentTypeDeduction, program.Name+" (Employee)", employeeShare))
+			if program.PensionDeductible {
+				result.PensionContribution += employeeShare
+			}
+		}
+		if employerShare > 0 {
+			result.EmployerItems = append(result.EmployerItems, newComponent(ComponentTypeEmployerContribution, program.Name+" (Employer)", employerShare))
+			result.EmployerTotal += employerShare
+			if program.TaxableBenefit {
+				result.TaxableBenefit += employerShare
+			}
+		}
+	}
+
+	return result, nil
+}
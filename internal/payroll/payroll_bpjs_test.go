@@ -0,0 +1,141 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_Create_BPJSContributions(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	expectTx(t, deps.sqlMock, true)
+
+	setting := payroll.PayrollSetting{
+		CompanyID:                uuid.MustParse(companyID),
+		WorkHoursPerDay:          8,
+		WorkDaysPerWeek:          5,
+		TaxCalculator:            payroll.TaxCalculatorNone,
+		JHTEmployeeRateBps:       200,
+		JHTEmployerRateBps:       370,
+		JPEmployeeRateBps:        100,
+		JPEmployerRateBps:        200,
+		JPWageCap:                10547400,
+		JKKEmployerRateBps:       24,
+		JKMEmployerRateBps:       30,
+		KesehatanEmployeeRateBps: 100,
+		KesehatanEmployerRateBps: 400,
+		KesehatanWageCap:         12000000,
+	}
+	deps.repo.employeeBelongsToCompany = func(ctx context.Context, cid, eid string) (bool, error) {
+		return true, nil
+	}
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &setting, nil
+	}
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-03-01",
+		PeriodEnd:   "2026-03-31",
+		BaseSalary:  int64Ptr(20000000),
+	})
+
+	assert.NoError(t, err)
+
+	amounts := make(map[string]int64)
+	for _, component := range resp.Components {
+		if assert.NotNil(t, component.SourceType) {
+			assert.Equal(t, payroll.ComponentSourceBPJS, *component.SourceType)
+		}
+		amounts[component.ComponentName] = component.TotalAmount
+	}
+	// JP dan Kesehatan dihitung dari batas upah, JHT/JKK/JKM dari upah penuh
+	assert.Equal(t, map[string]int64{
+		"BPJS JHT (Employee)":       400000,
+		"BPJS JHT (Employer)":       740000,
+		"BPJS JP (Employee)":        105474,
+		"BPJS JP (Employer)":        210948,
+		"BPJS JKK (Employer)":       48000,
+		"BPJS JKM (Employer)":       60000,
+		"BPJS Kesehatan (Employee)": 120000,
+		"BPJS Kesehatan (Employer)": 480000,
+	}, amounts)
+
+	assert.Equal(t, int64(400000+105474+120000), resp.TotalDeduction)
+	assert.Equal(t, int64(740000+210948+48000+60000+480000), resp.EmployerCost)
+	assert.Equal(t, int64(20000000-625474), resp.NetSalary)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_GetBPJSReport(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	t.Run("aggregates per employee and program", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		payrollA := uuid.New()
+		payrollB := uuid.New()
+		employeeA := uuid.New()
+		employeeB := uuid.New()
+		deps.repo.findBPJSContributionsFn = func(ctx context.Context, cid string, start, end time.Time) ([]payroll.BPJSContributionRow, error) {
+			assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), start)
+			assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), end)
+			return []payroll.BPJSContributionRow{
+				{PayrollID: payrollA, EmployeeID: employeeA, EmployeeName: "Andi", BaseSalary: 10000000, ComponentType: payroll.ComponentTypeDeduction, Program: payroll.BPJSProgramJHT, TotalAmount: 200000},
+				{PayrollID: payrollA, EmployeeID: employeeA, EmployeeName: "Andi", BaseSalary: 10000000, ComponentType: payroll.ComponentTypeEmployerContribution, Program: payroll.BPJSProgramJHT, TotalAmount: 370000},
+				{PayrollID: payrollA, EmployeeID: employeeA, EmployeeName: "Andi", BaseSalary: 10000000, ComponentType: payroll.ComponentTypeEmployerContribution, Program: payroll.BPJSProgramJKK, TotalAmount: 24000},
+				{PayrollID: payrollB, EmployeeID: employeeB, EmployeeName: "Budi", BaseSalary: 5000000, ComponentType: payroll.ComponentTypeDeduction, Program: payroll.BPJSProgramJHT, TotalAmount: 100000},
+			}, nil
+		}
+
+		resp, err := deps.service.GetBPJSReport(ctx, companyID, payroll.BPJSReportFilterRequest{Period: "2026-03"})
+
+		assert.NoError(t, err)
+		if assert.Len(t, resp.Rows, 2) {
+			assert.Equal(t, "Andi", resp.Rows[0].EmployeeName)
+			assert.Equal(t, int64(200000), resp.Rows[0].EmployeeTotal)
+			assert.Equal(t, int64(394000), resp.Rows[0].EmployerTotal)
+			assert.Equal(t, payroll.BPJSProgramAmount{Employee: 200000, Employer: 370000}, resp.Rows[0].Programs[payroll.BPJSProgramJHT])
+		}
+		assert.Equal(t, payroll.BPJSProgramAmount{Employee: 300000, Employer: 370000}, resp.Programs[payroll.BPJSProgramJHT])
+		assert.Equal(t, int64(300000), resp.EmployeeTotal)
+		assert.Equal(t, int64(394000), resp.EmployerTotal)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.GetBPJSReport(ctx, companyID, payroll.BPJSReportFilterRequest{Period: "03-2026"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPeriodFormat)
+	})
+}
@@ -0,0 +1,443 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	attendanceStatusLate   = "LATE"
+	attendanceStatusAbsent = "ABSENT"
+
+	leaveTypeUnpaid = "UNPAID"
+
+	ComponentSourceLeave = "LEAVE"
+)
+
+// attendanceInputs adalah ringkasan kehadiran karyawan dalam satu periode payroll.
+type attendanceInputs struct {
+	WorkingDays   int64
+	PresentDays   int64
+	AbsentDays    int64
+	LateDays      int64
+	OvertimeHours int64
+
+	// PresentDates dipakai komponen per hari hadir yang hanya berlaku sebagian periode.
+	PresentDates []time.Time
+}
+
+// payrollInputs adalah hasil langkah kalkulasi sebelum payroll dipersist.
+type payrollInputs struct {
+	Setting    PayrollSetting
+	Attendance attendanceInputs
+
+	// PeriodWorkingDays adalah jumlah hari kerja penuh dalam periode, dasar tarif harian.
+	PeriodWorkingDays int64
+	Leaves            []LeaveRange
+
+	// Assignments adalah komponen berulang karyawan yang berlaku dalam periode.
+	Assignments []EmployeeComponentAssignment
+
+	// Employment adalah rentang aktif karyawan dalam periode (tanggal masuk s/d keluar).
+	Employment employmentProration
+
+	// Loans adalah pinjaman aktif karyawan yang cicilannya sudah jatuh tempo.
+	Loans []EmployeeLoan
+}
+
+// loadSetting mengambil aturan payroll company, fallback ke default jika belum diatur.
+func (s *service) loadSetting(ctx context.Context, repo Repository, companyID uuid.UUID) (PayrollSetting, error) {
+	setting, err := repo.FindSetting(ctx, companyID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultPayrollSetting(companyID), nil
+		}
+		return PayrollSetting{}, err
+	}
+	if setting == nil {
+		return defaultPayrollSetting(companyID), nil
+	}
+	return *setting, nil
+}
+
+// collectInputs membaca masa kerja, data attendance, cuti, komponen berulang, dan pinjaman untuk periode payroll.
+// Langkah ini hanya membaca data sehingga bisa dipakai ulang di luar alur persist.
+func (s *service) collectInputs(
+	ctx context.Context,
+	repo Repository,
+	companyID uuid.UUID,
+	employeeID string,
+	periodStart, periodEnd time.Time,
+) (payrollInputs, error) {
+	setting, err := s.loadSetting(ctx, repo, companyID)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+
+	employmentPeriod, err := repo.FindEmploymentPeriod(ctx, companyID.String(), employeeID, periodStart, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+	employment, err := resolveEmployment(employmentPeriod, setting, periodStart, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+
+	records, err := repo.FindAttendanceRecords(ctx, companyID.String(), employeeID, periodStart, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+	leaves, err := repo.FindApprovedLeaves(ctx, companyID.String(), employeeID, periodStart, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+	assignments, err := repo.FindEffectiveComponentAssignments(ctx, companyID.String(), employeeID, periodStart, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+	loans, err := repo.FindActiveLoans(ctx, companyID.String(), employeeID, periodEnd)
+	if err != nil {
+		return payrollInputs{}, err
+	}
+
+	return payrollInputs{
+		Setting:           setting,
+		Attendance:        summarizeAttendance(setting, records, leaves, employment.Start, employment.End, time.Now().UTC()),
+		PeriodWorkingDays: countWorkingDays(periodStart, periodEnd, setting.WorkDaysPerWeek),
+		Leaves:            leaves,
+		Assignments:       assignments,
+		Employment:        employment,
+		Loans:             loans,
+	}, nil
+}
+
+// regularPayrollInput adalah input HR untuk payroll REGULAR, sama untuk Create, Regenerate, dan Simulate.
+type regularPayrollInput struct {
+	BaseSalary     *int64
+	Allowance      int64
+	OvertimeHours  int64
+	OvertimeRate   int64
+	Deduction      int64
+	AllowanceItems []PayrollComponentInput
+	DeductionItems []PayrollComponentInput
+
+	// BaseSalaryIncreaseBps hanya dipakai simulasi: kenaikan (100 = 1%) dari gaji pokok efektif.
+	BaseSalaryIncreaseBps int64
+}
+
+type regularPayrollResult struct {
+	Components []PayrollComponent // ALLOWANCE, DEDUCTION, dan EMPLOYER_CONTRIBUTION
+	Loans      []EmployeeLoan     // Pinjaman yang cicilannya ikut dipotong
+}
+
+// calculateRegularPayroll menjalankan seluruh pipeline perhitungan payroll REGULAR (gaji pokok,
+// prorata, komponen berulang, potongan kehadiran/cuti/pinjaman, BPJS, lembur, PPh 21) dan
+// mengisi nilai finansial payroll. Langkah ini hanya membaca data; persist dilakukan caller.
+// payrollID diisi saat menghitung ulang payroll tersimpan agar tidak ikut terhitung di pajak
+// periode yang sama.
+func (s *service) calculateRegularPayroll(
+	ctx context.Context,
+	repo Repository,
+	payroll *Payroll,
+	payrollID *uuid.UUID,
+	in regularPayrollInput,
+) (regularPayrollResult, error) {
+	companyID := payroll.CompanyID
+	employeeID := payroll.EmployeeID.String()
+	periodStart, periodEnd := payroll.PeriodStart, payroll.PeriodEnd
+
+	inputs, err := s.collectInputs(ctx, repo, companyID, employeeID, periodStart, periodEnd)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+
+	history, err := repo.FindSalaryHistory(ctx, employeeID, periodStart, periodEnd)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	var raiseNote *string
+	if in.BaseSalary == nil && in.BaseSalaryIncreaseBps != 0 && len(history) > 0 {
+		// Kenaikan diterapkan ke tarif gaji sebelum prorata, bukan sebagai override,
+		// agar karyawan yang masuk atau keluar di tengah periode tetap diprorata.
+		note := fmt.Sprintf("Simulasi kenaikan %s dari %s", formatBps(in.BaseSalaryIncreaseBps), formatRupiah(fullMonthBaseSalary(history, periodStart, periodEnd)))
+		raiseNote = &note
+		raised := make([]SalaryHistory, len(history))
+		for i, h := range history {
+			raised[i] = SalaryHistory{BaseSalary: h.BaseSalary * (10000 + in.BaseSalaryIncreaseBps) / 10000, EffectiveDate: h.EffectiveDate}
+		}
+		history = raised
+	}
+	baseSalary, err := resolveBaseSalary(history, periodStart, periodEnd, inputs.Employment, inputs.Setting.WorkDaysPerWeek, in.BaseSalary)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	if raiseNote != nil {
+		note := *raiseNote
+		if baseSalary.Note != nil {
+			note += "; " + *baseSalary.Note
+		}
+		baseSalary.Note = &note
+	}
+
+	allowanceItems, deductionItems, err := buildComponents(companyID, payrollID, in.AllowanceItems, in.DeductionItems)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	// Gaji pokok penuh (sebelum prorata masa kerja) menjadi dasar tarif harian dan persentase komponen.
+	fullBaseSalary := baseSalary.Full
+
+	recurringAllowances, recurringDeductions, err := templateComponents(companyID, payrollID, fullBaseSalary, periodStart, periodEnd, inputs)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	allowanceItems = append(allowanceItems, recurringAllowances...)
+	deductionItems = append(deductionItems, recurringDeductions...)
+	deductionItems = append(deductionItems, attendanceDeductions(companyID, payrollID, inputs)...)
+	deductionItems = append(deductionItems, unpaidLeaveDeductions(companyID, payrollID, fullBaseSalary, periodStart, periodEnd, inputs)...)
+	loanItems, err := loanDeductions(companyID, payrollID, inputs)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	deductionItems = append(deductionItems, loanItems...)
+	bpjs, err := calculateBPJS(companyID, payrollID, baseSalary.Amount, inputs.Setting)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	deductionItems = append(deductionItems, bpjs.EmployeeItems...)
+
+	overtimeHours, overtimeRate := resolveOvertime(in.OvertimeHours, in.OvertimeRate, inputs)
+	overtimeAmount, err := calculateOvertime(overtimeHours, overtimeRate)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+
+	grossIncome := baseSalary.Amount + in.Allowance + sumComponents(allowanceItems) + overtimeAmount + bpjs.TaxableBenefit
+	taxAmount, taxComponent, err := s.calculateTax(ctx, repo, inputs.Setting, companyID, payroll.EmployeeID, payrollID, periodStart, periodEnd, grossIncome, bpjs.PensionContribution)
+	if err != nil {
+		return regularPayrollResult{}, err
+	}
+	allowanceItems, deductionItems = appendTaxComponent(allowanceItems, deductionItems, taxComponent)
+
+	totalAllowance := in.Allowance + sumComponents(allowanceItems)
+	totalDeduction := in.Deduction + sumComponents(deductionItems)
+	if err := validateMoney(baseSalary.Amount, totalAllowance, totalDeduction); err != nil {
+		return regularPayrollResult{}, err
+	}
+
+	payroll.BaseSalary = baseSalary.Amount
+	payroll.BaseSalaryOverride = baseSalary.Override
+	payroll.DerivedBaseSalary = baseSalary.Derived
+	payroll.BaseSalaryNote = baseSalary.Note
+	applyProrationColumns(payroll, inputs.Employment)
+	payroll.Allowance = totalAllowance
+	payroll.OvertimeHours = overtimeHours
+	payroll.OvertimeRate = overtimeRate
+	payroll.OvertimeAmount = overtimeAmount
+	payroll.Deduction = totalDeduction
+	payroll.GrossIncome = grossIncome
+	payroll.TaxAmount = taxAmount
+	payroll.PensionContribution = bpjs.PensionContribution
+	payroll.EmployerCost = bpjs.EmployerTotal
+	payroll.NetSalary = baseSalary.Amount + totalAllowance + overtimeAmount - totalDeduction
+
+	return regularPayrollResult{
+		Components: append(append(allowanceItems, deductionItems...), bpjs.EmployerItems...),
+		Loans:      inputs.Loans,
+	}, nil
+}
+
+// summarizeAttendance menghitung jam lembur (jam kerja melebihi jadwal harian),
+// hari terlambat, dan hari tidak hadir. Hari kerja tanpa attendance yang tercakup
+// cuti APPROVED tidak dihitung absen. Hari setelah today diabaikan.
+func summarizeAttendance(
+	setting PayrollSetting,
+	records []AttendanceRecord,
+	leaves []LeaveRange,
+	periodStart, periodEnd, today time.Time,
+) attendanceInputs {
+	var summary attendanceInputs
+
+	byDate := make(map[string]AttendanceRecord, len(records))
+	var overtimeMinutes int64
+	scheduledMinutes := setting.WorkHoursPerDay * 60
+	for _, record := range records {
+		byDate[record.AttendanceDate.Format("2006-01-02")] = record
+		if record.Status == attendanceStatusLate {
+			summary.LateDays++
+		}
+		if record.ClockOut == nil {
+			continue
+		}
+		worked := int64(record.ClockOut.Sub(record.ClockIn).Minutes())
+		if worked > scheduledMinutes {
+			overtimeMinutes += worked - scheduledMinutes
+		}
+	}
+	summary.OvertimeHours = overtimeMinutes / 60
+
+	lastDay := periodEnd
+	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if todayDate.Before(lastDay) {
+		lastDay = todayDate
+	}
+
+	for day := periodStart; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		if !isWorkingDay(day, setting.WorkDaysPerWeek) {
+			continue
+		}
+		summary.WorkingDays++
+
+		record, ok := byDate[day.Format("2006-01-02")]
+		if ok && record.Status != attendanceStatusAbsent {
+			summary.PresentDays++
+			summary.PresentDates = append(summary.PresentDates, day)
+			continue
+		}
+		if coveredByLeave(day, leaves) {
+			continue
+		}
+		summary.AbsentDays++
+	}
+
+	return summary
+}
+
+// attendanceDeductions mengubah hari absen dan terlambat menjadi komponen DEDUCTION
+// berdasarkan aturan potongan company.
+func attendanceDeductions(companyID uuid.UUID, payrollID *uuid.UUID, inputs payrollInputs) []PayrollComponent {
+	payrollRef := uuid.Nil
+	if payrollID != nil {
+		payrollRef = *payrollID
+	}
+
+	components := make([]PayrollComponent, 0, 2)
+	add := func(name string, days, amount int64, note string) {
+		if days <= 0 || amount <= 0 {
+			return
+		}
+		components = append(components, PayrollComponent{
+			ID:            uuid.New(),
+			PayrollID:     payrollRef,
+			CompanyID:     companyID,
+			ComponentType: ComponentTypeDeduction,
+			ComponentName: name,
+			Quantity:      days,
+			UnitAmount:    amount,
+			TotalAmount:   days * amount,
+			Notes:         &note,
+		})
+	}
+
+	attendance := inputs.Attendance
+	add("Absence Deduction", attendance.AbsentDays, inputs.Setting.AbsentDeductionAmount,
+		fmt.Sprintf("%d hari tidak hadir dari %d hari kerja", attendance.AbsentDays, attendance.WorkingDays))
+	add("Late Deduction", attendance.LateDays, inputs.Setting.LateDeductionAmount,
+		fmt.Sprintf("%d hari terlambat", attendance.LateDays))
+
+	return components
+}
+
+// unpaidLeaveDeductions membuat komponen DEDUCTION prorata untuk setiap cuti UNPAID
+// yang APPROVED: hari kerja cuti dalam periode x (gaji pokok / hari kerja periode).
+func unpaidLeaveDeductions(
+	companyID uuid.UUID,
+	payrollID *uuid.UUID,
+	baseSalary int64,
+	periodStart, periodEnd time.Time,
+	inputs payrollInputs,
+) []PayrollComponent {
+	if inputs.PeriodWorkingDays <= 0 || baseSalary <= 0 {
+		return nil
+	}
+
+	payrollRef := uuid.Nil
+	if payrollID != nil {
+		payrollRef = *payrollID
+	}
+	dailyRate := baseSalary / inputs.PeriodWorkingDays
+	sourceType := ComponentSourceLeave
+
+	components := make([]PayrollComponent, 0)
+	for _, leave := range inputs.Leaves {
+		if leave.LeaveType != leaveTypeUnpaid {
+			continue
+		}
+		start := leave.StartDate
+		if start.Before(periodStart) {
+			start = periodStart
+		}
+		end := leave.EndDate
+		if end.After(periodEnd) {
+			end = periodEnd
+		}
+		days := countWorkingDays(start, end, inputs.Setting.WorkDaysPerWeek)
+		if days <= 0 {
+			continue
+		}
+
+		leaveID := leave.ID
+		note := fmt.Sprintf("Cuti tidak dibayar %s s/d %s: %d/%d hari kerja",
+			start.Format("2006-01-02"), end.Format("2006-01-02"), days, inputs.PeriodWorkingDays)
+		components = append(components, PayrollComponent{
+			ID:            uuid.New(),
+			PayrollID:     payrollRef,
+			CompanyID:     companyID,
+			ComponentType: ComponentTypeDeduction,
+			ComponentName: "Unpaid Leave",
+			Quantity:      days,
+			UnitAmount:    dailyRate,
+			TotalAmount:   days * dailyRate,
+			Notes:         &note,
+			SourceType:    &sourceType,
+			SourceID:      &leaveID,
+		})
+	}
+	return components
+}
+
+// resolveOvertime memakai input manual jika diisi, selain itu jam lembur dari
+// attendance dan tarif lembur dari setting company.
+func resolveOvertime(hours, rate int64, inputs payrollInputs) (int64, int64) {
+	if hours == 0 {
+		hours = inputs.Attendance.OvertimeHours
+	}
+	if rate == 0 {
+		rate = inputs.Setting.OvertimeHourlyRate
+	}
+	return hours, rate
+}
+
+func isWorkingDay(day time.Time, workDaysPerWeek int) bool {
+	switch day.Weekday() {
+	case time.Sunday:
+		return workDaysPerWeek >= 7
+	case time.Saturday:
+		return workDaysPerWeek >= 6
+	default:
+		return true
+	}
+}
+
+func countWorkingDays(start, end time.Time, workDaysPerWeek int) int64 {
+	var days int64
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day, workDaysPerWeek) {
+			days++
+		}
+	}
+	return days
+}
+
+func coveredByLeave(day time.Time, leaves []LeaveRange) bool {
+	for _, leave := range leaves {
+		if !day.Before(leave.StartDate) && !day.After(leave.EndDate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Cancel membatalkan payroll APPROVED yang belum dibayar.
+func (s *service) Cancel(
+	ctx context.Context,
+	companyID, actorID, id string,
+	req CancelPayrollRequest,
+) (PayrollResponse, error) {
+	actorUUID, reason, err := validateCancelRequest(companyID, actorID, req)
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	payroll, err := qtx.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollResponse{}, payrollerrors.ErrPayrollNotFound
+		}
+		return PayrollResponse{}, err
+	}
+	if payroll.ReversalOfID != nil {
+		return PayrollResponse{}, payrollerrors.ErrPayrollIsReversal
+	}
+	if payroll.Status != StatusApproved {
+		return PayrollResponse{}, payrollerrors.ErrCancelOnlyApproved
+	}
+
+	now := time.Now().UTC()
+	payroll.Status = StatusCancelled
+	payroll.CancelReason = &reason
+	payroll.CancelledBy = &actorUUID
+	payroll.CancelledAt = &now
+	// Payslip lama dihapus agar regenerate menampilkan status pembatalan.
+	payroll.PayslipURL = nil
+	payroll.PayslipGeneratedAt = nil
+
+	if err := qtx.Update(ctx, payroll); err != nil {
+		return PayrollResponse{}, err
+	}
+	// Cicilan pinjaman yang dipotong payroll ini belum dibayarkan, sisa pinjaman dikembalikan.
+	if err := s.rollbackLoanRepayments(ctx, qtx, companyID, payroll.ID.String()); err != nil {
+		return PayrollResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PayrollResponse{}, err
+	}
+
+	return mapToResponse(*payroll), nil
+}
+
+// Reverse membalik payroll PAID dengan membuat payroll penyesuaian bernilai negatif
+// yang tertaut ke payroll asli, termasuk komponen yang dinegasikan agar breakdown dan
+// payslip sesuai total. Payroll penyesuaian dibuat DRAFT dan melewati rantai approval
+// seperti payroll lain, lalu ditandai PAID lewat alur mark-paid setelah dana dikembalikan.
+func (s *service) Reverse(
+	ctx context.Context,
+	companyID, actorID, id string,
+	req CancelPayrollRequest,
+) (PayrollResponse, error) {
+	actorUUID, reason, err := validateCancelRequest(companyID, actorID, req)
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	original, err := qtx.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollResponse{}, payrollerrors.ErrPayrollNotFound
+		}
+		return PayrollResponse{}, err
+	}
+	if original.ReversalOfID != nil {
+		return PayrollResponse{}, payrollerrors.ErrPayrollIsReversal
+	}
+	if original.Status != StatusPaid {
+		return PayrollResponse{}, payrollerrors.ErrReverseOnlyPaid
+	}
+
+	now := time.Now().UTC()
+	note := fmt.Sprintf("Reversal of payroll %s", original.ID.String())
+	reversal := &Payroll{
+		ID:            uuid.New(),
+		CompanyID:     original.CompanyID,
+		EmployeeID:    original.EmployeeID,
+		PayrollType:   payrollTypeOrRegular(original.PayrollType),
+		ReferenceDate: original.ReferenceDate,
+		PeriodStart:   original.PeriodStart,
+		PeriodEnd:     original.PeriodEnd,
+
+		BaseSalary:     -original.BaseSalary,
+		Allowance:      -original.Allowance,
+		OvertimeHours:  -original.OvertimeHours,
+		OvertimeRate:   original.OvertimeRate,
+		OvertimeAmount: -original.OvertimeAmount,
+		Deduction:      -original.Deduction,
+		NetSalary:      -original.NetSalary,
+
+		GrossIncome:         -original.GrossIncome,
+		TaxAmount:           -original.TaxAmount,
+		PensionContribution: -original.PensionContribution,
+		EmployerCost:        -original.EmployerCost,
+
+		BaseSalaryOverride: true,
+		BaseSalaryNote:     &note,
+
+		ReversalOfID: &original.ID,
+		CancelReason: &reason,
+
+		Status:    StatusDraft,
+		CreatedBy: actorUUID,
+	}
+	if err := qtx.Create(ctx, reversal); err != nil {
+		return PayrollResponse{}, err
+	}
+	if err := qtx.ReplaceComponents(ctx, companyID, reversal.ID.String(), negateComponents(original.Components, reversal)); err != nil {
+		return PayrollResponse{}, err
+	}
+
+	original.Status = StatusReversed
+	original.ReversedByID = &reversal.ID
+	original.CancelReason = &reason
+	original.CancelledBy = &actorUUID
+	original.CancelledAt = &now
+	// Payslip lama dihapus agar regenerate menampilkan informasi reversal.
+	original.PayslipURL = nil
+	original.PayslipGeneratedAt = nil
+
+	if err := qtx.Update(ctx, original); err != nil {
+		return PayrollResponse{}, err
+	}
+	// Potongan cicilan ikut dibalik oleh payroll penyesuaian, sisa pinjaman dikembalikan.
+	if err := s.rollbackLoanRepayments(ctx, qtx, companyID, original.ID.String()); err != nil {
+		return PayrollResponse{}, err
+	}
+
+	persisted, err := qtx.FindByIDAndCompany(ctx, companyID, reversal.ID.String())
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PayrollResponse{}, err
+	}
+
+	return mapToResponse(*persisted), nil
+}
+
+// negateComponents menyalin komponen payroll asli dengan nilai negatif untuk payroll penyesuaian.
+func negateComponents(components []PayrollComponent, reversal *Payroll) []PayrollComponent {
+	negated := make([]PayrollComponent, 0, len(components))
+	for _, c := range components {
+		negated = append(negated, PayrollComponent{
+			ID:            uuid.New(),
+			PayrollID:     reversal.ID,
+			CompanyID:     reversal.CompanyID,
+			ComponentType: c.ComponentType,
+			ComponentName: c.ComponentName,
+			Quantity:      c.Quantity,
+			UnitAmount:    -c.UnitAmount,
+			TotalAmount:   -c.TotalAmount,
+			Notes:         c.Notes,
+			SourceType:    c.SourceType,
+			SourceID:      c.SourceID,
+		})
+	}
+	return negated
+}
+
+func validateCancelRequest(companyID, actorID string, req CancelPayrollRequest) (uuid.UUID, string, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return uuid.Nil, "", payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return uuid.Nil, "", payrollerrors.ErrInvalidActorID
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return uuid.Nil, "", payrollerrors.ErrCancelReasonRequired
+	}
+	return actorUUID, reason, nil
+}
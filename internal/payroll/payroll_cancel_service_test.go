@@ -0,0 +1,207 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_Cancel(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("approved payroll becomes cancelled", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		payslipURL := "https://example.com/payslip.pdf"
+		existing := &payroll.Payroll{
+			ID:         uuid.New(),
+			CompanyID:  uuid.MustParse(companyID),
+			EmployeeID: uuid.New(),
+			Status:     payroll.StatusApproved,
+			NetSalary:  5000000,
+			PayslipURL: &payslipURL,
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return existing, nil
+		}
+		var updated *payroll.Payroll
+		deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+			updated = p
+			return nil
+		}
+
+		resp, err := deps.service.Cancel(ctx, companyID, actorID, existing.ID.String(), payroll.CancelPayrollRequest{Reason: "  salah periode  "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.StatusCancelled, resp.Status)
+		if assert.NotNil(t, resp.CancelReason) {
+			assert.Equal(t, "salah periode", *resp.CancelReason)
+		}
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, actorID, updated.CancelledBy.String())
+			assert.NotNil(t, updated.CancelledAt)
+			assert.Nil(t, updated.PayslipURL)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("only approved payroll can be cancelled", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{ID: uuid.New(), Status: payroll.StatusPaid}, nil
+		}
+
+		_, err := deps.service.Cancel(ctx, companyID, actorID, uuid.New().String(), payroll.CancelPayrollRequest{Reason: "batal"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrCancelOnlyApproved)
+	})
+
+	t.Run("reason is required", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.Cancel(ctx, companyID, actorID, uuid.New().String(), payroll.CancelPayrollRequest{Reason: "   "})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrCancelReasonRequired)
+	})
+}
+
+func TestPayrollService_Reverse(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("paid payroll gets negative adjustment", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		paidAt := time.Now().UTC()
+		original := &payroll.Payroll{
+			ID:           uuid.New(),
+			CompanyID:    uuid.MustParse(companyID),
+			EmployeeID:   uuid.New(),
+			PeriodStart:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:    time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			BaseSalary:   10000000,
+			Allowance:    500000,
+			Deduction:    300000,
+			NetSalary:    10200000,
+			TaxAmount:    200000,
+			EmployerCost: 1000000,
+			Status:       payroll.StatusPaid,
+			PaidAt:       &paidAt,
+			Components: []payroll.PayrollComponent{
+				{ComponentType: payroll.ComponentTypeAllowance, ComponentName: "Tunjangan Transport", Quantity: 1, UnitAmount: 500000, TotalAmount: 500000},
+				{ComponentType: payroll.ComponentTypeDeduction, ComponentName: "PPh 21", Quantity: 1, UnitAmount: 300000, TotalAmount: 300000},
+			},
+		}
+		var created *payroll.Payroll
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			created = p
+			return nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			if id == original.ID.String() {
+				return original, nil
+			}
+			return created, nil
+		}
+		var updated *payroll.Payroll
+		deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+			updated = p
+			return nil
+		}
+		var components []payroll.PayrollComponent
+		deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+			assert.Equal(t, created.ID.String(), pid)
+			components = items
+			return nil
+		}
+
+		resp, err := deps.service.Reverse(ctx, companyID, actorID, original.ID.String(), payroll.CancelPayrollRequest{Reason: "double transfer"})
+
+		assert.NoError(t, err)
+		// Penyesuaian tetap melewati rantai approval
+		assert.Equal(t, payroll.StatusDraft, resp.Status)
+		assert.Nil(t, resp.ApprovedBy)
+		assert.Equal(t, int64(-10200000), resp.NetSalary)
+		assert.Equal(t, int64(-10000000), resp.BaseSalary)
+		assert.Equal(t, int64(-200000), resp.TaxAmount)
+		assert.Equal(t, int64(-1000000), resp.EmployerCost)
+		if assert.NotNil(t, resp.ReversalOfID) {
+			assert.Equal(t, original.ID.String(), *resp.ReversalOfID)
+		}
+		if assert.NotNil(t, updated) {
+			assert.Equal(t, payroll.StatusReversed, updated.Status)
+			assert.Equal(t, created.ID, *updated.ReversedByID)
+		}
+		if assert.Len(t, components, 2) {
+			assert.Equal(t, int64(-500000), components[0].TotalAmount)
+			assert.Equal(t, int64(-500000), components[0].UnitAmount)
+			assert.Equal(t, "PPh 21", components[1].ComponentName)
+			assert.Equal(t, int64(-300000), components[1].TotalAmount)
+			assert.Equal(t, created.ID, components[1].PayrollID)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("reversal adjustment cannot be regenerated or deleted", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+		expectTx(t, deps.sqlMock, false)
+
+		originalID := uuid.New()
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{ID: uuid.MustParse(id), Status: payroll.StatusDraft, ReversalOfID: &originalID}, nil
+		}
+
+		_, err := deps.service.Regenerate(ctx, companyID, actorID, uuid.New().String(), payroll.RegeneratePayrollRequest{})
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollIsReversal)
+		assert.ErrorIs(t, deps.service.Delete(ctx, companyID, uuid.New().String()), payrollerrors.ErrPayrollIsReversal)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("only paid payroll can be reversed", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{ID: uuid.New(), Status: payroll.StatusApproved}, nil
+		}
+
+		_, err := deps.service.Reverse(ctx, companyID, actorID, uuid.New().String(), payroll.CancelPayrollRequest{Reason: "salah"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrReverseOnlyPaid)
+	})
+
+	t.Run("reversal adjustment cannot be reversed again", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		originalID := uuid.New()
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{ID: uuid.New(), Status: payroll.StatusPaid, ReversalOfID: &originalID}, nil
+		}
+
+		_, err := deps.service.Reverse(ctx, companyID, actorID, uuid.New().String(), payroll.CancelPayrollRequest{Reason: "salah"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollIsReversal)
+	})
+}
@@ -0,0 +1,76 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Cara menghitung nilai komponen template dalam satu periode payroll.
+	CalculationFixed            = "FIXED"              // Nominal tetap per periode
+	CalculationPercentOfBase    = "PERCENT_OF_BASE"    // Persentase (bps) dari gaji pokok
+	CalculationPerAttendanceDay = "PER_ATTENDANCE_DAY" // Nominal x hari hadir
+
+	// ComponentSourceTemplate menandai komponen hasil ekspansi assignment template;
+	// SourceID berisi id EmployeeComponentAssignment.
+	ComponentSourceTemplate = "COMPONENT_TEMPLATE"
+)
+
+// ComponentTemplate adalah master komponen gaji berulang per company, mis. tunjangan
+// transport, uang makan, atau iuran koperasi.
+type ComponentTemplate struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Code            string    `gorm:"type:varchar(50);not null"`
+	Name            string    `gorm:"type:varchar(120);not null"`
+	ComponentType   string    `gorm:"type:varchar(20);not null"` // ALLOWANCE atau DEDUCTION
+	CalculationType string    `gorm:"type:varchar(30);not null"`
+
+	// Amount dipakai FIXED dan PER_ATTENDANCE_DAY, RateBps (100 = 1%) dipakai PERCENT_OF_BASE.
+	Amount  int64 `gorm:"type:bigint;not null;default:0"`
+	RateBps int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Template nonaktif tidak lagi diekspansi ke payroll baru, assignment tetap tersimpan.
+	IsActive bool `gorm:"not null;default:true"`
+
+	// Prorate membuat komponen ikut diprorata sesuai masa kerja karyawan yang masuk
+	// atau keluar di tengah periode, dengan metode prorata company.
+	Prorate bool `gorm:"not null;default:false"`
+
+	CreatedBy uuid.UUID  `gorm:"type:uuid;not null"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (ComponentTemplate) TableName() string {
+	return "payroll_component_templates"
+}
+
+// EmployeeComponentAssignment memasang template ke karyawan untuk rentang tanggal
+// tertentu. Amount/RateBps mengganti nilai template khusus untuk karyawan ini.
+type EmployeeComponentAssignment struct {
+	ID         uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID  uuid.UUID          `gorm:"type:uuid;not null;index"`
+	EmployeeID uuid.UUID          `gorm:"type:uuid;not null;index"`
+	Employee   *LeaveEmployee     `gorm:"foreignKey:EmployeeID;references:ID"`
+	TemplateID uuid.UUID          `gorm:"type:uuid;not null;index"`
+	Template   *ComponentTemplate `gorm:"foreignKey:TemplateID;references:ID"`
+
+	Amount  *int64 `gorm:"type:bigint"`
+	RateBps *int64 `gorm:"type:bigint"`
+
+	// EffectiveTo kosong berarti berlaku tanpa batas akhir.
+	EffectiveFrom time.Time  `gorm:"type:date;not null"`
+	EffectiveTo   *time.Time `gorm:"type:date"`
+	Notes         *string    `gorm:"type:text"`
+
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (EmployeeComponentAssignment) TableName() string {
+	return "employee_payroll_components"
+}
@@ -0,0 +1,600 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *service) GetComponentTemplates(ctx context.Context, companyID string) ([]ComponentTemplateResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+
+	templates, err := s.repo.FindComponentTemplates(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]ComponentTemplateResponse, len(templates))
+	for i, template := range templates {
+		resp[i] = mapToComponentTemplateResponse(template)
+	}
+	return resp, nil
+}
+
+func (s *service) CreateComponentTemplate(
+	ctx context.Context,
+	companyID, actorID string,
+	req ComponentTemplateRequest,
+) (ComponentTemplateResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return ComponentTemplateResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return ComponentTemplateResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	req, err = normalizeComponentTemplateRequest(req)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	exists, err := qtx.ComponentTemplateCodeExists(ctx, companyID, req.Code, nil)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+	if exists {
+		return ComponentTemplateResponse{}, payrollerrors.ErrComponentTemplateCodeExists
+	}
+
+	template := &ComponentTemplate{
+		ID:        uuid.New(),
+		CompanyID: companyUUID,
+		IsActive:  true,
+		CreatedBy: actorUUID,
+	}
+	applyComponentTemplateRequest(template, req)
+
+	if err := qtx.CreateComponentTemplate(ctx, template); err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	return mapToComponentTemplateResponse(*template), nil
+}
+
+// UpdateComponentTemplate mengubah master komponen. Payroll DRAFT yang memakai template
+// ini ditandai stale agar di-regenerate dengan nilai baru.
+func (s *service) UpdateComponentTemplate(
+	ctx context.Context,
+	companyID, actorID, id string,
+	req ComponentTemplateRequest,
+) (ComponentTemplateResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return ComponentTemplateResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return ComponentTemplateResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	req, err = normalizeComponentTemplateRequest(req)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	template, err := qtx.FindComponentTemplateByID(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ComponentTemplateResponse{}, payrollerrors.ErrComponentTemplateNotFound
+		}
+		return ComponentTemplateResponse{}, err
+	}
+
+	exists, err := qtx.ComponentTemplateCodeExists(ctx, companyID, req.Code, &id)
+	if err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+	if exists {
+		return ComponentTemplateResponse{}, payrollerrors.ErrComponentTemplateCodeExists
+	}
+
+	applyComponentTemplateRequest(template, req)
+	template.UpdatedBy = &actorUUID
+
+	if err := qtx.UpdateComponentTemplate(ctx, template); err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+	if err := qtx.MarkPayrollsStaleByTemplate(ctx, companyID, template.ID.String(), "component template "+template.Code+" changed"); err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ComponentTemplateResponse{}, err
+	}
+
+	return mapToComponentTemplateResponse(*template), nil
+}
+
+func (s *service) GetComponentAssignments(ctx context.Context, companyID, employeeID string) ([]ComponentAssignmentResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(employeeID); err != nil {
+		return nil, payrollerrors.ErrInvalidEmployeeID
+	}
+
+	assignments, err := s.repo.FindComponentAssignments(ctx, companyID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]ComponentAssignmentResponse, len(assignments))
+	for i, assignment := range assignments {
+		resp[i] = mapToComponentAssignmentResponse(assignment)
+	}
+	return resp, nil
+}
+
+func (s *service) CreateComponentAssignment(
+	ctx context.Context,
+	companyID, actorID string,
+	req CreateComponentAssignmentRequest,
+) (ComponentAssignmentResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	employeeUUID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrInvalidEmployeeID
+	}
+	templateUUID, err := uuid.Parse(req.TemplateID)
+	if err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrComponentTemplateNotFound
+	}
+	effectiveFrom, effectiveTo, err := validateComponentAssignment(req.Amount, req.RateBps, req.EffectiveFrom, req.EffectiveTo)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	belongs, err := qtx.EmployeeBelongsToCompany(ctx, companyID, req.EmployeeID)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	if !belongs {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrEmployeeNotInCompany
+	}
+	if _, err := qtx.FindComponentTemplateByID(ctx, companyID, req.TemplateID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ComponentAssignmentResponse{}, payrollerrors.ErrComponentTemplateNotFound
+		}
+		return ComponentAssignmentResponse{}, err
+	}
+
+	overlap, err := qtx.HasOverlappingComponentAssignment(ctx, companyID, req.EmployeeID, req.TemplateID, effectiveFrom, effectiveTo, nil)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	if overlap {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrComponentAssignmentOverlap
+	}
+
+	assignment := &EmployeeComponentAssignment{
+		ID:            uuid.New(),
+		CompanyID:     companyUUID,
+		EmployeeID:    employeeUUID,
+		TemplateID:    templateUUID,
+		Amount:        req.Amount,
+		RateBps:       req.RateBps,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+		Notes:         req.Notes,
+		CreatedBy:     actorUUID,
+	}
+	if err := qtx.CreateComponentAssignment(ctx, assignment); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, req.EmployeeID, effectiveFrom, effectiveTo, "component assignment "+assignment.ID.String()+" changed"); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	persisted, err := qtx.FindComponentAssignmentByID(ctx, companyID, assignment.ID.String())
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	return mapToComponentAssignmentResponse(*persisted), nil
+}
+
+// UpdateComponentAssignment mengubah nilai override atau rentang berlaku, mis. mengisi
+// effective_to saat tunjangan dihentikan. Payroll DRAFT pada rentang lama maupun baru
+// ditandai stale.
+func (s *service) UpdateComponentAssignment(
+	ctx context.Context,
+	companyID, id string,
+	req UpdateComponentAssignmentRequest,
+) (ComponentAssignmentResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrComponentAssignmentNotFound
+	}
+	effectiveFrom, effectiveTo, err := validateComponentAssignment(req.Amount, req.RateBps, req.EffectiveFrom, req.EffectiveTo)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	assignment, err := qtx.FindComponentAssignmentByID(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ComponentAssignmentResponse{}, payrollerrors.ErrComponentAssignmentNotFound
+		}
+		return ComponentAssignmentResponse{}, err
+	}
+
+	employeeID := assignment.EmployeeID.String()
+	overlap, err := qtx.HasOverlappingComponentAssignment(ctx, companyID, employeeID, assignment.TemplateID.String(), effectiveFrom, effectiveTo, &id)
+	if err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	if overlap {
+		return ComponentAssignmentResponse{}, payrollerrors.ErrComponentAssignmentOverlap
+	}
+
+	reason := "component assignment " + id + " changed"
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, employeeID, assignment.EffectiveFrom, assignment.EffectiveTo, reason); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	assignment.Amount = req.Amount
+	assignment.RateBps = req.RateBps
+	assignment.EffectiveFrom = effectiveFrom
+	assignment.EffectiveTo = effectiveTo
+	assignment.Notes = req.Notes
+
+	if err := qtx.UpdateComponentAssignment(ctx, assignment); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, employeeID, effectiveFrom, effectiveTo, reason); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ComponentAssignmentResponse{}, err
+	}
+
+	return mapToComponentAssignmentResponse(*assignment), nil
+}
+
+func (s *service) DeleteComponentAssignment(ctx context.Context, companyID, id string) error {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return payrollerrors.ErrComponentAssignmentNotFound
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	assignment, err := qtx.FindComponentAssignmentByID(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return payrollerrors.ErrComponentAssignmentNotFound
+		}
+		return err
+	}
+
+	if err := qtx.DeleteComponentAssignment(ctx, companyID, id); err != nil {
+		return err
+	}
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, assignment.EmployeeID.String(), assignment.EffectiveFrom, assignment.EffectiveTo, "component assignment "+id+" removed"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// templateComponents mengekspansi assignment komponen berulang menjadi komponen
+// ALLOWANCE dan DEDUCTION. Assignment yang hanya berlaku sebagian periode diprorata
+// sesuai metode prorata company (FIXED, PERCENT_OF_BASE) atau hanya menghitung hari
+// hadir di dalam rentangnya (PER_ATTENDANCE_DAY). Template dengan flag Prorate juga
+// dipotong ke masa kerja karyawan. baseSalary adalah gaji pokok penuh sebelum prorata.
+func templateComponents(
+	companyID uuid.UUID,
+	payrollID *uuid.UUID,
+	baseSalary int64,
+	periodStart, periodEnd time.Time,
+	inputs payrollInputs,
+) ([]PayrollComponent, []PayrollComponent, error) {
+	payrollRef := uuid.Nil
+	if payrollID != nil {
+		payrollRef = *payrollID
+	}
+	source := ComponentSourceTemplate
+
+	allowances := make([]PayrollComponent, 0)
+	deductions := make([]PayrollComponent, 0)
+	for _, assignment := range inputs.Assignments {
+		template := assignment.Template
+		if template == nil || !template.IsActive {
+			continue
+		}
+
+		start := periodStart
+		if assignment.EffectiveFrom.After(start) {
+			start = assignment.EffectiveFrom
+		}
+		end := periodEnd
+		if assignment.EffectiveTo != nil && assignment.EffectiveTo.Before(end) {
+			end = *assignment.EffectiveTo
+		}
+		if template.Prorate && !inputs.Employment.Start.IsZero() {
+			if inputs.Employment.Start.After(start) {
+				start = inputs.Employment.Start
+			}
+			if inputs.Employment.End.Before(end) {
+				end = inputs.Employment.End
+			}
+		}
+		if start.After(end) {
+			continue
+		}
+
+		amount := template.Amount
+		if assignment.Amount != nil {
+			amount = *assignment.Amount
+		}
+		rate := template.RateBps
+		if assignment.RateBps != nil {
+			rate = *assignment.RateBps
+		}
+
+		var quantity, unitAmount int64
+		var note string
+		switch template.CalculationType {
+		case CalculationFixed:
+			quantity, unitAmount = 1, amount
+			note = fmt.Sprintf("%s: nominal tetap", template.Code)
+		case CalculationPercentOfBase:
+			quantity, unitAmount = 1, baseSalary*rate/10_000
+			note = fmt.Sprintf("%s: %s dari gaji pokok", template.Code, formatBps(rate))
+		case CalculationPerAttendanceDay:
+			quantity, unitAmount = countDatesBetween(inputs.Attendance.PresentDates, start, end), amount
+			note = fmt.Sprintf("%s: %d hari hadir", template.Code, quantity)
+		default:
+			continue
+		}
+
+		method := prorationMethod(inputs.Setting)
+		activeDays, totalDays := prorationDays(method, inputs.Setting.WorkDaysPerWeek, start, end, periodStart, periodEnd)
+		partial := start.After(periodStart) || end.Before(periodEnd)
+		if partial && template.CalculationType != CalculationPerAttendanceDay && totalDays > 0 {
+			unitAmount = unitAmount * activeDays / totalDays
+			note += fmt.Sprintf(", prorata %d/%d %s (%s s/d %s)",
+				activeDays, totalDays, prorationUnit(method), start.Format("2006-01-02"), end.Format("2006-01-02"))
+		}
+		if quantity <= 0 || unitAmount <= 0 {
+			continue
+		}
+
+		metadata, err := json.Marshal(map[string]any{
+			"template_id":      template.ID.String(),
+			"template_code":    template.Code,
+			"calculation_type": template.CalculationType,
+			"amount":           amount,
+			"rate_bps":         rate,
+			"effective_from":   start.Format("2006-01-02"),
+			"effective_to":     end.Format("2006-01-02"),
+			"active_days":      activeDays,
+			"prorate":          template.Prorate,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		raw := string(metadata)
+		assignmentID := assignment.ID
+
+		component := PayrollComponent{
+			ID:            uuid.New(),
+			PayrollID:     payrollRef,
+			CompanyID:     companyID,
+			ComponentType: template.ComponentType,
+			ComponentName: template.Name,
+			Quantity:      quantity,
+			UnitAmount:    unitAmount,
+			TotalAmount:   quantity * unitAmount,
+			Notes:         &note,
+			SourceType:    &source,
+			SourceID:      &assignmentID,
+			Metadata:      &raw,
+		}
+		if template.ComponentType == ComponentTypeDeduction {
+			deductions = append(deductions, component)
+		} else {
+			allowances = append(allowances, component)
+		}
+	}
+	return allowances, deductions, nil
+}
+
+func normalizeComponentTemplateRequest(req ComponentTemplateRequest) (ComponentTemplateRequest, error) {
+	req.Code = strings.ToUpper(strings.TrimSpace(req.Code))
+	req.Name = strings.TrimSpace(req.Name)
+	req.ComponentType = strings.ToUpper(strings.TrimSpace(req.ComponentType))
+	req.CalculationType = strings.ToUpper(strings.TrimSpace(req.CalculationType))
+
+	if req.Code == "" || len(req.Code) > 50 || req.Name == "" || len(req.Name) > 120 {
+		return req, payrollerrors.ErrInvalidComponentTemplate
+	}
+	if req.ComponentType != ComponentTypeAllowance && req.ComponentType != ComponentTypeDeduction {
+		return req, payrollerrors.ErrInvalidComponentTemplate
+	}
+	switch req.CalculationType {
+	case CalculationFixed, CalculationPerAttendanceDay:
+		if req.Amount <= 0 {
+			return req, payrollerrors.ErrInvalidComponentTemplate
+		}
+		req.RateBps = 0
+	case CalculationPercentOfBase:
+		if req.RateBps <= 0 || req.RateBps > 10_000 {
+			return req, payrollerrors.ErrInvalidComponentTemplate
+		}
+		req.Amount = 0
+	default:
+		return req, payrollerrors.ErrInvalidComponentTemplate
+	}
+	return req, nil
+}
+
+func applyComponentTemplateRequest(template *ComponentTemplate, req ComponentTemplateRequest) {
+	template.Code = req.Code
+	template.Name = req.Name
+	template.ComponentType = req.ComponentType
+	template.CalculationType = req.CalculationType
+	template.Amount = req.Amount
+	template.RateBps = req.RateBps
+	template.Prorate = req.Prorate
+	setIfPresent(&template.IsActive, req.IsActive)
+}
+
+func validateComponentAssignment(amount, rateBps *int64, from, to string) (time.Time, *time.Time, error) {
+	if amount != nil && *amount < 0 {
+		return time.Time{}, nil, payrollerrors.ErrInvalidComponentAssignment
+	}
+	if rateBps != nil && (*rateBps < 0 || *rateBps > 10_000) {
+		return time.Time{}, nil, payrollerrors.ErrInvalidComponentAssignment
+	}
+	effectiveFrom, err := parseDate(from)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if strings.TrimSpace(to) == "" {
+		return effectiveFrom, nil, nil
+	}
+	effectiveTo, err := parseDate(to)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if effectiveTo.Before(effectiveFrom) {
+		return time.Time{}, nil, payrollerrors.ErrInvalidComponentAssignment
+	}
+	return effectiveFrom, &effectiveTo, nil
+}
+
+func countDatesBetween(dates []time.Time, start, end time.Time) int64 {
+	var count int64
+	for _, date := range dates {
+		if !date.Before(start) && !date.After(end) {
+			count++
+		}
+	}
+	return count
+}
+
+// formatBps menampilkan basis poin sebagai persen, mis. 250 -> "2.50%".
+func formatBps(bps int64) string {
+	return fmt.Sprintf("%d.%02d%%", bps/100, bps%100)
+}
+
+func mapToComponentTemplateResponse(template ComponentTemplate) ComponentTemplateResponse {
+	return ComponentTemplateResponse{
+		ID:              template.ID.String(),
+		CompanyID:       template.CompanyID.String(),
+		Code:            template.Code,
+		Name:            template.Name,
+		ComponentType:   template.ComponentType,
+		CalculationType: template.CalculationType,
+		Amount:          template.Amount,
+		RateBps:         template.RateBps,
+		IsActive:        template.IsActive,
+		Prorate:         template.Prorate,
+		CreatedBy:       template.CreatedBy.String(),
+		UpdatedBy:       uuidPtrToString(template.UpdatedBy),
+		CreatedAt:       template.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       template.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func mapToComponentAssignmentResponse(assignment EmployeeComponentAssignment) ComponentAssignmentResponse {
+	resp := ComponentAssignmentResponse{
+		ID:            assignment.ID.String(),
+		EmployeeID:    assignment.EmployeeID.String(),
+		Amount:        assignment.Amount,
+		RateBps:       assignment.RateBps,
+		EffectiveFrom: assignment.EffectiveFrom.Format("2006-01-02"),
+		Notes:         assignment.Notes,
+		CreatedBy:     assignment.CreatedBy.String(),
+		CreatedAt:     assignment.CreatedAt.Format(time.RFC3339),
+	}
+	if assignment.Employee != nil {
+		resp.EmployeeName = assignment.Employee.FullName
+	}
+	if assignment.Template != nil {
+		resp.Template = mapToComponentTemplateResponse(*assignment.Template)
+	}
+	if assignment.EffectiveTo != nil {
+		v := assignment.EffectiveTo.Format("2006-01-02")
+		resp.EffectiveTo = &v
+	}
+	return resp
+}
@@ -0,0 +1,264 @@
+package payroll_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_Create_ExpandsComponentTemplates(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, true)
+	// Februari 2026 memiliki 20 hari kerja (Senin-Jumat), BPJS dan pajak dinonaktifkan
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{CompanyID: uuid.MustParse(cid), WorkHoursPerDay: 8, WorkDaysPerWeek: 5, TaxCalculator: payroll.TaxCalculatorNone}, nil
+	}
+	deps.repo.findAttendanceRecordsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.AttendanceRecord, error) {
+		records := make([]payroll.AttendanceRecord, 0, 3)
+		for _, day := range []int{2, 3, 4} {
+			date := time.Date(2026, time.February, day, 0, 0, 0, 0, time.UTC)
+			clockOut := date.Add(17 * time.Hour)
+			records = append(records, payroll.AttendanceRecord{AttendanceDate: date, ClockIn: date.Add(9 * time.Hour), ClockOut: &clockOut, Status: "PRESENT"})
+		}
+		return records, nil
+	}
+
+	transport := &payroll.ComponentTemplate{ID: uuid.New(), Code: "TRANSPORT", Name: "Tunjangan Transport", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationFixed, Amount: 500000, IsActive: true}
+	meal := &payroll.ComponentTemplate{ID: uuid.New(), Code: "MEAL", Name: "Uang Makan", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationPerAttendanceDay, Amount: 30000, IsActive: true}
+	cooperative := &payroll.ComponentTemplate{ID: uuid.New(), Code: "KOPERASI", Name: "Iuran Koperasi", ComponentType: payroll.ComponentTypeDeduction, CalculationType: payroll.CalculationPercentOfBase, RateBps: 100, IsActive: true}
+	inactive := &payroll.ComponentTemplate{ID: uuid.New(), Code: "OLD", Name: "Tunjangan Lama", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationFixed, Amount: 999999, IsActive: false}
+
+	mealAmount := int64(35000)
+	cooperativeID := uuid.New()
+	deps.repo.findEffectiveAssignsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.EmployeeComponentAssignment, error) {
+		assert.Equal(t, employeeID, eid)
+		return []payroll.EmployeeComponentAssignment{
+			{ID: uuid.New(), TemplateID: transport.ID, Template: transport, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), TemplateID: meal.ID, Template: meal, Amount: &mealAmount, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			// Mulai 16 Februari: 10 dari 20 hari kerja
+			{ID: cooperativeID, TemplateID: cooperative.ID, Template: cooperative, EffectiveFrom: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), TemplateID: inactive.ID, Template: inactive, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	}
+
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-02-01",
+		PeriodEnd:   "2026-02-28",
+		BaseSalary:  int64Ptr(6000000),
+	})
+
+	assert.NoError(t, err)
+	byName := map[string]payroll.PayrollComponentResponse{}
+	for _, component := range resp.Components {
+		byName[component.ComponentName] = component
+		if assert.NotNil(t, component.SourceType) {
+			assert.Equal(t, payroll.ComponentSourceTemplate, *component.SourceType)
+		}
+	}
+	assert.Len(t, resp.Components, 3)
+	assert.Equal(t, int64(500000), byName["Tunjangan Transport"].TotalAmount)
+	assert.Equal(t, int64(3), byName["Uang Makan"].Quantity)
+	assert.Equal(t, int64(35000), byName["Uang Makan"].UnitAmount)
+
+	koperasi := byName["Iuran Koperasi"]
+	assert.Equal(t, payroll.ComponentTypeDeduction, koperasi.ComponentType)
+	assert.Equal(t, int64(30000), koperasi.TotalAmount)
+	if assert.NotNil(t, koperasi.SourceID) && assert.NotNil(t, koperasi.Notes) {
+		assert.Equal(t, cooperativeID.String(), *koperasi.SourceID)
+		assert.Contains(t, *koperasi.Notes, "prorata 10/20 hari kerja")
+	}
+	var metadata map[string]any
+	assert.NoError(t, json.Unmarshal(koperasi.Metadata, &metadata))
+	assert.Equal(t, "KOPERASI", metadata["template_code"])
+
+	assert.Equal(t, int64(605000), resp.TotalAllowance)
+	assert.Equal(t, int64(6000000+605000-30000), resp.NetSalary)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_CreateComponentTemplate(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("percent of base requires rate", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.CreateComponentTemplate(ctx, companyID, actorID, payroll.ComponentTemplateRequest{
+			Code: "koperasi", Name: "Iuran Koperasi", ComponentType: "DEDUCTION", CalculationType: "PERCENT_OF_BASE",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidComponentTemplate)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.templateCodeExistsFn = func(ctx context.Context, cid, code string, excludeID *string) (bool, error) {
+			return code == "TRANSPORT", nil
+		}
+
+		_, err := deps.service.CreateComponentTemplate(ctx, companyID, actorID, payroll.ComponentTemplateRequest{
+			Code: "transport", Name: "Tunjangan Transport", ComponentType: "ALLOWANCE", CalculationType: "FIXED", Amount: 500000,
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrComponentTemplateCodeExists)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("normalizes and persists", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+		var saved *payroll.ComponentTemplate
+		deps.repo.createTemplateFn = func(ctx context.Context, template *payroll.ComponentTemplate) error {
+			saved = template
+			return nil
+		}
+
+		resp, err := deps.service.CreateComponentTemplate(ctx, companyID, actorID, payroll.ComponentTemplateRequest{
+			Code: " meal ", Name: "Uang Makan", ComponentType: "allowance", CalculationType: "per_attendance_day", Amount: 30000, RateBps: 50,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "MEAL", resp.Code)
+		assert.True(t, resp.IsActive)
+		if assert.NotNil(t, saved) {
+			assert.Equal(t, payroll.CalculationPerAttendanceDay, saved.CalculationType)
+			assert.Equal(t, int64(0), saved.RateBps)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_CreateComponentAssignment(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+	templateID := uuid.New()
+
+	newDeps := func(t *testing.T, commit bool) *payrollServiceDeps {
+		deps := setupPayrollServiceTest(t)
+		expectTx(t, deps.sqlMock, commit)
+		deps.repo.employeeBelongsToCompany = func(ctx context.Context, cid, eid string) (bool, error) {
+			return true, nil
+		}
+		deps.repo.findTemplateByIDFn = func(ctx context.Context, cid, id string) (*payroll.ComponentTemplate, error) {
+			return &payroll.ComponentTemplate{ID: templateID, Code: "TRANSPORT", Name: "Tunjangan Transport"}, nil
+		}
+		return deps
+	}
+	req := payroll.CreateComponentAssignmentRequest{
+		EmployeeID:    employeeID,
+		TemplateID:    templateID.String(),
+		EffectiveFrom: "2026-03-01",
+		EffectiveTo:   "2026-12-31",
+	}
+
+	t.Run("rejects overlapping period", func(t *testing.T) {
+		deps := newDeps(t, false)
+		defer deps.db.Close()
+		deps.repo.assignmentOverlapsFn = func(ctx context.Context, cid, eid, tid string, from time.Time, to *time.Time, excludeID *string) (bool, error) {
+			return true, nil
+		}
+
+		_, err := deps.service.CreateComponentAssignment(ctx, companyID, actorID, req)
+
+		assert.ErrorIs(t, err, payrollerrors.ErrComponentAssignmentOverlap)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("effective_to before effective_from", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		invalid := req
+		invalid.EffectiveTo = "2026-02-01"
+
+		_, err := deps.service.CreateComponentAssignment(ctx, companyID, actorID, invalid)
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidComponentAssignment)
+	})
+
+	t.Run("marks draft payrolls in range stale", func(t *testing.T) {
+		deps := newDeps(t, true)
+		defer deps.db.Close()
+		var saved *payroll.EmployeeComponentAssignment
+		deps.repo.createAssignmentFn = func(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error {
+			saved = assignment
+			return nil
+		}
+		var staleFrom time.Time
+		var staleTo *time.Time
+		deps.repo.markStaleByEmployeeFn = func(ctx context.Context, cid, eid string, from time.Time, to *time.Time, reason string) error {
+			staleFrom, staleTo = from, to
+			return nil
+		}
+		deps.repo.findAssignmentByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeComponentAssignment, error) {
+			saved.Template = &payroll.ComponentTemplate{ID: templateID, Code: "TRANSPORT"}
+			return saved, nil
+		}
+
+		resp, err := deps.service.CreateComponentAssignment(ctx, companyID, actorID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "TRANSPORT", resp.Template.Code)
+		assert.Equal(t, "2026-03-01", staleFrom.Format("2006-01-02"))
+		if assert.NotNil(t, staleTo) && assert.NotNil(t, resp.EffectiveTo) {
+			assert.Equal(t, "2026-12-31", staleTo.Format("2006-01-02"))
+			assert.Equal(t, "2026-12-31", *resp.EffectiveTo)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_ComponentAssignment_InvalidIDs(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	req := payroll.UpdateComponentAssignmentRequest{EffectiveFrom: "2026-03-01"}
+
+	_, err := deps.service.UpdateComponentAssignment(ctx, companyID, "not-a-uuid", req)
+	assert.ErrorIs(t, err, payrollerrors.ErrComponentAssignmentNotFound)
+	_, err = deps.service.UpdateComponentAssignment(ctx, "bad-company", uuid.New().String(), req)
+	assert.ErrorIs(t, err, payrollerrors.ErrInvalidCompanyID)
+
+	assert.ErrorIs(t, deps.service.DeleteComponentAssignment(ctx, companyID, "not-a-uuid"), payrollerrors.ErrComponentAssignmentNotFound)
+	assert.ErrorIs(t, deps.service.DeleteComponentAssignment(ctx, "bad-company", uuid.New().String()), payrollerrors.ErrInvalidCompanyID)
+	// Ditolak sebelum transaksi dibuka
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
@@ -1,11 +1,22 @@
 package payroll
 
+import (
+	"encoding/json"
+
+	"go-hris/internal/shared/pagination"
+)
+
+// GetPayrollsFilterRequest adalah query string list payroll. sort_by: period_start (default,
+// terbaru dulu), period_end, net_salary, status, created_at, employee_name; q mencari nama
+// atau nomor karyawan.
 type GetPayrollsFilterRequest struct {
+	pagination.Request
 	Period       string `form:"period"`
 	PeriodStart  string `form:"period_start"`
 	PeriodEnd    string `form:"period_end"`
 	DepartmentID string `form:"department_id"`
 	Status       string `form:"status"`
+	PayrollType  string `form:"payroll_type"`
 }
 
 type PayrollQueryFilter struct {
@@ -13,13 +24,17 @@ type PayrollQueryFilter struct {
 	PeriodEnd    *string
 	DepartmentID *string
 	Status       *string
+	PayrollType  *string
+	Page         pagination.Query // Hanya dipakai FindPageByCompany
 }
 
 type CreatePayrollRequest struct {
 	EmployeeID     string                  `json:"employee_id" binding:"required,uuid"`
+	PayrollType    string                  `json:"payroll_type"`   // Opsional: REGULAR (default), THR, BONUS atau CORRECTION
+	ReferenceDate  string                  `json:"reference_date"` // Opsional untuk THR: tanggal hari raya, default period_end
 	PeriodStart    string                  `json:"period_start" binding:"required"`
 	PeriodEnd      string                  `json:"period_end" binding:"required"`
-	BaseSalary     int64                   `json:"base_salary" binding:"required"`
+	BaseSalary     *int64                  `json:"base_salary"` // Opsional: override manual, default diambil dari employee_salaries (THR: upah sebulan)
 	Allowance      int64                   `json:"allowance"`
 	OvertimeHours  int64                   `json:"overtime_hours"`
 	OvertimeRate   int64                   `json:"overtime_rate"`
@@ -29,7 +44,7 @@ type CreatePayrollRequest struct {
 }
 
 type RegeneratePayrollRequest struct {
-	BaseSalary     int64                   `json:"base_salary" binding:"required"`
+	BaseSalary     *int64                  `json:"base_salary"` // Opsional: override manual, default diambil dari employee_salaries
 	Allowance      int64                   `json:"allowance"`
 	OvertimeHours  int64                   `json:"overtime_hours"`
 	OvertimeRate   int64                   `json:"overtime_rate"`
@@ -38,6 +53,10 @@ type RegeneratePayrollRequest struct {
 	DeductionItems []PayrollComponentInput `json:"deduction_items"`
 }
 
+type CancelPayrollRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
 type PayrollComponentInput struct {
 	ComponentName string  `json:"component_name" binding:"required"`
 	Quantity      int64   `json:"quantity"`
@@ -46,36 +65,58 @@ type PayrollComponentInput struct {
 }
 
 type PayrollComponentResponse struct {
-	ID            string  `json:"id"`
-	ComponentType string  `json:"component_type"`
-	ComponentName string  `json:"component_name"`
-	Quantity      int64   `json:"quantity"`
-	UnitAmount    int64   `json:"unit_amount"`
-	TotalAmount   int64   `json:"total_amount"`
-	Notes         *string `json:"notes,omitempty"`
+	ID            string          `json:"id"`
+	ComponentType string          `json:"component_type"`
+	ComponentName string          `json:"component_name"`
+	Quantity      int64           `json:"quantity"`
+	UnitAmount    int64           `json:"unit_amount"`
+	TotalAmount   int64           `json:"total_amount"`
+	Notes         *string         `json:"notes,omitempty"`
+	SourceType    *string         `json:"source_type,omitempty"`
+	SourceID      *string         `json:"source_id,omitempty"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
 }
 
 type PayrollBreakdownLine struct {
-	Label      string  `json:"label"`
-	Quantity   *int64  `json:"quantity,omitempty"`
-	UnitAmount *int64  `json:"unit_amount,omitempty"`
-	Amount     int64   `json:"amount"`
-	Notes      *string `json:"notes,omitempty"`
+	Label      string          `json:"label"`
+	Quantity   *int64          `json:"quantity,omitempty"`
+	UnitAmount *int64          `json:"unit_amount,omitempty"`
+	Amount     int64           `json:"amount"`
+	Notes      *string         `json:"notes,omitempty"`
+	SourceType *string         `json:"source_type,omitempty"`
+	SourceID   *string         `json:"source_id,omitempty"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
 }
 
 type PayrollBreakdownResponse struct {
-	PayrollID      string                 `json:"payroll_id"`
-	EmployeeID     string                 `json:"employee_id"`
-	PeriodStart    string                 `json:"period_start"`
-	PeriodEnd      string                 `json:"period_end"`
-	Status         string                 `json:"status"`
-	BaseSalary     PayrollBreakdownLine   `json:"base_salary"`
-	Allowances     []PayrollBreakdownLine `json:"allowances"`
-	AllowanceTotal int64                  `json:"allowance_total"`
-	Overtime       PayrollBreakdownLine   `json:"overtime"`
-	Deductions     []PayrollBreakdownLine `json:"deductions"`
-	DeductionTotal int64                  `json:"deduction_total"`
-	NetSalary      int64                  `json:"net_salary"`
+	PayrollID      string                    `json:"payroll_id,omitempty"` // Kosong pada hasil simulasi
+	EmployeeID     string                    `json:"employee_id"`
+	EmployeeName   string                    `json:"employee_name,omitempty"`
+	PayrollType    string                    `json:"payroll_type"`
+	PeriodStart    string                    `json:"period_start"`
+	PeriodEnd      string                    `json:"period_end"`
+	Status         string                    `json:"status"`
+	IsStale        bool                      `json:"is_stale"`
+	BaseSalary     PayrollBreakdownLine      `json:"base_salary"`
+	Proration      *PayrollProrationResponse `json:"proration,omitempty"`
+	Allowances     []PayrollBreakdownLine    `json:"allowances"`
+	AllowanceTotal int64                     `json:"allowance_total"`
+	Overtime       PayrollBreakdownLine      `json:"overtime"`
+	Deductions     []PayrollBreakdownLine    `json:"deductions"`
+	DeductionTotal int64                     `json:"deduction_total"`
+	NetSalary      int64                     `json:"net_salary"`
+
+	// Beban perusahaan di luar net salary (mis. iuran BPJS pemberi kerja)
+	EmployerCosts     []PayrollBreakdownLine `json:"employer_costs"`
+	EmployerCostTotal int64                  `json:"employer_cost_total"`
+}
+
+// PayrollProrationResponse menjelaskan prorata masa kerja: Factor = Days / TotalDays.
+type PayrollProrationResponse struct {
+	Method    string  `json:"method"`
+	Days      int64   `json:"days"`
+	TotalDays int64   `json:"total_days"`
+	Factor    float64 `json:"factor"`
 }
 
 type PayrollResponse struct {
@@ -83,23 +124,569 @@ type PayrollResponse struct {
 	CompanyID          string                     `json:"company_id"`
 	EmployeeID         string                     `json:"employee_id"`
 	EmployeeName       string                     `json:"employee_name"`
+	RunID              *string                    `json:"run_id,omitempty"`
+	PayrollType        string                     `json:"payroll_type"`
+	ReferenceDate      *string                    `json:"reference_date,omitempty"`
 	PeriodStart        string                     `json:"period_start"`
 	PeriodEnd          string                     `json:"period_end"`
 	BaseSalary         int64                      `json:"base_salary"`
+	BaseSalaryOverride bool                       `json:"base_salary_override"`
+	DerivedBaseSalary  *int64                     `json:"derived_base_salary,omitempty"`
+	Proration          *PayrollProrationResponse  `json:"proration,omitempty"`
+	IsStale            bool                       `json:"is_stale"`
+	StaleReason        *string                    `json:"stale_reason,omitempty"`
 	TotalAllowance     int64                      `json:"total_allowance"`
 	OvertimeHours      int64                      `json:"overtime_hours"`
 	OvertimeRate       int64                      `json:"overtime_rate"`
 	TotalOvertime      int64                      `json:"total_overtime"`
 	TotalDeduction     int64                      `json:"total_deduction"`
+	EmployerCost       int64                      `json:"employer_cost"`
+	GrossIncome        int64                      `json:"gross_income"`
+	TaxAmount          int64                      `json:"tax_amount"`
 	Allowance          int64                      `json:"allowance"`
 	Deduction          int64                      `json:"deduction"`
 	NetSalary          int64                      `json:"net_salary"`
 	Status             string                     `json:"status"`
 	CreatedBy          string                     `json:"created_by"`
 	PaidAt             *string                    `json:"paid_at,omitempty"`
+	PaymentReference   *string                    `json:"payment_reference,omitempty"`
 	ApprovedBy         *string                    `json:"approved_by,omitempty"`
 	ApprovedAt         *string                    `json:"approved_at,omitempty"`
 	PayslipURL         *string                    `json:"payslip_url,omitempty"`
 	PayslipGeneratedAt *string                    `json:"payslip_generated_at,omitempty"`
+	ReversalOfID       *string                    `json:"reversal_of_id,omitempty"`
+	ReversedByID       *string                    `json:"reversed_by_id,omitempty"`
+	CancelReason       *string                    `json:"cancel_reason,omitempty"`
+	CancelledBy        *string                    `json:"cancelled_by,omitempty"`
+	CancelledAt        *string                    `json:"cancelled_at,omitempty"`
 	Components         []PayrollComponentResponse `json:"components,omitempty"`
+	Approvals          []PayrollApprovalResponse  `json:"approvals,omitempty"`
+	NextApprovalStep   *ApprovalStepResponse      `json:"next_approval_step,omitempty"` // Terisi pada respons approve yang belum final
+}
+
+// ApprovePayrollRequest opsional; comment dicatat pada langkah approval yang dilalui.
+type ApprovePayrollRequest struct {
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+type ApprovalStepRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Role        string `json:"role" binding:"required"` // Role aktor yang boleh menyetujui, mis. HR, Finance, Owner
+	MinNetTotal int64  `json:"min_net_total"`           // Opsional: langkah hanya wajib jika total net salary >= nilai ini
+}
+
+// UpdateApprovalChainRequest mengganti rantai approval; urutan langkah mengikuti urutan array.
+// Array kosong mengembalikan company ke approval satu langkah bawaan.
+type UpdateApprovalChainRequest struct {
+	Steps []ApprovalStepRequest `json:"steps" binding:"dive"`
+}
+
+type ApprovalStepResponse struct {
+	StepOrder   int    `json:"step_order"`
+	Name        string `json:"name"`
+	Role        string `json:"role,omitempty"` // Kosong pada langkah bawaan: semua pemegang payroll:approve
+	MinNetTotal int64  `json:"min_net_total"`
+}
+
+type ApprovalChainResponse struct {
+	IsDefault bool                   `json:"is_default"`
+	Steps     []ApprovalStepResponse `json:"steps"`
+}
+
+type PayrollApprovalResponse struct {
+	StepOrder  int     `json:"step_order"`
+	StepName   string  `json:"step_name"`
+	Role       string  `json:"role,omitempty"`
+	ActorID    string  `json:"actor_id"`
+	Comment    *string `json:"comment,omitempty"`
+	ApprovedAt string  `json:"approved_at"`
+}
+
+type GetPayrollPeriodsRequest struct {
+	Year int `form:"year"` // Default tahun berjalan
+}
+
+// PayrollPeriodActionRequest dipakai process, close, dan reopen; reason wajib untuk reopen.
+type PayrollPeriodActionRequest struct {
+	Reason string `json:"reason" binding:"max=1000"`
+}
+
+type PayrollPeriodAuditResponse struct {
+	Action     string  `json:"action"`
+	FromStatus string  `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Reason     *string `json:"reason,omitempty"`
+	ActorID    string  `json:"actor_id"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type PayrollPeriodResponse struct {
+	ID          *string                      `json:"id,omitempty"` // Kosong untuk bulan yang belum pernah diproses
+	Period      string                       `json:"period"`       // YYYY-MM
+	PeriodStart string                       `json:"period_start"`
+	PeriodEnd   string                       `json:"period_end"`
+	Status      string                       `json:"status"`
+	ClosedBy    *string                      `json:"closed_by,omitempty"`
+	ClosedAt    *string                      `json:"closed_at,omitempty"`
+	Audits      []PayrollPeriodAuditResponse `json:"audits,omitempty"`
+}
+
+type CreatePayrollRunRequest struct {
+	Period        string `json:"period"`
+	PeriodStart   string `json:"period_start"`
+	PeriodEnd     string `json:"period_end"`
+	DepartmentID  string `json:"department_id"`
+	PayrollType   string `json:"payroll_type"`   // Opsional: REGULAR (default) atau THR
+	ReferenceDate string `json:"reference_date"` // Opsional untuk THR: tanggal hari raya, default period_end
+}
+
+// SimulatePayrollRequest menghitung payroll REGULAR tanpa menyimpan apa pun. Tanpa employee_id,
+// simulasi mencakup seluruh karyawan aktif periode tersebut (opsional per departemen).
+type SimulatePayrollRequest struct {
+	EmployeeID   string `json:"employee_id" binding:"omitempty,uuid"`
+	DepartmentID string `json:"department_id" binding:"omitempty,uuid"`
+	Period       string `json:"period"` // YYYY-MM, alternatif period_start/period_end
+	PeriodStart  string `json:"period_start"`
+	PeriodEnd    string `json:"period_end"`
+
+	// What-if: gaji pokok baru (hanya untuk employee_id) atau kenaikan dari gaji efektif (100 = 1%).
+	BaseSalary            *int64 `json:"base_salary"`
+	BaseSalaryIncreaseBps int64  `json:"base_salary_increase_bps"`
+
+	Allowance      int64                   `json:"allowance"`
+	OvertimeHours  int64                   `json:"overtime_hours"`
+	OvertimeRate   int64                   `json:"overtime_rate"`
+	Deduction      int64                   `json:"deduction"`
+	AllowanceItems []PayrollComponentInput `json:"allowance_items"`
+	DeductionItems []PayrollComponentInput `json:"deduction_items"`
+}
+
+type PayrollSimulationResponse struct {
+	PeriodStart       string                      `json:"period_start"`
+	PeriodEnd         string                      `json:"period_end"`
+	TotalEmployees    int                         `json:"total_employees"`
+	SuccessCount      int                         `json:"success_count"`
+	FailedCount       int                         `json:"failed_count"`
+	TotalNetSalary    int64                       `json:"total_net_salary"`
+	TotalEmployerCost int64                       `json:"total_employer_cost"`
+	Payrolls          []PayrollBreakdownResponse  `json:"payrolls"`
+	Failures          []PayrollRunFailureResponse `json:"failures"`
+}
+
+type PayrollRunFailureResponse struct {
+	EmployeeID   string `json:"employee_id"`
+	EmployeeName string `json:"employee_name"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type PayrollRunResponse struct {
+	ID               string                      `json:"id"`
+	CompanyID        string                      `json:"company_id"`
+	DepartmentID     *string                     `json:"department_id,omitempty"`
+	PayrollType      string                      `json:"payroll_type"`
+	ReferenceDate    *string                     `json:"reference_date,omitempty"`
+	PeriodStart      string                      `json:"period_start"`
+	PeriodEnd        string                      `json:"period_end"`
+	Status           string                      `json:"status"`
+	TotalEmployees   int                         `json:"total_employees"`
+	SuccessCount     int                         `json:"success_count"`
+	FailedCount      int                         `json:"failed_count"`
+	TotalNetSalary   int64                       `json:"total_net_salary"`
+	CreatedBy        string                      `json:"created_by"`
+	CreatedAt        string                      `json:"created_at"`
+	ApprovedBy       *string                     `json:"approved_by,omitempty"`
+	ApprovedAt       *string                     `json:"approved_at,omitempty"`
+	PaidAt           *string                     `json:"paid_at,omitempty"`
+	Failures         []PayrollRunFailureResponse `json:"failures,omitempty"`
+	Approvals        []PayrollApprovalResponse   `json:"approvals,omitempty"`
+	NextApprovalStep *ApprovalStepResponse       `json:"next_approval_step,omitempty"`
+	Payrolls         []PayrollResponse           `json:"payrolls,omitempty"`
+}
+
+type UpdatePayrollSettingRequest struct {
+	WorkHoursPerDay       int64  `json:"work_hours_per_day" binding:"required"`
+	WorkDaysPerWeek       int    `json:"work_days_per_week" binding:"required"`
+	OvertimeHourlyRate    int64  `json:"overtime_hourly_rate"`
+	AbsentDeductionAmount int64  `json:"absent_deduction_amount"`
+	LateDeductionAmount   int64  `json:"late_deduction_amount"`
+	TaxCalculator         string `json:"tax_calculator"` // Opsional: PPH21 atau NONE, kosong = tidak diubah
+
+	// Opsional: tarif BPJS (basis poin) dan batas upah, kosong = tidak diubah
+	JHTEmployeeRateBps       *int64 `json:"jht_employee_rate_bps"`
+	JHTEmployerRateBps       *int64 `json:"jht_employer_rate_bps"`
+	JPEmployeeRateBps        *int64 `json:"jp_employee_rate_bps"`
+	JPEmployerRateBps        *int64 `json:"jp_employer_rate_bps"`
+	JPWageCap                *int64 `json:"jp_wage_cap"`
+	JKKEmployerRateBps       *int64 `json:"jkk_employer_rate_bps"`
+	JKMEmployerRateBps       *int64 `json:"jkm_employer_rate_bps"`
+	KesehatanEmployeeRateBps *int64 `json:"kesehatan_employee_rate_bps"`
+	KesehatanEmployerRateBps *int64 `json:"kesehatan_employer_rate_bps"`
+	KesehatanWageCap         *int64 `json:"kesehatan_wage_cap"`
+
+	// Opsional: rekening sumber transfer gaji, kosong = tidak diubah
+	DebitBankCode      *string `json:"debit_bank_code"`
+	DebitAccountNumber *string `json:"debit_account_number"`
+	DebitAccountName   *string `json:"debit_account_name"`
+	BankCompanyCode    *string `json:"bank_company_code"`
+
+	// Opsional: NONE, BIRTH_DATE_EMPLOYEE_NUMBER, EMPLOYEE_NUMBER atau BIRTH_DATE
+	PayslipPasswordMode *string `json:"payslip_password_mode"`
+
+	// Opsional: WORKING_DAYS atau CALENDAR_DAYS untuk prorata karyawan masuk/keluar di tengah periode
+	ProrationMethod *string `json:"proration_method"`
+}
+
+type PayrollSettingResponse struct {
+	CompanyID             string `json:"company_id"`
+	WorkHoursPerDay       int64  `json:"work_hours_per_day"`
+	WorkDaysPerWeek       int    `json:"work_days_per_week"`
+	OvertimeHourlyRate    int64  `json:"overtime_hourly_rate"`
+	AbsentDeductionAmount int64  `json:"absent_deduction_amount"`
+	LateDeductionAmount   int64  `json:"late_deduction_amount"`
+	TaxCalculator         string `json:"tax_calculator"`
+
+	JHTEmployeeRateBps       int64 `json:"jht_employee_rate_bps"`
+	JHTEmployerRateBps       int64 `json:"jht_employer_rate_bps"`
+	JPEmployeeRateBps        int64 `json:"jp_employee_rate_bps"`
+	JPEmployerRateBps        int64 `json:"jp_employer_rate_bps"`
+	JPWageCap                int64 `json:"jp_wage_cap"`
+	JKKEmployerRateBps       int64 `json:"jkk_employer_rate_bps"`
+	JKMEmployerRateBps       int64 `json:"jkm_employer_rate_bps"`
+	KesehatanEmployeeRateBps int64 `json:"kesehatan_employee_rate_bps"`
+	KesehatanEmployerRateBps int64 `json:"kesehatan_employer_rate_bps"`
+	KesehatanWageCap         int64 `json:"kesehatan_wage_cap"`
+
+	DebitBankCode      string `json:"debit_bank_code"`
+	DebitAccountNumber string `json:"debit_account_number"`
+	DebitAccountName   string `json:"debit_account_name"`
+	BankCompanyCode    string `json:"bank_company_code"`
+
+	PayslipPasswordMode string `json:"payslip_password_mode"`
+	ProrationMethod     string `json:"proration_method"`
+
+	UpdatedBy *string `json:"updated_by,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+}
+
+type ComponentTemplateRequest struct {
+	Code            string `json:"code" binding:"required"`
+	Name            string `json:"name" binding:"required"`
+	ComponentType   string `json:"component_type" binding:"required"`   // ALLOWANCE atau DEDUCTION
+	CalculationType string `json:"calculation_type" binding:"required"` // FIXED, PERCENT_OF_BASE atau PER_ATTENDANCE_DAY
+	Amount          int64  `json:"amount"`
+	RateBps         int64  `json:"rate_bps"`
+	IsActive        *bool  `json:"is_active"` // Opsional, default true
+	Prorate         bool   `json:"prorate"`   // Prorata sesuai masa kerja karyawan baru/keluar
+}
+
+type ComponentTemplateResponse struct {
+	ID              string  `json:"id"`
+	CompanyID       string  `json:"company_id"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	ComponentType   string  `json:"component_type"`
+	CalculationType string  `json:"calculation_type"`
+	Amount          int64   `json:"amount"`
+	RateBps         int64   `json:"rate_bps"`
+	IsActive        bool    `json:"is_active"`
+	Prorate         bool    `json:"prorate"`
+	CreatedBy       string  `json:"created_by"`
+	UpdatedBy       *string `json:"updated_by,omitempty"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+type CreateComponentAssignmentRequest struct {
+	EmployeeID    string  `json:"employee_id" binding:"required,uuid"`
+	TemplateID    string  `json:"template_id" binding:"required,uuid"`
+	Amount        *int64  `json:"amount"`   // Opsional: override nominal template
+	RateBps       *int64  `json:"rate_bps"` // Opsional: override tarif template
+	EffectiveFrom string  `json:"effective_from" binding:"required"`
+	EffectiveTo   string  `json:"effective_to"` // Opsional (YYYY-MM-DD), kosong = tanpa batas
+	Notes         *string `json:"notes"`
+}
+
+type UpdateComponentAssignmentRequest struct {
+	Amount        *int64  `json:"amount"`
+	RateBps       *int64  `json:"rate_bps"`
+	EffectiveFrom string  `json:"effective_from" binding:"required"`
+	EffectiveTo   string  `json:"effective_to"`
+	Notes         *string `json:"notes"`
+}
+
+type GetComponentAssignmentsRequest struct {
+	EmployeeID string `form:"employee_id" binding:"required,uuid"`
+}
+
+type ComponentAssignmentResponse struct {
+	ID            string                    `json:"id"`
+	EmployeeID    string                    `json:"employee_id"`
+	EmployeeName  string                    `json:"employee_name"`
+	Template      ComponentTemplateResponse `json:"template"`
+	Amount        *int64                    `json:"amount,omitempty"`
+	RateBps       *int64                    `json:"rate_bps,omitempty"`
+	EffectiveFrom string                    `json:"effective_from"`
+	EffectiveTo   *string                   `json:"effective_to,omitempty"`
+	Notes         *string                   `json:"notes,omitempty"`
+	CreatedBy     string                    `json:"created_by"`
+	CreatedAt     string                    `json:"created_at"`
+}
+
+type CreateLoanRequest struct {
+	EmployeeID       string  `json:"employee_id" binding:"required,uuid"`
+	LoanType         string  `json:"loan_type"` // Opsional: LOAN (default) atau SALARY_ADVANCE
+	Principal        int64   `json:"principal" binding:"required"`
+	InstallmentCount int64   `json:"installment_count"`               // Opsional, default 1
+	StartPeriod      string  `json:"start_period" binding:"required"` // YYYY-MM, bulan cicilan pertama dipotong
+	Notes            *string `json:"notes"`
+}
+
+type PayoffLoanRequest struct {
+	PaidOn string  `json:"paid_on"` // Opsional (YYYY-MM-DD), default hari ini
+	Notes  *string `json:"notes"`
+}
+
+type GetLoansFilterRequest struct {
+	EmployeeID string `form:"employee_id"`
+	Status     string `form:"status"`
+}
+
+type LoanQueryFilter struct {
+	EmployeeID *string
+	Status     *string
+}
+
+type LoanResponse struct {
+	ID                 string                  `json:"id"`
+	CompanyID          string                  `json:"company_id"`
+	EmployeeID         string                  `json:"employee_id"`
+	EmployeeName       string                  `json:"employee_name"`
+	LoanType           string                  `json:"loan_type"`
+	Principal          int64                   `json:"principal"`
+	InstallmentCount   int64                   `json:"installment_count"`
+	InstallmentAmount  int64                   `json:"installment_amount"`
+	StartPeriod        string                  `json:"start_period"`
+	OutstandingBalance int64                   `json:"outstanding_balance"`
+	PaidInstallments   int64                   `json:"paid_installments"`
+	Status             string                  `json:"status"`
+	Notes              *string                 `json:"notes,omitempty"`
+	PaidOffAt          *string                 `json:"paid_off_at,omitempty"`
+	Repayments         []LoanRepaymentResponse `json:"repayments,omitempty"`
+	CreatedBy          string                  `json:"created_by"`
+	CreatedAt          string                  `json:"created_at"`
+	UpdatedAt          string                  `json:"updated_at"`
+}
+
+type LoanRepaymentResponse struct {
+	ID            string  `json:"id"`
+	PayrollID     *string `json:"payroll_id,omitempty"`
+	RepaymentType string  `json:"repayment_type"`
+	Amount        int64   `json:"amount"`
+	BalanceAfter  int64   `json:"balance_after"`
+	PaidOn        string  `json:"paid_on"`
+	Notes         *string `json:"notes,omitempty"`
+	ReversedAt    *string `json:"reversed_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type PayslipDownloadResponse struct {
+	URL       string `json:"url"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type BPJSReportFilterRequest struct {
+	Period string `form:"period" binding:"required"`
+}
+
+type BPJSProgramAmount struct {
+	Employee int64 `json:"employee"`
+	Employer int64 `json:"employer"`
+}
+
+type BPJSReportRow struct {
+	EmployeeID    string                       `json:"employee_id"`
+	EmployeeName  string                       `json:"employee_name"`
+	PayrollID     string                       `json:"payroll_id"`
+	PayrollStatus string                       `json:"payroll_status"`
+	Wage          int64                        `json:"wage"`
+	Programs      map[string]BPJSProgramAmount `json:"programs"`
+	EmployeeTotal int64                        `json:"employee_total"`
+	EmployerTotal int64                        `json:"employer_total"`
+}
+
+type BPJSReportResponse struct {
+	CompanyID     string                       `json:"company_id"`
+	PeriodStart   string                       `json:"period_start"`
+	PeriodEnd     string                       `json:"period_end"`
+	Rows          []BPJSReportRow              `json:"rows"`
+	Programs      map[string]BPJSProgramAmount `json:"programs"`
+	EmployeeTotal int64                        `json:"employee_total"`
+	EmployerTotal int64                        `json:"employer_total"`
+}
+
+// PayrollVarianceFilterRequest membandingkan payroll satu periode (period atau run_id) dengan
+// periode pembanding (default bulan sebelumnya).
+type PayrollVarianceFilterRequest struct {
+	Period          string `form:"period"` // YYYY-MM
+	RunID           string `form:"run_id" binding:"omitempty,uuid"`
+	ComparePeriod   string `form:"compare_period"` // YYYY-MM, default bulan sebelum period
+	DepartmentID    string `form:"department_id" binding:"omitempty,uuid"`
+	PayrollType     string `form:"payroll_type"`     // Default REGULAR
+	ThresholdBps    *int64 `form:"threshold_bps"`    // Perubahan net salary yang ditandai (100 = 1%), default 1000
+	ThresholdAmount int64  `form:"threshold_amount"` // Opsional: perubahan nominal yang juga ditandai
+}
+
+type PayrollVarianceComponentRow struct {
+	ComponentType  string `json:"component_type"` // BASE_SALARY, OVERTIME, ALLOWANCE, DEDUCTION, EMPLOYER_CONTRIBUTION
+	ComponentName  string `json:"component_name"`
+	PreviousAmount int64  `json:"previous_amount"`
+	CurrentAmount  int64  `json:"current_amount"`
+	Change         int64  `json:"change"`
+	IsNew          bool   `json:"is_new"`
+	IsRemoved      bool   `json:"is_removed"`
+}
+
+type PayrollVarianceEmployeeRow struct {
+	EmployeeID   string `json:"employee_id"`
+	EmployeeName string `json:"employee_name"`
+	Status       string `json:"status"` // NEW, MISSING atau EXISTING
+	PreviousNet  int64  `json:"previous_net"`
+	CurrentNet   int64  `json:"current_net"`
+	NetChange    int64  `json:"net_change"`
+	NetChangeBps *int64 `json:"net_change_bps,omitempty"` // Kosong jika net periode pembanding 0
+	Flagged      bool   `json:"flagged"`
+
+	// Components hanya berisi komponen yang nilainya berubah.
+	Components []PayrollVarianceComponentRow `json:"components"`
+}
+
+type PayrollVarianceTotals struct {
+	PreviousEmployees    int   `json:"previous_employees"`
+	CurrentEmployees     int   `json:"current_employees"`
+	PreviousNet          int64 `json:"previous_net"`
+	CurrentNet           int64 `json:"current_net"`
+	NetChange            int64 `json:"net_change"`
+	PreviousEmployerCost int64 `json:"previous_employer_cost"`
+	CurrentEmployerCost  int64 `json:"current_employer_cost"`
+}
+
+type PayrollVarianceEmployeeRef struct {
+	EmployeeID   string `json:"employee_id"`
+	EmployeeName string `json:"employee_name"`
+	NetSalary    int64  `json:"net_salary"`
+}
+
+type PayrollVarianceComponentRef struct {
+	ComponentType string `json:"component_type"`
+	ComponentName string `json:"component_name"`
+}
+
+type PayrollVarianceResponse struct {
+	CompanyID          string                        `json:"company_id"`
+	RunID              *string                       `json:"run_id,omitempty"`
+	PayrollType        string                        `json:"payroll_type"`
+	PeriodStart        string                        `json:"period_start"`
+	PeriodEnd          string                        `json:"period_end"`
+	ComparePeriodStart string                        `json:"compare_period_start"`
+	ComparePeriodEnd   string                        `json:"compare_period_end"`
+	ThresholdBps       int64                         `json:"threshold_bps"`
+	ThresholdAmount    int64                         `json:"threshold_amount"`
+	Totals             PayrollVarianceTotals         `json:"totals"`
+	FlaggedCount       int                           `json:"flagged_count"`
+	Employees          []PayrollVarianceEmployeeRow  `json:"employees"`
+	Components         []PayrollVarianceComponentRow `json:"components"`
+	NewEmployees       []PayrollVarianceEmployeeRef  `json:"new_employees"`
+	MissingEmployees   []PayrollVarianceEmployeeRef  `json:"missing_employees"`
+	NewComponents      []PayrollVarianceComponentRef `json:"new_components"`
+}
+
+type BankExportRequest struct {
+	Format        string   `json:"format" binding:"required"` // BCA, MANDIRI, BNI atau PAIN001
+	PayrollIDs    []string `json:"payroll_ids" binding:"required,min=1,dive,uuid"`
+	ExecutionDate string   `json:"execution_date"` // Opsional (YYYY-MM-DD), default hari ini
+	Reexport      bool     `json:"reexport"`       // Wajib true untuk mengekspor ulang payroll yang sudah ada di file sebelumnya
+}
+
+// BankExportFile adalah file transfer gaji yang siap diunggah ke internet banking.
+type BankExportFile struct {
+	BatchID       string
+	FileName      string
+	ContentType   string
+	Content       []byte
+	TransferCount int
+	TotalAmount   int64
+}
+
+type BankResultItemResponse struct {
+	Reference     string  `json:"reference"`
+	PayrollID     *string `json:"payroll_id,omitempty"`
+	Result        string  `json:"result"` // PAID, FAILED atau SKIPPED
+	BankStatus    string  `json:"bank_status"`
+	BankReference string  `json:"bank_reference,omitempty"`
+	Message       string  `json:"message,omitempty"`
+}
+
+type BankResultResponse struct {
+	TotalRows    int                      `json:"total_rows"`
+	PaidCount    int                      `json:"paid_count"`
+	FailedCount  int                      `json:"failed_count"`
+	SkippedCount int                      `json:"skipped_count"`
+	Items        []BankResultItemResponse `json:"items"`
+}
+
+type AccountMappingRequest struct {
+	ComponentType     string  `json:"component_type" binding:"required"` // BASE_SALARY, OVERTIME, ALLOWANCE, DEDUCTION, EMPLOYER_CONTRIBUTION, NET_PAYABLE
+	SourceType        *string `json:"source_type"`                       // Opsional, mis. TAX, BPJS, LOAN, LEAVE, THR, COMPONENT_TEMPLATE
+	ComponentName     *string `json:"component_name"`                    // Opsional, cocok nama komponen tanpa membedakan huruf besar/kecil
+	AccountCode       string  `json:"account_code" binding:"required"`
+	AccountName       string  `json:"account_name" binding:"required"`
+	ContraAccountCode *string `json:"contra_account_code"` // Wajib untuk EMPLOYER_CONTRIBUTION (akun utang iuran)
+	ContraAccountName *string `json:"contra_account_name"`
+	SplitByCostCenter bool    `json:"split_by_cost_center"`
+}
+
+// UpdateAccountMappingsRequest mengganti seluruh mapping akun company. Daftar kosong
+// mengembalikan company ke bagan akun bawaan.
+type UpdateAccountMappingsRequest struct {
+	Mappings []AccountMappingRequest `json:"mappings" binding:"dive"`
+}
+
+type AccountMappingResponse struct {
+	ID                *string `json:"id,omitempty"`
+	ComponentType     string  `json:"component_type"`
+	SourceType        *string `json:"source_type,omitempty"`
+	ComponentName     *string `json:"component_name,omitempty"`
+	AccountCode       string  `json:"account_code"`
+	AccountName       string  `json:"account_name"`
+	ContraAccountCode *string `json:"contra_account_code,omitempty"`
+	ContraAccountName *string `json:"contra_account_name,omitempty"`
+	SplitByCostCenter bool    `json:"split_by_cost_center"`
+	IsDefault         bool    `json:"is_default"`
+}
+
+// JournalExportRequest memilih payroll yang dijurnal: satu payroll run APPROVED/PAID
+// (run_id) atau seluruh payroll yang sudah di-approve dalam satu bulan (period).
+type JournalExportRequest struct {
+	RunID       string `json:"run_id" binding:"omitempty,uuid"`
+	Period      string `json:"period"`                    // YYYY-MM
+	Format      string `json:"format" binding:"required"` // CSV, ACCURATE atau JURNAL
+	PostingDate string `json:"posting_date"`              // Opsional (YYYY-MM-DD), default akhir periode
+}
+
+type JournalQueryFilter struct {
+	RunID       *string
+	PeriodStart *string
+	PeriodEnd   *string
+	Statuses    []string
+}
+
+// JournalExportFile adalah jurnal payroll seimbang yang siap diimpor ke aplikasi akuntansi.
+type JournalExportFile struct {
+	FileName     string
+	ContentType  string
+	Content      []byte
+	PayrollCount int
+	LineCount    int
+	TotalDebit   int64
 }
@@ -10,12 +10,18 @@ import (
 type Payroll struct {
 	ID         uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	CompanyID  uuid.UUID      `gorm:"type:uuid;not null;index:idx_company_status"`
-	EmployeeID uuid.UUID      `gorm:"type:uuid;not null;index:idx_employee_period,unique"`
+	EmployeeID uuid.UUID      `gorm:"type:uuid;not null;index:idx_employee_period"`
 	Employee   *LeaveEmployee `gorm:"foreignKey:EmployeeID;references:ID"`
+	RunID      *uuid.UUID     `gorm:"type:uuid;index"` // Terisi jika payroll dibuat lewat payroll run
+
+	// Jenis payroll: REGULAR, atau off-cycle THR, BONUS, CORRECTION yang boleh berdampingan
+	// dengan payroll REGULAR di periode yang sama. ReferenceDate adalah tanggal hari raya (THR).
+	PayrollType   string     `gorm:"type:varchar(20);not null;default:'REGULAR'"`
+	ReferenceDate *time.Time `gorm:"type:date"`
 
 	// Periode
-	PeriodStart time.Time `gorm:"type:date;not null;index:idx_employee_period,unique"`
-	PeriodEnd   time.Time `gorm:"type:date;not null;index:idx_employee_period,unique"`
+	PeriodStart time.Time `gorm:"type:date;not null;index:idx_employee_period"`
+	PeriodEnd   time.Time `gorm:"type:date;not null;index:idx_employee_period"`
 
 	// Financials disimpan dalam satuan terkecil (mis: sen) untuk hindari floating error.
 	BaseSalary     int64 `gorm:"type:bigint;not null;default:0"`
@@ -26,6 +32,40 @@ type Payroll struct {
 	Deduction      int64 `gorm:"type:bigint;not null;default:0"`
 	NetSalary      int64 `gorm:"type:bigint;not null;default:0"`
 
+	// Dasar dan hasil pajak penghasilan, diakumulasi untuk perhitungan year-to-date.
+	// TaxAmount negatif berarti lebih bayar yang dikembalikan (true-up akhir tahun).
+	GrossIncome int64 `gorm:"type:bigint;not null;default:0"`
+	TaxAmount   int64 `gorm:"type:bigint;not null;default:0"`
+
+	// PensionContribution adalah iuran JHT + JP karyawan, pengurang penghasilan neto PPh 21.
+	// EmployerCost adalah total beban perusahaan (iuran BPJS pemberi kerja), di luar net salary.
+	PensionContribution int64 `gorm:"type:bigint;not null;default:0"`
+	EmployerCost        int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Asal gaji pokok: diturunkan dari employee_salaries atau override manual oleh HR.
+	BaseSalaryOverride bool    `gorm:"not null;default:false"`
+	DerivedBaseSalary  *int64  `gorm:"type:bigint"` // Nilai hasil derivasi sistem, disimpan juga saat override untuk audit
+	BaseSalaryNote     *string `gorm:"type:text"`   // Rincian prorata jika ada kenaikan gaji di tengah periode
+
+	// Prorata masa kerja untuk karyawan yang masuk/keluar di tengah periode.
+	// ProrationMethod kosong berarti karyawan aktif sepanjang periode.
+	ProrationMethod   *string `gorm:"type:varchar(20)"`
+	ProratedDays      int64   `gorm:"type:bigint;not null;default:0"`
+	ProrationBaseDays int64   `gorm:"type:bigint;not null;default:0"`
+
+	// IsStale ditandai saat data sumber (mis. cuti) berubah setelah payroll DRAFT dibuat.
+	// Payroll stale harus di-regenerate sebelum bisa di-approve.
+	IsStale     bool    `gorm:"not null;default:false"`
+	StaleReason *string `gorm:"type:text"`
+
+	// Pembatalan & reversal. Payroll APPROVED bisa dibatalkan (CANCELLED), payroll PAID
+	// dibalik (REVERSED) dengan membuat payroll penyesuaian bernilai negatif.
+	ReversalOfID *uuid.UUID `gorm:"type:uuid;index"` // Terisi pada payroll penyesuaian, menunjuk payroll yang dibalik
+	ReversedByID *uuid.UUID `gorm:"type:uuid"`       // Terisi pada payroll asli, menunjuk payroll penyesuaiannya
+	CancelReason *string    `gorm:"type:text"`
+	CancelledBy  *uuid.UUID `gorm:"type:uuid"`
+	CancelledAt  *time.Time
+
 	// Workflow & Audit
 	Status     string     `gorm:"type:varchar(20);not null;default:'DRAFT';index:idx_company_status"`
 	CreatedBy  uuid.UUID  `gorm:"type:uuid;not null"`
@@ -34,18 +74,27 @@ type Payroll struct {
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 	PaidAt             *time.Time `gorm:"index"`
+	PaymentReference   *string    // Referensi transaksi dari file hasil transfer bank
+	BankExportBatchID  *string    `gorm:"type:varchar(50);index"` // Batch file bulk transfer terakhir yang memuat payroll ini
+	BankExportedAt     *time.Time
 	ApprovedAt         *time.Time `gorm:"index"`
 	PayslipURL         *string
 	PayslipGeneratedAt *time.Time     `gorm:"index"`
 	DeletedAt          gorm.DeletedAt `gorm:"index"` // Aktifkan Soft Delete jika perlu
 
 	Components []PayrollComponent `gorm:"foreignKey:PayrollID"`
+	Approvals  []PayrollApproval  `gorm:"foreignKey:PayrollID"` // Langkah approval yang sudah dilalui
 
 	// Belongs To Relationships (Optional, untuk Eager Loading)
 	// Company  Company  `gorm:"foreignKey:CompanyID"`
 	// Employee Employee `gorm:"foreignKey:EmployeeID"`
 }
 
+// IsOffCycle bernilai true untuk payroll THR, BONUS, dan CORRECTION.
+func (p Payroll) IsOffCycle() bool {
+	return p.PayrollType != "" && p.PayrollType != PayrollTypeRegular
+}
+
 type PayrollComponent struct {
 	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	PayrollID     uuid.UUID `gorm:"type:uuid;not null;index"`
@@ -56,8 +105,16 @@ type PayrollComponent struct {
 	UnitAmount    int64     `gorm:"type:bigint;not null;default:0"`
 	TotalAmount   int64     `gorm:"type:bigint;not null;default:0"`
 	Notes         *string   `gorm:"type:text"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+
+	// Asal komponen yang dihasilkan otomatis (mis. LEAVE + id cuti). Kosong untuk input manual.
+	SourceType *string    `gorm:"type:varchar(30)"`
+	SourceID   *uuid.UUID `gorm:"type:uuid;index"`
+
+	// Metadata menyimpan input perhitungan (mis. pajak) dalam JSON untuk audit.
+	Metadata *string `gorm:"type:jsonb"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type LeaveEmployee struct {
@@ -1,10 +1,14 @@
 package payroll
 
 import (
+	"context"
 	"encoding/json"
-	payrollerrors "go-hris/internal/payroll/errors"
+	"errors"
+	"fmt"
+	"go-hris/internal/middleware"
 	"go-hris/internal/shared/apperror"
 	"go-hris/internal/shared/response"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -84,33 +88,14 @@ func (h *Handler) GetAll(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.service.GetAll(ctx, companyID, filterReq)
+	resp, total, err := h.service.GetAll(ctx, companyID, filterReq)
 	if err != nil {
 		h.writeServiceError(c, err)
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	total := int64(len(resp))
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > len(resp) {
-		start = len(resp)
-	}
-	if end > len(resp) {
-		end = len(resp)
-	}
-
-	meta := response.NewPaginationMeta(total, page, pageSize)
-	response.Success(c, http.StatusOK, resp[start:end], &meta)
+	meta := filterReq.Meta(total)
+	response.Success(c, http.StatusOK, resp, &meta)
 }
 
 func (h *Handler) GetById(c *gin.Context) {
@@ -141,22 +126,40 @@ func (h *Handler) GetBreakdown(c *gin.Context) {
 	response.Success(c, http.StatusOK, resp, nil)
 }
 
-func (h *Handler) DownloadPayslip(c *gin.Context) {
+func (h *Handler) Simulate(c *gin.Context) {
 	ctx := c.Request.Context()
-	targetID := c.Param("id")
 	companyID := c.GetString("company_id")
 
-	resp, err := h.service.GetByID(ctx, companyID, targetID)
+	var req SimulatePayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.Simulate(ctx, companyID, req)
 	if err != nil {
 		h.writeServiceError(c, err)
 		return
 	}
-	if resp.PayslipURL == nil || *resp.PayslipURL == "" {
-		h.writeServiceError(c, payrollerrors.ErrPayslipNotGenerated)
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) DownloadPayslip(c *gin.Context) {
+	ctx := c.Request.Context()
+	targetID := c.Param("id")
+	companyID := c.GetString("company_id")
+	// payroll:read juga dimiliki role Employee, jadi payslip orang lain butuh payroll:read_all
+	canReadAll := middleware.HasPermission(c, "payroll", "read_all")
+
+	download, err := h.service.GetPayslipDownload(ctx, companyID, c.GetString("employee_id"), targetID, canReadAll)
+	if err != nil {
+		h.writeServiceError(c, err)
 		return
 	}
 
-	c.Redirect(http.StatusTemporaryRedirect, *resp.PayslipURL)
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusTemporaryRedirect, download.URL)
 }
 
 func (h *Handler) Regenerate(c *gin.Context) {
@@ -186,7 +189,33 @@ func (h *Handler) Approve(c *gin.Context) {
 	companyID := c.GetString("company_id")
 	actorID := getActorID(c)
 
-	resp, err := h.service.Approve(ctx, companyID, actorID, id)
+	req, ok := bindApproveRequest(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.Approve(ctx, companyID, actorID, c.GetString("role"), id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) Cancel(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req CancelPayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.Cancel(ctx, companyID, actorID, id, req)
 	if err != nil {
 		h.writeServiceError(c, err)
 		return
@@ -195,6 +224,27 @@ func (h *Handler) Approve(c *gin.Context) {
 	response.Success(c, http.StatusOK, resp, nil)
 }
 
+func (h *Handler) Reverse(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req CancelPayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.Reverse(ctx, companyID, actorID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, resp, nil)
+}
+
 func (h *Handler) MarkAsPaid(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
@@ -222,3 +272,602 @@ func (h *Handler) Delete(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, gin.H{"deleted": true}, nil)
 }
+
+func (h *Handler) CreateRun(c *gin.Context) {
+	lockKey, _ := c.Get("idempotency_lock_key")
+	cacheKey, _ := c.Get("idempotency_cache_key")
+
+	if h.rdb != nil {
+		if lk, ok := lockKey.(string); ok && lk != "" {
+			defer h.rdb.Del(c.Request.Context(), lk)
+		}
+	}
+
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req CreatePayrollRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.CreateRun(c.Request.Context(), companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	if h.rdb != nil {
+		if ck, ok := cacheKey.(string); ok && ck != "" {
+			if payload, marshalErr := json.Marshal(resp); marshalErr == nil {
+				_ = h.rdb.Set(c.Request.Context(), ck, payload, 24*time.Hour).Err()
+			}
+		}
+	}
+
+	response.Success(c, http.StatusCreated, resp, nil)
+}
+
+func (h *Handler) GetRuns(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetRuns(ctx, companyID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetRunById(c *gin.Context) {
+	ctx := c.Request.Context()
+	targetID := c.Param("id")
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetRunByID(ctx, companyID, targetID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) ApproveRun(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	req, ok := bindApproveRequest(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.ApproveRun(ctx, companyID, actorID, c.GetString("role"), id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+// bindApproveRequest membaca body approve yang opsional (hanya berisi comment).
+func bindApproveRequest(c *gin.Context) (ApprovePayrollRequest, bool) {
+	var req ApprovePayrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return req, false
+	}
+	return req, true
+}
+
+func (h *Handler) MarkRunAsPaid(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	resp, err := h.service.MarkRunAsPaid(ctx, companyID, actorID, id)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetPeriods(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req GetPayrollPeriodsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.GetPeriods(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetPeriod(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetPeriod(ctx, companyID, c.Param("period"))
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) ProcessPeriod(c *gin.Context) {
+	h.transitionPeriod(c, h.service.ProcessPeriod)
+}
+
+func (h *Handler) ClosePeriod(c *gin.Context) {
+	h.transitionPeriod(c, h.service.ClosePeriod)
+}
+
+func (h *Handler) ReopenPeriod(c *gin.Context) {
+	h.transitionPeriod(c, h.service.ReopenPeriod)
+}
+
+// transitionPeriod menangani endpoint perubahan status periode; body (reason) opsional
+// kecuali untuk reopen yang divalidasi di service.
+func (h *Handler) transitionPeriod(
+	c *gin.Context,
+	fn func(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error),
+) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req PayrollPeriodActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := fn(ctx, companyID, actorID, c.Param("period"), req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetSetting(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetSetting(ctx, companyID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) UpdateSetting(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req UpdatePayrollSettingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.UpdateSetting(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetComponentTemplates(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetComponentTemplates(ctx, companyID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) CreateComponentTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req ComponentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.CreateComponentTemplate(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, resp, nil)
+}
+
+func (h *Handler) UpdateComponentTemplate(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req ComponentTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.UpdateComponentTemplate(ctx, companyID, actorID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetComponentAssignments(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req GetComponentAssignmentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.GetComponentAssignments(ctx, companyID, req.EmployeeID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) CreateComponentAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req CreateComponentAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.CreateComponentAssignment(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, resp, nil)
+}
+
+func (h *Handler) UpdateComponentAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+
+	var req UpdateComponentAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.UpdateComponentAssignment(ctx, companyID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) DeleteComponentAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+
+	if err := h.service.DeleteComponentAssignment(ctx, companyID, id); err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"deleted": true}, nil)
+}
+
+func (h *Handler) GetLoans(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req GetLoansFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.GetLoans(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetLoanById(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetLoanByID(ctx, companyID, id)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) CreateLoan(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req CreateLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.CreateLoan(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, resp, nil)
+}
+
+func (h *Handler) PayoffLoan(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req PayoffLoanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.PayoffLoan(ctx, companyID, actorID, id, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetBPJSReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req BPJSReportFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.GetBPJSReport(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetVarianceReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req PayrollVarianceFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.GetVarianceReport(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) ExportBankTransfer(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req BankExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	file, err := h.service.ExportBankTransfer(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	c.Header("X-Transfer-Batch-ID", file.BatchID)
+	c.Header("X-Transfer-Count", strconv.Itoa(file.TransferCount))
+	c.Header("X-Transfer-Total", strconv.FormatInt(file.TotalAmount, 10))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (h *Handler) GetApprovalChain(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetApprovalChain(ctx, companyID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) UpdateApprovalChain(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req UpdateApprovalChainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.UpdateApprovalChain(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) GetAccountMappings(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	resp, err := h.service.GetAccountMappings(ctx, companyID)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) UpdateAccountMappings(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	var req UpdateAccountMappingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.UpdateAccountMappings(ctx, companyID, actorID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
+
+func (h *Handler) ExportJournal(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+
+	var req JournalExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	file, err := h.service.ExportJournal(ctx, companyID, req)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file.FileName))
+	c.Header("X-Journal-Payroll-Count", strconv.Itoa(file.PayrollCount))
+	c.Header("X-Journal-Line-Count", strconv.Itoa(file.LineCount))
+	c.Header("X-Journal-Total", strconv.FormatInt(file.TotalDebit, 10))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// maxBankResultFileSize membatasi ukuran file hasil transfer yang diunggah (5 MB).
+const maxBankResultFileSize = 5 << 20
+
+func (h *Handler) ImportBankResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	companyID := c.GetString("company_id")
+	actorID := getActorID(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+	if fileHeader.Size > maxBankResultFileSize {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", "file too large")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxBankResultFileSize))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
+		return
+	}
+
+	resp, err := h.service.ImportBankResult(ctx, companyID, actorID, content)
+	if err != nil {
+		h.writeServiceError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp, nil)
+}
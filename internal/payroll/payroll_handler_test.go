@@ -9,6 +9,8 @@ import (
 	"strings"
 	"testing"
 
+	"go-hris/internal/domain"
+	"go-hris/internal/middleware"
 	"go-hris/internal/payroll"
 	payrollerrors "go-hris/internal/payroll/errors"
 
@@ -38,21 +40,57 @@ func mustDecodeEnvelope(t *testing.T, body []byte) apiEnvelope {
 
 type fakePayrollService struct {
 	createFn          func(ctx context.Context, companyID, actorID string, req payroll.CreatePayrollRequest) (payroll.PayrollResponse, error)
-	getAllFn          func(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, error)
+	getAllFn          func(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, int64, error)
 	getByIDFn         func(ctx context.Context, companyID, id string) (payroll.PayrollResponse, error)
 	getBreakdownFn    func(ctx context.Context, companyID, id string) (payroll.PayrollBreakdownResponse, error)
 	regenerateFn      func(ctx context.Context, companyID, actorID, id string, req payroll.RegeneratePayrollRequest) (payroll.PayrollResponse, error)
-	approveFn         func(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error)
+	approveFn         func(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollResponse, error)
 	markPaidFn        func(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error)
 	generatePayslipFn func(ctx context.Context, companyID, id string) (payroll.PayrollResponse, error)
+	getPayslipFn      func(ctx context.Context, companyID, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error)
 	deleteFn          func(ctx context.Context, companyID, id string) error
+	cancelFn          func(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error)
+	reverseFn         func(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error)
+	createRunFn       func(ctx context.Context, companyID, actorID string, req payroll.CreatePayrollRunRequest) (payroll.PayrollRunResponse, error)
+	getRunsFn         func(ctx context.Context, companyID string) ([]payroll.PayrollRunResponse, error)
+	getRunByIDFn      func(ctx context.Context, companyID, id string) (payroll.PayrollRunResponse, error)
+	approveRunFn      func(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollRunResponse, error)
+	markRunPaidFn     func(ctx context.Context, companyID, actorID, id string) (payroll.PayrollRunResponse, error)
+	getSettingFn      func(ctx context.Context, companyID string) (payroll.PayrollSettingResponse, error)
+	updateSettingFn   func(ctx context.Context, companyID, actorID string, req payroll.UpdatePayrollSettingRequest) (payroll.PayrollSettingResponse, error)
+	getTemplatesFn    func(ctx context.Context, companyID string) ([]payroll.ComponentTemplateResponse, error)
+	createTemplateFn  func(ctx context.Context, companyID, actorID string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error)
+	updateTemplateFn  func(ctx context.Context, companyID, actorID, id string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error)
+	getAssignmentsFn  func(ctx context.Context, companyID, employeeID string) ([]payroll.ComponentAssignmentResponse, error)
+	createAssignFn    func(ctx context.Context, companyID, actorID string, req payroll.CreateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error)
+	updateAssignFn    func(ctx context.Context, companyID, id string, req payroll.UpdateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error)
+	deleteAssignFn    func(ctx context.Context, companyID, id string) error
+	getLoansFn        func(ctx context.Context, companyID string, req payroll.GetLoansFilterRequest) ([]payroll.LoanResponse, error)
+	getLoanFn         func(ctx context.Context, companyID, id string) (payroll.LoanResponse, error)
+	createLoanFn      func(ctx context.Context, companyID, actorID string, req payroll.CreateLoanRequest) (payroll.LoanResponse, error)
+	payoffLoanFn      func(ctx context.Context, companyID, actorID, id string, req payroll.PayoffLoanRequest) (payroll.LoanResponse, error)
+	getBPJSReportFn   func(ctx context.Context, companyID string, req payroll.BPJSReportFilterRequest) (payroll.BPJSReportResponse, error)
+	getVarianceFn     func(ctx context.Context, companyID string, req payroll.PayrollVarianceFilterRequest) (payroll.PayrollVarianceResponse, error)
+	exportBankFn      func(ctx context.Context, companyID string, req payroll.BankExportRequest) (payroll.BankExportFile, error)
+	importBankFn      func(ctx context.Context, companyID, actorID string, content []byte) (payroll.BankResultResponse, error)
+	getPeriodsFn      func(ctx context.Context, companyID string, req payroll.GetPayrollPeriodsRequest) ([]payroll.PayrollPeriodResponse, error)
+	getPeriodFn       func(ctx context.Context, companyID, period string) (payroll.PayrollPeriodResponse, error)
+	processPeriodFn   func(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error)
+	closePeriodFn     func(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error)
+	reopenPeriodFn    func(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error)
+	getChainFn        func(ctx context.Context, companyID string) (payroll.ApprovalChainResponse, error)
+	updateChainFn     func(ctx context.Context, companyID, actorID string, req payroll.UpdateApprovalChainRequest) (payroll.ApprovalChainResponse, error)
+	getMappingsFn     func(ctx context.Context, companyID string) ([]payroll.AccountMappingResponse, error)
+	updateMappingsFn  func(ctx context.Context, companyID, actorID string, req payroll.UpdateAccountMappingsRequest) ([]payroll.AccountMappingResponse, error)
+	exportJournalFn   func(ctx context.Context, companyID string, req payroll.JournalExportRequest) (payroll.JournalExportFile, error)
+	simulateFn        func(ctx context.Context, companyID string, req payroll.SimulatePayrollRequest) (payroll.PayrollSimulationResponse, error)
 }
 
 func (f *fakePayrollService) Create(ctx context.Context, companyID, actorID string, req payroll.CreatePayrollRequest) (payroll.PayrollResponse, error) {
 	return f.createFn(ctx, companyID, actorID, req)
 }
 
-func (f *fakePayrollService) GetAll(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, error) {
+func (f *fakePayrollService) GetAll(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, int64, error) {
 	return f.getAllFn(ctx, companyID, filter)
 }
 
@@ -67,8 +105,8 @@ func (f *fakePayrollService) Regenerate(ctx context.Context, companyID, actorID,
 	return f.regenerateFn(ctx, companyID, actorID, id, req)
 }
 
-func (f *fakePayrollService) Approve(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error) {
-	return f.approveFn(ctx, companyID, actorID, id)
+func (f *fakePayrollService) Approve(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollResponse, error) {
+	return f.approveFn(ctx, companyID, actorID, role, id, req)
 }
 
 func (f *fakePayrollService) MarkAsPaid(ctx context.Context, companyID, actorID, id string) (payroll.PayrollResponse, error) {
@@ -77,11 +115,154 @@ func (f *fakePayrollService) MarkAsPaid(ctx context.Context, companyID, actorID,
 func (f *fakePayrollService) GeneratePayslip(ctx context.Context, companyID, id string) (payroll.PayrollResponse, error) {
 	return f.generatePayslipFn(ctx, companyID, id)
 }
+func (f *fakePayrollService) GetPayslipDownload(ctx context.Context, companyID, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error) {
+	return f.getPayslipFn(ctx, companyID, requesterEmployeeID, id, canReadAll)
+}
 
 func (f *fakePayrollService) Delete(ctx context.Context, companyID, id string) error {
 	return f.deleteFn(ctx, companyID, id)
 }
 
+func (f *fakePayrollService) Cancel(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error) {
+	return f.cancelFn(ctx, companyID, actorID, id, req)
+}
+
+func (f *fakePayrollService) Reverse(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error) {
+	return f.reverseFn(ctx, companyID, actorID, id, req)
+}
+
+func (f *fakePayrollService) CreateRun(ctx context.Context, companyID, actorID string, req payroll.CreatePayrollRunRequest) (payroll.PayrollRunResponse, error) {
+	return f.createRunFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) GetRuns(ctx context.Context, companyID string) ([]payroll.PayrollRunResponse, error) {
+	return f.getRunsFn(ctx, companyID)
+}
+
+func (f *fakePayrollService) GetRunByID(ctx context.Context, companyID, id string) (payroll.PayrollRunResponse, error) {
+	return f.getRunByIDFn(ctx, companyID, id)
+}
+
+func (f *fakePayrollService) ApproveRun(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollRunResponse, error) {
+	return f.approveRunFn(ctx, companyID, actorID, role, id, req)
+}
+
+func (f *fakePayrollService) MarkRunAsPaid(ctx context.Context, companyID, actorID, id string) (payroll.PayrollRunResponse, error) {
+	return f.markRunPaidFn(ctx, companyID, actorID, id)
+}
+
+func (f *fakePayrollService) GetSetting(ctx context.Context, companyID string) (payroll.PayrollSettingResponse, error) {
+	return f.getSettingFn(ctx, companyID)
+}
+
+func (f *fakePayrollService) UpdateSetting(ctx context.Context, companyID, actorID string, req payroll.UpdatePayrollSettingRequest) (payroll.PayrollSettingResponse, error) {
+	return f.updateSettingFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) GetComponentTemplates(ctx context.Context, companyID string) ([]payroll.ComponentTemplateResponse, error) {
+	return f.getTemplatesFn(ctx, companyID)
+}
+
+func (f *fakePayrollService) CreateComponentTemplate(ctx context.Context, companyID, actorID string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error) {
+	return f.createTemplateFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) UpdateComponentTemplate(ctx context.Context, companyID, actorID, id string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error) {
+	return f.updateTemplateFn(ctx, companyID, actorID, id, req)
+}
+
+func (f *fakePayrollService) GetComponentAssignments(ctx context.Context, companyID, employeeID string) ([]payroll.ComponentAssignmentResponse, error) {
+	return f.getAssignmentsFn(ctx, companyID, employeeID)
+}
+
+func (f *fakePayrollService) CreateComponentAssignment(ctx context.Context, companyID, actorID string, req payroll.CreateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error) {
+	return f.createAssignFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) UpdateComponentAssignment(ctx context.Context, companyID, id string, req payroll.UpdateComponentAssignmentRequest) (payroll.ComponentAssignmentResponse, error) {
+	return f.updateAssignFn(ctx, companyID, id, req)
+}
+
+func (f *fakePayrollService) DeleteComponentAssignment(ctx context.Context, companyID, id string) error {
+	return f.deleteAssignFn(ctx, companyID, id)
+}
+
+func (f *fakePayrollService) GetLoans(ctx context.Context, companyID string, req payroll.GetLoansFilterRequest) ([]payroll.LoanResponse, error) {
+	return f.getLoansFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) GetLoanByID(ctx context.Context, companyID, id string) (payroll.LoanResponse, error) {
+	return f.getLoanFn(ctx, companyID, id)
+}
+
+func (f *fakePayrollService) CreateLoan(ctx context.Context, companyID, actorID string, req payroll.CreateLoanRequest) (payroll.LoanResponse, error) {
+	return f.createLoanFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) PayoffLoan(ctx context.Context, companyID, actorID, id string, req payroll.PayoffLoanRequest) (payroll.LoanResponse, error) {
+	return f.payoffLoanFn(ctx, companyID, actorID, id, req)
+}
+
+func (f *fakePayrollService) GetBPJSReport(ctx context.Context, companyID string, req payroll.BPJSReportFilterRequest) (payroll.BPJSReportResponse, error) {
+	return f.getBPJSReportFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) ExportBankTransfer(ctx context.Context, companyID string, req payroll.BankExportRequest) (payroll.BankExportFile, error) {
+	return f.exportBankFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) ImportBankResult(ctx context.Context, companyID, actorID string, content []byte) (payroll.BankResultResponse, error) {
+	return f.importBankFn(ctx, companyID, actorID, content)
+}
+
+func (f *fakePayrollService) GetPeriods(ctx context.Context, companyID string, req payroll.GetPayrollPeriodsRequest) ([]payroll.PayrollPeriodResponse, error) {
+	return f.getPeriodsFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) GetPeriod(ctx context.Context, companyID, period string) (payroll.PayrollPeriodResponse, error) {
+	return f.getPeriodFn(ctx, companyID, period)
+}
+
+func (f *fakePayrollService) ProcessPeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	return f.processPeriodFn(ctx, companyID, actorID, period, req)
+}
+
+func (f *fakePayrollService) ClosePeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	return f.closePeriodFn(ctx, companyID, actorID, period, req)
+}
+
+func (f *fakePayrollService) ReopenPeriod(ctx context.Context, companyID, actorID, period string, req payroll.PayrollPeriodActionRequest) (payroll.PayrollPeriodResponse, error) {
+	return f.reopenPeriodFn(ctx, companyID, actorID, period, req)
+}
+
+func (f *fakePayrollService) GetApprovalChain(ctx context.Context, companyID string) (payroll.ApprovalChainResponse, error) {
+	return f.getChainFn(ctx, companyID)
+}
+
+func (f *fakePayrollService) UpdateApprovalChain(ctx context.Context, companyID, actorID string, req payroll.UpdateApprovalChainRequest) (payroll.ApprovalChainResponse, error) {
+	return f.updateChainFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) GetAccountMappings(ctx context.Context, companyID string) ([]payroll.AccountMappingResponse, error) {
+	return f.getMappingsFn(ctx, companyID)
+}
+
+func (f *fakePayrollService) UpdateAccountMappings(ctx context.Context, companyID, actorID string, req payroll.UpdateAccountMappingsRequest) ([]payroll.AccountMappingResponse, error) {
+	return f.updateMappingsFn(ctx, companyID, actorID, req)
+}
+
+func (f *fakePayrollService) GetVarianceReport(ctx context.Context, companyID string, req payroll.PayrollVarianceFilterRequest) (payroll.PayrollVarianceResponse, error) {
+	return f.getVarianceFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) Simulate(ctx context.Context, companyID string, req payroll.SimulatePayrollRequest) (payroll.PayrollSimulationResponse, error) {
+	return f.simulateFn(ctx, companyID, req)
+}
+
+func (f *fakePayrollService) ExportJournal(ctx context.Context, companyID string, req payroll.JournalExportRequest) (payroll.JournalExportFile, error) {
+	return f.exportJournalFn(ctx, companyID, req)
+}
+
 func TestPayrollHandler_Create(t *testing.T) {
 	companyID := uuid.New().String()
 	actorID := uuid.New().String()
@@ -173,10 +354,12 @@ func TestPayrollHandler_ApproveAndMarkPaid(t *testing.T) {
 	id := uuid.New().String()
 
 	svc := &fakePayrollService{
-		approveFn: func(ctx context.Context, cid, aid, pid string) (payroll.PayrollResponse, error) {
+		approveFn: func(ctx context.Context, cid, aid, role, pid string, req payroll.ApprovePayrollRequest) (payroll.PayrollResponse, error) {
 			assert.Equal(t, companyID, cid)
 			assert.Equal(t, actorID, aid)
+			assert.Equal(t, "Finance", role)
 			assert.Equal(t, id, pid)
+			assert.Equal(t, "ok", req.Comment)
 			return payroll.PayrollResponse{ID: id, Status: payroll.StatusApproved}, nil
 		},
 		markPaidFn: func(ctx context.Context, cid, aid, pid string) (payroll.PayrollResponse, error) {
@@ -188,10 +371,12 @@ func TestPayrollHandler_ApproveAndMarkPaid(t *testing.T) {
 
 	wApprove := httptest.NewRecorder()
 	cApprove, _ := gin.CreateTestContext(wApprove)
-	cApprove.Request = httptest.NewRequest(http.MethodPost, "/payrolls/"+id+"/approve", nil)
+	cApprove.Request = httptest.NewRequest(http.MethodPost, "/payrolls/"+id+"/approve", strings.NewReader(`{"comment":"ok"}`))
+	cApprove.Request.Header.Set("Content-Type", "application/json")
 	cApprove.Params = []gin.Param{{Key: "id", Value: id}}
 	cApprove.Set("company_id", companyID)
 	cApprove.Set("employee_id", actorID)
+	cApprove.Set("role", "Finance")
 	h.Approve(cApprove)
 	assert.Equal(t, http.StatusOK, wApprove.Code)
 
@@ -227,11 +412,14 @@ func TestPayrollHandler_Delete(t *testing.T) {
 
 func TestPayrollHandler_DownloadPayslip(t *testing.T) {
 	companyID := uuid.New().String()
+	employeeID := uuid.New().String()
 	payrollID := uuid.New().String()
-	url := "/files/payslips/payslip_" + payrollID + ".pdf"
+	signedURL := "/files/payslips/payslip_" + payrollID + ".pdf?expires=1&signature=abc"
 	svc := &fakePayrollService{
-		getByIDFn: func(ctx context.Context, cid, id string) (payroll.PayrollResponse, error) {
-			return payroll.PayrollResponse{ID: id, PayslipURL: &url}, nil
+		getPayslipFn: func(ctx context.Context, cid, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error) {
+			assert.Equal(t, employeeID, requesterEmployeeID)
+			assert.False(t, canReadAll)
+			return payroll.PayslipDownloadResponse{URL: signedURL}, nil
 		},
 	}
 
@@ -241,19 +429,88 @@ func TestPayrollHandler_DownloadPayslip(t *testing.T) {
 	c.Request = httptest.NewRequest(http.MethodGet, "/payrolls/"+payrollID+"/payslip/download", nil)
 	c.Params = []gin.Param{{Key: "id", Value: payrollID}}
 	c.Set("company_id", companyID)
+	c.Set("employee_id", employeeID)
 
 	h.DownloadPayslip(c)
 
 	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
-	assert.Equal(t, url, w.Header().Get("Location"))
+	assert.Equal(t, signedURL, w.Header().Get("Location"))
+}
+
+func TestPayrollHandler_DownloadPayslip_Forbidden(t *testing.T) {
+	svc := &fakePayrollService{
+		getPayslipFn: func(ctx context.Context, cid, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error) {
+			return payroll.PayslipDownloadResponse{}, payrollerrors.ErrPayslipForbidden
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/payrolls/x/payslip/download", nil)
+	c.Params = []gin.Param{{Key: "id", Value: uuid.New().String()}}
+	c.Set("company_id", uuid.New().String())
+	c.Set("employee_id", uuid.New().String())
+
+	h.DownloadPayslip(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+type fakeEnforcer struct {
+	allowed map[string]bool
+}
+
+func (f fakeEnforcer) Enforce(req domain.EnforceRequest) (bool, error) {
+	return f.allowed[req.Resource+":"+req.Action], nil
+}
+
+func TestPayrollHandler_DownloadPayslip_ReadAllByPermission(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions map[string]bool
+		canReadAll  bool
+		wantStatus  int
+	}{
+		{name: "payroll read only", permissions: map[string]bool{"payroll:read": true}, canReadAll: false, wantStatus: http.StatusForbidden},
+		{name: "payroll read_all", permissions: map[string]bool{"payroll:read": true, "payroll:read_all": true}, canReadAll: true, wantStatus: http.StatusTemporaryRedirect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakePayrollService{
+				getPayslipFn: func(ctx context.Context, cid, requesterEmployeeID, id string, canReadAll bool) (payroll.PayslipDownloadResponse, error) {
+					assert.Equal(t, tt.canReadAll, canReadAll)
+					if !canReadAll {
+						// Payslip milik karyawan lain
+						return payroll.PayslipDownloadResponse{}, payrollerrors.ErrPayslipForbidden
+					}
+					return payroll.PayslipDownloadResponse{URL: "/files/payslips/x.pdf"}, nil
+				},
+			}
+
+			h := payroll.NewHandler(svc)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/payrolls/x/payslip/download", nil)
+			c.Params = []gin.Param{{Key: "id", Value: uuid.New().String()}}
+			c.Set("company_id", uuid.New().String())
+			c.Set("employee_id", uuid.New().String())
+
+			middleware.RBACCheck(fakeEnforcer{allowed: tt.permissions}, "payroll", "read_all")(c)
+			h.DownloadPayslip(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
 }
 
 func TestPayrollHandler_InternalError(t *testing.T) {
 	svc := &fakePayrollService{
-		getAllFn: func(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, error) {
+		getAllFn: func(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, int64, error) {
 			assert.Equal(t, "2026-02", filter.Period)
 			assert.Equal(t, "draft", filter.Status)
-			return nil, errors.New("boom")
+			return nil, 0, errors.New("boom")
 		},
 	}
 
@@ -269,3 +526,194 @@ func TestPayrollHandler_InternalError(t *testing.T) {
 	env := mustDecodeEnvelope(t, w.Body.Bytes())
 	assert.Equal(t, "INTERNAL_ERROR", env.Error.Code)
 }
+
+func TestPayrollHandler_GetAll_Meta(t *testing.T) {
+	svc := &fakePayrollService{
+		getAllFn: func(ctx context.Context, companyID string, filter payroll.GetPayrollsFilterRequest) ([]payroll.PayrollResponse, int64, error) {
+			assert.Equal(t, 3, filter.Page)
+			assert.Equal(t, "employee_name", filter.SortBy)
+			return []payroll.PayrollResponse{{ID: uuid.New().String()}}, 41, nil
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/payrolls?page=3&page_size=20&sort_by=employee_name", nil)
+	c.Set("company_id", uuid.New().String())
+
+	h.GetAll(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"meta":{"total":41,"totalPages":3,"page":3,"pageSize":20}`)
+}
+
+func TestPayrollHandler_CreateRun(t *testing.T) {
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	svc := &fakePayrollService{
+		createRunFn: func(ctx context.Context, cid, aid string, req payroll.CreatePayrollRunRequest) (payroll.PayrollRunResponse, error) {
+			assert.Equal(t, companyID, cid)
+			assert.Equal(t, actorID, aid)
+			assert.Equal(t, "2026-02", req.Period)
+			return payroll.PayrollRunResponse{
+				ID:             uuid.New().String(),
+				Status:         payroll.StatusDraft,
+				TotalEmployees: 2,
+				SuccessCount:   1,
+				FailedCount:    1,
+			}, nil
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/payrolls/runs", strings.NewReader(`{"period":"2026-02"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("company_id", companyID)
+	c.Set("employee_id", actorID)
+
+	h.CreateRun(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	env := mustDecodeEnvelope(t, w.Body.Bytes())
+	assert.True(t, env.Ok)
+}
+
+func TestPayrollHandler_ApproveRun_NotFound(t *testing.T) {
+	svc := &fakePayrollService{
+		approveRunFn: func(ctx context.Context, companyID, actorID, role, id string, req payroll.ApprovePayrollRequest) (payroll.PayrollRunResponse, error) {
+			return payroll.PayrollRunResponse{}, payrollerrors.ErrPayrollRunNotFound
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	runID := uuid.New().String()
+	c.Request = httptest.NewRequest(http.MethodPost, "/payrolls/runs/"+runID+"/approve", nil)
+	c.Params = []gin.Param{{Key: "id", Value: runID}}
+	c.Set("company_id", uuid.New().String())
+	c.Set("employee_id", uuid.New().String())
+
+	h.ApproveRun(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	env := mustDecodeEnvelope(t, w.Body.Bytes())
+	assert.Equal(t, "NOT_FOUND", env.Error.Code)
+}
+
+func TestPayrollHandler_UpdateSetting(t *testing.T) {
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	svc := &fakePayrollService{
+		updateSettingFn: func(ctx context.Context, cid, aid string, req payroll.UpdatePayrollSettingRequest) (payroll.PayrollSettingResponse, error) {
+			assert.Equal(t, companyID, cid)
+			assert.Equal(t, actorID, aid)
+			assert.Equal(t, int64(8), req.WorkHoursPerDay)
+			assert.Equal(t, int64(100000), req.AbsentDeductionAmount)
+			return payroll.PayrollSettingResponse{CompanyID: cid, WorkHoursPerDay: 8, WorkDaysPerWeek: 5, AbsentDeductionAmount: 100000}, nil
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"work_hours_per_day":8,"work_days_per_week":5,"absent_deduction_amount":100000}`
+	c.Request = httptest.NewRequest(http.MethodPut, "/payrolls/settings", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("company_id", companyID)
+	c.Set("employee_id", actorID)
+
+	h.UpdateSetting(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	env := mustDecodeEnvelope(t, w.Body.Bytes())
+	assert.True(t, env.Ok)
+}
+
+func TestPayrollHandler_Cancel_RequiresReason(t *testing.T) {
+	svc := &fakePayrollService{
+		cancelFn: func(ctx context.Context, companyID, actorID, id string, req payroll.CancelPayrollRequest) (payroll.PayrollResponse, error) {
+			t.Fatal("service should not be called without reason")
+			return payroll.PayrollResponse{}, nil
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	payrollID := uuid.New().String()
+	c.Request = httptest.NewRequest(http.MethodPost, "/payrolls/"+payrollID+"/cancel", strings.NewReader(`{}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = []gin.Param{{Key: "id", Value: payrollID}}
+	c.Set("company_id", uuid.New().String())
+	c.Set("employee_id", uuid.New().String())
+
+	h.Cancel(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	env := mustDecodeEnvelope(t, w.Body.Bytes())
+	assert.Equal(t, "VALIDATION_ERROR", env.Error.Code)
+}
+
+func TestPayrollHandler_ExportBankTransfer(t *testing.T) {
+	companyID := uuid.New().String()
+	svc := &fakePayrollService{
+		exportBankFn: func(ctx context.Context, cid string, req payroll.BankExportRequest) (payroll.BankExportFile, error) {
+			assert.Equal(t, companyID, cid)
+			assert.Equal(t, payroll.BankFormatBCA, req.Format)
+			return payroll.BankExportFile{
+				BatchID:       "PAY20260325ABCDEF12",
+				FileName:      "payroll-bca.csv",
+				ContentType:   "text/csv",
+				Content:       []byte("H,1\n"),
+				TransferCount: 1,
+				TotalAmount:   5000000,
+			}, nil
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"format":"BCA","payroll_ids":["` + uuid.New().String() + `"]}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/payrolls/bank-exports", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("company_id", companyID)
+
+	h.ExportBankTransfer(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="payroll-bca.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "PAY20260325ABCDEF12", w.Header().Get("X-Transfer-Batch-ID"))
+	assert.Equal(t, "1", w.Header().Get("X-Transfer-Count"))
+	assert.Equal(t, "H,1\n", w.Body.String())
+}
+
+func TestPayrollHandler_CreateComponentTemplate_DuplicateCode(t *testing.T) {
+	svc := &fakePayrollService{
+		createTemplateFn: func(ctx context.Context, cid, aid string, req payroll.ComponentTemplateRequest) (payroll.ComponentTemplateResponse, error) {
+			assert.Equal(t, "TRANSPORT", req.Code)
+			return payroll.ComponentTemplateResponse{}, payrollerrors.ErrComponentTemplateCodeExists
+		},
+	}
+
+	h := payroll.NewHandler(svc)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"code":"TRANSPORT","name":"Tunjangan Transport","component_type":"ALLOWANCE","calculation_type":"FIXED","amount":500000}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/payrolls/component-templates", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("company_id", uuid.New().String())
+	c.Set("employee_id", uuid.New().String())
+
+	h.CreateComponentTemplate(c)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	env := mustDecodeEnvelope(t, w.Body.Bytes())
+	assert.Equal(t, "CONFLICT", env.Error.Code)
+}
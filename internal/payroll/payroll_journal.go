@@ -0,0 +1,277 @@
+package payroll
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+// defaultAccountMappings adalah bagan akun bawaan yang dipakai bila company belum
+// memetakan pos tersebut. Kode akun mengikuti penomoran umum Accurate/Jurnal.id.
+var defaultAccountMappings = []AccountMapping{
+	defaultAccountMapping(JournalItemBaseSalary, "", "6-1100", "Beban Gaji Pokok", true),
+	defaultAccountMapping(JournalItemOvertime, "", "6-1200", "Beban Lembur", true),
+	defaultAccountMapping(ComponentTypeAllowance, "", "6-1300", "Beban Tunjangan", true),
+	defaultAccountMapping(ComponentTypeAllowance, ComponentSourceTHR, "6-1400", "Beban THR", true),
+	// Lebih bayar PPh 21 yang dikembalikan ke karyawan mengurangi utang pajak.
+	defaultAccountMapping(ComponentTypeAllowance, ComponentSourceTax, "2-1200", "Utang PPh 21", false),
+	defaultAccountMapping(ComponentTypeDeduction, "", "2-1900", "Utang Potongan Karyawan Lainnya", false),
+	defaultAccountMapping(ComponentTypeDeduction, ComponentSourceTax, "2-1200", "Utang PPh 21", false),
+	defaultAccountMapping(ComponentTypeDeduction, ComponentSourceBPJS, "2-1300", "Utang BPJS", false),
+	defaultAccountMapping(ComponentTypeDeduction, ComponentSourceLoan, "1-1400", "Piutang Karyawan", false),
+	// Potongan cuti tidak dibayar dan ketidakhadiran mengurangi beban gaji, bukan utang.
+	defaultAccountMapping(ComponentTypeDeduction, ComponentSourceLeave, "6-1100", "Beban Gaji Pokok", true),
+	{
+		ComponentType:     ComponentTypeDeduction,
+		ComponentName:     stringPtr("Absence Deduction"),
+		AccountCode:       "6-1100",
+		AccountName:       "Beban Gaji Pokok",
+		SplitByCostCenter: true,
+	},
+	{
+		ComponentType:     ComponentTypeDeduction,
+		ComponentName:     stringPtr("Late Deduction"),
+		AccountCode:       "6-1100",
+		AccountName:       "Beban Gaji Pokok",
+		SplitByCostCenter: true,
+	},
+	{
+		ComponentType:     ComponentTypeEmployerContribution,
+		AccountCode:       "6-1500",
+		AccountName:       "Beban BPJS Perusahaan",
+		ContraAccountCode: stringPtr("2-1300"),
+		ContraAccountName: stringPtr("Utang BPJS"),
+		SplitByCostCenter: true,
+	},
+	defaultAccountMapping(JournalItemNetPayable, "", "2-1100", "Utang Gaji", false),
+}
+
+func defaultAccountMapping(componentType, sourceType, code, name string, split bool) AccountMapping {
+	m := AccountMapping{
+		ComponentType:     componentType,
+		AccountCode:       code,
+		AccountName:       name,
+		SplitByCostCenter: split,
+	}
+	if sourceType != "" {
+		m.SourceType = &sourceType
+	}
+	return m
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func isValidJournalItem(v string) bool {
+	switch v {
+	case JournalItemBaseSalary, JournalItemOvertime, JournalItemNetPayable,
+		ComponentTypeAllowance, ComponentTypeDeduction, ComponentTypeEmployerContribution:
+		return true
+	default:
+		return false
+	}
+}
+
+// accountMappingKey mengidentifikasi mapping; satu kunci hanya boleh dipetakan sekali.
+func accountMappingKey(m AccountMapping) string {
+	source, name := "", ""
+	if m.SourceType != nil {
+		source = strings.ToUpper(*m.SourceType)
+	}
+	if m.ComponentName != nil {
+		name = strings.ToLower(*m.ComponentName)
+	}
+	return m.ComponentType + "|" + source + "|" + name
+}
+
+// resolveAccountMapping memilih mapping paling spesifik untuk satu pos jurnal: cocok nama
+// komponen lebih kuat dari cocok sumber, dan mapping company menang atas bawaan jika sama
+// spesifiknya. Mapping bawaan tanpa sumber selalu tersedia sebagai fallback.
+func resolveAccountMapping(mappings []AccountMapping, itemType string, sourceType *string, componentName string) AccountMapping {
+	var best AccountMapping
+	bestScore := -1
+	for _, candidates := range [][]AccountMapping{mappings, defaultAccountMappings} {
+		for _, m := range candidates {
+			if m.ComponentType != itemType {
+				continue
+			}
+			score := 0
+			if m.ComponentName != nil {
+				if !strings.EqualFold(strings.TrimSpace(*m.ComponentName), strings.TrimSpace(componentName)) {
+					continue
+				}
+				score += 2
+			}
+			if m.SourceType != nil {
+				if sourceType == nil || !strings.EqualFold(*m.SourceType, *sourceType) {
+					continue
+				}
+				score++
+			}
+			if score > bestScore {
+				best, bestScore = m, score
+			}
+		}
+	}
+	return best
+}
+
+type journalLine struct {
+	AccountCode string
+	AccountName string
+	CostCenter  string
+	Description string
+	Debit       int64
+	Credit      int64
+}
+
+type payrollJournal struct {
+	Number       string
+	PostingDate  time.Time
+	Description  string
+	PayrollCount int
+	Lines        []journalLine
+	TotalDebit   int64
+	TotalCredit  int64
+}
+
+type journalLineKey struct {
+	AccountCode string
+	CostCenter  string
+}
+
+// journalBuilder mengakumulasi nilai bertanda per akun dan cost center; positif = debit.
+type journalBuilder struct {
+	mappings []AccountMapping
+	amounts  map[journalLineKey]int64
+	names    map[journalLineKey]string
+}
+
+func (b *journalBuilder) post(code, name string, split bool, costCenter string, amount int64) {
+	if amount == 0 {
+		return
+	}
+	if !split {
+		costCenter = ""
+	}
+	key := journalLineKey{AccountCode: code, CostCenter: costCenter}
+	b.amounts[key] += amount
+	b.names[key] = name
+}
+
+// postItem membukukan satu pos; amount bertanda dari sisi beban/aset (debit) untuk
+// ALLOWANCE dan sejenisnya, atau dari sisi utang (kredit) untuk DEDUCTION dan NET_PAYABLE.
+func (b *journalBuilder) postItem(itemType string, sourceType *string, componentName, costCenter string, amount int64) {
+	m := resolveAccountMapping(b.mappings, itemType, sourceType, componentName)
+	switch itemType {
+	case ComponentTypeDeduction, JournalItemNetPayable:
+		b.post(m.AccountCode, m.AccountName, m.SplitByCostCenter, costCenter, -amount)
+	case ComponentTypeEmployerContribution:
+		b.post(m.AccountCode, m.AccountName, m.SplitByCostCenter, costCenter, amount)
+		contraCode, contraName := m.AccountCode, m.AccountName
+		if m.ContraAccountCode != nil {
+			contraCode = *m.ContraAccountCode
+		}
+		if m.ContraAccountName != nil {
+			contraName = *m.ContraAccountName
+		}
+		b.post(contraCode, contraName, false, costCenter, -amount)
+	default:
+		b.post(m.AccountCode, m.AccountName, m.SplitByCostCenter, costCenter, amount)
+	}
+}
+
+// buildPayrollJournal menyusun jurnal dari payroll dan komponen tersimpannya. Selisih
+// antara total di header payroll dan jumlah komponen (nilai lump-sum input manual)
+// dibukukan ke mapping umum pos tersebut sehingga jurnal selalu seimbang. Payroll
+// penyesuaian reversal tidak punya komponen sendiri, jadi memakai komponen payroll
+// asli dengan tanda dibalik.
+func buildPayrollJournal(
+	rows []JournalPayrollRow,
+	components map[uuid.UUID][]PayrollComponent,
+	mappings []AccountMapping,
+) ([]journalLine, error) {
+	b := &journalBuilder{
+		mappings: mappings,
+		amounts:  make(map[journalLineKey]int64),
+		names:    make(map[journalLineKey]string),
+	}
+
+	for _, row := range rows {
+		costCenter := ""
+		if row.DepartmentName != nil {
+			costCenter = *row.DepartmentName
+		}
+
+		// Payroll penyesuaian reversal sudah menyimpan komponen bernilai negatif.
+		items := components[row.PayrollID]
+		remaining := map[string]int64{
+			ComponentTypeAllowance:            row.Allowance,
+			ComponentTypeDeduction:            row.Deduction,
+			ComponentTypeEmployerContribution: row.EmployerCost,
+		}
+		b.postItem(JournalItemBaseSalary, nil, "", costCenter, row.BaseSalary)
+		b.postItem(JournalItemOvertime, nil, "", costCenter, row.OvertimeAmount)
+		for _, item := range items {
+			if _, ok := remaining[item.ComponentType]; !ok {
+				continue
+			}
+			b.postItem(item.ComponentType, item.SourceType, item.ComponentName, costCenter, item.TotalAmount)
+			remaining[item.ComponentType] -= item.TotalAmount
+		}
+		for _, itemType := range []string{ComponentTypeAllowance, ComponentTypeDeduction, ComponentTypeEmployerContribution} {
+			b.postItem(itemType, nil, "", costCenter, remaining[itemType])
+		}
+		b.postItem(JournalItemNetPayable, nil, "", costCenter, row.NetSalary)
+	}
+
+	keys := make([]journalLineKey, 0, len(b.amounts))
+	for key, amount := range b.amounts {
+		if amount != 0 {
+			keys = append(keys, key)
+		}
+	}
+	// Baris debit lebih dulu, lalu urut kode akun dan cost center.
+	sort.Slice(keys, func(i, j int) bool {
+		di, dj := b.amounts[keys[i]] > 0, b.amounts[keys[j]] > 0
+		if di != dj {
+			return di
+		}
+		if keys[i].AccountCode != keys[j].AccountCode {
+			return keys[i].AccountCode < keys[j].AccountCode
+		}
+		return keys[i].CostCenter < keys[j].CostCenter
+	})
+
+	lines := make([]journalLine, 0, len(keys))
+	var totalDebit, totalCredit int64
+	for _, key := range keys {
+		line := journalLine{
+			AccountCode: key.AccountCode,
+			AccountName: b.names[key],
+			CostCenter:  key.CostCenter,
+			Description: b.names[key],
+		}
+		if key.CostCenter != "" {
+			line.Description = fmt.Sprintf("%s - %s", b.names[key], key.CostCenter)
+		}
+		if amount := b.amounts[key]; amount > 0 {
+			line.Debit = amount
+			totalDebit += amount
+		} else {
+			line.Credit = -amount
+			totalCredit += -amount
+		}
+		lines = append(lines, line)
+	}
+	if totalDebit != totalCredit {
+		return nil, fmt.Errorf("%w: debit %d, credit %d", payrollerrors.ErrJournalUnbalanced, totalDebit, totalCredit)
+	}
+	return lines, nil
+}
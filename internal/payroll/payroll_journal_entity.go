@@ -0,0 +1,64 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Jenis pos jurnal payroll yang bisa dipetakan ke akun. ALLOWANCE, DEDUCTION dan
+	// EMPLOYER_CONTRIBUTION memakai konstanta ComponentType yang sama dengan PayrollComponent.
+	JournalItemBaseSalary = "BASE_SALARY"
+	JournalItemOvertime   = "OVERTIME"
+	JournalItemNetPayable = "NET_PAYABLE" // Utang gaji bersih yang akan ditransfer ke karyawan
+)
+
+// AccountMapping memetakan pos payroll ke akun bagan akun (chart of accounts) company.
+// SourceType dan ComponentName opsional; mapping yang lebih spesifik menang saat resolve.
+type AccountMapping struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	ComponentType string    `gorm:"type:varchar(30);not null"`
+	SourceType    *string   `gorm:"type:varchar(30)"`
+	ComponentName *string   `gorm:"type:varchar(120)"`
+
+	AccountCode string `gorm:"type:varchar(50);not null"`
+	AccountName string `gorm:"type:varchar(120);not null"`
+
+	// Akun lawan untuk EMPLOYER_CONTRIBUTION: beban di AccountCode (debit), utang iuran
+	// di ContraAccountCode (kredit).
+	ContraAccountCode *string `gorm:"type:varchar(50)"`
+	ContraAccountName *string `gorm:"type:varchar(120)"`
+
+	// SplitByCostCenter memecah baris jurnal per departemen karyawan.
+	SplitByCostCenter bool `gorm:"not null;default:false"`
+
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (AccountMapping) TableName() string {
+	return "payroll_account_mappings"
+}
+
+// JournalPayrollRow adalah proyeksi payroll beserta departemen karyawan untuk jurnal.
+type JournalPayrollRow struct {
+	PayrollID      uuid.UUID
+	EmployeeID     uuid.UUID
+	EmployeeName   string
+	DepartmentID   *uuid.UUID
+	DepartmentName *string
+	PayrollType    string
+	Status         string
+	BaseSalary     int64
+	Allowance      int64
+	OvertimeAmount int64
+	Deduction      int64
+	NetSalary      int64
+	EmployerCost   int64
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	ReversalOfID   *uuid.UUID
+}
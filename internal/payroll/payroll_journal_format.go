@@ -0,0 +1,127 @@
+package payroll
+
+import (
+	"encoding/json"
+)
+
+const (
+	JournalFormatCSV      = "CSV"
+	JournalFormatAccurate = "ACCURATE" // JSON journal voucher Accurate Online (journal-voucher/save.do)
+	JournalFormatJurnal   = "JURNAL"   // JSON journal entry Jurnal.id (POST /journal_entries)
+)
+
+type journalFileFormat struct {
+	Extension   string
+	ContentType string
+	Write       func(payrollJournal) ([]byte, error)
+}
+
+var journalFileFormats = map[string]journalFileFormat{
+	JournalFormatCSV:      {Extension: "csv", ContentType: "text/csv", Write: writeJournalCSV},
+	JournalFormatAccurate: {Extension: "json", ContentType: "application/json", Write: writeAccurateJournal},
+	JournalFormatJurnal:   {Extension: "json", ContentType: "application/json", Write: writeJurnalJournal},
+}
+
+// writeJournalCSV: satu baris per akun dan cost center, nomor jurnal diulang di setiap baris.
+func writeJournalCSV(journal payrollJournal) ([]byte, error) {
+	records := [][]string{{
+		"journal_number", "posting_date", "account_code", "account_name",
+		"cost_center", "description", "debit", "credit",
+	}}
+	for _, line := range journal.Lines {
+		records = append(records, []string{
+			journal.Number,
+			journal.PostingDate.Format("2006-01-02"),
+			line.AccountCode,
+			line.AccountName,
+			line.CostCenter,
+			line.Description,
+			amountString(line.Debit),
+			amountString(line.Credit),
+		})
+	}
+	return writeCSV(records)
+}
+
+type accurateJournalDetail struct {
+	AccountNo      string `json:"accountNo"`
+	Amount         int64  `json:"amount"`
+	AmountType     string `json:"amountType"` // DEBIT atau CREDIT
+	DepartmentName string `json:"departmentName,omitempty"`
+	Memo           string `json:"memo"`
+}
+
+type accurateJournalVoucher struct {
+	Number               string                  `json:"number"`
+	TransDate            string                  `json:"transDate"` // dd/MM/yyyy
+	Description          string                  `json:"description"`
+	DetailJournalVoucher []accurateJournalDetail `json:"detailJournalVoucher"`
+}
+
+// writeAccurateJournal: cost center dikirim sebagai departmentName Accurate.
+func writeAccurateJournal(journal payrollJournal) ([]byte, error) {
+	voucher := accurateJournalVoucher{
+		Number:               journal.Number,
+		TransDate:            journal.PostingDate.Format("02/01/2006"),
+		Description:          journal.Description,
+		DetailJournalVoucher: make([]accurateJournalDetail, 0, len(journal.Lines)),
+	}
+	for _, line := range journal.Lines {
+		detail := accurateJournalDetail{
+			AccountNo:      line.AccountCode,
+			Amount:         line.Debit,
+			AmountType:     "DEBIT",
+			DepartmentName: line.CostCenter,
+			Memo:           line.Description,
+		}
+		if line.Credit > 0 {
+			detail.Amount = line.Credit
+			detail.AmountType = "CREDIT"
+		}
+		voucher.DetailJournalVoucher = append(voucher.DetailJournalVoucher, detail)
+	}
+	return json.MarshalIndent(voucher, "", "  ")
+}
+
+type jurnalAccountLine struct {
+	AccountName string `json:"account_name"`
+	AccountCode string `json:"account_code"`
+	Description string `json:"description"`
+	Debit       int64  `json:"debit"`
+	Credit      int64  `json:"credit"`
+}
+
+type jurnalJournalEntry struct {
+	TransactionNo   string              `json:"transaction_no"`
+	TransactionDate string              `json:"transaction_date"` // dd/mm/yyyy
+	Memo            string              `json:"memo"`
+	Lines           []jurnalAccountLine `json:"transaction_account_lines_attributes"`
+	Tags            []string            `json:"tags"`
+}
+
+// writeJurnalJournal: Jurnal.id mencocokkan akun lewat account_name; cost center
+// dicantumkan di deskripsi baris dan departemen dijadikan tag transaksi.
+func writeJurnalJournal(journal payrollJournal) ([]byte, error) {
+	entry := jurnalJournalEntry{
+		TransactionNo:   journal.Number,
+		TransactionDate: journal.PostingDate.Format("02/01/2006"),
+		Memo:            journal.Description,
+		Lines:           make([]jurnalAccountLine, 0, len(journal.Lines)),
+		Tags:            []string{"payroll"},
+	}
+	seen := map[string]bool{}
+	for _, line := range journal.Lines {
+		entry.Lines = append(entry.Lines, jurnalAccountLine{
+			AccountName: line.AccountName,
+			AccountCode: line.AccountCode,
+			Description: line.Description,
+			Debit:       line.Debit,
+			Credit:      line.Credit,
+		})
+		if line.CostCenter != "" && !seen[line.CostCenter] {
+			seen[line.CostCenter] = true
+			entry.Tags = append(entry.Tags, line.CostCenter)
+		}
+	}
+	return json.MarshalIndent(map[string]jurnalJournalEntry{"journal_entry": entry}, "", "  ")
+}
@@ -0,0 +1,270 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetAccountMappings mengembalikan bagan akun efektif company: mapping yang dikonfigurasi
+// diikuti mapping bawaan yang belum ditimpa (is_default = true).
+func (s *service) GetAccountMappings(ctx context.Context, companyID string) ([]AccountMappingResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+
+	mappings, err := s.repo.FindAccountMappings(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	return mapToAccountMappingResponses(mappings), nil
+}
+
+// UpdateAccountMappings mengganti seluruh mapping akun company dalam satu transaksi.
+func (s *service) UpdateAccountMappings(
+	ctx context.Context,
+	companyID, actorID string,
+	req UpdateAccountMappingsRequest,
+) ([]AccountMappingResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return nil, payrollerrors.ErrInvalidActorID
+	}
+
+	mappings := make([]AccountMapping, 0, len(req.Mappings))
+	seen := make(map[string]struct{}, len(req.Mappings))
+	for _, item := range req.Mappings {
+		mapping, err := normalizeAccountMapping(item)
+		if err != nil {
+			return nil, err
+		}
+		key := accountMappingKey(mapping)
+		if _, ok := seen[key]; ok {
+			return nil, payrollerrors.ErrInvalidAccountMapping
+		}
+		seen[key] = struct{}{}
+
+		mapping.ID = uuid.New()
+		mapping.CompanyID = companyUUID
+		mapping.CreatedBy = actorUUID
+		mappings = append(mappings, mapping)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+	if err := qtx.ReplaceAccountMappings(ctx, companyID, mappings); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return mapToAccountMappingResponses(mappings), nil
+}
+
+// ExportJournal menyusun jurnal gaji seimbang dari komponen payroll tersimpan: beban gaji
+// per cost center departemen, utang PPh 21, utang BPJS, dan utang gaji bersih.
+func (s *service) ExportJournal(ctx context.Context, companyID string, req JournalExportRequest) (JournalExportFile, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return JournalExportFile{}, payrollerrors.ErrInvalidCompanyID
+	}
+	formatCode := strings.ToUpper(strings.TrimSpace(req.Format))
+	format, ok := journalFileFormats[formatCode]
+	if !ok {
+		return JournalExportFile{}, payrollerrors.ErrInvalidJournalFormat
+	}
+
+	runID := strings.TrimSpace(req.RunID)
+	period := strings.TrimSpace(req.Period)
+	if (runID == "") == (period == "") {
+		return JournalExportFile{}, payrollerrors.ErrInvalidJournalScope
+	}
+
+	filter := JournalQueryFilter{Statuses: []string{StatusApproved, StatusPaid, StatusReversed}}
+	var periodStart, periodEnd time.Time
+	var reference string
+	if runID != "" {
+		run, err := s.repo.FindRunByIDAndCompany(ctx, companyID, runID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return JournalExportFile{}, payrollerrors.ErrPayrollRunNotFound
+			}
+			return JournalExportFile{}, err
+		}
+		if run.Status != StatusApproved && run.Status != StatusPaid {
+			return JournalExportFile{}, payrollerrors.ErrJournalRunNotApproved
+		}
+		filter.RunID = &runID
+		periodStart, periodEnd = run.PeriodStart, run.PeriodEnd
+		reference = strings.ToUpper(run.ID.String()[:8])
+	} else {
+		start, end, err := parseMonthPeriod(period)
+		if err != nil {
+			return JournalExportFile{}, err
+		}
+		filter.PeriodStart, filter.PeriodEnd = &start, &end
+		periodStart, _ = parseDate(start)
+		periodEnd, _ = parseDate(end)
+		reference = periodStart.Format("200601")
+	}
+
+	postingDate := periodEnd
+	if strings.TrimSpace(req.PostingDate) != "" {
+		var err error
+		postingDate, err = parseDate(strings.TrimSpace(req.PostingDate))
+		if err != nil {
+			return JournalExportFile{}, err
+		}
+	}
+
+	rows, err := s.repo.FindJournalPayrolls(ctx, companyID, filter)
+	if err != nil {
+		return JournalExportFile{}, err
+	}
+	if len(rows) == 0 {
+		return JournalExportFile{}, payrollerrors.ErrJournalNoPayroll
+	}
+
+	payrollIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		payrollIDs = append(payrollIDs, row.PayrollID.String())
+	}
+	items, err := s.repo.FindComponentsByPayrollIDs(ctx, companyID, payrollIDs)
+	if err != nil {
+		return JournalExportFile{}, err
+	}
+	components := make(map[uuid.UUID][]PayrollComponent, len(rows))
+	for _, item := range items {
+		components[item.PayrollID] = append(components[item.PayrollID], item)
+	}
+
+	mappings, err := s.repo.FindAccountMappings(ctx, companyID)
+	if err != nil {
+		return JournalExportFile{}, err
+	}
+
+	lines, err := buildPayrollJournal(rows, components, mappings)
+	if err != nil {
+		return JournalExportFile{}, err
+	}
+
+	journal := payrollJournal{
+		Number:       fmt.Sprintf("PAYJV-%s-%s", postingDate.Format("20060102"), reference),
+		PostingDate:  postingDate,
+		Description:  fmt.Sprintf("Jurnal gaji periode %s s/d %s", periodStart.Format("02/01/2006"), periodEnd.Format("02/01/2006")),
+		PayrollCount: len(rows),
+		Lines:        lines,
+	}
+	for _, line := range lines {
+		journal.TotalDebit += line.Debit
+		journal.TotalCredit += line.Credit
+	}
+
+	content, err := format.Write(journal)
+	if err != nil {
+		return JournalExportFile{}, err
+	}
+
+	return JournalExportFile{
+		FileName:     fmt.Sprintf("payroll-journal-%s-%s.%s", strings.ToLower(formatCode), journal.Number, format.Extension),
+		ContentType:  format.ContentType,
+		Content:      content,
+		PayrollCount: journal.PayrollCount,
+		LineCount:    len(lines),
+		TotalDebit:   journal.TotalDebit,
+	}, nil
+}
+
+func normalizeAccountMapping(req AccountMappingRequest) (AccountMapping, error) {
+	mapping := AccountMapping{
+		ComponentType:     strings.ToUpper(strings.TrimSpace(req.ComponentType)),
+		AccountCode:       strings.TrimSpace(req.AccountCode),
+		AccountName:       strings.TrimSpace(req.AccountName),
+		SplitByCostCenter: req.SplitByCostCenter,
+	}
+	if !isValidJournalItem(mapping.ComponentType) ||
+		mapping.AccountCode == "" || len(mapping.AccountCode) > 50 ||
+		mapping.AccountName == "" || len(mapping.AccountName) > 120 {
+		return AccountMapping{}, payrollerrors.ErrInvalidAccountMapping
+	}
+
+	byComponent := mapping.ComponentType == ComponentTypeAllowance ||
+		mapping.ComponentType == ComponentTypeDeduction ||
+		mapping.ComponentType == ComponentTypeEmployerContribution
+	if req.SourceType != nil && strings.TrimSpace(*req.SourceType) != "" {
+		if !byComponent {
+			return AccountMapping{}, payrollerrors.ErrInvalidAccountMapping
+		}
+		mapping.SourceType = stringPtr(strings.ToUpper(strings.TrimSpace(*req.SourceType)))
+	}
+	if req.ComponentName != nil && strings.TrimSpace(*req.ComponentName) != "" {
+		if !byComponent {
+			return AccountMapping{}, payrollerrors.ErrInvalidAccountMapping
+		}
+		mapping.ComponentName = stringPtr(strings.TrimSpace(*req.ComponentName))
+	}
+
+	if mapping.ComponentType == ComponentTypeEmployerContribution {
+		if req.ContraAccountCode == nil || strings.TrimSpace(*req.ContraAccountCode) == "" || len(strings.TrimSpace(*req.ContraAccountCode)) > 50 {
+			return AccountMapping{}, payrollerrors.ErrInvalidAccountMapping
+		}
+		mapping.ContraAccountCode = stringPtr(strings.TrimSpace(*req.ContraAccountCode))
+		contraName := mapping.AccountName
+		if req.ContraAccountName != nil && strings.TrimSpace(*req.ContraAccountName) != "" {
+			contraName = strings.TrimSpace(*req.ContraAccountName)
+		}
+		mapping.ContraAccountName = &contraName
+	}
+	return mapping, nil
+}
+
+func mapToAccountMappingResponses(mappings []AccountMapping) []AccountMappingResponse {
+	resp := make([]AccountMappingResponse, 0, len(mappings)+len(defaultAccountMappings))
+	configured := make(map[string]struct{}, len(mappings))
+	for _, m := range mappings {
+		configured[accountMappingKey(m)] = struct{}{}
+		item := mapToAccountMappingResponse(m)
+		id := m.ID.String()
+		item.ID = &id
+		resp = append(resp, item)
+	}
+	for _, m := range defaultAccountMappings {
+		if _, ok := configured[accountMappingKey(m)]; ok {
+			continue
+		}
+		item := mapToAccountMappingResponse(m)
+		item.IsDefault = true
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func mapToAccountMappingResponse(m AccountMapping) AccountMappingResponse {
+	return AccountMappingResponse{
+		ComponentType:     m.ComponentType,
+		SourceType:        m.SourceType,
+		ComponentName:     m.ComponentName,
+		AccountCode:       m.AccountCode,
+		AccountName:       m.AccountName,
+		ContraAccountCode: m.ContraAccountCode,
+		ContraAccountName: m.ContraAccountName,
+		SplitByCostCenter: m.SplitByCostCenter,
+	}
+}
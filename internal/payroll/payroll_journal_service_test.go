@@ -0,0 +1,297 @@
+package payroll_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	journalPayrollA = uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000001")
+	journalPayrollB = uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000002")
+)
+
+func journalRows() []payroll.JournalPayrollRow {
+	strPtr := func(v string) *string { return &v }
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	return []payroll.JournalPayrollRow{
+		{
+			PayrollID:      journalPayrollA,
+			EmployeeName:   "Andi",
+			DepartmentName: strPtr("Engineering"),
+			Status:         payroll.StatusApproved,
+			BaseSalary:     10000000,
+			Allowance:      1000000, // 500.000 dari template, 500.000 lump-sum manual
+			OvertimeAmount: 200000,
+			Deduction:      800000,
+			NetSalary:      10400000,
+			EmployerCost:   300000,
+			PeriodStart:    periodStart,
+			PeriodEnd:      periodEnd,
+		},
+		{
+			PayrollID:      journalPayrollB,
+			EmployeeName:   "Budi",
+			DepartmentName: strPtr("Finance"),
+			Status:         payroll.StatusPaid,
+			BaseSalary:     5000000,
+			Deduction:      50000,
+			NetSalary:      4950000,
+			EmployerCost:   150000,
+			PeriodStart:    periodStart,
+			PeriodEnd:      periodEnd,
+		},
+	}
+}
+
+func journalComponents() []payroll.PayrollComponent {
+	source := func(v string) *string { return &v }
+	return []payroll.PayrollComponent{
+		{PayrollID: journalPayrollA, ComponentType: payroll.ComponentTypeAllowance, ComponentName: "Transport", TotalAmount: 500000, SourceType: source(payroll.ComponentSourceTemplate)},
+		{PayrollID: journalPayrollA, ComponentType: payroll.ComponentTypeDeduction, ComponentName: "PPh 21", TotalAmount: 200000, SourceType: source(payroll.ComponentSourceTax)},
+		{PayrollID: journalPayrollA, ComponentType: payroll.ComponentTypeDeduction, ComponentName: "BPJS Kesehatan", TotalAmount: 100000, SourceType: source(payroll.ComponentSourceBPJS)},
+		{PayrollID: journalPayrollA, ComponentType: payroll.ComponentTypeDeduction, ComponentName: "Cicilan Pinjaman", TotalAmount: 500000, SourceType: source(payroll.ComponentSourceLoan)},
+		{PayrollID: journalPayrollA, ComponentType: payroll.ComponentTypeEmployerContribution, ComponentName: "BPJS Kesehatan", TotalAmount: 300000, SourceType: source(payroll.ComponentSourceBPJS)},
+		{PayrollID: journalPayrollB, ComponentType: payroll.ComponentTypeDeduction, ComponentName: "BPJS Kesehatan", TotalAmount: 50000, SourceType: source(payroll.ComponentSourceBPJS)},
+		{PayrollID: journalPayrollB, ComponentType: payroll.ComponentTypeEmployerContribution, ComponentName: "BPJS Kesehatan", TotalAmount: 150000, SourceType: source(payroll.ComponentSourceBPJS)},
+	}
+}
+
+func TestPayrollService_ExportJournal(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	runID := uuid.New()
+
+	t.Run("balanced csv by cost center", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.PayrollRun, error) {
+			return &payroll.PayrollRun{
+				ID:          runID,
+				Status:      payroll.StatusApproved,
+				PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			}, nil
+		}
+		deps.repo.findJournalPayrollsFn = func(ctx context.Context, cid string, filter payroll.JournalQueryFilter) ([]payroll.JournalPayrollRow, error) {
+			if assert.NotNil(t, filter.RunID) {
+				assert.Equal(t, runID.String(), *filter.RunID)
+			}
+			assert.ElementsMatch(t, []string{payroll.StatusApproved, payroll.StatusPaid, payroll.StatusReversed}, filter.Statuses)
+			return journalRows(), nil
+		}
+		deps.repo.findComponentsByIDsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.PayrollComponent, error) {
+			assert.ElementsMatch(t, []string{journalPayrollA.String(), journalPayrollB.String()}, ids)
+			return journalComponents(), nil
+		}
+		deps.repo.findAccountMappingsFn = func(ctx context.Context, cid string) ([]payroll.AccountMapping, error) {
+			name := "transport"
+			return []payroll.AccountMapping{{
+				ComponentType:     payroll.ComponentTypeAllowance,
+				ComponentName:     &name,
+				AccountCode:       "6-1310",
+				AccountName:       "Beban Transport",
+				SplitByCostCenter: true,
+			}}, nil
+		}
+
+		file, err := deps.service.ExportJournal(ctx, companyID, payroll.JournalExportRequest{
+			RunID:  runID.String(),
+			Format: "csv",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, file.PayrollCount)
+		assert.Equal(t, 11, file.LineCount)
+		assert.Equal(t, int64(16650000), file.TotalDebit)
+		assert.Equal(t, "text/csv", file.ContentType)
+
+		reader := csv.NewReader(bytes.NewReader(file.Content))
+		records, err := reader.ReadAll()
+		assert.NoError(t, err)
+		var lines [][]string
+		var debit, credit int64
+		for _, record := range records[1:] {
+			assert.Equal(t, "2026-03-31", record[1])
+			lines = append(lines, []string{record[2], record[4], record[6], record[7]})
+			d, _ := strconv.ParseInt(record[6], 10, 64)
+			c, _ := strconv.ParseInt(record[7], 10, 64)
+			debit += d
+			credit += c
+		}
+		assert.Equal(t, debit, credit)
+		assert.Equal(t, [][]string{
+			{"6-1100", "Engineering", "10000000", "0"},
+			{"6-1100", "Finance", "5000000", "0"},
+			{"6-1200", "Engineering", "200000", "0"},
+			{"6-1300", "Engineering", "500000", "0"},
+			{"6-1310", "Engineering", "500000", "0"},
+			{"6-1500", "Engineering", "300000", "0"},
+			{"6-1500", "Finance", "150000", "0"},
+			{"1-1400", "", "0", "500000"},
+			{"2-1100", "", "0", "15350000"},
+			{"2-1200", "", "0", "200000"},
+			{"2-1300", "", "0", "600000"},
+		}, lines)
+	})
+
+	t.Run("reversal posts its negated components with flipped sides", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		reversalID := uuid.New()
+		deps.repo.findJournalPayrollsFn = func(ctx context.Context, cid string, filter payroll.JournalQueryFilter) ([]payroll.JournalPayrollRow, error) {
+			assert.Nil(t, filter.RunID)
+			if assert.NotNil(t, filter.PeriodStart) && assert.NotNil(t, filter.PeriodEnd) {
+				assert.Equal(t, "2026-03-01", *filter.PeriodStart)
+				assert.Equal(t, "2026-03-31", *filter.PeriodEnd)
+			}
+			original := journalRows()[1]
+			return []payroll.JournalPayrollRow{{
+				PayrollID:      reversalID,
+				DepartmentName: original.DepartmentName,
+				Status:         payroll.StatusApproved,
+				BaseSalary:     -original.BaseSalary,
+				Deduction:      -original.Deduction,
+				NetSalary:      -original.NetSalary,
+				EmployerCost:   -original.EmployerCost,
+				ReversalOfID:   &journalPayrollB,
+			}}, nil
+		}
+		deps.repo.findComponentsByIDsFn = func(ctx context.Context, cid string, ids []string) ([]payroll.PayrollComponent, error) {
+			assert.Equal(t, []string{reversalID.String()}, ids)
+			var items []payroll.PayrollComponent
+			for _, c := range journalComponents()[5:] {
+				c.PayrollID = reversalID
+				c.UnitAmount, c.TotalAmount = -c.UnitAmount, -c.TotalAmount
+				items = append(items, c)
+			}
+			return items, nil
+		}
+
+		file, err := deps.service.ExportJournal(ctx, companyID, payroll.JournalExportRequest{
+			Period:      "2026-03",
+			Format:      "ACCURATE",
+			PostingDate: "2026-04-05",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", file.ContentType)
+
+		var voucher struct {
+			TransDate string `json:"transDate"`
+			Details   []struct {
+				AccountNo      string `json:"accountNo"`
+				Amount         int64  `json:"amount"`
+				AmountType     string `json:"amountType"`
+				DepartmentName string `json:"departmentName"`
+			} `json:"detailJournalVoucher"`
+		}
+		assert.NoError(t, json.Unmarshal(file.Content, &voucher))
+		assert.Equal(t, "05/04/2026", voucher.TransDate)
+		if assert.Len(t, voucher.Details, 4) {
+			assert.Equal(t, "2-1100", voucher.Details[0].AccountNo)
+			assert.Equal(t, "DEBIT", voucher.Details[0].AmountType)
+			assert.Equal(t, int64(4950000), voucher.Details[0].Amount)
+			assert.Equal(t, "2-1300", voucher.Details[1].AccountNo)
+			assert.Equal(t, int64(200000), voucher.Details[1].Amount)
+			assert.Equal(t, "6-1100", voucher.Details[2].AccountNo)
+			assert.Equal(t, "CREDIT", voucher.Details[2].AmountType)
+			assert.Equal(t, "Finance", voucher.Details[2].DepartmentName)
+			assert.Equal(t, "6-1500", voucher.Details[3].AccountNo)
+			assert.Equal(t, int64(150000), voucher.Details[3].Amount)
+		}
+	})
+
+	t.Run("run not approved", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.PayrollRun, error) {
+			return &payroll.PayrollRun{ID: runID, Status: payroll.StatusDraft}, nil
+		}
+
+		_, err := deps.service.ExportJournal(ctx, companyID, payroll.JournalExportRequest{RunID: runID.String(), Format: "JURNAL"})
+		assert.ErrorIs(t, err, payrollerrors.ErrJournalRunNotApproved)
+	})
+
+	t.Run("requires exactly one scope", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.ExportJournal(ctx, companyID, payroll.JournalExportRequest{RunID: runID.String(), Period: "2026-03", Format: "CSV"})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidJournalScope)
+
+		_, err = deps.service.ExportJournal(ctx, companyID, payroll.JournalExportRequest{Period: "2026-03", Format: "XLS"})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidJournalFormat)
+	})
+}
+
+func TestPayrollService_UpdateAccountMappings(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	strPtr := func(v string) *string { return &v }
+
+	t.Run("replaces mappings and lists remaining defaults", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.replaceAccountMappingsFn = func(ctx context.Context, cid string, mappings []payroll.AccountMapping) error {
+			if assert.Len(t, mappings, 1) {
+				assert.Equal(t, "2-1310", *mappings[0].ContraAccountCode)
+				assert.Equal(t, payroll.ComponentSourceBPJS, *mappings[0].SourceType)
+			}
+			return nil
+		}
+
+		resp, err := deps.service.UpdateAccountMappings(ctx, companyID, actorID, payroll.UpdateAccountMappingsRequest{
+			Mappings: []payroll.AccountMappingRequest{{
+				ComponentType:     "employer_contribution",
+				SourceType:        strPtr("bpjs"),
+				AccountCode:       "6-1510",
+				AccountName:       "Beban BPJS",
+				ContraAccountCode: strPtr("2-1310"),
+			}},
+		})
+
+		assert.NoError(t, err)
+		if assert.NotEmpty(t, resp) {
+			assert.False(t, resp[0].IsDefault)
+			assert.NotNil(t, resp[0].ID)
+			assert.True(t, resp[len(resp)-1].IsDefault)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("rejects invalid mappings", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		cases := [][]payroll.AccountMappingRequest{
+			{{ComponentType: "EMPLOYER_CONTRIBUTION", AccountCode: "6-1500", AccountName: "Beban BPJS"}},
+			{{ComponentType: "NET_PAYABLE", SourceType: strPtr("TAX"), AccountCode: "2-1100", AccountName: "Utang Gaji"}},
+			{{ComponentType: "SALARY", AccountCode: "6-1100", AccountName: "Beban Gaji"}},
+			{
+				{ComponentType: "DEDUCTION", SourceType: strPtr("TAX"), AccountCode: "2-1200", AccountName: "Utang PPh 21"},
+				{ComponentType: "DEDUCTION", SourceType: strPtr("tax"), AccountCode: "2-1201", AccountName: "Utang PPh 21"},
+			},
+		}
+		for _, mappings := range cases {
+			_, err := deps.service.UpdateAccountMappings(ctx, companyID, actorID, payroll.UpdateAccountMappingsRequest{Mappings: mappings})
+			assert.ErrorIs(t, err, payrollerrors.ErrInvalidAccountMapping)
+		}
+	})
+}
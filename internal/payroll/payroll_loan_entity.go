@@ -0,0 +1,81 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Jenis pinjaman karyawan. Kasbon biasanya dilunasi dalam satu kali potong gaji.
+	LoanTypeLoan          = "LOAN"
+	LoanTypeSalaryAdvance = "SALARY_ADVANCE"
+
+	LoanStatusActive  = "ACTIVE"
+	LoanStatusPaidOff = "PAID_OFF"
+
+	// Cara pembayaran cicilan: dipotong dari payroll atau pelunasan dipercepat di luar payroll.
+	LoanRepaymentPayroll = "PAYROLL"
+	LoanRepaymentPayoff  = "PAYOFF"
+
+	// ComponentSourceLoan menandai komponen potongan cicilan; SourceID berisi id EmployeeLoan.
+	ComponentSourceLoan = "LOAN"
+)
+
+// EmployeeLoan adalah pinjaman atau kasbon karyawan yang dikembalikan lewat potongan
+// payroll REGULAR, satu cicilan per periode mulai StartPeriod.
+type EmployeeLoan struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID  uuid.UUID      `gorm:"type:uuid;not null;index"`
+	EmployeeID uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Employee   *LeaveEmployee `gorm:"foreignKey:EmployeeID;references:ID"`
+	LoanType   string         `gorm:"type:varchar(20);not null"`
+
+	Principal         int64 `gorm:"type:bigint;not null"`
+	InstallmentCount  int64 `gorm:"type:bigint;not null"`
+	InstallmentAmount int64 `gorm:"type:bigint;not null"` // Pembulatan ke atas; cicilan terakhir mengikuti sisa pinjaman
+
+	// StartPeriod adalah tanggal pertama bulan cicilan pertama dipotong.
+	StartPeriod time.Time `gorm:"type:date;not null"`
+
+	OutstandingBalance int64   `gorm:"type:bigint;not null"`
+	PaidInstallments   int64   `gorm:"type:bigint;not null;default:0"`
+	Status             string  `gorm:"type:varchar(20);not null;default:'ACTIVE'"`
+	Notes              *string `gorm:"type:text"`
+
+	PaidOffAt *time.Time `gorm:"type:timestamptz"`
+
+	Repayments []LoanRepayment `gorm:"foreignKey:LoanID;references:ID"`
+
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (EmployeeLoan) TableName() string {
+	return "employee_loans"
+}
+
+// LoanRepayment mencatat setiap pengurangan sisa pinjaman. Repayment dari payroll yang
+// dihapus, dibatalkan, atau dibalik ditandai ReversedAt dan sisa pinjaman dikembalikan.
+type LoanRepayment struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	LoanID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PayrollID     *uuid.UUID `gorm:"type:uuid;index"`
+	RepaymentType string     `gorm:"type:varchar(20);not null"`
+	Amount        int64      `gorm:"type:bigint;not null"`
+	// BalanceAfter adalah sisa pinjaman setelah repayment ini.
+	BalanceAfter int64     `gorm:"type:bigint;not null"`
+	PaidOn       time.Time `gorm:"type:date;not null"`
+	Notes        *string   `gorm:"type:text"`
+
+	ReversedAt *time.Time `gorm:"type:timestamptz"`
+
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt time.Time
+}
+
+func (LoanRepayment) TableName() string {
+	return "employee_loan_repayments"
+}
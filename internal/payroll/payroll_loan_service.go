@@ -0,0 +1,420 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxLoanInstallments = 120
+
+func (s *service) GetLoans(ctx context.Context, companyID string, req GetLoansFilterRequest) ([]LoanResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+
+	filter := LoanQueryFilter{}
+	if req.EmployeeID != "" {
+		if _, err := uuid.Parse(req.EmployeeID); err != nil {
+			return nil, payrollerrors.ErrInvalidEmployeeID
+		}
+		filter.EmployeeID = &req.EmployeeID
+	}
+	if req.Status != "" {
+		status := strings.ToUpper(strings.TrimSpace(req.Status))
+		if status != LoanStatusActive && status != LoanStatusPaidOff {
+			return nil, payrollerrors.ErrInvalidLoanStatusFilter
+		}
+		filter.Status = &status
+	}
+
+	loans, err := s.repo.FindLoans(ctx, companyID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]LoanResponse, len(loans))
+	for i, loan := range loans {
+		resp[i] = mapToLoanResponse(loan)
+	}
+	return resp, nil
+}
+
+func (s *service) GetLoanByID(ctx context.Context, companyID, id string) (LoanResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return LoanResponse{}, payrollerrors.ErrLoanNotFound
+	}
+
+	loan, err := s.repo.FindLoanByID(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return LoanResponse{}, payrollerrors.ErrLoanNotFound
+		}
+		return LoanResponse{}, err
+	}
+	return mapToLoanResponse(*loan), nil
+}
+
+// CreateLoan mencatat pinjaman atau kasbon karyawan. Payroll DRAFT mulai StartPeriod
+// ditandai stale agar cicilan pertama ikut terpotong saat di-regenerate.
+func (s *service) CreateLoan(
+	ctx context.Context,
+	companyID, actorID string,
+	req CreateLoanRequest,
+) (LoanResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	employeeUUID, err := uuid.Parse(req.EmployeeID)
+	if err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidEmployeeID
+	}
+
+	loanType := strings.ToUpper(strings.TrimSpace(req.LoanType))
+	if loanType == "" {
+		loanType = LoanTypeLoan
+	}
+	if loanType != LoanTypeLoan && loanType != LoanTypeSalaryAdvance {
+		return LoanResponse{}, payrollerrors.ErrInvalidLoan
+	}
+	installments := req.InstallmentCount
+	if installments == 0 {
+		installments = 1
+	}
+	if req.Principal <= 0 || installments < 1 || installments > maxLoanInstallments || installments > req.Principal {
+		return LoanResponse{}, payrollerrors.ErrInvalidLoan
+	}
+	startStr, _, err := parseMonthPeriod(req.StartPeriod)
+	if err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidLoan
+	}
+	startPeriod, _ := parseDate(startStr)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return LoanResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	belongs, err := qtx.EmployeeBelongsToCompany(ctx, companyID, req.EmployeeID)
+	if err != nil {
+		return LoanResponse{}, err
+	}
+	if !belongs {
+		return LoanResponse{}, payrollerrors.ErrEmployeeNotInCompany
+	}
+
+	loan := &EmployeeLoan{
+		ID:                 uuid.New(),
+		CompanyID:          companyUUID,
+		EmployeeID:         employeeUUID,
+		LoanType:           loanType,
+		Principal:          req.Principal,
+		InstallmentCount:   installments,
+		InstallmentAmount:  (req.Principal + installments - 1) / installments,
+		StartPeriod:        startPeriod,
+		OutstandingBalance: req.Principal,
+		Status:             LoanStatusActive,
+		Notes:              req.Notes,
+		CreatedBy:          actorUUID,
+	}
+	if err := qtx.CreateLoan(ctx, loan); err != nil {
+		return LoanResponse{}, err
+	}
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, req.EmployeeID, startPeriod, nil, "loan "+loan.ID.String()+" created"); err != nil {
+		return LoanResponse{}, err
+	}
+
+	persisted, err := qtx.FindLoanByID(ctx, companyID, loan.ID.String())
+	if err != nil {
+		return LoanResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return LoanResponse{}, err
+	}
+
+	return mapToLoanResponse(*persisted), nil
+}
+
+// PayoffLoan melunasi sisa pinjaman di luar payroll (pelunasan dipercepat). Payroll DRAFT
+// yang belum memotong cicilan ditandai stale agar potongannya hilang saat di-regenerate.
+func (s *service) PayoffLoan(
+	ctx context.Context,
+	companyID, actorID, id string,
+	req PayoffLoanRequest,
+) (LoanResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return LoanResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return LoanResponse{}, payrollerrors.ErrLoanNotFound
+	}
+
+	now := time.Now().UTC()
+	paidOn := dateOnly(now)
+	if strings.TrimSpace(req.PaidOn) != "" {
+		paidOn, err = parseDate(strings.TrimSpace(req.PaidOn))
+		if err != nil {
+			return LoanResponse{}, err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return LoanResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	loan, err := qtx.FindLoanByID(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return LoanResponse{}, payrollerrors.ErrLoanNotFound
+		}
+		return LoanResponse{}, err
+	}
+	if loan.Status != LoanStatusActive || loan.OutstandingBalance <= 0 {
+		return LoanResponse{}, payrollerrors.ErrLoanAlreadyPaidOff
+	}
+
+	repayment := &LoanRepayment{
+		ID:            uuid.New(),
+		CompanyID:     loan.CompanyID,
+		LoanID:        loan.ID,
+		RepaymentType: LoanRepaymentPayoff,
+		Amount:        loan.OutstandingBalance,
+		BalanceAfter:  0,
+		PaidOn:        paidOn,
+		Notes:         req.Notes,
+		CreatedBy:     actorUUID,
+	}
+	if err := qtx.CreateLoanRepayment(ctx, repayment); err != nil {
+		return LoanResponse{}, err
+	}
+
+	loan.OutstandingBalance = 0
+	loan.Status = LoanStatusPaidOff
+	loan.PaidOffAt = &now
+	if err := qtx.UpdateLoan(ctx, loan); err != nil {
+		return LoanResponse{}, err
+	}
+	if err := qtx.MarkPayrollsStaleByEmployee(ctx, companyID, loan.EmployeeID.String(), paidOn, nil, "loan "+id+" paid off"); err != nil {
+		return LoanResponse{}, err
+	}
+
+	persisted, err := qtx.FindLoanByID(ctx, companyID, id)
+	if err != nil {
+		return LoanResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return LoanResponse{}, err
+	}
+
+	return mapToLoanResponse(*persisted), nil
+}
+
+// loanDeductions membuat komponen DEDUCTION cicilan untuk setiap pinjaman aktif yang
+// sudah jatuh tempo di periode ini. Cicilan terakhir mengikuti sisa pinjaman.
+func loanDeductions(companyID uuid.UUID, payrollID *uuid.UUID, inputs payrollInputs) ([]PayrollComponent, error) {
+	payrollRef := uuid.Nil
+	if payrollID != nil {
+		payrollRef = *payrollID
+	}
+	source := ComponentSourceLoan
+
+	deductions := make([]PayrollComponent, 0)
+	for _, loan := range inputs.Loans {
+		amount := min(loan.InstallmentAmount, loan.OutstandingBalance)
+		if loan.Status != LoanStatusActive || amount <= 0 {
+			continue
+		}
+
+		installment := loan.PaidInstallments + 1
+		note := fmt.Sprintf("Cicilan %d/%d, sisa setelah potongan %s",
+			installment, loan.InstallmentCount, formatRupiah(loan.OutstandingBalance-amount))
+		metadata, err := json.Marshal(map[string]any{
+			"loan_id":            loan.ID.String(),
+			"loan_type":          loan.LoanType,
+			"installment_number": installment,
+			"installment_count":  loan.InstallmentCount,
+			"balance_before":     loan.OutstandingBalance,
+			"balance_after":      loan.OutstandingBalance - amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		raw := string(metadata)
+		loanID := loan.ID
+
+		name := "Cicilan Pinjaman"
+		if loan.LoanType == LoanTypeSalaryAdvance {
+			name = "Potongan Kasbon"
+		}
+		deductions = append(deductions, PayrollComponent{
+			ID:            uuid.New(),
+			PayrollID:     payrollRef,
+			CompanyID:     companyID,
+			ComponentType: ComponentTypeDeduction,
+			ComponentName: name,
+			Quantity:      1,
+			UnitAmount:    amount,
+			TotalAmount:   amount,
+			Notes:         &note,
+			SourceType:    &source,
+			SourceID:      &loanID,
+			Metadata:      &raw,
+		})
+	}
+	return deductions, nil
+}
+
+// postLoanRepayments mengurangi sisa pinjaman sesuai komponen cicilan payroll yang baru
+// dipersist dan mencatat repayment-nya.
+func (s *service) postLoanRepayments(ctx context.Context, qtx Repository, payroll *Payroll, loans []EmployeeLoan, components []PayrollComponent) error {
+	byID := make(map[uuid.UUID]EmployeeLoan, len(loans))
+	for _, loan := range loans {
+		byID[loan.ID] = loan
+	}
+
+	for _, component := range components {
+		if component.SourceType == nil || *component.SourceType != ComponentSourceLoan || component.SourceID == nil {
+			continue
+		}
+		loan, ok := byID[*component.SourceID]
+		if !ok {
+			continue
+		}
+
+		loan.OutstandingBalance -= component.TotalAmount
+		loan.PaidInstallments++
+		if loan.OutstandingBalance <= 0 {
+			now := time.Now().UTC()
+			loan.OutstandingBalance = 0
+			loan.Status = LoanStatusPaidOff
+			loan.PaidOffAt = &now
+		}
+		if err := qtx.UpdateLoan(ctx, &loan); err != nil {
+			return err
+		}
+
+		payrollID := payroll.ID
+		if err := qtx.CreateLoanRepayment(ctx, &LoanRepayment{
+			ID:            uuid.New(),
+			CompanyID:     payroll.CompanyID,
+			LoanID:        loan.ID,
+			PayrollID:     &payrollID,
+			RepaymentType: LoanRepaymentPayroll,
+			Amount:        component.TotalAmount,
+			BalanceAfter:  loan.OutstandingBalance,
+			PaidOn:        payroll.PeriodEnd,
+			CreatedBy:     payroll.CreatedBy,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollbackLoanRepayments mengembalikan sisa pinjaman dari cicilan yang dipotong payroll,
+// dipakai saat payroll dihapus, di-regenerate, dibatalkan, atau dibalik.
+func (s *service) rollbackLoanRepayments(ctx context.Context, qtx Repository, companyID, payrollID string) error {
+	repayments, err := qtx.FindLoanRepaymentsByPayroll(ctx, companyID, payrollID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for i := range repayments {
+		repayment := &repayments[i]
+		loan, err := qtx.FindLoanByID(ctx, companyID, repayment.LoanID.String())
+		if err != nil {
+			return err
+		}
+
+		loan.OutstandingBalance += repayment.Amount
+		if loan.PaidInstallments > 0 {
+			loan.PaidInstallments--
+		}
+		loan.Status = LoanStatusActive
+		loan.PaidOffAt = nil
+		if err := qtx.UpdateLoan(ctx, loan); err != nil {
+			return err
+		}
+
+		repayment.ReversedAt = &now
+		if err := qtx.UpdateLoanRepayment(ctx, repayment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mapToLoanResponse(loan EmployeeLoan) LoanResponse {
+	resp := LoanResponse{
+		ID:                 loan.ID.String(),
+		CompanyID:          loan.CompanyID.String(),
+		EmployeeID:         loan.EmployeeID.String(),
+		LoanType:           loan.LoanType,
+		Principal:          loan.Principal,
+		InstallmentCount:   loan.InstallmentCount,
+		InstallmentAmount:  loan.InstallmentAmount,
+		StartPeriod:        loan.StartPeriod.Format("2006-01"),
+		OutstandingBalance: loan.OutstandingBalance,
+		PaidInstallments:   loan.PaidInstallments,
+		Status:             loan.Status,
+		Notes:              loan.Notes,
+		CreatedBy:          loan.CreatedBy.String(),
+		CreatedAt:          loan.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          loan.UpdatedAt.Format(time.RFC3339),
+	}
+	if loan.Employee != nil {
+		resp.EmployeeName = loan.Employee.FullName
+	}
+	if loan.PaidOffAt != nil {
+		v := loan.PaidOffAt.Format(time.RFC3339)
+		resp.PaidOffAt = &v
+	}
+	for _, repayment := range loan.Repayments {
+		item := LoanRepaymentResponse{
+			ID:            repayment.ID.String(),
+			PayrollID:     uuidPtrToString(repayment.PayrollID),
+			RepaymentType: repayment.RepaymentType,
+			Amount:        repayment.Amount,
+			BalanceAfter:  repayment.BalanceAfter,
+			PaidOn:        repayment.PaidOn.Format("2006-01-02"),
+			Notes:         repayment.Notes,
+			CreatedAt:     repayment.CreatedAt.Format(time.RFC3339),
+		}
+		if repayment.ReversedAt != nil {
+			v := repayment.ReversedAt.Format(time.RFC3339)
+			item.ReversedAt = &v
+		}
+		resp.Repayments = append(resp.Repayments, item)
+	}
+	return resp
+}
@@ -0,0 +1,337 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_CreateLoan(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	t.Run("success", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		var saved *payroll.EmployeeLoan
+		deps.repo.createLoanFn = func(ctx context.Context, loan *payroll.EmployeeLoan) error {
+			saved = loan
+			return nil
+		}
+		deps.repo.findLoanByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeLoan, error) {
+			return saved, nil
+		}
+		var staleFrom time.Time
+		deps.repo.markStaleByEmployeeFn = func(ctx context.Context, cid, eid string, from time.Time, to *time.Time, reason string) error {
+			staleFrom = from
+			return nil
+		}
+
+		resp, err := deps.service.CreateLoan(ctx, companyID, actorID, payroll.CreateLoanRequest{
+			EmployeeID:       employeeID,
+			Principal:        1000000,
+			InstallmentCount: 3,
+			StartPeriod:      "2026-03",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.LoanTypeLoan, resp.LoanType)
+		assert.Equal(t, payroll.LoanStatusActive, resp.Status)
+		assert.Equal(t, int64(333334), resp.InstallmentAmount)
+		assert.Equal(t, int64(1000000), resp.OutstandingBalance)
+		assert.Equal(t, "2026-03", resp.StartPeriod)
+		assert.Equal(t, "2026-03-01", staleFrom.Format("2006-01-02"))
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid installment count", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.CreateLoan(ctx, companyID, actorID, payroll.CreateLoanRequest{
+			EmployeeID:       employeeID,
+			LoanType:         payroll.LoanTypeSalaryAdvance,
+			Principal:        1000000,
+			InstallmentCount: 121,
+			StartPeriod:      "2026-03",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidLoan)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_GetLoanByID(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	t.Run("success", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		loanID := uuid.New()
+		deps.repo.findLoanByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeLoan, error) {
+			assert.Equal(t, loanID.String(), id)
+			return &payroll.EmployeeLoan{ID: loanID, Status: payroll.LoanStatusActive}, nil
+		}
+
+		resp, err := deps.service.GetLoanByID(ctx, companyID, loanID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, loanID.String(), resp.ID)
+	})
+
+	t.Run("malformed id is not found", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.GetLoanByID(ctx, companyID, "not-a-uuid")
+		assert.ErrorIs(t, err, payrollerrors.ErrLoanNotFound)
+
+		_, err = deps.service.PayoffLoan(ctx, companyID, uuid.New().String(), "not-a-uuid", payroll.PayoffLoanRequest{})
+		assert.ErrorIs(t, err, payrollerrors.ErrLoanNotFound)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_PayoffLoan(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("success", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		loan := &payroll.EmployeeLoan{
+			ID:                 uuid.New(),
+			CompanyID:          uuid.MustParse(companyID),
+			EmployeeID:         uuid.New(),
+			LoanType:           payroll.LoanTypeLoan,
+			Principal:          1000000,
+			InstallmentCount:   3,
+			InstallmentAmount:  333334,
+			OutstandingBalance: 666666,
+			PaidInstallments:   1,
+			Status:             payroll.LoanStatusActive,
+		}
+		deps.repo.findLoanByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeLoan, error) {
+			return loan, nil
+		}
+		var repayment *payroll.LoanRepayment
+		deps.repo.createRepaymentFn = func(ctx context.Context, r *payroll.LoanRepayment) error {
+			repayment = r
+			return nil
+		}
+
+		resp, err := deps.service.PayoffLoan(ctx, companyID, actorID, loan.ID.String(), payroll.PayoffLoanRequest{PaidOn: "2026-04-10"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.LoanStatusPaidOff, resp.Status)
+		assert.Equal(t, int64(0), resp.OutstandingBalance)
+		assert.NotNil(t, resp.PaidOffAt)
+		if assert.NotNil(t, repayment) {
+			assert.Equal(t, payroll.LoanRepaymentPayoff, repayment.RepaymentType)
+			assert.Equal(t, int64(666666), repayment.Amount)
+			assert.Nil(t, repayment.PayrollID)
+			assert.Equal(t, "2026-04-10", repayment.PaidOn.Format("2006-01-02"))
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("already paid off", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		deps.repo.findLoanByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeLoan, error) {
+			return &payroll.EmployeeLoan{ID: uuid.New(), Status: payroll.LoanStatusPaidOff}, nil
+		}
+
+		_, err := deps.service.PayoffLoan(ctx, companyID, actorID, uuid.New().String(), payroll.PayoffLoanRequest{})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrLoanAlreadyPaidOff)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_Create_DeductsLoanInstallment(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	expectTx(t, deps.sqlMock, true)
+
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{
+			CompanyID:       uuid.MustParse(cid),
+			WorkHoursPerDay: 8,
+			WorkDaysPerWeek: 5,
+			TaxCalculator:   payroll.TaxCalculatorNone,
+		}, nil
+	}
+	deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+		return []payroll.SalaryHistory{{BaseSalary: 5000000, EffectiveDate: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)}}, nil
+	}
+	// Cicilan terakhir: sisa pinjaman lebih kecil dari nominal cicilan
+	loan := payroll.EmployeeLoan{
+		ID:                 uuid.New(),
+		CompanyID:          uuid.MustParse(companyID),
+		EmployeeID:         uuid.MustParse(employeeID),
+		LoanType:           payroll.LoanTypeLoan,
+		Principal:          1000000,
+		InstallmentCount:   3,
+		InstallmentAmount:  333334,
+		StartPeriod:        time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC),
+		OutstandingBalance: 333332,
+		PaidInstallments:   2,
+		Status:             payroll.LoanStatusActive,
+	}
+	deps.repo.findActiveLoansFn = func(ctx context.Context, cid, eid string, periodEnd time.Time) ([]payroll.EmployeeLoan, error) {
+		assert.Equal(t, "2026-02-28", periodEnd.Format("2006-01-02"))
+		return []payroll.EmployeeLoan{loan}, nil
+	}
+
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+	var updatedLoan *payroll.EmployeeLoan
+	deps.repo.updateLoanFn = func(ctx context.Context, l *payroll.EmployeeLoan) error {
+		updatedLoan = l
+		return nil
+	}
+	var repayment *payroll.LoanRepayment
+	deps.repo.createRepaymentFn = func(ctx context.Context, r *payroll.LoanRepayment) error {
+		repayment = r
+		return nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-02-01",
+		PeriodEnd:   "2026-02-28",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(333332), resp.TotalDeduction)
+	assert.Equal(t, int64(5000000-333332), resp.NetSalary)
+
+	var installment *payroll.PayrollComponent
+	for i := range components {
+		if components[i].SourceType != nil && *components[i].SourceType == payroll.ComponentSourceLoan {
+			installment = &components[i]
+		}
+	}
+	if assert.NotNil(t, installment) {
+		assert.Equal(t, payroll.ComponentTypeDeduction, installment.ComponentType)
+		assert.Equal(t, loan.ID, *installment.SourceID)
+		assert.Contains(t, *installment.Notes, "Cicilan 3/3")
+	}
+	if assert.NotNil(t, updatedLoan) {
+		assert.Equal(t, int64(0), updatedLoan.OutstandingBalance)
+		assert.Equal(t, int64(3), updatedLoan.PaidInstallments)
+		assert.Equal(t, payroll.LoanStatusPaidOff, updatedLoan.Status)
+	}
+	if assert.NotNil(t, repayment) {
+		assert.Equal(t, created.ID, *repayment.PayrollID)
+		assert.Equal(t, payroll.LoanRepaymentPayroll, repayment.RepaymentType)
+		assert.Equal(t, int64(333332), repayment.Amount)
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_Reverse_RollsBackLoanInstallment(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	expectTx(t, deps.sqlMock, true)
+
+	original := &payroll.Payroll{
+		ID:          uuid.New(),
+		CompanyID:   uuid.MustParse(companyID),
+		EmployeeID:  uuid.New(),
+		PayrollType: payroll.PayrollTypeRegular,
+		PeriodStart: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+		BaseSalary:  5000000,
+		Deduction:   333332,
+		NetSalary:   4666668,
+		Status:      payroll.StatusPaid,
+	}
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		if id == original.ID.String() {
+			return original, nil
+		}
+		return created, nil
+	}
+
+	paidOffAt := time.Now().UTC()
+	loan := &payroll.EmployeeLoan{
+		ID:                 uuid.New(),
+		CompanyID:          original.CompanyID,
+		EmployeeID:         original.EmployeeID,
+		InstallmentCount:   3,
+		OutstandingBalance: 0,
+		PaidInstallments:   3,
+		Status:             payroll.LoanStatusPaidOff,
+		PaidOffAt:          &paidOffAt,
+	}
+	repayment := payroll.LoanRepayment{ID: uuid.New(), LoanID: loan.ID, PayrollID: &original.ID, Amount: 333332}
+	deps.repo.findRepaymentsByPayFn = func(ctx context.Context, cid, pid string) ([]payroll.LoanRepayment, error) {
+		assert.Equal(t, original.ID.String(), pid)
+		return []payroll.LoanRepayment{repayment}, nil
+	}
+	deps.repo.findLoanByIDFn = func(ctx context.Context, cid, id string) (*payroll.EmployeeLoan, error) {
+		return loan, nil
+	}
+	var reversed *payroll.LoanRepayment
+	deps.repo.updateRepaymentFn = func(ctx context.Context, r *payroll.LoanRepayment) error {
+		reversed = r
+		return nil
+	}
+
+	_, err := deps.service.Reverse(ctx, companyID, actorID, original.ID.String(), payroll.CancelPayrollRequest{Reason: "salah transfer"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(333332), loan.OutstandingBalance)
+	assert.Equal(t, int64(2), loan.PaidInstallments)
+	assert.Equal(t, payroll.LoanStatusActive, loan.Status)
+	assert.Nil(t, loan.PaidOffAt)
+	if assert.NotNil(t, reversed) {
+		assert.NotNil(t, reversed.ReversedAt)
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
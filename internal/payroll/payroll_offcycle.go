@@ -0,0 +1,377 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Jenis payroll. REGULAR adalah gaji bulanan; jenis lain adalah payroll off-cycle yang
+// boleh berdampingan dengan payroll REGULAR di periode yang sama.
+const (
+	PayrollTypeRegular    = "REGULAR"
+	PayrollTypeTHR        = "THR"        // Tunjangan Hari Raya Keagamaan
+	PayrollTypeBonus      = "BONUS"      // Bonus/insentif di luar gaji bulanan
+	PayrollTypeCorrection = "CORRECTION" // Koreksi kurang/lebih bayar periode sebelumnya
+
+	ComponentSourceTHR = "THR"
+)
+
+// isExclusivePayrollType menandai jenis payroll yang hanya boleh satu per karyawan per
+// periode. BONUS dan CORRECTION boleh dibuat berkali-kali.
+func isExclusivePayrollType(payrollType string) bool {
+	return payrollType == PayrollTypeRegular || payrollType == PayrollTypeTHR
+}
+
+// normalizePayrollType mengembalikan jenis payroll dalam huruf besar, default REGULAR.
+func normalizePayrollType(payrollType string) (string, error) {
+	payrollType = strings.ToUpper(strings.TrimSpace(payrollType))
+	switch payrollType {
+	case "":
+		return PayrollTypeRegular, nil
+	case PayrollTypeRegular, PayrollTypeTHR, PayrollTypeBonus, PayrollTypeCorrection:
+		return payrollType, nil
+	}
+	return "", payrollerrors.ErrInvalidPayrollType
+}
+
+// payrollTypeOrRegular memperlakukan payroll lama tanpa jenis sebagai REGULAR.
+func payrollTypeOrRegular(payrollType string) string {
+	if payrollType == "" {
+		return PayrollTypeRegular
+	}
+	return payrollType
+}
+
+// offCycleRequest adalah input payroll off-cycle dari create maupun regenerate.
+type offCycleRequest struct {
+	PayrollType    string
+	ReferenceDate  time.Time
+	MonthlyWage    *int64 // Override upah sebulan untuk THR
+	Allowance      int64
+	Deduction      int64
+	AllowanceItems []PayrollComponentInput
+	DeductionItems []PayrollComponentInput
+}
+
+// offCycleResult adalah hasil perhitungan payroll off-cycle sebelum dipersist.
+type offCycleResult struct {
+	Allowances     []PayrollComponent
+	Deductions     []PayrollComponent
+	TotalAllowance int64
+	TotalDeduction int64
+	GrossIncome    int64
+	TaxAmount      int64
+	NetSalary      int64
+}
+
+// calculateOffCycle menghitung payroll THR, BONUS, atau CORRECTION. Payroll off-cycle
+// tidak memiliki gaji pokok, lembur, potongan kehadiran, komponen berulang, maupun
+// BPJS; penghasilannya hanya dari THR dan item yang dikirim, lalu dipotong PPh 21
+// bersama penghasilan payroll lain di periode yang sama.
+func (s *service) calculateOffCycle(
+	ctx context.Context,
+	repo Repository,
+	companyID, employeeID uuid.UUID,
+	payrollID *uuid.UUID,
+	periodStart, periodEnd time.Time,
+	req offCycleRequest,
+) (offCycleResult, error) {
+	if err := validateMoney(req.Allowance, req.Deduction); err != nil {
+		return offCycleResult{}, err
+	}
+
+	setting, err := s.loadSetting(ctx, repo, companyID)
+	if err != nil {
+		return offCycleResult{}, err
+	}
+
+	allowanceItems, deductionItems, err := buildComponents(companyID, payrollID, req.AllowanceItems, req.DeductionItems)
+	if err != nil {
+		return offCycleResult{}, err
+	}
+
+	switch req.PayrollType {
+	case PayrollTypeTHR:
+		thr, err := s.thrComponent(ctx, repo, companyID, employeeID, payrollID, req.ReferenceDate, req.MonthlyWage)
+		if err != nil {
+			return offCycleResult{}, err
+		}
+		allowanceItems = append([]PayrollComponent{thr}, allowanceItems...)
+	case PayrollTypeBonus:
+		if req.Allowance+sumComponents(allowanceItems) <= 0 {
+			return offCycleResult{}, payrollerrors.ErrInvalidOffCyclePayroll
+		}
+	case PayrollTypeCorrection:
+		if req.Allowance+req.Deduction+sumComponents(allowanceItems)+sumComponents(deductionItems) <= 0 {
+			return offCycleResult{}, payrollerrors.ErrInvalidOffCyclePayroll
+		}
+	}
+
+	grossIncome := req.Allowance + sumComponents(allowanceItems)
+	taxAmount, taxComponent, err := s.calculateTax(ctx, repo, setting, companyID, employeeID, payrollID, periodStart, periodEnd, grossIncome, 0)
+	if err != nil {
+		return offCycleResult{}, err
+	}
+	allowanceItems, deductionItems = appendTaxComponent(allowanceItems, deductionItems, taxComponent)
+
+	totalAllowance := req.Allowance + sumComponents(allowanceItems)
+	totalDeduction := req.Deduction + sumComponents(deductionItems)
+	if err := validateMoney(totalAllowance, totalDeduction); err != nil {
+		return offCycleResult{}, err
+	}
+
+	return offCycleResult{
+		Allowances:     allowanceItems,
+		Deductions:     deductionItems,
+		TotalAllowance: totalAllowance,
+		TotalDeduction: totalDeduction,
+		GrossIncome:    grossIncome,
+		TaxAmount:      taxAmount,
+		NetSalary:      totalAllowance - totalDeduction,
+	}, nil
+}
+
+// thrEntitlement menghitung THR sesuai Permenaker 6/2016: masa kerja kurang dari 1 bulan
+// tidak berhak, 1-11 bulan mendapat masa kerja/12 x upah sebulan, dan 12 bulan atau
+// lebih mendapat 1 x upah sebulan. Masa kerja dihitung dalam bulan penuh sampai
+// tanggal hari raya.
+func thrEntitlement(hireDate, referenceDate time.Time, monthlyWage int64) (int64, int64) {
+	months := int64(referenceDate.Year()-hireDate.Year())*12 + int64(referenceDate.Month()-hireDate.Month())
+	if referenceDate.Day() < hireDate.Day() {
+		months--
+	}
+	switch {
+	case months < 1:
+		return months, 0
+	case months >= 12:
+		return months, monthlyWage
+	default:
+		return months, monthlyWage * months / 12
+	}
+}
+
+// thrComponent membuat komponen ALLOWANCE THR. Upah sebulan adalah gaji pokok yang
+// berlaku pada tanggal hari raya ditambah tunjangan tetap (template FIXED ALLOWANCE
+// yang aktif pada tanggal tersebut), kecuali diisi manual.
+func (s *service) thrComponent(
+	ctx context.Context,
+	repo Repository,
+	companyID, employeeID uuid.UUID,
+	payrollID *uuid.UUID,
+	referenceDate time.Time,
+	monthlyWageOverride *int64,
+) (PayrollComponent, error) {
+	employment, err := repo.FindEmploymentPeriod(ctx, companyID.String(), employeeID.String(), referenceDate, referenceDate)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollComponent{}, payrollerrors.ErrEmployeeNotInCompany
+		}
+		return PayrollComponent{}, err
+	}
+	if employment.HireDate.IsZero() {
+		return PayrollComponent{}, payrollerrors.ErrTHRNotEligible
+	}
+	hireDate := dateOnly(employment.HireDate)
+
+	var baseSalary, fixedAllowances, monthlyWage int64
+	if monthlyWageOverride != nil {
+		if *monthlyWageOverride < 0 {
+			return PayrollComponent{}, payrollerrors.ErrInvalidMoneyValue
+		}
+		monthlyWage = *monthlyWageOverride
+	} else {
+		history, err := repo.FindSalaryHistory(ctx, employeeID.String(), referenceDate, referenceDate)
+		if err != nil {
+			return PayrollComponent{}, err
+		}
+		if len(history) == 0 {
+			return PayrollComponent{}, payrollerrors.ErrEmployeeSalaryNotFound
+		}
+		baseSalary = history[len(history)-1].BaseSalary
+
+		assignments, err := repo.FindEffectiveComponentAssignments(ctx, companyID.String(), employeeID.String(), referenceDate, referenceDate)
+		if err != nil {
+			return PayrollComponent{}, err
+		}
+		for _, assignment := range assignments {
+			template := assignment.Template
+			if template == nil || !template.IsActive ||
+				template.ComponentType != ComponentTypeAllowance || template.CalculationType != CalculationFixed {
+				continue
+			}
+			amount := template.Amount
+			if assignment.Amount != nil {
+				amount = *assignment.Amount
+			}
+			fixedAllowances += amount
+		}
+		monthlyWage = baseSalary + fixedAllowances
+	}
+
+	months, amount := thrEntitlement(hireDate, referenceDate, monthlyWage)
+	if amount <= 0 {
+		return PayrollComponent{}, payrollerrors.ErrTHRNotEligible
+	}
+
+	factor := "1 x"
+	if months < 12 {
+		factor = fmt.Sprintf("%d/12 x", months)
+	}
+	note := fmt.Sprintf("Masa kerja %d bulan per %s: %s upah sebulan %s",
+		months, referenceDate.Format("2006-01-02"), factor, formatRupiah(monthlyWage))
+	metadata, err := json.Marshal(map[string]any{
+		"hire_date":        hireDate.Format("2006-01-02"),
+		"reference_date":   referenceDate.Format("2006-01-02"),
+		"service_months":   months,
+		"monthly_wage":     monthlyWage,
+		"base_salary":      baseSalary,
+		"fixed_allowances": fixedAllowances,
+		"wage_override":    monthlyWageOverride != nil,
+	})
+	if err != nil {
+		return PayrollComponent{}, err
+	}
+	raw := string(metadata)
+	source := ComponentSourceTHR
+
+	component := PayrollComponent{
+		ID:            uuid.New(),
+		CompanyID:     companyID,
+		ComponentType: ComponentTypeAllowance,
+		ComponentName: "THR Keagamaan",
+		Quantity:      1,
+		UnitAmount:    amount,
+		TotalAmount:   amount,
+		Notes:         &note,
+		SourceType:    &source,
+		Metadata:      &raw,
+	}
+	if payrollID != nil {
+		component.PayrollID = *payrollID
+	}
+	return component, nil
+}
+
+// resolveReferenceDate mengembalikan tanggal hari raya untuk THR, default akhir periode.
+func resolveReferenceDate(payrollType, referenceDate string, periodEnd time.Time) (*time.Time, error) {
+	if payrollType != PayrollTypeTHR {
+		return nil, nil
+	}
+	if strings.TrimSpace(referenceDate) == "" {
+		return &periodEnd, nil
+	}
+	parsed, err := parseDate(strings.TrimSpace(referenceDate))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// createOffCyclePayroll membuat payroll DRAFT untuk jenis THR, BONUS, atau CORRECTION.
+func (s *service) createOffCyclePayroll(
+	ctx context.Context,
+	qtx Repository,
+	companyID string,
+	companyUUID, employeeUUID, createdByUUID uuid.UUID,
+	periodStart, periodEnd time.Time,
+	payrollType string,
+	referenceDate *time.Time,
+	req CreatePayrollRequest,
+	runID *uuid.UUID,
+) (*Payroll, error) {
+	result, err := s.calculateOffCycle(ctx, qtx, companyUUID, employeeUUID, nil, periodStart, periodEnd, offCycleRequest{
+		PayrollType:    payrollType,
+		ReferenceDate:  timeValue(referenceDate),
+		MonthlyWage:    req.BaseSalary,
+		Allowance:      req.Allowance,
+		Deduction:      req.Deduction,
+		AllowanceItems: req.AllowanceItems,
+		DeductionItems: req.DeductionItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payroll := &Payroll{
+		ID:            uuid.New(),
+		CompanyID:     companyUUID,
+		EmployeeID:    employeeUUID,
+		PayrollType:   payrollType,
+		ReferenceDate: referenceDate,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		Allowance:     result.TotalAllowance,
+		Deduction:     result.TotalDeduction,
+		NetSalary:     result.NetSalary,
+		GrossIncome:   result.GrossIncome,
+		TaxAmount:     result.TaxAmount,
+		Status:        StatusDraft,
+		CreatedBy:     createdByUUID,
+		RunID:         runID,
+	}
+	if err := qtx.Create(ctx, payroll); err != nil {
+		return nil, err
+	}
+
+	allComponents := attachPayrollID(payroll.ID, append(result.Allowances, result.Deductions...))
+	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), allComponents); err != nil {
+		return nil, err
+	}
+
+	return qtx.FindByIDAndCompany(ctx, companyID, payroll.ID.String())
+}
+
+// regenerateOffCycle menghitung ulang payroll off-cycle DRAFT dengan input baru.
+func (s *service) regenerateOffCycle(
+	ctx context.Context,
+	qtx Repository,
+	companyID string,
+	payroll *Payroll,
+	req RegeneratePayrollRequest,
+) (*Payroll, error) {
+	result, err := s.calculateOffCycle(ctx, qtx, payroll.CompanyID, payroll.EmployeeID, &payroll.ID, payroll.PeriodStart, payroll.PeriodEnd, offCycleRequest{
+		PayrollType:    payroll.PayrollType,
+		ReferenceDate:  timeValue(payroll.ReferenceDate),
+		MonthlyWage:    req.BaseSalary,
+		Allowance:      req.Allowance,
+		Deduction:      req.Deduction,
+		AllowanceItems: req.AllowanceItems,
+		DeductionItems: req.DeductionItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payroll.Allowance = result.TotalAllowance
+	payroll.Deduction = result.TotalDeduction
+	payroll.NetSalary = result.NetSalary
+	payroll.GrossIncome = result.GrossIncome
+	payroll.TaxAmount = result.TaxAmount
+	payroll.IsStale = false
+	payroll.StaleReason = nil
+
+	if err := qtx.Update(ctx, payroll); err != nil {
+		return nil, err
+	}
+	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), append(result.Allowances, result.Deductions...)); err != nil {
+		return nil, err
+	}
+
+	return qtx.FindByIDAndCompany(ctx, companyID, payroll.ID.String())
+}
+
+func timeValue(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
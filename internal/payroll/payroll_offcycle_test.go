@@ -0,0 +1,220 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_Create_THR(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	setup := func(t *testing.T, hireDate time.Time) (*payrollServiceDeps, *[]payroll.PayrollComponent) {
+		deps := setupPayrollServiceTest(t)
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return &payroll.PayrollSetting{
+				CompanyID:       uuid.MustParse(cid),
+				WorkHoursPerDay: 8,
+				WorkDaysPerWeek: 5,
+				TaxCalculator:   payroll.TaxCalculatorNone,
+			}, nil
+		}
+		deps.repo.hasOverlappingPeriodFn = func(ctx context.Context, cid, eid, payrollType string, start, end time.Time, excludeID *string) (bool, error) {
+			assert.Equal(t, payroll.PayrollTypeTHR, payrollType)
+			return false, nil
+		}
+		deps.repo.findEmploymentPeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time) (payroll.EmploymentPeriod, error) {
+			return payroll.EmploymentPeriod{HireDate: hireDate}, nil
+		}
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			assert.Equal(t, "2026-03-20", start.Format("2006-01-02"))
+			return []payroll.SalaryHistory{{BaseSalary: 6000000, EffectiveDate: time.Date(2025, time.August, 10, 0, 0, 0, 0, time.UTC)}}, nil
+		}
+		fixed := &payroll.ComponentTemplate{ID: uuid.New(), Code: "JABATAN", Name: "Tunjangan Jabatan", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationFixed, Amount: 500000, IsActive: true}
+		meal := &payroll.ComponentTemplate{ID: uuid.New(), Code: "MAKAN", Name: "Uang Makan", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationPerAttendanceDay, Amount: 50000, IsActive: true}
+		deps.repo.findEffectiveAssignsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.EmployeeComponentAssignment, error) {
+			return []payroll.EmployeeComponentAssignment{
+				{ID: uuid.New(), TemplateID: fixed.ID, Template: fixed},
+				{ID: uuid.New(), TemplateID: meal.ID, Template: meal},
+			}, nil
+		}
+
+		var components []payroll.PayrollComponent
+		var created *payroll.Payroll
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			created = p
+			return nil
+		}
+		deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+			components = items
+			return nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			created.Components = components
+			return created, nil
+		}
+		return deps, &components
+	}
+
+	t.Run("service under one year prorated by months", func(t *testing.T) {
+		// Masuk 10 Agustus 2025, lebaran 20 Maret 2026: 7 bulan penuh
+		deps, components := setup(t, time.Date(2025, time.August, 10, 0, 0, 0, 0, time.UTC))
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:    employeeID,
+			PayrollType:   "thr",
+			ReferenceDate: "2026-03-20",
+			PeriodStart:   "2026-03-01",
+			PeriodEnd:     "2026-03-31",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.PayrollTypeTHR, resp.PayrollType)
+		if assert.NotNil(t, resp.ReferenceDate) {
+			assert.Equal(t, "2026-03-20", *resp.ReferenceDate)
+		}
+		assert.Equal(t, int64(0), resp.BaseSalary)
+		// (6.000.000 + 500.000) x 7/12
+		assert.Equal(t, int64(3791666), resp.Allowance)
+		assert.Equal(t, int64(3791666), resp.NetSalary)
+		if assert.Len(t, *components, 1) {
+			thr := (*components)[0]
+			assert.Equal(t, "THR Keagamaan", thr.ComponentName)
+			if assert.NotNil(t, thr.SourceType) {
+				assert.Equal(t, payroll.ComponentSourceTHR, *thr.SourceType)
+			}
+			if assert.NotNil(t, thr.Notes) {
+				assert.Contains(t, *thr.Notes, "Masa kerja 7 bulan")
+				assert.Contains(t, *thr.Notes, "7/12 x")
+			}
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("service one year or more gets full monthly wage", func(t *testing.T) {
+		deps, _ := setup(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC))
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:    employeeID,
+			PayrollType:   payroll.PayrollTypeTHR,
+			ReferenceDate: "2026-03-20",
+			PeriodStart:   "2026-03-01",
+			PeriodEnd:     "2026-03-31",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6500000), resp.Allowance)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("service under one month not eligible", func(t *testing.T) {
+		deps, _ := setup(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:    employeeID,
+			PayrollType:   payroll.PayrollTypeTHR,
+			ReferenceDate: "2026-03-20",
+			PeriodStart:   "2026-03-01",
+			PeriodEnd:     "2026-03-31",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrTHRNotEligible)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid payroll type", func(t *testing.T) {
+		deps, _ := setup(t, time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC))
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PayrollType: "HOLIDAY",
+			PeriodStart: "2026-03-01",
+			PeriodEnd:   "2026-03-31",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPayrollType)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_Create_BonusCoexistsWithRegular(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{
+			CompanyID:       uuid.MustParse(cid),
+			WorkHoursPerDay: 8,
+			WorkDaysPerWeek: 5,
+			TaxCalculator:   payroll.TaxCalculatorPPh21,
+		}, nil
+	}
+	deps.repo.hasOverlappingPeriodFn = func(ctx context.Context, cid, eid, payrollType string, start, end time.Time, excludeID *string) (bool, error) {
+		t.Fatal("bonus payroll must not check overlapping period")
+		return true, nil
+	}
+	// Payroll REGULAR Februari sudah ada: bruto 10.000.000, PPh 21 TER 2% = 200.000
+	deps.repo.findTaxSamePeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time, excludeID *string) (payroll.TaxYearToDate, error) {
+		assert.Equal(t, "2026-02-01", start.Format("2006-01-02"))
+		assert.Equal(t, "2026-02-28", end.Format("2006-01-02"))
+		assert.Nil(t, excludeID)
+		return payroll.TaxYearToDate{GrossIncome: 10000000, TaxAmount: 200000, PayrollCount: 1}, nil
+	}
+
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+	expectTx(t, deps.sqlMock, true)
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PayrollType: payroll.PayrollTypeBonus,
+		PeriodStart: "2026-02-01",
+		PeriodEnd:   "2026-02-28",
+		AllowanceItems: []payroll.PayrollComponentInput{
+			{ComponentName: "Bonus Kinerja", Quantity: 1, UnitAmount: 5000000},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.PayrollTypeBonus, resp.PayrollType)
+	assert.Nil(t, resp.ReferenceDate)
+	assert.Equal(t, int64(5000000), resp.GrossIncome)
+	// TER atas gabungan 15.000.000 = 6% (900.000) dikurangi 200.000 yang sudah dipotong
+	assert.Equal(t, int64(700000), resp.TaxAmount)
+	assert.Equal(t, int64(4300000), resp.NetSalary)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,58 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status periode payroll. Bulan yang belum punya baris payroll_periods dianggap OPEN.
+const (
+	PeriodStatusOpen       = "OPEN"
+	PeriodStatusProcessing = "PROCESSING"
+	PeriodStatusClosed     = "CLOSED"
+)
+
+// Aksi perubahan status periode yang dicatat di audit.
+const (
+	PeriodActionProcess = "PROCESS"
+	PeriodActionClose   = "CLOSE"
+	PeriodActionReopen  = "REOPEN"
+)
+
+// PayrollPeriod adalah periode payroll bulanan company. Periode CLOSED mengunci Create,
+// Regenerate, Delete payroll dan perubahan gaji berlaku surut yang beririsan dengannya.
+type PayrollPeriod struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:uq_payroll_periods_company_start"`
+	PeriodStart time.Time  `gorm:"type:date;not null;uniqueIndex:uq_payroll_periods_company_start"`
+	PeriodEnd   time.Time  `gorm:"type:date;not null"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'OPEN'"`
+	ClosedBy    *uuid.UUID `gorm:"type:uuid"`
+	ClosedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Audits []PayrollPeriodAudit `gorm:"foreignKey:PeriodID"`
+}
+
+func (PayrollPeriod) TableName() string {
+	return "payroll_periods"
+}
+
+// PayrollPeriodAudit mencatat setiap perubahan status periode beserta aktor dan alasannya.
+type PayrollPeriodAudit struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID  uuid.UUID `gorm:"type:uuid;not null"`
+	PeriodID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Action     string    `gorm:"type:varchar(20);not null"`
+	FromStatus string    `gorm:"type:varchar(20);not null"`
+	ToStatus   string    `gorm:"type:varchar(20);not null"`
+	Reason     *string   `gorm:"type:text"`
+	ActorID    uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt  time.Time
+}
+
+func (PayrollPeriodAudit) TableName() string {
+	return "payroll_period_audits"
+}
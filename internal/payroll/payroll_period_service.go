@@ -0,0 +1,262 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetPeriods mengembalikan 12 periode bulanan satu tahun; bulan yang belum pernah
+// diproses ditampilkan sebagai OPEN.
+func (s *service) GetPeriods(ctx context.Context, companyID string, req GetPayrollPeriodsRequest) ([]PayrollPeriodResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+	year := req.Year
+	if year == 0 {
+		year = time.Now().UTC().Year()
+	}
+	if year < 2000 || year > 2100 {
+		return nil, payrollerrors.ErrInvalidPeriodFormat
+	}
+
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 1, 0, 0, 0, 0, time.UTC)
+	periods, err := s.repo.FindPeriods(ctx, companyID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[string]PayrollPeriod, len(periods))
+	for _, p := range periods {
+		byStart[p.PeriodStart.Format("2006-01")] = p
+	}
+
+	resp := make([]PayrollPeriodResponse, 0, 12)
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		period, ok := byStart[month.Format("2006-01")]
+		if !ok {
+			period = openPayrollPeriod(month)
+		}
+		item := mapToPeriodResponse(period)
+		item.Audits = nil
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+func (s *service) GetPeriod(ctx context.Context, companyID, period string) (PayrollPeriodResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return PayrollPeriodResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	periodStart, err := parsePeriodMonth(period)
+	if err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+
+	found, err := findPeriod(ctx, s.repo, companyID, periodStart)
+	if err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+	return mapToPeriodResponse(*found), nil
+}
+
+// ProcessPeriod menandai periode sedang diproses (OPEN -> PROCESSING).
+func (s *service) ProcessPeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error) {
+	return s.transitionPeriod(ctx, companyID, actorID, period, PeriodActionProcess, req.Reason)
+}
+
+// ClosePeriod mengunci periode setelah payroll dibayar dan dilaporkan. Periode yang masih
+// punya payroll DRAFT tidak bisa ditutup.
+func (s *service) ClosePeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error) {
+	return s.transitionPeriod(ctx, companyID, actorID, period, PeriodActionClose, req.Reason)
+}
+
+// ReopenPeriod membuka kembali periode CLOSED dengan alasan yang dicatat di audit.
+func (s *service) ReopenPeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error) {
+	return s.transitionPeriod(ctx, companyID, actorID, period, PeriodActionReopen, req.Reason)
+}
+
+func (s *service) transitionPeriod(
+	ctx context.Context,
+	companyID, actorID, period, action, reason string,
+) (PayrollPeriodResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return PayrollPeriodResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return PayrollPeriodResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	periodStart, err := parsePeriodMonth(period)
+	if err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+	reason = strings.TrimSpace(reason)
+	if action == PeriodActionReopen && reason == "" {
+		return PayrollPeriodResponse{}, payrollerrors.ErrPeriodReopenReasonRequired
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	current, err := findPeriod(ctx, qtx, companyID, periodStart)
+	if err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+	if current.ID == uuid.Nil {
+		current.ID = uuid.New()
+		current.CompanyID = companyUUID
+	}
+
+	fromStatus := current.Status
+	var toStatus string
+	switch {
+	case action == PeriodActionProcess && fromStatus == PeriodStatusOpen:
+		toStatus = PeriodStatusProcessing
+	case action == PeriodActionClose && fromStatus != PeriodStatusClosed:
+		toStatus = PeriodStatusClosed
+	case action == PeriodActionReopen && fromStatus == PeriodStatusClosed:
+		toStatus = PeriodStatusOpen
+	default:
+		return PayrollPeriodResponse{}, payrollerrors.ErrInvalidPeriodTransition
+	}
+
+	now := time.Now().UTC()
+	if toStatus == PeriodStatusClosed {
+		start := current.PeriodStart.Format("2006-01-02")
+		end := current.PeriodEnd.Format("2006-01-02")
+		draft := StatusDraft
+		drafts, err := qtx.FindAllByCompany(ctx, companyID, PayrollQueryFilter{
+			Status:      &draft,
+			PeriodStart: &start,
+			PeriodEnd:   &end,
+		})
+		if err != nil {
+			return PayrollPeriodResponse{}, err
+		}
+		if len(drafts) > 0 {
+			return PayrollPeriodResponse{}, payrollerrors.ErrPeriodHasDraftPayroll
+		}
+		current.ClosedBy = &actorUUID
+		current.ClosedAt = &now
+	} else if fromStatus == PeriodStatusClosed {
+		current.ClosedBy = nil
+		current.ClosedAt = nil
+	}
+	current.Status = toStatus
+
+	if err := qtx.SavePeriod(ctx, current); err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+
+	audit := PayrollPeriodAudit{
+		ID:         uuid.New(),
+		CompanyID:  companyUUID,
+		PeriodID:   current.ID,
+		Action:     action,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ActorID:    actorUUID,
+		CreatedAt:  now,
+	}
+	if reason != "" {
+		audit.Reason = &reason
+	}
+	if err := qtx.CreatePeriodAudit(ctx, &audit); err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+	current.Audits = append(current.Audits, audit)
+
+	if err := tx.Commit(); err != nil {
+		return PayrollPeriodResponse{}, err
+	}
+
+	return mapToPeriodResponse(*current), nil
+}
+
+// findPeriod mengembalikan periode tersimpan, atau periode OPEN baru (belum disimpan)
+// untuk bulan yang belum pernah diproses.
+func findPeriod(ctx context.Context, repo Repository, companyID string, periodStart time.Time) (*PayrollPeriod, error) {
+	period, err := repo.FindPeriodByStart(ctx, companyID, periodStart)
+	if err == nil {
+		return period, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	open := openPayrollPeriod(periodStart)
+	return &open, nil
+}
+
+// ensurePeriodOpen menolak perubahan payroll yang rentangnya beririsan dengan periode CLOSED.
+func ensurePeriodOpen(ctx context.Context, repo Repository, companyID string, periodStart, periodEnd time.Time) error {
+	closed, err := repo.HasClosedPeriod(ctx, companyID, periodStart, periodEnd)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return payrollerrors.ErrPayrollPeriodClosed
+	}
+	return nil
+}
+
+func openPayrollPeriod(periodStart time.Time) PayrollPeriod {
+	return PayrollPeriod{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, -1),
+		Status:      PeriodStatusOpen,
+	}
+}
+
+func parsePeriodMonth(period string) (time.Time, error) {
+	start, _, err := parseMonthPeriod(period)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseDate(start)
+}
+
+func mapToPeriodResponse(period PayrollPeriod) PayrollPeriodResponse {
+	resp := PayrollPeriodResponse{
+		Period:      period.PeriodStart.Format("2006-01"),
+		PeriodStart: period.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:   period.PeriodEnd.Format("2006-01-02"),
+		Status:      period.Status,
+		ClosedBy:    uuidPtrToString(period.ClosedBy),
+	}
+	if period.ID != uuid.Nil {
+		id := period.ID.String()
+		resp.ID = &id
+	}
+	if period.ClosedAt != nil {
+		v := period.ClosedAt.Format(time.RFC3339)
+		resp.ClosedAt = &v
+	}
+
+	if len(period.Audits) > 0 {
+		resp.Audits = make([]PayrollPeriodAuditResponse, 0, len(period.Audits))
+		for _, a := range period.Audits {
+			resp.Audits = append(resp.Audits, PayrollPeriodAuditResponse{
+				Action:     a.Action,
+				FromStatus: a.FromStatus,
+				ToStatus:   a.ToStatus,
+				Reason:     a.Reason,
+				ActorID:    a.ActorID.String(),
+				CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+			})
+		}
+	}
+	return resp
+}
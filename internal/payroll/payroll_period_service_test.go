@@ -0,0 +1,253 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_PeriodTransitions(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	periodStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("close open period records audit", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		var saved *payroll.PayrollPeriod
+		var audit *payroll.PayrollPeriodAudit
+		deps.repo.findAllByCompanyFn = func(ctx context.Context, cid string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
+			assert.Equal(t, payroll.StatusDraft, *filter.Status)
+			assert.Equal(t, "2026-01-01", *filter.PeriodStart)
+			assert.Equal(t, "2026-01-31", *filter.PeriodEnd)
+			return nil, nil
+		}
+		deps.repo.savePeriodFn = func(ctx context.Context, period *payroll.PayrollPeriod) error {
+			saved = period
+			return nil
+		}
+		deps.repo.createPeriodAuditFn = func(ctx context.Context, a *payroll.PayrollPeriodAudit) error {
+			audit = a
+			return nil
+		}
+
+		expectTx(t, deps.sqlMock, true)
+		resp, err := deps.service.ClosePeriod(ctx, companyID, actorID, "2026-01", payroll.PayrollPeriodActionRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.PeriodStatusClosed, resp.Status)
+		assert.NotNil(t, resp.ID)
+		if assert.NotNil(t, resp.ClosedBy) {
+			assert.Equal(t, actorID, *resp.ClosedBy)
+		}
+		if assert.NotNil(t, saved) {
+			assert.Equal(t, periodStart, saved.PeriodStart)
+			assert.Equal(t, "2026-01-31", saved.PeriodEnd.Format("2006-01-02"))
+		}
+		if assert.NotNil(t, audit) {
+			assert.Equal(t, payroll.PeriodActionClose, audit.Action)
+			assert.Equal(t, payroll.PeriodStatusOpen, audit.FromStatus)
+			assert.Equal(t, payroll.PeriodStatusClosed, audit.ToStatus)
+			assert.Equal(t, saved.ID, audit.PeriodID)
+		}
+		assert.Len(t, resp.Audits, 1)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("close rejected while draft payroll exists", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		deps.repo.findAllByCompanyFn = func(ctx context.Context, cid string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
+			return []payroll.Payroll{{ID: uuid.New(), Status: payroll.StatusDraft}}, nil
+		}
+
+		expectTx(t, deps.sqlMock, false)
+		_, err := deps.service.ClosePeriod(ctx, companyID, actorID, "2026-01", payroll.PayrollPeriodActionRequest{})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPeriodHasDraftPayroll)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("reopen requires reason", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.ReopenPeriod(ctx, companyID, actorID, "2026-01", payroll.PayrollPeriodActionRequest{Reason: "  "})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPeriodReopenReasonRequired)
+	})
+
+	t.Run("reopen closed period clears closing info", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		closedBy := uuid.New()
+		closedAt := time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
+		periodID := uuid.New()
+		deps.repo.findPeriodByStartFn = func(ctx context.Context, cid string, start time.Time) (*payroll.PayrollPeriod, error) {
+			assert.Equal(t, periodStart, start)
+			return &payroll.PayrollPeriod{
+				ID:          periodID,
+				CompanyID:   uuid.MustParse(cid),
+				PeriodStart: periodStart,
+				PeriodEnd:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+				Status:      payroll.PeriodStatusClosed,
+				ClosedBy:    &closedBy,
+				ClosedAt:    &closedAt,
+				Audits: []payroll.PayrollPeriodAudit{
+					{Action: payroll.PeriodActionClose, FromStatus: payroll.PeriodStatusOpen, ToStatus: payroll.PeriodStatusClosed, ActorID: closedBy},
+				},
+			}, nil
+		}
+		var audit *payroll.PayrollPeriodAudit
+		deps.repo.createPeriodAuditFn = func(ctx context.Context, a *payroll.PayrollPeriodAudit) error {
+			audit = a
+			return nil
+		}
+
+		expectTx(t, deps.sqlMock, true)
+		resp, err := deps.service.ReopenPeriod(ctx, companyID, actorID, "2026-01", payroll.PayrollPeriodActionRequest{Reason: "koreksi lembur"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, payroll.PeriodStatusOpen, resp.Status)
+		assert.Nil(t, resp.ClosedBy)
+		assert.Nil(t, resp.ClosedAt)
+		assert.Len(t, resp.Audits, 2)
+		if assert.NotNil(t, audit) && assert.NotNil(t, audit.Reason) {
+			assert.Equal(t, periodID, audit.PeriodID)
+			assert.Equal(t, payroll.PeriodActionReopen, audit.Action)
+			assert.Equal(t, "koreksi lembur", *audit.Reason)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("reopen open period is invalid", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		_, err := deps.service.ReopenPeriod(ctx, companyID, actorID, "2026-01", payroll.PayrollPeriodActionRequest{Reason: "salah"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPeriodTransition)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_GetPeriods(t *testing.T) {
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	deps.repo.findPeriodsFn = func(ctx context.Context, cid string, from time.Time, to time.Time) ([]payroll.PayrollPeriod, error) {
+		assert.Equal(t, "2026-01-01", from.Format("2006-01-02"))
+		assert.Equal(t, "2026-12-01", to.Format("2006-01-02"))
+		return []payroll.PayrollPeriod{{
+			ID:          uuid.New(),
+			PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			Status:      payroll.PeriodStatusClosed,
+		}}, nil
+	}
+
+	resp, err := deps.service.GetPeriods(context.Background(), uuid.New().String(), payroll.GetPayrollPeriodsRequest{Year: 2026})
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp, 12) {
+		assert.Equal(t, payroll.PeriodStatusOpen, resp[0].Status)
+		assert.Nil(t, resp[0].ID)
+		assert.Equal(t, "2026-02-28", resp[1].PeriodEnd)
+		assert.Equal(t, "2026-03", resp[2].Period)
+		assert.Equal(t, payroll.PeriodStatusClosed, resp[2].Status)
+		assert.NotNil(t, resp[2].ID)
+	}
+}
+
+func TestPayrollService_ClosedPeriodLocksPayroll(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	payrollID := uuid.New().String()
+
+	setup := func(t *testing.T) *payrollServiceDeps {
+		deps := setupPayrollServiceTest(t)
+		deps.repo.hasClosedPeriodFn = func(ctx context.Context, cid string, start time.Time, end time.Time) (bool, error) {
+			return true, nil
+		}
+		deps.repo.employeeBelongsToCompany = func(ctx context.Context, cid, eid string) (bool, error) {
+			return true, nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{
+				ID:          uuid.MustParse(id),
+				CompanyID:   uuid.MustParse(cid),
+				PeriodStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+				Status:      payroll.StatusDraft,
+			}, nil
+		}
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			t.Fatal("payroll must not be created in a closed period")
+			return nil
+		}
+		deps.repo.deleteFn = func(ctx context.Context, cid string, id string) error {
+			t.Fatal("payroll must not be deleted in a closed period")
+			return nil
+		}
+		return deps
+	}
+
+	t.Run("create", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		_, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  uuid.New().String(),
+			PeriodStart: "2026-01-01",
+			PeriodEnd:   "2026-01-31",
+			BaseSalary:  int64Ptr(10000000),
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("regenerate", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		_, err := deps.service.Regenerate(ctx, companyID, actorID, payrollID, payroll.RegeneratePayrollRequest{BaseSalary: int64Ptr(100)})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		err := deps.service.Delete(ctx, companyID, payrollID)
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollPeriodClosed)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("run", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.CreateRun(ctx, companyID, actorID, payroll.CreatePayrollRunRequest{Period: "2026-01"})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollPeriodClosed)
+	})
+}
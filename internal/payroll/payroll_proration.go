@@ -0,0 +1,127 @@
+package payroll
+
+import (
+	"math"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+)
+
+// Metode prorata untuk karyawan yang masuk atau keluar di tengah periode payroll,
+// diatur per company lewat payroll settings.
+const (
+	ProrationWorkingDays  = "WORKING_DAYS"  // Hari kerja aktif / hari kerja periode
+	ProrationCalendarDays = "CALENDAR_DAYS" // Hari kalender aktif / hari kalender periode
+)
+
+// EmploymentPeriod adalah proyeksi tanggal masuk dan tanggal keluar karyawan.
+// HireDate kosong (zero) berarti periode payroll tidak dibatasi tanggal masuk.
+type EmploymentPeriod struct {
+	HireDate        time.Time
+	TerminationDate *time.Time
+}
+
+// employmentProration adalah bagian periode payroll saat karyawan berstatus aktif.
+type employmentProration struct {
+	Method    string
+	Start     time.Time
+	End       time.Time
+	Days      int64
+	TotalDays int64
+}
+
+// Partial bernilai true jika karyawan tidak aktif sepanjang periode payroll.
+func (p employmentProration) Partial() bool {
+	return p.TotalDays > 0 && p.Days < p.TotalDays
+}
+
+func isValidProrationMethod(method string) bool {
+	return method == ProrationWorkingDays || method == ProrationCalendarDays
+}
+
+// prorationMethod mengembalikan metode prorata company, default WORKING_DAYS.
+func prorationMethod(setting PayrollSetting) string {
+	if setting.ProrationMethod == ProrationCalendarDays {
+		return ProrationCalendarDays
+	}
+	return ProrationWorkingDays
+}
+
+func prorationUnit(method string) string {
+	if method == ProrationCalendarDays {
+		return "hari kalender"
+	}
+	return "hari kerja"
+}
+
+// prorationDays menghitung hari aktif (start s/d end) dan total hari periode sesuai metode.
+func prorationDays(method string, workDaysPerWeek int, start, end, periodStart, periodEnd time.Time) (int64, int64) {
+	if method == ProrationCalendarDays {
+		return daysBetween(start, end), daysBetween(periodStart, periodEnd)
+	}
+	return countWorkingDays(start, end, workDaysPerWeek), countWorkingDays(periodStart, periodEnd, workDaysPerWeek)
+}
+
+// resolveEmployment memotong periode payroll dengan tanggal masuk dan tanggal keluar
+// karyawan. Karyawan yang tidak aktif sama sekali dalam periode tidak bisa dibuatkan payroll.
+func resolveEmployment(
+	employment EmploymentPeriod,
+	setting PayrollSetting,
+	periodStart, periodEnd time.Time,
+) (employmentProration, error) {
+	start, end := periodStart, periodEnd
+	if !employment.HireDate.IsZero() {
+		if hireDate := dateOnly(employment.HireDate); hireDate.After(start) {
+			start = hireDate
+		}
+	}
+	if employment.TerminationDate != nil {
+		if terminationDate := dateOnly(*employment.TerminationDate); terminationDate.Before(end) {
+			end = terminationDate
+		}
+	}
+	if start.After(end) {
+		return employmentProration{}, payrollerrors.ErrEmployeeNotEmployedInPeriod
+	}
+
+	method := prorationMethod(setting)
+	days, totalDays := prorationDays(method, setting.WorkDaysPerWeek, start, end, periodStart, periodEnd)
+	return employmentProration{
+		Method:    method,
+		Start:     start,
+		End:       end,
+		Days:      days,
+		TotalDays: totalDays,
+	}, nil
+}
+
+// applyProrationColumns menyalin hasil prorata masa kerja ke payroll. Kolom dikosongkan
+// jika karyawan aktif sepanjang periode.
+func applyProrationColumns(payroll *Payroll, proration employmentProration) {
+	if !proration.Partial() {
+		payroll.ProrationMethod = nil
+		payroll.ProratedDays = 0
+		payroll.ProrationBaseDays = 0
+		return
+	}
+	method := proration.Method
+	payroll.ProrationMethod = &method
+	payroll.ProratedDays = proration.Days
+	payroll.ProrationBaseDays = proration.TotalDays
+}
+
+func mapToProrationResponse(payroll Payroll) *PayrollProrationResponse {
+	if payroll.ProrationMethod == nil || payroll.ProrationBaseDays <= 0 {
+		return nil
+	}
+	return &PayrollProrationResponse{
+		Method:    *payroll.ProrationMethod,
+		Days:      payroll.ProratedDays,
+		TotalDays: payroll.ProrationBaseDays,
+		Factor:    math.Round(float64(payroll.ProratedDays)/float64(payroll.ProrationBaseDays)*10_000) / 10_000,
+	}
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
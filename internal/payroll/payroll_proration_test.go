@@ -0,0 +1,221 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_Create_ProratesMidPeriodEmployment(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	setup := func(t *testing.T, method string, employment payroll.EmploymentPeriod) (*payrollServiceDeps, *[]payroll.PayrollComponent) {
+		deps := setupPayrollServiceTest(t)
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return &payroll.PayrollSetting{
+				CompanyID:             uuid.MustParse(cid),
+				WorkHoursPerDay:       8,
+				WorkDaysPerWeek:       5,
+				AbsentDeductionAmount: 100000,
+				TaxCalculator:         payroll.TaxCalculatorNone,
+				ProrationMethod:       method,
+			}, nil
+		}
+		deps.repo.findEmploymentPeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time) (payroll.EmploymentPeriod, error) {
+			assert.Equal(t, employeeID, eid)
+			// Periode diteruskan agar repo memilih masa kerja (termasuk sebelum rehire) yang beririsan
+			assert.Equal(t, "2026-02-01", start.Format("2006-01-02"))
+			assert.Equal(t, "2026-02-28", end.Format("2006-01-02"))
+			return employment, nil
+		}
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			return []payroll.SalaryHistory{{BaseSalary: 6000000, EffectiveDate: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)}}, nil
+		}
+		// Hadir penuh selama masa kerja, hari sebelum masuk/setelah keluar tidak boleh dihitung absen
+		deps.repo.findAttendanceRecordsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.AttendanceRecord, error) {
+			records := make([]payroll.AttendanceRecord, 0)
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				clockOut := day.Add(17 * time.Hour)
+				records = append(records, payroll.AttendanceRecord{AttendanceDate: day, ClockIn: day.Add(9 * time.Hour), ClockOut: &clockOut, Status: "PRESENT"})
+			}
+			return records, nil
+		}
+		transport := &payroll.ComponentTemplate{ID: uuid.New(), Code: "TRANSPORT", Name: "Tunjangan Transport", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationFixed, Amount: 500000, IsActive: true, Prorate: true}
+		internet := &payroll.ComponentTemplate{ID: uuid.New(), Code: "INTERNET", Name: "Tunjangan Internet", ComponentType: payroll.ComponentTypeAllowance, CalculationType: payroll.CalculationFixed, Amount: 200000, IsActive: true}
+		deps.repo.findEffectiveAssignsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.EmployeeComponentAssignment, error) {
+			return []payroll.EmployeeComponentAssignment{
+				{ID: uuid.New(), TemplateID: transport.ID, Template: transport, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: uuid.New(), TemplateID: internet.ID, Template: internet, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			}, nil
+		}
+
+		var components []payroll.PayrollComponent
+		var created *payroll.Payroll
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			created = p
+			return nil
+		}
+		deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+			components = items
+			return nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			created.Components = components
+			return created, nil
+		}
+		return deps, &components
+	}
+
+	t.Run("new hire prorated by working days", func(t *testing.T) {
+		// Masuk Senin 16 Februari 2026: 10 dari 20 hari kerja
+		deps, _ := setup(t, payroll.ProrationWorkingDays, payroll.EmploymentPeriod{HireDate: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)})
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3000000), resp.BaseSalary)
+		if assert.NotNil(t, resp.Proration) {
+			assert.Equal(t, payroll.ProrationWorkingDays, resp.Proration.Method)
+			assert.Equal(t, int64(10), resp.Proration.Days)
+			assert.Equal(t, int64(20), resp.Proration.TotalDays)
+			assert.Equal(t, 0.5, resp.Proration.Factor)
+		}
+		byName := map[string]payroll.PayrollComponentResponse{}
+		for _, component := range resp.Components {
+			byName[component.ComponentName] = component
+		}
+		assert.NotContains(t, byName, "Absence Deduction")
+		assert.Equal(t, int64(250000), byName["Tunjangan Transport"].TotalAmount)
+		assert.Equal(t, int64(200000), byName["Tunjangan Internet"].TotalAmount)
+		assert.Equal(t, int64(3000000+450000), resp.NetSalary)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("termination prorated by calendar days", func(t *testing.T) {
+		// Keluar 14 Februari 2026: 14 dari 28 hari kalender
+		terminationDate := time.Date(2026, time.February, 14, 0, 0, 0, 0, time.UTC)
+		deps, components := setup(t, payroll.ProrationCalendarDays, payroll.EmploymentPeriod{
+			HireDate:        time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			TerminationDate: &terminationDate,
+		})
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3000000), resp.BaseSalary)
+		if assert.NotNil(t, resp.DerivedBaseSalary) {
+			assert.Equal(t, int64(3000000), *resp.DerivedBaseSalary)
+		}
+		if assert.NotNil(t, resp.Proration) {
+			assert.Equal(t, payroll.ProrationCalendarDays, resp.Proration.Method)
+			assert.Equal(t, int64(14), resp.Proration.Days)
+			assert.Equal(t, int64(28), resp.Proration.TotalDays)
+		}
+		for _, component := range *components {
+			if component.ComponentName == "Tunjangan Transport" && assert.NotNil(t, component.Notes) {
+				assert.Equal(t, int64(250000), component.TotalAmount)
+				assert.Contains(t, *component.Notes, "prorata 14/28 hari kalender")
+			}
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("salary effective on hire date is prorated once", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			method   string
+			hireDate time.Time
+			expected int64
+		}{
+			// Masuk Senin 16 Februari 2026: 10 dari 20 hari kerja
+			{name: "working days", method: payroll.ProrationWorkingDays, hireDate: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC), expected: 3000000},
+			// Masuk 15 Februari 2026: 14 dari 28 hari kalender
+			{name: "calendar days", method: payroll.ProrationCalendarDays, hireDate: time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC), expected: 3000000},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				deps, components := setup(t, tc.method, payroll.EmploymentPeriod{HireDate: tc.hireDate})
+				defer deps.db.Close()
+				expectTx(t, deps.sqlMock, true)
+				// Baris gaji pertama karyawan baru berlaku sejak tanggal masuk
+				deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+					return []payroll.SalaryHistory{{BaseSalary: 6000000, EffectiveDate: tc.hireDate}}, nil
+				}
+
+				resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+					EmployeeID:  employeeID,
+					PeriodStart: "2026-02-01",
+					PeriodEnd:   "2026-02-28",
+				})
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, resp.BaseSalary)
+				if assert.NotNil(t, resp.DerivedBaseSalary) {
+					assert.Equal(t, tc.expected, *resp.DerivedBaseSalary)
+				}
+				// Tarif komponen prorata tetap dari tarif sebulan penuh
+				for _, component := range *components {
+					if component.ComponentName == "Tunjangan Transport" {
+						assert.Equal(t, int64(250000), component.TotalAmount)
+					}
+				}
+				assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+			})
+		}
+	})
+
+	t.Run("manual override is not prorated", func(t *testing.T) {
+		deps, _ := setup(t, payroll.ProrationWorkingDays, payroll.EmploymentPeriod{HireDate: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)})
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+			BaseSalary:  int64Ptr(4000000),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4000000), resp.BaseSalary)
+		if assert.NotNil(t, resp.DerivedBaseSalary) {
+			assert.Equal(t, int64(3000000), *resp.DerivedBaseSalary)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("hired after period", func(t *testing.T) {
+		deps, _ := setup(t, payroll.ProrationWorkingDays, payroll.EmploymentPeriod{HireDate: time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)})
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrEmployeeNotEmployedInPeriod)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
@@ -3,10 +3,13 @@ package payroll
 import (
 	"context"
 	"database/sql"
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/tenant"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -source=payroll_repo.go -destination=mock/payroll_repo_mock.go -package=mock
@@ -14,12 +17,78 @@ type Repository interface {
 	WithTx(tx *sql.Tx) Repository
 	Create(ctx context.Context, payroll *Payroll) error
 	FindAllByCompany(ctx context.Context, companyID string, filter PayrollQueryFilter) ([]Payroll, error)
+	FindPageByCompany(ctx context.Context, companyID string, filter PayrollQueryFilter) ([]Payroll, int64, error)
 	FindByIDAndCompany(ctx context.Context, companyID string, id string) (*Payroll, error)
 	ReplaceComponents(ctx context.Context, companyID string, payrollID string, components []PayrollComponent) error
 	Update(ctx context.Context, payroll *Payroll) error
 	Delete(ctx context.Context, companyID string, id string) error
 	EmployeeBelongsToCompany(ctx context.Context, companyID string, employeeID string) (bool, error)
-	HasOverlappingPeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error)
+	HasOverlappingPeriod(ctx context.Context, companyID string, employeeID string, payrollType string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error)
+	FindRegularPayrollIDInPeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) (*uuid.UUID, error)
+	FindByRun(ctx context.Context, companyID string, runID string) ([]Payroll, error)
+	FindSalaryHistory(ctx context.Context, employeeID string, periodStart time.Time, periodEnd time.Time) ([]SalaryHistory, error)
+	FindAttendanceRecords(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]AttendanceRecord, error)
+	FindApprovedLeaves(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]LeaveRange, error)
+	FindEmployeeTaxStatus(ctx context.Context, companyID string, employeeID string) (string, error)
+	FindTaxYearToDate(ctx context.Context, companyID string, employeeID string, yearStart time.Time, before time.Time) (TaxYearToDate, error)
+	FindTaxSamePeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (TaxYearToDate, error)
+
+	FindBPJSContributions(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) ([]BPJSContributionRow, error)
+	FindBankTransferRows(ctx context.Context, companyID string, payrollIDs []string) ([]BankTransferRow, error)
+	MarkBankExported(ctx context.Context, companyID string, payrollIDs []string, batchID string, exportedAt time.Time) error
+	FindJournalPayrolls(ctx context.Context, companyID string, filter JournalQueryFilter) ([]JournalPayrollRow, error)
+	FindComponentsByPayrollIDs(ctx context.Context, companyID string, payrollIDs []string) ([]PayrollComponent, error)
+	FindAccountMappings(ctx context.Context, companyID string) ([]AccountMapping, error)
+	ReplaceAccountMappings(ctx context.Context, companyID string, mappings []AccountMapping) error
+	FindEmploymentPeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) (EmploymentPeriod, error)
+	FindPayslipProfile(ctx context.Context, companyID string, employeeID string) (PayslipProfile, error)
+	FindPayslipYearToDate(ctx context.Context, companyID string, employeeID string, yearStart time.Time, periodEnd time.Time, excludePayrollID string) (PayslipYearToDate, error)
+
+	FindSetting(ctx context.Context, companyID string) (*PayrollSetting, error)
+	UpsertSetting(ctx context.Context, setting *PayrollSetting) error
+
+	FindApprovalSteps(ctx context.Context, companyID string) ([]ApprovalStep, error)
+	ReplaceApprovalSteps(ctx context.Context, companyID string, steps []ApprovalStep) error
+	CreateApproval(ctx context.Context, approval *PayrollApproval) error
+	ResetApprovals(ctx context.Context, companyID string, payrollID string, runID *string) error
+	ResetPendingApprovals(ctx context.Context, companyID string) error
+
+	FindPeriods(ctx context.Context, companyID string, from time.Time, to time.Time) ([]PayrollPeriod, error)
+	FindPeriodByStart(ctx context.Context, companyID string, periodStart time.Time) (*PayrollPeriod, error)
+	SavePeriod(ctx context.Context, period *PayrollPeriod) error
+	CreatePeriodAudit(ctx context.Context, audit *PayrollPeriodAudit) error
+	HasClosedPeriod(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) (bool, error)
+
+	CreateComponentTemplate(ctx context.Context, template *ComponentTemplate) error
+	UpdateComponentTemplate(ctx context.Context, template *ComponentTemplate) error
+	FindComponentTemplates(ctx context.Context, companyID string) ([]ComponentTemplate, error)
+	FindComponentTemplateByID(ctx context.Context, companyID string, id string) (*ComponentTemplate, error)
+	ComponentTemplateCodeExists(ctx context.Context, companyID string, code string, excludeID *string) (bool, error)
+	CreateComponentAssignment(ctx context.Context, assignment *EmployeeComponentAssignment) error
+	UpdateComponentAssignment(ctx context.Context, assignment *EmployeeComponentAssignment) error
+	DeleteComponentAssignment(ctx context.Context, companyID string, id string) error
+	FindComponentAssignmentByID(ctx context.Context, companyID string, id string) (*EmployeeComponentAssignment, error)
+	FindComponentAssignments(ctx context.Context, companyID string, employeeID string) ([]EmployeeComponentAssignment, error)
+	FindEffectiveComponentAssignments(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]EmployeeComponentAssignment, error)
+	HasOverlappingComponentAssignment(ctx context.Context, companyID string, employeeID string, templateID string, effectiveFrom time.Time, effectiveTo *time.Time, excludeID *string) (bool, error)
+	MarkPayrollsStaleByEmployee(ctx context.Context, companyID string, employeeID string, from time.Time, to *time.Time, reason string) error
+	MarkPayrollsStaleByTemplate(ctx context.Context, companyID string, templateID string, reason string) error
+
+	CreateLoan(ctx context.Context, loan *EmployeeLoan) error
+	UpdateLoan(ctx context.Context, loan *EmployeeLoan) error
+	FindLoans(ctx context.Context, companyID string, filter LoanQueryFilter) ([]EmployeeLoan, error)
+	FindLoanByID(ctx context.Context, companyID string, id string) (*EmployeeLoan, error)
+	FindActiveLoans(ctx context.Context, companyID string, employeeID string, periodEnd time.Time) ([]EmployeeLoan, error)
+	CreateLoanRepayment(ctx context.Context, repayment *LoanRepayment) error
+	UpdateLoanRepayment(ctx context.Context, repayment *LoanRepayment) error
+	FindLoanRepaymentsByPayroll(ctx context.Context, companyID string, payrollID string) ([]LoanRepayment, error)
+
+	CreateRun(ctx context.Context, run *PayrollRun) error
+	UpdateRun(ctx context.Context, run *PayrollRun) error
+	FindRunByIDAndCompany(ctx context.Context, companyID string, id string) (*PayrollRun, error)
+	FindRunsByCompany(ctx context.Context, companyID string) ([]PayrollRun, error)
+	CreateRunFailures(ctx context.Context, failures []PayrollRunFailure) error
+	FindEmployeesForRun(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time, departmentID *string) ([]RunEmployee, error)
 }
 
 type repository struct {
@@ -42,18 +111,60 @@ func (r *repository) Create(ctx context.Context, payroll *Payroll) error {
 	return r.db.WithContext(ctx).Create(payroll).Error
 }
 
+var payrollListSort = pagination.Sort{
+	Columns: map[string]string{
+		"period_start":  "payrolls.period_start",
+		"period_end":    "payrolls.period_end",
+		"net_salary":    "payrolls.net_salary",
+		"status":        "payrolls.status",
+		"created_at":    "payrolls.created_at",
+		"employee_name": "employees.full_name",
+	},
+	Default:    "period_start",
+	DefaultDir: "desc",
+	TieBreaker: "payrolls.id",
+}
+
 func (r *repository) FindAllByCompany(ctx context.Context, companyID string, filter PayrollQueryFilter) ([]Payroll, error) {
 	var payrolls []Payroll
+	err := r.filterByCompany(ctx, companyID, filter).
+		Preload("Employee").
+		Order("payrolls.period_start DESC").
+		Find(&payrolls).Error
+	return payrolls, err
+}
+
+// FindPageByCompany mengembalikan satu halaman payroll sesuai filter beserta total seluruh
+// data yang cocok.
+func (r *repository) FindPageByCompany(ctx context.Context, companyID string, filter PayrollQueryFilter) ([]Payroll, int64, error) {
+	db := r.filterByCompany(ctx, companyID, filter).
+		Scopes(pagination.Search(filter.Page.Search, "employees.full_name", "employees.employee_number")).
+		Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var payrolls []Payroll
+	err := db.Preload("Employee").
+		Scopes(pagination.Paginate(filter.Page)).
+		Find(&payrolls).Error
+	return payrolls, total, err
+}
 
+func (r *repository) filterByCompany(ctx context.Context, companyID string, filter PayrollQueryFilter) *gorm.DB {
 	db := r.db.WithContext(ctx).
 		Model(&Payroll{}).
-		Preload("Employee").
 		Joins("LEFT JOIN employees ON employees.id = payrolls.employee_id AND employees.deleted_at IS NULL").
 		Where("payrolls.company_id = ?", companyID)
 
 	if filter.Status != nil && *filter.Status != "" {
 		db = db.Where("payrolls.status = ?", *filter.Status)
 	}
+	if filter.PayrollType != nil && *filter.PayrollType != "" {
+		db = db.Where("payrolls.payroll_type = ?", *filter.PayrollType)
+	}
 	if filter.DepartmentID != nil && *filter.DepartmentID != "" {
 		db = db.Where("employees.department_id = ?", *filter.DepartmentID)
 	}
@@ -63,10 +174,7 @@ func (r *repository) FindAllByCompany(ctx context.Context, companyID string, fil
 	if filter.PeriodEnd != nil && *filter.PeriodEnd != "" {
 		db = db.Where("payrolls.period_start <= ?", *filter.PeriodEnd)
 	}
-
-	err := db.Order("payrolls.period_start DESC").
-		Find(&payrolls).Error
-	return payrolls, err
+	return db
 }
 
 func (r *repository) FindByIDAndCompany(ctx context.Context, companyID string, id string) (*Payroll, error) {
@@ -75,6 +183,7 @@ func (r *repository) FindByIDAndCompany(ctx context.Context, companyID string, i
 		Scopes(tenant.Scope(companyID)).
 		Preload("Employee").
 		Preload("Components").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("step_order") }).
 		First(&payroll, "id = ?", id).Error
 	return &payroll, err
 }
@@ -100,8 +209,8 @@ func (r *repository) ReplaceComponents(
 }
 
 func (r *repository) Update(ctx context.Context, p *Payroll) error {
-	// Avoid persisting preloaded Employee association on update.
-	return r.db.WithContext(ctx).Omit("Employee").Save(p).Error
+	// Avoid persisting preloaded Employee and Approvals associations on update.
+	return r.db.WithContext(ctx).Omit("Employee", "Approvals").Save(p).Error
 }
 
 func (r *repository) Delete(ctx context.Context, companyID string, id string) error {
@@ -121,10 +230,13 @@ func (r *repository) EmployeeBelongsToCompany(ctx context.Context, companyID str
 	return count > 0, err
 }
 
+// HasOverlappingPeriod memeriksa payroll aktif dengan jenis yang sama di periode yang
+// beririsan, sehingga payroll off-cycle tidak menghalangi payroll REGULAR dan sebaliknya.
 func (r *repository) HasOverlappingPeriod(
 	ctx context.Context,
 	companyID string,
 	employeeID string,
+	payrollType string,
 	periodStart time.Time,
 	periodEnd time.Time,
 	excludePayrollID *string,
@@ -133,7 +245,11 @@ func (r *repository) HasOverlappingPeriod(
 		Model(&Payroll{}).
 		Scopes(tenant.Scope(companyID)).
 		Where("employee_id = ?", employeeID).
-		Where("NOT (period_end < ? OR period_start > ?)", periodStart, periodEnd)
+		Where("payroll_type = ?", payrollType).
+		Where("NOT (period_end < ? OR period_start > ?)", periodStart, periodEnd).
+		// Payroll batal/dibalik dan payroll penyesuaian reversal tidak menghalangi payroll baru.
+		Where("status NOT IN ?", []string{StatusCancelled, StatusReversed}).
+		Where("reversal_of_id IS NULL")
 
 	if excludePayrollID != nil && *excludePayrollID != "" {
 		db = db.Where("id <> ?", *excludePayrollID)
@@ -143,3 +259,776 @@ func (r *repository) HasOverlappingPeriod(
 	err := db.Count(&count).Error
 	return count > 0, err
 }
+
+// FindRegularPayrollIDInPeriod mengembalikan id payroll REGULAR aktif karyawan dengan periode
+// yang sama persis, atau nil jika belum ada.
+func (r *repository) FindRegularPayrollIDInPeriod(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) (*uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("employee_id = ? AND payroll_type = ?", employeeID, PayrollTypeRegular).
+		Where("period_start = ? AND period_end = ?", periodStart, periodEnd).
+		Where("status IN ?", []string{StatusDraft, StatusApproved, StatusPaid}).
+		Where("reversal_of_id IS NULL").
+		Limit(1).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return &ids[0], nil
+}
+
+func (r *repository) FindByRun(ctx context.Context, companyID string, runID string) ([]Payroll, error) {
+	var payrolls []Payroll
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Employee").
+		Where("run_id = ?", runID).
+		Order("created_at ASC").
+		Find(&payrolls).Error
+	return payrolls, err
+}
+
+func (r *repository) CreateRun(ctx context.Context, run *PayrollRun) error {
+	return r.db.WithContext(ctx).Omit("Failures").Create(run).Error
+}
+
+func (r *repository) UpdateRun(ctx context.Context, run *PayrollRun) error {
+	return r.db.WithContext(ctx).Omit("Failures", "Approvals").Save(run).Error
+}
+
+func (r *repository) FindRunByIDAndCompany(ctx context.Context, companyID string, id string) (*PayrollRun, error) {
+	var run PayrollRun
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Failures.Employee").
+		Preload("Approvals", func(db *gorm.DB) *gorm.DB { return db.Order("step_order") }).
+		First(&run, "id = ?", id).Error
+	return &run, err
+}
+
+func (r *repository) FindRunsByCompany(ctx context.Context, companyID string) ([]PayrollRun, error) {
+	var runs []PayrollRun
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Order("period_start DESC, created_at DESC").
+		Find(&runs).Error
+	return runs, err
+}
+
+func (r *repository) CreateRunFailures(ctx context.Context, failures []PayrollRunFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Omit("Employee").Create(&failures).Error
+}
+
+// FindEmployeesForRun mengambil karyawan aktif per akhir periode, termasuk karyawan
+// yang keluar di tengah periode agar tetap dibayar prorata dan karyawan rehire yang masa
+// kerja sebelumnya beririsan dengan periode.
+func (r *repository) FindEmployeesForRun(
+	ctx context.Context,
+	companyID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+	departmentID *string,
+) ([]RunEmployee, error) {
+	var employees []RunEmployee
+
+	db := r.db.WithContext(ctx).
+		Table("employees").
+		Select("employees.id, employees.full_name").
+		Where("employees.company_id = ?", companyID).
+		Where("employees.deleted_at IS NULL").
+		Where(`((employees.hire_date <= ?
+			AND (employees.termination_date IS NULL OR employees.termination_date >= ?)
+			AND (employees.termination_date IS NOT NULL OR LOWER(employees.employment_status) NOT IN ?))
+		OR EXISTS (
+			SELECT 1 FROM employee_employment_stints s
+			WHERE s.employee_id = employees.id
+			  AND s.hire_date <= ?
+			  AND (s.termination_date IS NULL OR s.termination_date >= ?)
+		))`, periodEnd, periodStart, inactiveEmploymentStatuses, periodEnd, periodStart)
+
+	if departmentID != nil && *departmentID != "" {
+		db = db.Where("employees.department_id = ?", *departmentID)
+	}
+
+	err := db.Order("employees.full_name ASC").Scan(&employees).Error
+	return employees, err
+}
+
+// FindSalaryHistory mengambil gaji yang berlaku saat periode dimulai beserta
+// seluruh perubahan gaji di dalam periode, urut berdasarkan effective_date.
+func (r *repository) FindSalaryHistory(
+	ctx context.Context,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) ([]SalaryHistory, error) {
+	var history []SalaryHistory
+	err := r.db.WithContext(ctx).
+		Table("employee_salaries").
+		Select("base_salary, effective_date").
+		Where("employee_id = ?", employeeID).
+		Where("effective_date <= ?", periodEnd).
+		Where(`effective_date >= COALESCE((
+			SELECT MAX(es.effective_date) FROM employee_salaries es
+			WHERE es.employee_id = ? AND es.effective_date <= ?
+		), ?)`, employeeID, periodStart, periodStart).
+		Order("effective_date ASC").
+		Scan(&history).Error
+	return history, err
+}
+
+func (r *repository) FindAttendanceRecords(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) ([]AttendanceRecord, error) {
+	var records []AttendanceRecord
+	err := r.db.WithContext(ctx).
+		Table("attendances").
+		Select("attendance_date, clock_in, clock_out, status").
+		Where("company_id = ? AND employee_id = ?", companyID, employeeID).
+		Where("attendance_date BETWEEN ? AND ?", periodStart, periodEnd).
+		Where("deleted_at IS NULL").
+		Order("attendance_date ASC").
+		Scan(&records).Error
+	return records, err
+}
+
+func (r *repository) FindApprovedLeaves(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) ([]LeaveRange, error) {
+	var leaves []LeaveRange
+	err := r.db.WithContext(ctx).
+		Table("leaves").
+		Select("id, leave_type, start_date, end_date").
+		Where("company_id = ? AND employee_id = ?", companyID, employeeID).
+		Where("status = ?", "APPROVED").
+		Where("NOT (end_date < ? OR start_date > ?)", periodStart, periodEnd).
+		Where("deleted_at IS NULL").
+		Order("start_date ASC").
+		Scan(&leaves).Error
+	return leaves, err
+}
+
+// FindEmployeeTaxStatus mengambil status PTKP karyawan untuk perhitungan PPh 21.
+func (r *repository) FindEmployeeTaxStatus(ctx context.Context, companyID string, employeeID string) (string, error) {
+	var emp struct {
+		PTKPStatus string `gorm:"column:ptkp_status"`
+	}
+	err := r.db.WithContext(ctx).
+		Table("employees").
+		Select("ptkp_status").
+		Where("id = ? AND company_id = ? AND deleted_at IS NULL", employeeID, companyID).
+		Take(&emp).Error
+	return emp.PTKPStatus, err
+}
+
+// FindTaxYearToDate mengakumulasi bruto dan pajak payroll karyawan sejak awal tahun
+// pajak sampai sebelum periode yang sedang dihitung.
+func (r *repository) FindTaxYearToDate(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	yearStart time.Time,
+	before time.Time,
+) (TaxYearToDate, error) {
+	var ytd TaxYearToDate
+	err := r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		// PayrollCount adalah jumlah bulan gaji, payroll off-cycle tidak dihitung. Payroll yang
+		// dibalik dan payroll penyesuaiannya saling meniadakan, sehingga hanya periode payroll
+		// REGULAR yang masih berlaku yang dihitung. Jumlah nominal tetap menyertakan keduanya.
+		Select(`COALESCE(SUM(gross_income), 0) AS gross_income, COALESCE(SUM(tax_amount), 0) AS tax_amount, COALESCE(SUM(pension_contribution), 0) AS pension_contribution,
+			COUNT(DISTINCT period_start) FILTER (WHERE payroll_type = ? AND reversal_of_id IS NULL AND status <> ?) AS payroll_count`, PayrollTypeRegular, StatusReversed).
+		Where("company_id = ? AND employee_id = ?", companyID, employeeID).
+		Where("period_start >= ? AND period_end < ?", yearStart, before).
+		Where("status <> ?", StatusCancelled).
+		Scan(&ytd).Error
+	return ytd, err
+}
+
+// FindTaxSamePeriod mengakumulasi payroll lain di periode yang sama (mis. payroll
+// REGULAR saat menghitung THR) agar PPh 21 dihitung atas total penghasilan bulan itu.
+func (r *repository) FindTaxSamePeriod(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+	excludePayrollID *string,
+) (TaxYearToDate, error) {
+	var total TaxYearToDate
+	db := r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		Select("COALESCE(SUM(gross_income), 0) AS gross_income, COALESCE(SUM(tax_amount), 0) AS tax_amount, COALESCE(SUM(pension_contribution), 0) AS pension_contribution, COUNT(*) AS payroll_count").
+		Where("company_id = ? AND employee_id = ?", companyID, employeeID).
+		Where("period_start = ? AND period_end = ?", periodStart, periodEnd).
+		Where("status <> ?", StatusCancelled)
+	if excludePayrollID != nil && *excludePayrollID != "" {
+		db = db.Where("id <> ?", *excludePayrollID)
+	}
+	err := db.Scan(&total).Error
+	return total, err
+}
+
+// FindBPJSContributions mengambil komponen iuran BPJS dari payroll yang periodenya
+// dimulai di dalam rentang laporan.
+func (r *repository) FindBPJSContributions(
+	ctx context.Context,
+	companyID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) ([]BPJSContributionRow, error) {
+	var rows []BPJSContributionRow
+	err := r.db.WithContext(ctx).
+		Table("payroll_components pc").
+		Select(`p.id AS payroll_id, p.employee_id, e.full_name AS employee_name, p.status AS payroll_status,
+			p.base_salary, pc.component_type, pc.metadata->>'program' AS program, pc.total_amount`).
+		Joins("JOIN payrolls p ON p.id = pc.payroll_id").
+		Joins("JOIN employees e ON e.id = p.employee_id").
+		Where("pc.company_id = ? AND pc.source_type = ?", companyID, ComponentSourceBPJS).
+		Where("p.deleted_at IS NULL").
+		Where("p.status NOT IN ?", []string{StatusCancelled, StatusReversed}).
+		Where("p.period_start BETWEEN ? AND ?", periodStart, periodEnd).
+		Order("e.full_name ASC, p.period_start ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) FindBankTransferRows(
+	ctx context.Context,
+	companyID string,
+	payrollIDs []string,
+) ([]BankTransferRow, error) {
+	var rows []BankTransferRow
+	err := r.db.WithContext(ctx).
+		Table("payrolls p").
+		Select(`p.id AS payroll_id, p.employee_id, e.employee_number, e.full_name AS employee_name, e.email,
+			e.bank_code, e.bank_account_number, e.bank_account_name,
+			p.status, p.net_salary, p.period_start, p.period_end, p.reversal_of_id,
+			p.bank_export_batch_id, p.bank_exported_at`).
+		Joins("JOIN employees e ON e.id = p.employee_id").
+		Where("p.company_id = ? AND p.id IN ?", companyID, payrollIDs).
+		Where("p.deleted_at IS NULL").
+		Order("e.full_name ASC, p.period_start ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// MarkBankExported mencatat batch file bulk transfer pada payroll yang diekspor.
+func (r *repository) MarkBankExported(
+	ctx context.Context,
+	companyID string,
+	payrollIDs []string,
+	batchID string,
+	exportedAt time.Time,
+) error {
+	return r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("id IN ? AND status = ?", payrollIDs, StatusApproved).
+		Updates(map[string]any{
+			"bank_export_batch_id": batchID,
+			"bank_exported_at":     exportedAt,
+		}).Error
+}
+
+func (r *repository) FindJournalPayrolls(
+	ctx context.Context,
+	companyID string,
+	filter JournalQueryFilter,
+) ([]JournalPayrollRow, error) {
+	query := r.db.WithContext(ctx).
+		Table("payrolls p").
+		Select(`p.id AS payroll_id, p.employee_id, e.full_name AS employee_name,
+			e.department_id, d.name AS department_name, p.payroll_type, p.status,
+			p.base_salary, p.allowance, p.overtime_amount, p.deduction, p.net_salary, p.employer_cost,
+			p.period_start, p.period_end, p.reversal_of_id`).
+		Joins("JOIN employees e ON e.id = p.employee_id").
+		Joins("LEFT JOIN departments d ON d.id = e.department_id").
+		Where("p.company_id = ? AND p.deleted_at IS NULL", companyID).
+		Where("p.status IN ?", filter.Statuses)
+
+	if filter.RunID != nil {
+		query = query.Where("p.run_id = ?", *filter.RunID)
+	}
+	if filter.PeriodStart != nil {
+		query = query.Where("p.period_start >= ?", *filter.PeriodStart)
+	}
+	if filter.PeriodEnd != nil {
+		query = query.Where("p.period_end <= ?", *filter.PeriodEnd)
+	}
+
+	var rows []JournalPayrollRow
+	err := query.Order("d.name ASC, e.full_name ASC, p.period_start ASC").Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) FindComponentsByPayrollIDs(
+	ctx context.Context,
+	companyID string,
+	payrollIDs []string,
+) ([]PayrollComponent, error) {
+	var components []PayrollComponent
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND payroll_id IN ?", companyID, payrollIDs).
+		Order("payroll_id ASC, component_type ASC, component_name ASC").
+		Find(&components).Error
+	return components, err
+}
+
+func (r *repository) FindAccountMappings(ctx context.Context, companyID string) ([]AccountMapping, error) {
+	var mappings []AccountMapping
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Order("component_type ASC, source_type ASC NULLS FIRST, component_name ASC NULLS FIRST").
+		Find(&mappings).Error
+	return mappings, err
+}
+
+func (r *repository) ReplaceAccountMappings(ctx context.Context, companyID string, mappings []AccountMapping) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Scopes(tenant.Scope(companyID)).Delete(&AccountMapping{}).Error; err != nil {
+		return err
+	}
+
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	return db.Create(&mappings).Error
+}
+
+func (r *repository) FindApprovalSteps(ctx context.Context, companyID string) ([]ApprovalStep, error) {
+	var steps []ApprovalStep
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Order("step_order ASC").
+		Find(&steps).Error
+	return steps, err
+}
+
+func (r *repository) ReplaceApprovalSteps(ctx context.Context, companyID string, steps []ApprovalStep) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Scopes(tenant.Scope(companyID)).Delete(&ApprovalStep{}).Error; err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	return db.Create(&steps).Error
+}
+
+func (r *repository) CreateApproval(ctx context.Context, approval *PayrollApproval) error {
+	return r.db.WithContext(ctx).Create(approval).Error
+}
+
+// ResetApprovals menghapus jejak approval payroll (dan run-nya jika ada) karena nilai
+// payroll berubah sehingga rantai approval harus diulang dari awal.
+func (r *repository) ResetApprovals(ctx context.Context, companyID string, payrollID string, runID *string) error {
+	db := r.db.WithContext(ctx).Scopes(tenant.Scope(companyID))
+	if runID != nil {
+		db = db.Where("(payroll_id = ? OR run_id = ?)", payrollID, *runID)
+	} else {
+		db = db.Where("payroll_id = ?", payrollID)
+	}
+	return db.Delete(&PayrollApproval{}).Error
+}
+
+// ResetPendingApprovals menghapus approval payroll dan run yang masih DRAFT karena rantai
+// approval company diganti; approval lama tidak boleh memenuhi langkah baru dengan role lain.
+func (r *repository) ResetPendingApprovals(ctx context.Context, companyID string) error {
+	return r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Where(`(payroll_id IN (SELECT id FROM payrolls WHERE company_id = ? AND status = ?)
+			OR run_id IN (SELECT id FROM payroll_runs WHERE company_id = ? AND status = ?))`,
+			companyID, StatusDraft, companyID, StatusDraft).
+		Delete(&PayrollApproval{}).Error
+}
+
+func (r *repository) FindPeriods(ctx context.Context, companyID string, from time.Time, to time.Time) ([]PayrollPeriod, error) {
+	var periods []PayrollPeriod
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Where("period_start BETWEEN ? AND ?", from, to).
+		Order("period_start ASC").
+		Find(&periods).Error
+	return periods, err
+}
+
+func (r *repository) FindPeriodByStart(ctx context.Context, companyID string, periodStart time.Time) (*PayrollPeriod, error) {
+	var period PayrollPeriod
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Audits", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC") }).
+		First(&period, "period_start = ?", periodStart).Error
+	return &period, err
+}
+
+func (r *repository) SavePeriod(ctx context.Context, period *PayrollPeriod) error {
+	return r.db.WithContext(ctx).Omit("Audits").Save(period).Error
+}
+
+func (r *repository) CreatePeriodAudit(ctx context.Context, audit *PayrollPeriodAudit) error {
+	return r.db.WithContext(ctx).Create(audit).Error
+}
+
+// HasClosedPeriod mengecek apakah rentang payroll beririsan dengan periode CLOSED.
+func (r *repository) HasClosedPeriod(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&PayrollPeriod{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("status = ?", PeriodStatusClosed).
+		Where("period_start <= ? AND period_end >= ?", periodEnd, periodStart).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindEmploymentPeriod mengembalikan masa kerja karyawan yang beririsan dengan periode: masa
+// kerja berjalan di employees atau masa kerja sebelum rehire di employee_employment_stints.
+// Jika tidak ada yang beririsan, masa kerja terbaru dikembalikan agar caller bisa menolak
+// periode di luar masa kerja.
+func (r *repository) FindEmploymentPeriod(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) (EmploymentPeriod, error) {
+	query := `
+SELECT stints.hire_date, stints.termination_date
+FROM (
+	SELECT e.hire_date, e.termination_date
+	FROM employees e
+	WHERE e.company_id = ? AND e.id = ? AND e.deleted_at IS NULL
+	UNION ALL
+	SELECT s.hire_date, s.termination_date
+	FROM employee_employment_stints s
+	JOIN employees e ON e.id = s.employee_id AND e.deleted_at IS NULL
+	WHERE s.company_id = ? AND s.employee_id = ?
+) stints
+ORDER BY (stints.hire_date <= ? AND (stints.termination_date IS NULL OR stints.termination_date >= ?)) DESC,
+	stints.hire_date DESC
+LIMIT 1
+`
+	var period EmploymentPeriod
+	res := r.db.WithContext(ctx).
+		Raw(query, companyID, employeeID, companyID, employeeID, periodEnd, periodStart).
+		Scan(&period)
+	if res.Error != nil {
+		return EmploymentPeriod{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return EmploymentPeriod{}, gorm.ErrRecordNotFound
+	}
+	return period, nil
+}
+
+func (r *repository) FindPayslipProfile(ctx context.Context, companyID string, employeeID string) (PayslipProfile, error) {
+	var profile PayslipProfile
+	err := r.db.WithContext(ctx).
+		Table("employees e").
+		Select(`c.name AS company_name, c.logo AS company_logo, e.full_name AS employee_name, e.employee_number,
+			d.name AS department_name, pos.name AS position_name, e.ptkp_status, e.birth_date`).
+		Joins("JOIN companies c ON c.id = e.company_id").
+		Joins("LEFT JOIN departments d ON d.id = e.department_id").
+		Joins("LEFT JOIN positions pos ON pos.id = e.position_id").
+		Where("e.company_id = ? AND e.id = ?", companyID, employeeID).
+		Take(&profile).Error
+	return profile, err
+}
+
+// FindPayslipYearToDate mengakumulasi payroll karyawan sejak awal tahun sampai akhir
+// periode payslip. Payroll DRAFT dan CANCELLED tidak dihitung; payroll REVERSED tetap
+// dihitung karena saling meniadakan dengan payroll penyesuaiannya.
+func (r *repository) FindPayslipYearToDate(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	yearStart time.Time,
+	periodEnd time.Time,
+	excludePayrollID string,
+) (PayslipYearToDate, error) {
+	var ytd PayslipYearToDate
+	err := r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		Select(`COALESCE(SUM(gross_income), 0) AS gross_income,
+			COALESCE(SUM(deduction), 0) AS deduction,
+			COALESCE(SUM(tax_amount), 0) AS tax_amount,
+			COALESCE(SUM(pension_contribution), 0) AS pension_contribution,
+			COALESCE(SUM(net_salary), 0) AS net_salary,
+			COUNT(*) AS payroll_count`).
+		Where("company_id = ? AND employee_id = ? AND id <> ?", companyID, employeeID, excludePayrollID).
+		Where("period_start >= ? AND period_end <= ?", yearStart, periodEnd).
+		Where("status NOT IN ?", []string{StatusDraft, StatusCancelled}).
+		Scan(&ytd).Error
+	return ytd, err
+}
+
+func (r *repository) FindSetting(ctx context.Context, companyID string) (*PayrollSetting, error) {
+	var setting PayrollSetting
+	err := r.db.WithContext(ctx).
+		First(&setting, "company_id = ?", companyID).Error
+	return &setting, err
+}
+
+func (r *repository) UpsertSetting(ctx context.Context, setting *PayrollSetting) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}},
+			UpdateAll: true,
+		}).
+		Create(setting).Error
+}
+
+func (r *repository) CreateComponentTemplate(ctx context.Context, template *ComponentTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *repository) UpdateComponentTemplate(ctx context.Context, template *ComponentTemplate) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+func (r *repository) FindComponentTemplates(ctx context.Context, companyID string) ([]ComponentTemplate, error) {
+	var templates []ComponentTemplate
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Order("is_active DESC, code ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *repository) FindComponentTemplateByID(ctx context.Context, companyID string, id string) (*ComponentTemplate, error) {
+	var template ComponentTemplate
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		First(&template, "id = ?", id).Error
+	return &template, err
+}
+
+func (r *repository) ComponentTemplateCodeExists(ctx context.Context, companyID string, code string, excludeID *string) (bool, error) {
+	db := r.db.WithContext(ctx).
+		Model(&ComponentTemplate{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("code = ?", code)
+	if excludeID != nil && *excludeID != "" {
+		db = db.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	err := db.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) CreateComponentAssignment(ctx context.Context, assignment *EmployeeComponentAssignment) error {
+	return r.db.WithContext(ctx).Omit("Employee", "Template").Create(assignment).Error
+}
+
+func (r *repository) UpdateComponentAssignment(ctx context.Context, assignment *EmployeeComponentAssignment) error {
+	return r.db.WithContext(ctx).Omit("Employee", "Template").Save(assignment).Error
+}
+
+func (r *repository) DeleteComponentAssignment(ctx context.Context, companyID string, id string) error {
+	return r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Delete(&EmployeeComponentAssignment{}, "id = ?", id).Error
+}
+
+func (r *repository) FindComponentAssignmentByID(ctx context.Context, companyID string, id string) (*EmployeeComponentAssignment, error) {
+	var assignment EmployeeComponentAssignment
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Employee").
+		Preload("Template").
+		First(&assignment, "id = ?", id).Error
+	return &assignment, err
+}
+
+func (r *repository) FindComponentAssignments(ctx context.Context, companyID string, employeeID string) ([]EmployeeComponentAssignment, error) {
+	var assignments []EmployeeComponentAssignment
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Employee").
+		Preload("Template").
+		Where("employee_id = ?", employeeID).
+		Order("effective_from DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// FindEffectiveComponentAssignments mengambil assignment yang berlaku (sebagian) dalam
+// periode payroll dan template-nya masih aktif.
+func (r *repository) FindEffectiveComponentAssignments(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	periodStart time.Time,
+	periodEnd time.Time,
+) ([]EmployeeComponentAssignment, error) {
+	var assignments []EmployeeComponentAssignment
+	err := r.db.WithContext(ctx).
+		Preload("Template").
+		Joins("JOIN payroll_component_templates t ON t.id = employee_payroll_components.template_id").
+		Where("employee_payroll_components.company_id = ? AND employee_payroll_components.employee_id = ?", companyID, employeeID).
+		Where("t.is_active = ?", true).
+		Where("employee_payroll_components.effective_from <= ?", periodEnd).
+		Where("(employee_payroll_components.effective_to IS NULL OR employee_payroll_components.effective_to >= ?)", periodStart).
+		Order("t.component_type ASC, t.code ASC, employee_payroll_components.effective_from ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *repository) HasOverlappingComponentAssignment(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	templateID string,
+	effectiveFrom time.Time,
+	effectiveTo *time.Time,
+	excludeID *string,
+) (bool, error) {
+	db := r.db.WithContext(ctx).
+		Model(&EmployeeComponentAssignment{}).
+		Scopes(tenant.Scope(companyID)).
+		Where("employee_id = ? AND template_id = ?", employeeID, templateID).
+		Where("(effective_to IS NULL OR effective_to >= ?)", effectiveFrom)
+	if effectiveTo != nil {
+		db = db.Where("effective_from <= ?", *effectiveTo)
+	}
+	if excludeID != nil && *excludeID != "" {
+		db = db.Where("id <> ?", *excludeID)
+	}
+
+	var count int64
+	err := db.Count(&count).Error
+	return count > 0, err
+}
+
+// MarkPayrollsStaleByEmployee menandai payroll DRAFT karyawan yang periodenya beririsan
+// dengan rentang assignment yang berubah.
+func (r *repository) MarkPayrollsStaleByEmployee(
+	ctx context.Context,
+	companyID string,
+	employeeID string,
+	from time.Time,
+	to *time.Time,
+	reason string,
+) error {
+	db := r.db.WithContext(ctx).
+		Model(&Payroll{}).
+		Where("company_id = ? AND employee_id = ? AND status = ?", companyID, employeeID, StatusDraft).
+		Where("period_end >= ?", from)
+	if to != nil {
+		db = db.Where("period_start <= ?", *to)
+	}
+	return db.Updates(map[string]any{"is_stale": true, "stale_reason": reason}).Error
+}
+
+// MarkPayrollsStaleByTemplate menandai payroll DRAFT yang periodenya dicakup assignment
+// dari template yang berubah.
+func (r *repository) MarkPayrollsStaleByTemplate(ctx context.Context, companyID string, templateID string, reason string) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE payrolls p
+		SET is_stale = TRUE,
+			stale_reason = ?,
+			updated_at = now()
+		WHERE p.company_id = ?
+			AND p.status = ?
+			AND p.deleted_at IS NULL
+			AND EXISTS (
+				SELECT 1 FROM employee_payroll_components a
+				WHERE a.company_id = p.company_id
+					AND a.employee_id = p.employee_id
+					AND a.template_id = ?
+					AND a.effective_from <= p.period_end
+					AND (a.effective_to IS NULL OR a.effective_to >= p.period_start)
+			)
+	`, reason, companyID, StatusDraft, templateID).Error
+}
+
+func (r *repository) CreateLoan(ctx context.Context, loan *EmployeeLoan) error {
+	return r.db.WithContext(ctx).Omit("Employee", "Repayments").Create(loan).Error
+}
+
+func (r *repository) UpdateLoan(ctx context.Context, loan *EmployeeLoan) error {
+	return r.db.WithContext(ctx).Omit("Employee", "Repayments").Save(loan).Error
+}
+
+func (r *repository) FindLoans(ctx context.Context, companyID string, filter LoanQueryFilter) ([]EmployeeLoan, error) {
+	db := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Employee")
+	if filter.EmployeeID != nil && *filter.EmployeeID != "" {
+		db = db.Where("employee_id = ?", *filter.EmployeeID)
+	}
+	if filter.Status != nil && *filter.Status != "" {
+		db = db.Where("status = ?", *filter.Status)
+	}
+
+	var loans []EmployeeLoan
+	err := db.Order("created_at DESC").Find(&loans).Error
+	return loans, err
+}
+
+func (r *repository) FindLoanByID(ctx context.Context, companyID string, id string) (*EmployeeLoan, error) {
+	var loan EmployeeLoan
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Preload("Employee").
+		Preload("Repayments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("paid_on ASC, created_at ASC")
+		}).
+		First(&loan, "id = ?", id).Error
+	return &loan, err
+}
+
+// FindActiveLoans mengambil pinjaman karyawan yang masih memiliki sisa dan cicilan
+// pertamanya sudah jatuh tempo pada periode payroll.
+func (r *repository) FindActiveLoans(ctx context.Context, companyID string, employeeID string, periodEnd time.Time) ([]EmployeeLoan, error) {
+	var loans []EmployeeLoan
+	err := r.db.WithContext(ctx).
+		Where("company_id = ? AND employee_id = ?", companyID, employeeID).
+		Where("status = ? AND outstanding_balance > 0", LoanStatusActive).
+		Where("start_period <= ?", periodEnd).
+		Order("start_period ASC, created_at ASC").
+		Find(&loans).Error
+	return loans, err
+}
+
+func (r *repository) CreateLoanRepayment(ctx context.Context, repayment *LoanRepayment) error {
+	return r.db.WithContext(ctx).Create(repayment).Error
+}
+
+func (r *repository) UpdateLoanRepayment(ctx context.Context, repayment *LoanRepayment) error {
+	return r.db.WithContext(ctx).Save(repayment).Error
+}
+
+// FindLoanRepaymentsByPayroll mengambil cicilan yang dipotong payroll dan belum dibatalkan.
+func (r *repository) FindLoanRepaymentsByPayroll(ctx context.Context, companyID string, payrollID string) ([]LoanRepayment, error) {
+	var repayments []LoanRepayment
+	err := r.db.WithContext(ctx).
+		Scopes(tenant.Scope(companyID)).
+		Where("payroll_id = ? AND reversed_at IS NULL", payrollID).
+		Find(&repayments).Error
+	return repayments, err
+}
@@ -0,0 +1,72 @@
+package payroll
+
+import (
+	"context"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+// GetBPJSReport merekap iuran BPJS karyawan dan pemberi kerja per program untuk satu bulan.
+func (s *service) GetBPJSReport(ctx context.Context, companyID string, req BPJSReportFilterRequest) (BPJSReportResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return BPJSReportResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+
+	startStr, endStr, err := parseMonthPeriod(req.Period)
+	if err != nil {
+		return BPJSReportResponse{}, err
+	}
+	periodStart, _ := parseDate(startStr)
+	periodEnd, _ := parseDate(endStr)
+
+	contributions, err := s.repo.FindBPJSContributions(ctx, companyID, periodStart, periodEnd)
+	if err != nil {
+		return BPJSReportResponse{}, err
+	}
+
+	resp := BPJSReportResponse{
+		CompanyID:   companyID,
+		PeriodStart: startStr,
+		PeriodEnd:   endStr,
+		Rows:        make([]BPJSReportRow, 0),
+		Programs:    make(map[string]BPJSProgramAmount),
+	}
+	rowIndex := make(map[uuid.UUID]int)
+	for _, item := range contributions {
+		idx, ok := rowIndex[item.PayrollID]
+		if !ok {
+			idx = len(resp.Rows)
+			rowIndex[item.PayrollID] = idx
+			resp.Rows = append(resp.Rows, BPJSReportRow{
+				EmployeeID:    item.EmployeeID.String(),
+				EmployeeName:  item.EmployeeName,
+				PayrollID:     item.PayrollID.String(),
+				PayrollStatus: item.PayrollStatus,
+				Wage:          item.BaseSalary,
+				Programs:      make(map[string]BPJSProgramAmount),
+			})
+		}
+		row := &resp.Rows[idx]
+
+		rowProgram := row.Programs[item.Program]
+		totalProgram := resp.Programs[item.Program]
+		switch item.ComponentType {
+		case ComponentTypeDeduction:
+			rowProgram.Employee += item.TotalAmount
+			totalProgram.Employee += item.TotalAmount
+			row.EmployeeTotal += item.TotalAmount
+			resp.EmployeeTotal += item.TotalAmount
+		case ComponentTypeEmployerContribution:
+			rowProgram.Employer += item.TotalAmount
+			totalProgram.Employer += item.TotalAmount
+			row.EmployerTotal += item.TotalAmount
+			resp.EmployerTotal += item.TotalAmount
+		}
+		row.Programs[item.Program] = rowProgram
+		resp.Programs[item.Program] = totalProgram
+	}
+
+	return resp, nil
+}
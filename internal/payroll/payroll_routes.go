@@ -37,9 +37,10 @@ func RegisterRoutes(
 			middleware.RBACAuthorize(rbacService, "payroll", "read"),
 			handler.GetBreakdown,
 		)
+		// Karyawan boleh mengunduh payslip sendiri; payslip orang lain butuh payroll:read_all
 		payrolls.GET("/:id/payslip/download",
-			middleware.RateLimitByUser(0.5, 1), // Ketat karena proses generate PDF berat
-			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			middleware.RateLimitByUser(0.5, 1),
+			middleware.RBACCheck(rbacService, "payroll", "read_all"),
 			handler.DownloadPayslip,
 		)
 
@@ -53,6 +54,202 @@ func RegisterRoutes(
 		}
 		payrolls.POST("", append(createMiddleware, handler.Create)...)
 
+		// Pratinjau perhitungan payroll tanpa menyimpan data (what-if gaji pokok)
+		payrolls.POST("/simulate",
+			middleware.RateLimitByUser(0.2, 2),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.Simulate,
+		)
+
+		// Aturan perhitungan payroll per company (jadwal kerja, lembur, potongan kehadiran)
+		payrolls.GET("/settings",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetSetting,
+		)
+		payrolls.PUT("/settings",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.UpdateSetting,
+		)
+
+		// Master komponen gaji berulang dan assignment per karyawan, diekspansi otomatis saat payroll dibuat
+		templates := payrolls.Group("/component-templates")
+		templates.GET("",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetComponentTemplates,
+		)
+		templates.POST("",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.CreateComponentTemplate,
+		)
+		templates.PUT("/:id",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.UpdateComponentTemplate,
+		)
+		assignments := payrolls.Group("/component-assignments")
+		assignments.GET("",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetComponentAssignments,
+		)
+		assignments.POST("",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.CreateComponentAssignment,
+		)
+		assignments.PUT("/:id",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.UpdateComponentAssignment,
+		)
+		assignments.DELETE("/:id",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.DeleteComponentAssignment,
+		)
+
+		// Pinjaman dan kasbon karyawan, cicilannya dipotong otomatis dari payroll REGULAR
+		loans := payrolls.Group("/loans")
+		loans.GET("",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetLoans,
+		)
+		loans.GET("/:id",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetLoanById,
+		)
+		loans.POST("",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.CreateLoan,
+		)
+		loans.POST("/:id/payoff",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.PayoffLoan,
+		)
+
+		// Laporan iuran BPJS bulanan per company
+		payrolls.GET("/reports/bpjs",
+			middleware.RateLimitByUser(1, 2),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetBPJSReport,
+		)
+
+		// Perbandingan payroll antar periode untuk ditinjau sebelum approve
+		payrolls.GET("/reports/variance",
+			middleware.RateLimitByUser(1, 2),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetVarianceReport,
+		)
+
+		// File transfer gaji ke bank dan unggah file hasil transfer untuk menandai PAID
+		payrolls.POST("/bank-exports",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "pay"),
+			handler.ExportBankTransfer,
+		)
+		payrolls.POST("/bank-results",
+			middleware.RateLimitByUser(0.1, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "pay"),
+			handler.ImportBankResult,
+		)
+
+		// Rantai approval payroll (mis. HR -> Finance -> Owner di atas threshold)
+		payrolls.GET("/approval-chain",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetApprovalChain,
+		)
+		// Mengubah rantai butuh payroll:manage agar approver tidak bisa melewati step di atasnya
+		payrolls.PUT("/approval-chain",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "manage"),
+			handler.UpdateApprovalChain,
+		)
+
+		// Jurnal akuntansi payroll yang sudah di-approve dan mapping akunnya
+		payrolls.GET("/account-mappings",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetAccountMappings,
+		)
+		payrolls.PUT("/account-mappings",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.UpdateAccountMappings,
+		)
+		payrolls.POST("/journal-exports",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.ExportJournal,
+		)
+
+		// Periode payroll bulanan: CLOSED mengunci payroll dan perubahan gaji berlaku surut
+		periods := payrolls.Group("/periods")
+		periods.GET("",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetPeriods,
+		)
+		periods.GET("/:period",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetPeriod,
+		)
+		periods.POST("/:period/process",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+			handler.ProcessPeriod,
+		)
+		periods.POST("/:period/close",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "approve"),
+			handler.ClosePeriod,
+		)
+		periods.POST("/:period/reopen",
+			middleware.RateLimitByUser(0.1, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "manage"),
+			handler.ReopenPeriod,
+		)
+
+		// Payroll run: generate payroll satu periode untuk seluruh karyawan aktif
+		runs := payrolls.Group("/runs")
+		runs.GET("",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetRuns,
+		)
+		runs.GET("/:id",
+			middleware.RateLimitByUser(2, 5),
+			middleware.RBACAuthorize(rbacService, "payroll", "read"),
+			handler.GetRunById,
+		)
+		createRunMiddleware := []gin.HandlerFunc{
+			middleware.RateLimitByUser(0.05, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "create"),
+		}
+		if redisClient != nil {
+			createRunMiddleware = append([]gin.HandlerFunc{middleware.Idempotency(redisClient)}, createRunMiddleware...)
+		}
+		runs.POST("", append(createRunMiddleware, handler.CreateRun)...)
+		runs.POST("/:id/approve",
+			middleware.RateLimitByUser(0.2, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "approve"),
+			handler.ApproveRun,
+		)
+		runs.POST("/:id/mark-paid",
+			middleware.RateLimitByUser(0.1, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "pay"),
+			handler.MarkRunAsPaid,
+		)
+
 		payrolls.POST("/:id/regenerate",
 			middleware.RateLimitByUser(0.1, 1),
 			middleware.RBACAuthorize(rbacService, "payroll", "create"),
@@ -68,6 +265,16 @@ func RegisterRoutes(
 			middleware.RBACAuthorize(rbacService, "payroll", "pay"),
 			handler.MarkAsPaid,
 		)
+		payrolls.POST("/:id/cancel",
+			middleware.RateLimitByUser(0.1, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "cancel"),
+			handler.Cancel,
+		)
+		payrolls.POST("/:id/reverse",
+			middleware.RateLimitByUser(0.1, 1),
+			middleware.RBACAuthorize(rbacService, "payroll", "cancel"),
+			handler.Reverse,
+		)
 		payrolls.DELETE("/:id",
 			middleware.RateLimitByUser(0.05, 1),
 			middleware.RBACAuthorize(rbacService, "payroll", "delete"),
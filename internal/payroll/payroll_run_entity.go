@@ -0,0 +1,61 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayrollRun mengelompokkan payroll satu periode yang dibuat sekaligus
+// untuk seluruh karyawan aktif (opsional per departemen).
+type PayrollRun struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CompanyID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_payroll_runs_company_period"`
+	DepartmentID *uuid.UUID `gorm:"type:uuid"`
+
+	// Jenis payroll yang dibuat run: REGULAR atau THR (dengan tanggal hari raya).
+	PayrollType   string     `gorm:"type:varchar(20);not null;default:'REGULAR'"`
+	ReferenceDate *time.Time `gorm:"type:date"`
+
+	PeriodStart time.Time `gorm:"type:date;not null;index:idx_payroll_runs_company_period"`
+	PeriodEnd   time.Time `gorm:"type:date;not null;index:idx_payroll_runs_company_period"`
+
+	// Ringkasan hasil generate
+	TotalEmployees int   `gorm:"type:int;not null;default:0"`
+	SuccessCount   int   `gorm:"type:int;not null;default:0"`
+	FailedCount    int   `gorm:"type:int;not null;default:0"`
+	TotalNetSalary int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Workflow & Audit
+	Status     string     `gorm:"type:varchar(20);not null;default:'DRAFT'"`
+	CreatedBy  uuid.UUID  `gorm:"type:uuid;not null"`
+	ApprovedBy *uuid.UUID `gorm:"type:uuid"`
+	ApprovedAt *time.Time
+	PaidAt     *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	Failures  []PayrollRunFailure `gorm:"foreignKey:RunID"`
+	Approvals []PayrollApproval   `gorm:"foreignKey:RunID"`
+}
+
+// PayrollRunFailure mencatat karyawan yang gagal dibuatkan payroll dalam satu run.
+type PayrollRunFailure struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	RunID        uuid.UUID      `gorm:"type:uuid;not null;index"`
+	CompanyID    uuid.UUID      `gorm:"type:uuid;not null"`
+	EmployeeID   uuid.UUID      `gorm:"type:uuid;not null"`
+	Employee     *LeaveEmployee `gorm:"foreignKey:EmployeeID;references:ID"`
+	ErrorCode    string         `gorm:"type:varchar(50);not null"`
+	ErrorMessage string         `gorm:"type:text;not null"`
+	CreatedAt    time.Time
+}
+
+// RunEmployee adalah proyeksi karyawan aktif yang akan dibuatkan payroll dalam run.
+type RunEmployee struct {
+	ID       uuid.UUID
+	FullName string
+}
+
+// Status kepegawaian yang tidak ikut dibuatkan payroll dalam run.
+var inactiveEmploymentStatuses = []string{"inactive", "terminated", "resigned"}
@@ -0,0 +1,464 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/apperror"
+	"go-hris/internal/shared/contextutil"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CreateRun membuat payroll DRAFT untuk seluruh karyawan aktif pada periode run.
+// Setiap karyawan diproses dalam transaksi terpisah sehingga kegagalan satu
+// karyawan (mis. overlap) tidak membatalkan karyawan lain dan dicatat sebagai failure.
+// Run dibuat PROCESSING dan baru menjadi DRAFT bersama ringkasan dan failure-nya,
+// sehingga run yang prosesnya terhenti tidak pernah terlihat lengkap dan tidak bisa di-approve.
+func (s *service) CreateRun(
+	ctx context.Context,
+	companyID, actorID string,
+	req CreatePayrollRunRequest,
+) (PayrollRunResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidActorID
+	}
+
+	periodStart, periodEnd, err := resolveRunPeriod(req)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	if err := ensurePeriodOpen(ctx, s.repo, companyID, periodStart, periodEnd); err != nil {
+		return PayrollRunResponse{}, err
+	}
+	// Run hanya untuk payroll yang bisa dihitung tanpa input per karyawan.
+	payrollType, err := normalizePayrollType(req.PayrollType)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	if payrollType != PayrollTypeRegular && payrollType != PayrollTypeTHR {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidPayrollType
+	}
+	referenceDate, err := resolveReferenceDate(payrollType, req.ReferenceDate, periodEnd)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	var departmentID *string
+	var departmentUUID *uuid.UUID
+	if v := strings.TrimSpace(req.DepartmentID); v != "" {
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			return PayrollRunResponse{}, payrollerrors.ErrInvalidDepartmentID
+		}
+		departmentID = &v
+		departmentUUID = &parsed
+	}
+
+	employees, err := s.repo.FindEmployeesForRun(ctx, companyID, periodStart, periodEnd, departmentID)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	if len(employees) == 0 {
+		return PayrollRunResponse{}, payrollerrors.ErrPayrollRunNoEmployees
+	}
+
+	run := &PayrollRun{
+		ID:             uuid.New(),
+		CompanyID:      companyUUID,
+		DepartmentID:   departmentUUID,
+		PayrollType:    payrollType,
+		ReferenceDate:  referenceDate,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		TotalEmployees: len(employees),
+		Status:         StatusProcessing,
+		CreatedBy:      actorUUID,
+	}
+	if err := s.repo.CreateRun(ctx, run); err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	payrolls := make([]Payroll, 0, len(employees))
+	failures := make([]PayrollRunFailure, 0)
+	for _, emp := range employees {
+		persisted, err := s.createRunPayroll(ctx, companyID, actorID, run, emp)
+		if err != nil {
+			failures = append(failures, newRunFailure(ctx, run, emp, err))
+			continue
+		}
+		payrolls = append(payrolls, *persisted)
+		run.TotalNetSalary += persisted.NetSalary
+	}
+
+	run.SuccessCount = len(payrolls)
+	run.FailedCount = len(failures)
+	run.Status = StatusDraft
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+	if err := qtx.CreateRunFailures(ctx, failures); err != nil {
+		return PayrollRunResponse{}, err
+	}
+	if err := qtx.UpdateRun(ctx, run); err != nil {
+		return PayrollRunResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	run.Failures = failures
+	return mapToRunResponse(*run, payrolls), nil
+}
+
+func (s *service) createRunPayroll(
+	ctx context.Context,
+	companyID, actorID string,
+	run *PayrollRun,
+	emp RunEmployee,
+) (*Payroll, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	req := CreatePayrollRequest{
+		EmployeeID:  emp.ID.String(),
+		PayrollType: run.PayrollType,
+		PeriodStart: run.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:   run.PeriodEnd.Format("2006-01-02"),
+	}
+	if run.ReferenceDate != nil {
+		req.ReferenceDate = run.ReferenceDate.Format("2006-01-02")
+	}
+	persisted, err := s.createPayroll(ctx, s.repo.WithTx(tx), companyID, actorID, req, &run.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return persisted, nil
+}
+
+func (s *service) GetRuns(ctx context.Context, companyID string) ([]PayrollRunResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return nil, payrollerrors.ErrInvalidCompanyID
+	}
+
+	runs, err := s.repo.FindRunsByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]PayrollRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, mapToRunResponse(run, nil))
+	}
+	return responses, nil
+}
+
+func (s *service) GetRunByID(ctx context.Context, companyID, id string) (PayrollRunResponse, error) {
+	run, err := s.repo.FindRunByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollRunResponse{}, payrollerrors.ErrPayrollRunNotFound
+		}
+		return PayrollRunResponse{}, err
+	}
+
+	payrolls, err := s.repo.FindByRun(ctx, companyID, run.ID.String())
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	return mapToRunResponse(*run, payrolls), nil
+}
+
+// ApproveRun melewati satu langkah rantai approval untuk seluruh run, dengan threshold
+// dihitung dari total net salary payroll DRAFT. Pada langkah terakhir seluruh payroll DRAFT
+// di-approve sekaligus dan event permintaan payslip diantrikan ke outbox dalam transaksi yang sama.
+func (s *service) ApproveRun(ctx context.Context, companyID, actorID, role, id string, req ApprovePayrollRequest) (PayrollRunResponse, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	if _, err = uuid.Parse(companyID); err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidActorID
+	}
+
+	run, err := qtx.FindRunByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollRunResponse{}, payrollerrors.ErrPayrollRunNotFound
+		}
+		return PayrollRunResponse{}, err
+	}
+	if run.Status != StatusDraft {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidStatusTransition
+	}
+
+	payrolls, err := qtx.FindByRun(ctx, companyID, run.ID.String())
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	drafts := 0
+	var netTotal int64
+	for i := range payrolls {
+		if payrolls[i].Status != StatusDraft {
+			continue
+		}
+		if payrolls[i].IsStale {
+			return PayrollRunResponse{}, payrollerrors.ErrPayrollStale
+		}
+		drafts++
+		netTotal += payrolls[i].NetSalary
+	}
+	if drafts == 0 {
+		return PayrollRunResponse{}, payrollerrors.ErrPayrollRunHasNoDraft
+	}
+
+	now := time.Now().UTC()
+	required, err := requiredApprovalSteps(ctx, qtx, companyID, netTotal)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	final, err := recordApprovalStep(ctx, qtx, required, &run.Approvals, PayrollApproval{
+		CompanyID: run.CompanyID,
+		RunID:     &run.ID,
+		ActorID:   actorUUID,
+	}, role, req.Comment, now)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	if final {
+		for i := range payrolls {
+			if payrolls[i].Status != StatusDraft {
+				continue
+			}
+			if err := s.approvePayroll(ctx, tx, qtx, &payrolls[i], actorUUID, now); err != nil {
+				return PayrollRunResponse{}, err
+			}
+		}
+
+		run.Status = StatusApproved
+		run.ApprovedBy = &actorUUID
+		run.ApprovedAt = &now
+		if err := qtx.UpdateRun(ctx, run); err != nil {
+			return PayrollRunResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	resp := mapToRunResponse(*run, payrolls)
+	if next := nextApprovalStep(required, run.Approvals); !final && next != nil {
+		step := mapToApprovalStepResponse(*next)
+		resp.NextApprovalStep = &step
+	}
+	return resp, nil
+}
+
+func (s *service) MarkRunAsPaid(ctx context.Context, companyID, actorID, id string) (PayrollRunResponse, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	if _, err = uuid.Parse(companyID); err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	if _, err = uuid.Parse(actorID); err != nil {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidActorID
+	}
+
+	run, err := qtx.FindRunByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollRunResponse{}, payrollerrors.ErrPayrollRunNotFound
+		}
+		return PayrollRunResponse{}, err
+	}
+	if run.Status != StatusApproved {
+		return PayrollRunResponse{}, payrollerrors.ErrInvalidStatusTransition
+	}
+
+	payrolls, err := qtx.FindByRun(ctx, companyID, run.ID.String())
+	if err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	now := time.Now().UTC()
+	for i := range payrolls {
+		if payrolls[i].Status != StatusApproved {
+			continue
+		}
+		payrolls[i].Status = StatusPaid
+		payrolls[i].PaidAt = &now
+		if err := qtx.Update(ctx, &payrolls[i]); err != nil {
+			return PayrollRunResponse{}, err
+		}
+	}
+
+	run.Status = StatusPaid
+	run.PaidAt = &now
+	if err := qtx.UpdateRun(ctx, run); err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PayrollRunResponse{}, err
+	}
+
+	return mapToRunResponse(*run, payrolls), nil
+}
+
+// resolveRunPeriod menerima period (YYYY-MM) atau pasangan period_start/period_end.
+func resolveRunPeriod(req CreatePayrollRunRequest) (time.Time, time.Time, error) {
+	startRaw := strings.TrimSpace(req.PeriodStart)
+	endRaw := strings.TrimSpace(req.PeriodEnd)
+	if startRaw == "" && endRaw == "" {
+		if strings.TrimSpace(req.Period) == "" {
+			return time.Time{}, time.Time{}, payrollerrors.ErrInvalidPeriodFormat
+		}
+		monthStart, monthEnd, err := parseMonthPeriod(req.Period)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		startRaw, endRaw = monthStart, monthEnd
+	}
+
+	periodStart, err := parseDate(startRaw)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	periodEnd, err := parseDate(endRaw)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if periodStart.After(periodEnd) {
+		return time.Time{}, time.Time{}, payrollerrors.ErrInvalidDateRange
+	}
+	return periodStart, periodEnd, nil
+}
+
+func newRunFailure(ctx context.Context, run *PayrollRun, emp RunEmployee, err error) PayrollRunFailure {
+	code, message := failureDetail(ctx, emp, err)
+	return PayrollRunFailure{
+		ID:           uuid.New(),
+		RunID:        run.ID,
+		CompanyID:    run.CompanyID,
+		EmployeeID:   emp.ID,
+		Employee:     &LeaveEmployee{ID: emp.ID, FullName: emp.FullName},
+		ErrorCode:    code,
+		ErrorMessage: message,
+	}
+}
+
+// failureDetail mengambil kode dan pesan error per karyawan untuk dilaporkan tanpa
+// menggagalkan seluruh batch. Error internal hanya dicatat ke log dan dilaporkan
+// dengan pesan generik agar detail database tidak bocor ke response.
+func failureDetail(ctx context.Context, emp RunEmployee, err error) (string, string) {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code, appErr.Message
+	}
+	contextutil.GetLogger(ctx, nil).Error("payroll employee processing failed",
+		zap.String("employee_id", emp.ID.String()),
+		zap.Error(err),
+	)
+	return apperror.ErrInternal.Code, apperror.ErrInternal.Message
+}
+
+func mapToRunResponse(run PayrollRun, payrolls []Payroll) PayrollRunResponse {
+	resp := PayrollRunResponse{
+		ID:             run.ID.String(),
+		CompanyID:      run.CompanyID.String(),
+		PayrollType:    payrollTypeOrRegular(run.PayrollType),
+		PeriodStart:    run.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:      run.PeriodEnd.Format("2006-01-02"),
+		Status:         run.Status,
+		TotalEmployees: run.TotalEmployees,
+		SuccessCount:   run.SuccessCount,
+		FailedCount:    run.FailedCount,
+		TotalNetSalary: run.TotalNetSalary,
+		CreatedBy:      run.CreatedBy.String(),
+		CreatedAt:      run.CreatedAt.Format(time.RFC3339),
+	}
+
+	if run.DepartmentID != nil {
+		v := run.DepartmentID.String()
+		resp.DepartmentID = &v
+	}
+	if run.ReferenceDate != nil {
+		v := run.ReferenceDate.Format("2006-01-02")
+		resp.ReferenceDate = &v
+	}
+	if run.ApprovedBy != nil {
+		v := run.ApprovedBy.String()
+		resp.ApprovedBy = &v
+	}
+	if run.ApprovedAt != nil {
+		v := run.ApprovedAt.Format(time.RFC3339)
+		resp.ApprovedAt = &v
+	}
+	if run.PaidAt != nil {
+		v := run.PaidAt.Format(time.RFC3339)
+		resp.PaidAt = &v
+	}
+
+	resp.Approvals = mapToApprovalResponses(run.Approvals)
+
+	if len(run.Failures) > 0 {
+		resp.Failures = make([]PayrollRunFailureResponse, 0, len(run.Failures))
+		for _, failure := range run.Failures {
+			item := PayrollRunFailureResponse{
+				EmployeeID:   failure.EmployeeID.String(),
+				ErrorCode:    failure.ErrorCode,
+				ErrorMessage: failure.ErrorMessage,
+			}
+			if failure.Employee != nil {
+				item.EmployeeName = failure.Employee.FullName
+			}
+			resp.Failures = append(resp.Failures, item)
+		}
+	}
+
+	if len(payrolls) > 0 {
+		resp.Payrolls = mapToListResponse(payrolls)
+	}
+
+	return resp
+}
@@ -0,0 +1,185 @@
+package payroll_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-hris/internal/messaging/kafka"
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/apperror"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_CreateRun(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	okEmployee := payroll.RunEmployee{ID: uuid.New(), FullName: "Budi"}
+	overlapEmployee := payroll.RunEmployee{ID: uuid.New(), FullName: "Sari"}
+	noSalaryEmployee := payroll.RunEmployee{ID: uuid.New(), FullName: "Tono"}
+	brokenEmployee := payroll.RunEmployee{ID: uuid.New(), FullName: "Rina"}
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	// ok employee commit, overlap, tanpa gaji & error database rollback, ringkasan run commit
+	expectTx(t, deps.sqlMock, true)
+	expectTx(t, deps.sqlMock, false)
+	expectTx(t, deps.sqlMock, false)
+	expectTx(t, deps.sqlMock, false)
+	expectTx(t, deps.sqlMock, true)
+
+	deps.repo.findEmployeesForRunFn = func(ctx context.Context, cid string, periodStart, periodEnd time.Time, departmentID *string) ([]payroll.RunEmployee, error) {
+		assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), periodStart)
+		assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), periodEnd)
+		assert.Nil(t, departmentID)
+		return []payroll.RunEmployee{okEmployee, overlapEmployee, noSalaryEmployee, brokenEmployee}, nil
+	}
+	deps.repo.hasOverlappingPeriodFn = func(ctx context.Context, cid, employeeID, payrollType string, start, end time.Time, exclude *string) (bool, error) {
+		assert.Equal(t, payroll.PayrollTypeRegular, payrollType)
+		return employeeID == overlapEmployee.ID.String(), nil
+	}
+
+	deps.repo.findSalaryHistoryFn = func(ctx context.Context, employeeID string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+		switch employeeID {
+		case okEmployee.ID.String():
+			return []payroll.SalaryHistory{{BaseSalary: 8000000, EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}, nil
+		case brokenEmployee.ID.String():
+			return nil, errors.New("pq: relation \"employee_salaries\" does not exist")
+		}
+		return nil, nil
+	}
+
+	var runID uuid.UUID
+	deps.repo.createRunFn = func(ctx context.Context, run *payroll.PayrollRun) error {
+		runID = run.ID
+		assert.Equal(t, 4, run.TotalEmployees)
+		assert.Equal(t, payroll.StatusProcessing, run.Status)
+		return nil
+	}
+	var finalized *payroll.PayrollRun
+	deps.repo.updateRunFn = func(ctx context.Context, run *payroll.PayrollRun) error {
+		finalized = run
+		return nil
+	}
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		assert.Equal(t, int64(8000000), p.BaseSalary)
+		if assert.NotNil(t, p.RunID) {
+			assert.Equal(t, runID, *p.RunID)
+		}
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+		return &payroll.Payroll{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(cid), EmployeeID: okEmployee.ID, BaseSalary: 8000000, NetSalary: 8000000, Status: payroll.StatusDraft, RunID: &runID}, nil
+	}
+	var storedFailures []payroll.PayrollRunFailure
+	deps.repo.createRunFailuresFn = func(ctx context.Context, failures []payroll.PayrollRunFailure) error {
+		storedFailures = failures
+		return nil
+	}
+
+	resp, err := deps.service.CreateRun(ctx, companyID, actorID, payroll.CreatePayrollRunRequest{Period: "2026-02"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, resp.TotalEmployees)
+	assert.Equal(t, 1, resp.SuccessCount)
+	assert.Equal(t, 3, resp.FailedCount)
+	assert.Equal(t, payroll.StatusDraft, resp.Status)
+	if assert.NotNil(t, finalized) {
+		assert.Equal(t, payroll.StatusDraft, finalized.Status)
+	}
+	assert.Equal(t, int64(8000000), resp.TotalNetSalary)
+	assert.Len(t, resp.Payrolls, 1)
+	if assert.Len(t, storedFailures, 3) {
+		assert.Equal(t, overlapEmployee.ID, storedFailures[0].EmployeeID)
+		assert.Equal(t, payrollerrors.ErrPayrollOverlap.Code, storedFailures[0].ErrorCode)
+		assert.Equal(t, payrollerrors.ErrPayrollOverlap.Message, storedFailures[0].ErrorMessage)
+		assert.Equal(t, noSalaryEmployee.ID, storedFailures[1].EmployeeID)
+		assert.Equal(t, payrollerrors.ErrEmployeeSalaryNotFound.Message, storedFailures[1].ErrorMessage)
+		// Error internal tidak membocorkan detail database
+		assert.Equal(t, brokenEmployee.ID, storedFailures[2].EmployeeID)
+		assert.Equal(t, apperror.CodeInternalError, storedFailures[2].ErrorCode)
+		assert.Equal(t, apperror.ErrInternal.Message, storedFailures[2].ErrorMessage)
+	}
+	if assert.Len(t, resp.Failures, 3) {
+		assert.Equal(t, "Sari", resp.Failures[0].EmployeeName)
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_CreateRun_NoEmployees(t *testing.T) {
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	_, err := deps.service.CreateRun(context.Background(), uuid.New().String(), uuid.New().String(), payroll.CreatePayrollRunRequest{Period: "2026-02"})
+
+	assert.ErrorIs(t, err, payrollerrors.ErrPayrollRunNoEmployees)
+}
+
+func TestPayrollService_ApproveRun_QueuesPayslipEvents(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	runID := uuid.New()
+
+	db, sqlMock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &fakePayrollRepository{
+		findRunByIDAndCompanyFn: func(ctx context.Context, cid string, id string) (*payroll.PayrollRun, error) {
+			return &payroll.PayrollRun{ID: runID, CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft}, nil
+		},
+		findByRunFn: func(ctx context.Context, cid string, rid string) ([]payroll.Payroll, error) {
+			return []payroll.Payroll{
+				{ID: uuid.New(), CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft, RunID: &runID},
+				{ID: uuid.New(), CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft, RunID: &runID},
+			}, nil
+		},
+	}
+	queued := 0
+	outbox := &fakeOutboxRepository{
+		createFn: func(ctx context.Context, event kafka.OutboxEvent) error {
+			queued++
+			return nil
+		},
+	}
+	svc := payroll.NewServiceWithOutbox(db, repo, outbox)
+
+	expectTx(t, sqlMock, true)
+	resp, err := svc.ApproveRun(ctx, companyID, actorID, "Finance", runID.String(), payroll.ApprovePayrollRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, payroll.StatusApproved, resp.Status)
+	assert.Equal(t, 2, queued)
+	for _, p := range resp.Payrolls {
+		assert.Equal(t, payroll.StatusApproved, p.Status)
+	}
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_MarkRunAsPaid_RequiresApproved(t *testing.T) {
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, false)
+	deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.PayrollRun, error) {
+		return &payroll.PayrollRun{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft}, nil
+	}
+
+	_, err := deps.service.MarkRunAsPaid(context.Background(), uuid.New().String(), uuid.New().String(), uuid.New().String())
+
+	assert.ErrorIs(t, err, payrollerrors.ErrInvalidStatusTransition)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
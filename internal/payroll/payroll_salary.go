@@ -0,0 +1,182 @@
+package payroll
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+)
+
+// SalaryHistory adalah baris employee_salaries yang relevan untuk satu periode payroll.
+type SalaryHistory struct {
+	BaseSalary    int64
+	EffectiveDate time.Time
+}
+
+type salarySegment struct {
+	Start      time.Time
+	End        time.Time
+	Days       int64
+	BaseSalary int64
+	Amount     int64
+}
+
+// resolvedBaseSalary adalah hasil penentuan gaji pokok untuk satu payroll.
+type resolvedBaseSalary struct {
+	Amount   int64
+	Full     int64 // Gaji pokok sebulan penuh sebelum prorata masa kerja, dasar tarif harian dan persentase komponen
+	Override bool
+	Derived  *int64
+	Note     *string
+}
+
+// resolveBaseSalary memilih gaji pokok payroll. Segmen riwayat gaji dihitung sekali dan
+// dipotong ke masa kerja dalam periode, sehingga gaji yang baru berlaku sejak tanggal masuk
+// tidak diprorata dua kali. Jika override diisi, nilai manual dipakai dan hasil derivasi
+// (bila ada) tetap disimpan untuk audit.
+func resolveBaseSalary(
+	history []SalaryHistory,
+	periodStart, periodEnd time.Time,
+	employment employmentProration,
+	workDaysPerWeek int,
+	override *int64,
+) (resolvedBaseSalary, error) {
+	var derived *int64
+	var full int64
+	var note *string
+	if len(history) > 0 {
+		full = fullMonthBaseSalary(history, periodStart, periodEnd)
+		amount, segments := prorateBaseSalary(history, periodStart, periodEnd, employment, workDaysPerWeek)
+		derived = &amount
+		note = describeSalarySegments(segments, periodStart, periodEnd, employment)
+	}
+
+	if override != nil {
+		if *override < 0 {
+			return resolvedBaseSalary{}, payrollerrors.ErrInvalidMoneyValue
+		}
+		return resolvedBaseSalary{Amount: *override, Full: *override, Override: true, Derived: derived}, nil
+	}
+
+	if derived == nil {
+		return resolvedBaseSalary{}, payrollerrors.ErrEmployeeSalaryNotFound
+	}
+	return resolvedBaseSalary{Amount: *derived, Full: full, Derived: derived, Note: note}, nil
+}
+
+// salarySegments membagi periode per tanggal efektif gaji.
+func salarySegments(history []SalaryHistory, periodStart, periodEnd time.Time) []salarySegment {
+	segments := make([]salarySegment, 0, len(history))
+	for i, h := range history {
+		start := h.EffectiveDate
+		if start.Before(periodStart) {
+			start = periodStart
+		}
+		end := periodEnd
+		if i+1 < len(history) {
+			end = history[i+1].EffectiveDate.AddDate(0, 0, -1)
+		}
+		if end.Before(start) {
+			continue
+		}
+		segments = append(segments, salarySegment{Start: start, End: end, BaseSalary: h.BaseSalary})
+	}
+	return segments
+}
+
+// fullMonthBaseSalary menghitung gaji pokok seolah karyawan aktif sepanjang periode. Gaji
+// pertama dianggap berlaku sejak awal periode, mis. untuk karyawan baru yang gajinya
+// berlaku sejak tanggal masuk.
+func fullMonthBaseSalary(history []SalaryHistory, periodStart, periodEnd time.Time) int64 {
+	segments := salarySegments(history, periodStart, periodEnd)
+	if len(segments) == 0 {
+		return 0
+	}
+	segments[0].Start = periodStart
+
+	totalDays := daysBetween(periodStart, periodEnd)
+	var total int64
+	for _, seg := range segments {
+		total += seg.BaseSalary * daysBetween(seg.Start, seg.End) / totalDays
+	}
+	return total
+}
+
+// prorateBaseSalary menghitung gaji pokok per segmen tanggal efektif gaji. Tanpa prorata
+// masa kerja tiap segmen dihitung per hari kalender; jika karyawan masuk atau keluar di
+// tengah periode, segmen dipotong ke masa kerja dan dihitung dengan metode prorata company.
+func prorateBaseSalary(
+	history []SalaryHistory,
+	periodStart, periodEnd time.Time,
+	employment employmentProration,
+	workDaysPerWeek int,
+) (int64, []salarySegment) {
+	partial := employment.Partial()
+	totalDays := daysBetween(periodStart, periodEnd)
+	if partial {
+		totalDays = employment.TotalDays
+	}
+
+	segments := make([]salarySegment, 0, len(history))
+	var total int64
+	for _, seg := range salarySegments(history, periodStart, periodEnd) {
+		if partial {
+			if seg.Start.Before(employment.Start) {
+				seg.Start = employment.Start
+			}
+			if seg.End.After(employment.End) {
+				seg.End = employment.End
+			}
+			if seg.End.Before(seg.Start) {
+				continue
+			}
+			seg.Days, _ = prorationDays(employment.Method, workDaysPerWeek, seg.Start, seg.End, periodStart, periodEnd)
+		} else {
+			seg.Days = daysBetween(seg.Start, seg.End)
+		}
+		seg.Amount = seg.BaseSalary * seg.Days / totalDays
+		segments = append(segments, seg)
+		total += seg.Amount
+	}
+
+	return total, segments
+}
+
+func describeSalarySegments(segments []salarySegment, periodStart, periodEnd time.Time, employment employmentProration) *string {
+	parts := make([]string, 0, len(segments)+1)
+	if employment.Partial() {
+		parts = append(parts, fmt.Sprintf("Prorata masa kerja %s s/d %s: %d/%d %s",
+			employment.Start.Format("2006-01-02"), employment.End.Format("2006-01-02"),
+			employment.Days, employment.TotalDays, prorationUnit(employment.Method)))
+		if len(segments) == 1 {
+			note := parts[0]
+			return &note
+		}
+	} else if len(segments) == 1 && segments[0].Start.Equal(periodStart) && segments[0].End.Equal(periodEnd) {
+		return nil
+	}
+
+	totalDays, unit := daysBetween(periodStart, periodEnd), "hari"
+	if employment.Partial() {
+		totalDays, unit = employment.TotalDays, prorationUnit(employment.Method)
+	}
+	for _, seg := range segments {
+		parts = append(parts, fmt.Sprintf(
+			"%s s/d %s: %d/%d %s x %d = %d",
+			seg.Start.Format("2006-01-02"),
+			seg.End.Format("2006-01-02"),
+			seg.Days,
+			totalDays,
+			unit,
+			seg.BaseSalary,
+			seg.Amount,
+		))
+	}
+	note := strings.Join(parts, "; ")
+	return &note
+}
+
+func daysBetween(start, end time.Time) int64 {
+	return int64(end.Sub(start).Hours()/24) + 1
+}
@@ -9,8 +9,8 @@ import (
 	"go-hris/internal/events"
 	"go-hris/internal/messaging/kafka"
 	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/storage"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -23,6 +23,15 @@ const (
 	StatusApproved = "APPROVED"
 	StatusPaid     = "PAID"
 
+	// StatusCancelled untuk payroll APPROVED yang dibatalkan sebelum dibayar,
+	// StatusReversed untuk payroll PAID yang sudah dibalik oleh payroll penyesuaian.
+	StatusCancelled = "CANCELLED"
+	StatusReversed  = "REVERSED"
+
+	// StatusProcessing hanya untuk payroll run yang karyawannya masih diproses. Run yang
+	// tertinggal PROCESSING (mis. proses terhenti di tengah) tidak bisa di-approve.
+	StatusProcessing = "PROCESSING"
+
 	ComponentTypeAllowance = "ALLOWANCE"
 	ComponentTypeDeduction = "DEDUCTION"
 )
@@ -30,20 +39,65 @@ const (
 //go:generate mockgen -source=payroll_service.go -destination=mock/payroll_service_mock.go -package=mock
 type Service interface {
 	Create(ctx context.Context, companyID, actorID string, req CreatePayrollRequest) (PayrollResponse, error)
-	GetAll(ctx context.Context, companyID string, filterReq GetPayrollsFilterRequest) ([]PayrollResponse, error)
+	GetAll(ctx context.Context, companyID string, filterReq GetPayrollsFilterRequest) ([]PayrollResponse, int64, error)
 	GetByID(ctx context.Context, companyID, id string) (PayrollResponse, error)
 	GetBreakdown(ctx context.Context, companyID, id string) (PayrollBreakdownResponse, error)
 	Regenerate(ctx context.Context, companyID, actorID, id string, req RegeneratePayrollRequest) (PayrollResponse, error)
-	Approve(ctx context.Context, companyID, actorID, id string) (PayrollResponse, error)
+	Approve(ctx context.Context, companyID, actorID, role, id string, req ApprovePayrollRequest) (PayrollResponse, error)
 	MarkAsPaid(ctx context.Context, companyID, actorID, id string) (PayrollResponse, error)
 	GeneratePayslip(ctx context.Context, companyID, id string) (PayrollResponse, error)
+	GetPayslipDownload(ctx context.Context, companyID, requesterEmployeeID, id string, canReadAll bool) (PayslipDownloadResponse, error)
 	Delete(ctx context.Context, companyID, id string) error
+	Cancel(ctx context.Context, companyID, actorID, id string, req CancelPayrollRequest) (PayrollResponse, error)
+	Reverse(ctx context.Context, companyID, actorID, id string, req CancelPayrollRequest) (PayrollResponse, error)
+	Simulate(ctx context.Context, companyID string, req SimulatePayrollRequest) (PayrollSimulationResponse, error)
+
+	CreateRun(ctx context.Context, companyID, actorID string, req CreatePayrollRunRequest) (PayrollRunResponse, error)
+	GetRuns(ctx context.Context, companyID string) ([]PayrollRunResponse, error)
+	GetRunByID(ctx context.Context, companyID, id string) (PayrollRunResponse, error)
+	ApproveRun(ctx context.Context, companyID, actorID, role, id string, req ApprovePayrollRequest) (PayrollRunResponse, error)
+	MarkRunAsPaid(ctx context.Context, companyID, actorID, id string) (PayrollRunResponse, error)
+
+	GetPeriods(ctx context.Context, companyID string, req GetPayrollPeriodsRequest) ([]PayrollPeriodResponse, error)
+	GetPeriod(ctx context.Context, companyID, period string) (PayrollPeriodResponse, error)
+	ProcessPeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error)
+	ClosePeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error)
+	ReopenPeriod(ctx context.Context, companyID, actorID, period string, req PayrollPeriodActionRequest) (PayrollPeriodResponse, error)
+
+	GetSetting(ctx context.Context, companyID string) (PayrollSettingResponse, error)
+	UpdateSetting(ctx context.Context, companyID, actorID string, req UpdatePayrollSettingRequest) (PayrollSettingResponse, error)
+
+	GetComponentTemplates(ctx context.Context, companyID string) ([]ComponentTemplateResponse, error)
+	CreateComponentTemplate(ctx context.Context, companyID, actorID string, req ComponentTemplateRequest) (ComponentTemplateResponse, error)
+	UpdateComponentTemplate(ctx context.Context, companyID, actorID, id string, req ComponentTemplateRequest) (ComponentTemplateResponse, error)
+	GetComponentAssignments(ctx context.Context, companyID, employeeID string) ([]ComponentAssignmentResponse, error)
+	CreateComponentAssignment(ctx context.Context, companyID, actorID string, req CreateComponentAssignmentRequest) (ComponentAssignmentResponse, error)
+	UpdateComponentAssignment(ctx context.Context, companyID, id string, req UpdateComponentAssignmentRequest) (ComponentAssignmentResponse, error)
+	DeleteComponentAssignment(ctx context.Context, companyID, id string) error
+
+	GetLoans(ctx context.Context, companyID string, req GetLoansFilterRequest) ([]LoanResponse, error)
+	GetLoanByID(ctx context.Context, companyID, id string) (LoanResponse, error)
+	CreateLoan(ctx context.Context, companyID, actorID string, req CreateLoanRequest) (LoanResponse, error)
+	PayoffLoan(ctx context.Context, companyID, actorID, id string, req PayoffLoanRequest) (LoanResponse, error)
+
+	GetBPJSReport(ctx context.Context, companyID string, req BPJSReportFilterRequest) (BPJSReportResponse, error)
+	GetVarianceReport(ctx context.Context, companyID string, req PayrollVarianceFilterRequest) (PayrollVarianceResponse, error)
+
+	ExportBankTransfer(ctx context.Context, companyID string, req BankExportRequest) (BankExportFile, error)
+	ImportBankResult(ctx context.Context, companyID, actorID string, content []byte) (BankResultResponse, error)
+
+	GetApprovalChain(ctx context.Context, companyID string) (ApprovalChainResponse, error)
+	UpdateApprovalChain(ctx context.Context, companyID, actorID string, req UpdateApprovalChainRequest) (ApprovalChainResponse, error)
+	GetAccountMappings(ctx context.Context, companyID string) ([]AccountMappingResponse, error)
+	UpdateAccountMappings(ctx context.Context, companyID, actorID string, req UpdateAccountMappingsRequest) ([]AccountMappingResponse, error)
+	ExportJournal(ctx context.Context, companyID string, req JournalExportRequest) (JournalExportFile, error)
 }
 
 type service struct {
 	db     *sql.DB
 	repo   Repository
 	outbox kafka.OutboxRepository
+	blobs  storage.BlobStore
 }
 
 func NewService(db *sql.DB, repo Repository) Service {
@@ -51,7 +105,13 @@ func NewService(db *sql.DB, repo Repository) Service {
 }
 
 func NewServiceWithOutbox(db *sql.DB, repo Repository, outboxRepo kafka.OutboxRepository) Service {
-	return &service{db: db, repo: repo, outbox: outboxRepo}
+	return NewServiceWithStorage(db, repo, outboxRepo, nil)
+}
+
+// NewServiceWithStorage memasang BlobStore untuk menyimpan PDF payslip. API dan consumer
+// harus memakai backend yang sama (mis. S3/MinIO) agar payslip hasil consumer bisa diunduh.
+func NewServiceWithStorage(db *sql.DB, repo Repository, outboxRepo kafka.OutboxRepository, blobs storage.BlobStore) Service {
+	return &service{db: db, repo: repo, outbox: outboxRepo, blobs: blobs}
 }
 
 func (s *service) Create(
@@ -65,74 +125,7 @@ func (s *service) Create(
 	}
 	defer tx.Rollback()
 
-	qtx := s.repo.WithTx(tx)
-
-	companyUUID, employeeUUID, createdByUUID, periodStart, periodEnd, err := validateCreateRequest(companyID, actorID, req)
-	if err != nil {
-		return PayrollResponse{}, err
-	}
-
-	belongs, err := qtx.EmployeeBelongsToCompany(ctx, companyID, req.EmployeeID)
-	if err != nil {
-		return PayrollResponse{}, err
-	}
-	if !belongs {
-		return PayrollResponse{}, payrollerrors.ErrEmployeeNotInCompany
-	}
-
-	overlap, err := qtx.HasOverlappingPeriod(ctx, companyID, req.EmployeeID, periodStart, periodEnd, nil)
-	if err != nil {
-		return PayrollResponse{}, err
-	}
-	if overlap {
-		return PayrollResponse{}, payrollerrors.ErrPayrollOverlap
-	}
-
-	allowanceItems, deductionItems, err := buildComponents(companyUUID, nil, req.AllowanceItems, req.DeductionItems)
-	if err != nil {
-		return PayrollResponse{}, err
-	}
-	totalAllowanceItems := sumComponents(allowanceItems)
-	totalDeductionItems := sumComponents(deductionItems)
-
-	overtimeAmount, err := calculateOvertime(req.OvertimeHours, req.OvertimeRate)
-	if err != nil {
-		return PayrollResponse{}, err
-	}
-
-	totalAllowance := req.Allowance + totalAllowanceItems
-	totalDeduction := req.Deduction + totalDeductionItems
-	if err := validateMoney(req.BaseSalary, totalAllowance, totalDeduction); err != nil {
-		return PayrollResponse{}, err
-	}
-
-	payroll := &Payroll{
-		ID:             uuid.New(),
-		CompanyID:      companyUUID,
-		EmployeeID:     employeeUUID,
-		PeriodStart:    periodStart,
-		PeriodEnd:      periodEnd,
-		BaseSalary:     req.BaseSalary,
-		Allowance:      totalAllowance,
-		OvertimeHours:  req.OvertimeHours,
-		OvertimeRate:   req.OvertimeRate,
-		OvertimeAmount: overtimeAmount,
-		Deduction:      totalDeduction,
-		NetSalary:      req.BaseSalary + totalAllowance + overtimeAmount - totalDeduction,
-		Status:         StatusDraft,
-		CreatedBy:      createdByUUID,
-	}
-
-	if err := qtx.Create(ctx, payroll); err != nil {
-		return PayrollResponse{}, err
-	}
-
-	allComponents := attachPayrollID(payroll.ID, append(allowanceItems, deductionItems...))
-	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), allComponents); err != nil {
-		return PayrollResponse{}, err
-	}
-
-	persisted, err := qtx.FindByIDAndCompany(ctx, companyID, payroll.ID.String())
+	persisted, err := s.createPayroll(ctx, s.repo.WithTx(tx), companyID, actorID, req, nil)
 	if err != nil {
 		return PayrollResponse{}, err
 	}
@@ -148,18 +141,19 @@ func (s *service) GetAll(
 	ctx context.Context,
 	companyID string,
 	filterReq GetPayrollsFilterRequest,
-) ([]PayrollResponse, error) {
+) ([]PayrollResponse, int64, error) {
 	filter, err := s.buildListFilter(companyID, filterReq)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	filter.Page = filterReq.Query(payrollListSort)
 
-	payrolls, err := s.repo.FindAllByCompany(ctx, companyID, filter)
+	payrolls, total, err := s.repo.FindPageByCompany(ctx, companyID, filter)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return mapToListResponse(payrolls), nil
+	return mapToListResponse(payrolls), total, nil
 }
 
 func (s *service) buildListFilter(companyID string, req GetPayrollsFilterRequest) (PayrollQueryFilter, error) {
@@ -176,6 +170,14 @@ func (s *service) buildListFilter(companyID string, req GetPayrollsFilterRequest
 		filter.DepartmentID = &req.DepartmentID
 	}
 
+	if req.PayrollType != "" {
+		payrollType, err := normalizePayrollType(req.PayrollType)
+		if err != nil {
+			return PayrollQueryFilter{}, err
+		}
+		filter.PayrollType = &payrollType
+	}
+
 	if req.Status != "" {
 		status := strings.ToUpper(strings.TrimSpace(req.Status))
 		if !isValidPayrollStatus(status) {
@@ -278,39 +280,63 @@ func (s *service) Regenerate(
 	if payroll.Status != StatusDraft {
 		return PayrollResponse{}, payrollerrors.ErrRegenerateOnlyDraft
 	}
-
-	allowanceItems, deductionItems, err := buildComponents(payroll.CompanyID, &payroll.ID, req.AllowanceItems, req.DeductionItems)
-	if err != nil {
+	if payroll.ReversalOfID != nil {
+		return PayrollResponse{}, payrollerrors.ErrPayrollIsReversal
+	}
+	if err := ensurePeriodOpen(ctx, qtx, companyID, payroll.PeriodStart, payroll.PeriodEnd); err != nil {
 		return PayrollResponse{}, err
 	}
-	totalAllowanceItems := sumComponents(allowanceItems)
-	totalDeductionItems := sumComponents(deductionItems)
 
-	overtimeAmount, err := calculateOvertime(req.OvertimeHours, req.OvertimeRate)
-	if err != nil {
+	// Nilai payroll berubah, approval yang sudah diberikan (termasuk untuk run-nya) diulang.
+	var runID *string
+	if payroll.RunID != nil {
+		v := payroll.RunID.String()
+		runID = &v
+	}
+	if err := qtx.ResetApprovals(ctx, companyID, payroll.ID.String(), runID); err != nil {
 		return PayrollResponse{}, err
 	}
+	payroll.Approvals = nil
+
+	if payroll.IsOffCycle() {
+		persisted, err := s.regenerateOffCycle(ctx, qtx, companyID, payroll, req)
+		if err != nil {
+			return PayrollResponse{}, err
+		}
+		if err := tx.Commit(); err != nil {
+			return PayrollResponse{}, err
+		}
+		return mapToResponse(*persisted), nil
+	}
 
-	totalAllowance := req.Allowance + totalAllowanceItems
-	totalDeduction := req.Deduction + totalDeductionItems
-	if err := validateMoney(req.BaseSalary, totalAllowance, totalDeduction); err != nil {
+	// Cicilan pinjaman lama dikembalikan dulu agar dihitung ulang dari sisa pinjaman terbaru.
+	if err := s.rollbackLoanRepayments(ctx, qtx, companyID, payroll.ID.String()); err != nil {
 		return PayrollResponse{}, err
 	}
 
-	payroll.BaseSalary = req.BaseSalary
-	payroll.Allowance = totalAllowance
-	payroll.OvertimeHours = req.OvertimeHours
-	payroll.OvertimeRate = req.OvertimeRate
-	payroll.OvertimeAmount = overtimeAmount
-	payroll.Deduction = totalDeduction
-	payroll.NetSalary = req.BaseSalary + totalAllowance + overtimeAmount - totalDeduction
+	result, err := s.calculateRegularPayroll(ctx, qtx, payroll, &payroll.ID, regularPayrollInput{
+		BaseSalary:     req.BaseSalary,
+		Allowance:      req.Allowance,
+		OvertimeHours:  req.OvertimeHours,
+		OvertimeRate:   req.OvertimeRate,
+		Deduction:      req.Deduction,
+		AllowanceItems: req.AllowanceItems,
+		DeductionItems: req.DeductionItems,
+	})
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+	payroll.IsStale = false
+	payroll.StaleReason = nil
 
 	if err := qtx.Update(ctx, payroll); err != nil {
 		return PayrollResponse{}, err
 	}
 
-	allComponents := append(allowanceItems, deductionItems...)
-	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), allComponents); err != nil {
+	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), result.Components); err != nil {
+		return PayrollResponse{}, err
+	}
+	if err := s.postLoanRepayments(ctx, qtx, payroll, result.Loans, result.Components); err != nil {
 		return PayrollResponse{}, err
 	}
 
@@ -326,9 +352,12 @@ func (s *service) Regenerate(
 	return mapToResponse(*persisted), nil
 }
 
+// Approve melewati satu langkah rantai approval company. Payroll baru berpindah ke
+// APPROVED (dan event payslip diantrikan) setelah langkah wajib terakhir disetujui.
 func (s *service) Approve(
 	ctx context.Context,
-	companyID, actorID, id string,
+	companyID, actorID, role, id string,
+	req ApprovePayrollRequest,
 ) (PayrollResponse, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -356,39 +385,35 @@ func (s *service) Approve(
 	if payroll.Status != StatusDraft {
 		return PayrollResponse{}, payrollerrors.ErrInvalidStatusTransition
 	}
+	if payroll.IsStale {
+		return PayrollResponse{}, payrollerrors.ErrPayrollStale
+	}
+	// Payroll dalam run disetujui lewat ApproveRun agar threshold dihitung dari total run.
+	if payroll.RunID != nil {
+		return PayrollResponse{}, payrollerrors.ErrPayrollApprovedThroughRun
+	}
 
-	now := time.Now().UTC()
-	payroll.Status = StatusApproved
-	payroll.ApprovedBy = &actorUUID
-	payroll.ApprovedAt = &now
+	// Payroll penyesuaian reversal bernilai negatif; threshold memakai nilai absolutnya.
+	netTotal := payroll.NetSalary
+	if netTotal < 0 {
+		netTotal = -netTotal
+	}
 
-	if err := qtx.Update(ctx, payroll); err != nil {
+	now := time.Now().UTC()
+	required, err := requiredApprovalSteps(ctx, qtx, companyID, netTotal)
+	if err != nil {
 		return PayrollResponse{}, err
 	}
-
-	if s.outbox != nil {
-		event := events.PayrollPayslipRequestedEvent{
-			EventType:   "payroll_payslip_requested",
-			PayrollID:   payroll.ID.String(),
-			CompanyID:   companyID,
-			RequestedBy: actorID,
-			OccurredAt:  now,
-		}
-		payload, err := json.Marshal(event)
-		if err != nil {
-			return PayrollResponse{}, err
-		}
-
-		outboxRepo := s.outbox.WithTx(tx)
-		if err := outboxRepo.Create(ctx, kafka.OutboxEvent{
-			ID:            uuid.NewString(),
-			AggregateType: "payroll",
-			AggregateID:   payroll.ID.String(),
-			EventType:     event.EventType,
-			Topic:         events.PayrollPayslipRequestedTopic,
-			Payload:       payload,
-			Status:        kafka.OutboxStatusPending,
-		}); err != nil {
+	final, err := recordApprovalStep(ctx, qtx, required, &payroll.Approvals, PayrollApproval{
+		CompanyID: payroll.CompanyID,
+		PayrollID: &payroll.ID,
+		ActorID:   actorUUID,
+	}, role, req.Comment, now)
+	if err != nil {
+		return PayrollResponse{}, err
+	}
+	if final {
+		if err := s.approvePayroll(ctx, tx, qtx, payroll, actorUUID, now); err != nil {
 			return PayrollResponse{}, err
 		}
 	}
@@ -397,7 +422,12 @@ func (s *service) Approve(
 		return PayrollResponse{}, err
 	}
 
-	return mapToResponse(*payroll), nil
+	resp := mapToResponse(*payroll)
+	if next := nextApprovalStep(required, payroll.Approvals); !final && next != nil {
+		step := mapToApprovalStepResponse(*next)
+		resp.NextApprovalStep = &step
+	}
+	return resp, nil
 }
 
 func (s *service) MarkAsPaid(
@@ -468,30 +498,53 @@ func (s *service) GeneratePayslip(
 		return mapToResponse(*payroll), tx.Commit()
 	}
 
-	content, err := buildSimplePayslipPDF(buildPayslipLines(*payroll))
+	profile, err := qtx.FindPayslipProfile(ctx, companyID, payroll.EmployeeID.String())
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayrollResponse{}, err
+		}
+		profile = PayslipProfile{EmployeeName: payroll.EmployeeID.String()}
+	}
+
+	// YTD dihitung sampai periode payslip ini, termasuk payroll yang sedang dicetak.
+	yearStart := time.Date(payroll.PeriodEnd.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	ytd, err := qtx.FindPayslipYearToDate(ctx, companyID, payroll.EmployeeID.String(), yearStart, payroll.PeriodEnd, payroll.ID.String())
 	if err != nil {
 		return PayrollResponse{}, err
 	}
+	if payroll.Status != StatusDraft && payroll.Status != StatusCancelled {
+		ytd.GrossIncome += payroll.GrossIncome
+		ytd.Deduction += payroll.Deduction
+		ytd.TaxAmount += payroll.TaxAmount
+		ytd.PensionContribution += payroll.PensionContribution
+		ytd.NetSalary += payroll.NetSalary
+		ytd.PayrollCount++
+	}
 
-	baseDir := os.Getenv("PAYSLIP_STORAGE_DIR")
-	if strings.TrimSpace(baseDir) == "" {
-		baseDir = filepath.Join("storage", "payslips")
+	setting, err := s.loadSetting(ctx, qtx, payroll.CompanyID)
+	if err != nil {
+		return PayrollResponse{}, err
 	}
-	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+	password, err := payslipPassword(setting.PayslipPasswordMode, profile)
+	if err != nil {
 		return PayrollResponse{}, err
 	}
 
-	filename := fmt.Sprintf("payslip_%s.pdf", payroll.ID.String())
-	absPath := filepath.Join(baseDir, filename)
-	if err := os.WriteFile(absPath, content, 0o644); err != nil {
+	content, err := renderPayslipPDF(buildPayslipDocument(*payroll, profile, ytd, time.Now().UTC()), password)
+	if err != nil {
 		return PayrollResponse{}, err
 	}
 
-	urlPrefix := strings.TrimSpace(os.Getenv("PAYSLIP_PUBLIC_BASE_URL"))
-	if urlPrefix == "" {
-		urlPrefix = "/files/payslips"
+	if s.blobs == nil {
+		return PayrollResponse{}, payrollerrors.ErrPayslipStorageNotConfigured
+	}
+	if err := s.blobs.Put(ctx, payslipObjectKey(payroll.ID.String()), content, "application/pdf"); err != nil {
+		return PayrollResponse{}, err
 	}
-	publicURL := strings.TrimRight(urlPrefix, "/") + "/" + filename
+
+	// URL permanen mengarah ke endpoint download terautentikasi, yang kemudian
+	// mengalihkan ke signed URL storage yang kedaluwarsa.
+	publicURL := payslipDownloadURL(payroll.ID.String())
 	now := time.Now().UTC()
 	payroll.PayslipURL = &publicURL
 	payroll.PayslipGeneratedAt = &now
@@ -506,6 +559,56 @@ func (s *service) GeneratePayslip(
 	return mapToResponse(*payroll), nil
 }
 
+// payslipDownloadURL membentuk link payslip dari PAYSLIP_PUBLIC_BASE_URL (base URL API payroll,
+// mis. "https://hris.example.com/api/v1/payrolls"), default "/api/v1/payrolls".
+func payslipDownloadURL(payrollID string) string {
+	urlPrefix := strings.TrimSpace(os.Getenv("PAYSLIP_PUBLIC_BASE_URL"))
+	if urlPrefix == "" {
+		urlPrefix = "/api/v1/payrolls"
+	}
+	return strings.TrimRight(urlPrefix, "/") + "/" + payrollID + "/payslip/download"
+}
+
+// GetPayslipDownload membuat signed URL untuk PDF payslip yang sudah digenerate. Karyawan
+// boleh mengunduh payslip miliknya sendiri; payslip karyawan lain membutuhkan permission payroll:read.
+func (s *service) GetPayslipDownload(
+	ctx context.Context,
+	companyID, requesterEmployeeID, id string,
+	canReadAll bool,
+) (PayslipDownloadResponse, error) {
+	payroll, err := s.repo.FindByIDAndCompany(ctx, companyID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return PayslipDownloadResponse{}, payrollerrors.ErrPayrollNotFound
+		}
+		return PayslipDownloadResponse{}, err
+	}
+	if !canReadAll && payroll.EmployeeID.String() != requesterEmployeeID {
+		return PayslipDownloadResponse{}, payrollerrors.ErrPayslipForbidden
+	}
+	if payroll.PayslipURL == nil || *payroll.PayslipURL == "" {
+		return PayslipDownloadResponse{}, payrollerrors.ErrPayslipNotGenerated
+	}
+	if s.blobs == nil {
+		return PayslipDownloadResponse{}, payrollerrors.ErrPayslipStorageNotConfigured
+	}
+
+	expiresAt := time.Now().UTC().Add(payslipURLTTL)
+	url, err := s.blobs.SignedURL(ctx, payslipObjectKey(payroll.ID.String()), payslipURLTTL)
+	if err != nil {
+		return PayslipDownloadResponse{}, err
+	}
+
+	return PayslipDownloadResponse{URL: url, ExpiresAt: expiresAt.Format(time.RFC3339)}, nil
+}
+
+// payslipURLTTL adalah masa berlaku signed URL payslip.
+const payslipURLTTL = 5 * time.Minute
+
+func payslipObjectKey(payrollID string) string {
+	return fmt.Sprintf("payslips/payslip_%s.pdf", payrollID)
+}
+
 func (s *service) Delete(
 	ctx context.Context,
 	companyID, id string,
@@ -528,7 +631,16 @@ func (s *service) Delete(
 	if payroll.Status != StatusDraft {
 		return payrollerrors.ErrDeleteOnlyDraft
 	}
+	if payroll.ReversalOfID != nil {
+		return payrollerrors.ErrPayrollIsReversal
+	}
+	if err := ensurePeriodOpen(ctx, qtx, companyID, payroll.PeriodStart, payroll.PeriodEnd); err != nil {
+		return err
+	}
 
+	if err := s.rollbackLoanRepayments(ctx, qtx, companyID, id); err != nil {
+		return err
+	}
 	if err := qtx.Delete(ctx, companyID, id); err != nil {
 		return err
 	}
@@ -536,6 +648,142 @@ func (s *service) Delete(
 	return tx.Commit()
 }
 
+// createPayroll menjalankan validasi, cek overlap, dan persist payroll DRAFT
+// di dalam transaksi milik caller. Dipakai oleh Create dan payroll run.
+func (s *service) createPayroll(
+	ctx context.Context,
+	qtx Repository,
+	companyID, actorID string,
+	req CreatePayrollRequest,
+	runID *uuid.UUID,
+) (*Payroll, error) {
+	companyUUID, employeeUUID, createdByUUID, periodStart, periodEnd, err := validateCreateRequest(companyID, actorID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	payrollType, err := normalizePayrollType(req.PayrollType)
+	if err != nil {
+		return nil, err
+	}
+	referenceDate, err := resolveReferenceDate(payrollType, req.ReferenceDate, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	belongs, err := qtx.EmployeeBelongsToCompany(ctx, companyID, req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !belongs {
+		return nil, payrollerrors.ErrEmployeeNotInCompany
+	}
+	if err := ensurePeriodOpen(ctx, qtx, companyID, periodStart, periodEnd); err != nil {
+		return nil, err
+	}
+
+	// Payroll off-cycle BONUS/CORRECTION boleh lebih dari satu dalam periode yang sama.
+	if isExclusivePayrollType(payrollType) {
+		overlap, err := qtx.HasOverlappingPeriod(ctx, companyID, req.EmployeeID, payrollType, periodStart, periodEnd, nil)
+		if err != nil {
+			return nil, err
+		}
+		if overlap {
+			return nil, payrollerrors.ErrPayrollOverlap
+		}
+	}
+
+	if payrollType != PayrollTypeRegular {
+		return s.createOffCyclePayroll(ctx, qtx, companyID, companyUUID, employeeUUID, createdByUUID, periodStart, periodEnd, payrollType, referenceDate, req, runID)
+	}
+
+	payroll := &Payroll{
+		ID:          uuid.New(),
+		CompanyID:   companyUUID,
+		EmployeeID:  employeeUUID,
+		PayrollType: PayrollTypeRegular,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      StatusDraft,
+		CreatedBy:   createdByUUID,
+		RunID:       runID,
+	}
+	result, err := s.calculateRegularPayroll(ctx, qtx, payroll, nil, regularPayrollInput{
+		BaseSalary:     req.BaseSalary,
+		Allowance:      req.Allowance,
+		OvertimeHours:  req.OvertimeHours,
+		OvertimeRate:   req.OvertimeRate,
+		Deduction:      req.Deduction,
+		AllowanceItems: req.AllowanceItems,
+		DeductionItems: req.DeductionItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qtx.Create(ctx, payroll); err != nil {
+		return nil, err
+	}
+
+	allComponents := attachPayrollID(payroll.ID, result.Components)
+	if err := qtx.ReplaceComponents(ctx, companyID, payroll.ID.String(), allComponents); err != nil {
+		return nil, err
+	}
+	if err := s.postLoanRepayments(ctx, qtx, payroll, result.Loans, allComponents); err != nil {
+		return nil, err
+	}
+
+	return qtx.FindByIDAndCompany(ctx, companyID, payroll.ID.String())
+}
+
+// approvePayroll mengubah payroll DRAFT menjadi APPROVED dan mengantrikan
+// event permintaan payslip ke outbox dalam transaksi yang sama.
+func (s *service) approvePayroll(
+	ctx context.Context,
+	tx *sql.Tx,
+	qtx Repository,
+	payroll *Payroll,
+	actorUUID uuid.UUID,
+	now time.Time,
+) error {
+	payroll.Status = StatusApproved
+	payroll.ApprovedBy = &actorUUID
+	payroll.ApprovedAt = &now
+
+	if err := qtx.Update(ctx, payroll); err != nil {
+		return err
+	}
+
+	if s.outbox != nil {
+		event := events.PayrollPayslipRequestedEvent{
+			EventType:   "payroll_payslip_requested",
+			PayrollID:   payroll.ID.String(),
+			CompanyID:   payroll.CompanyID.String(),
+			RequestedBy: actorUUID.String(),
+			OccurredAt:  now,
+		}
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		outboxRepo := s.outbox.WithTx(tx)
+		if err := outboxRepo.Create(ctx, kafka.OutboxEvent{
+			ID:            uuid.NewString(),
+			AggregateType: "payroll",
+			AggregateID:   payroll.ID.String(),
+			EventType:     event.EventType,
+			Topic:         events.PayrollPayslipRequestedTopic,
+			Payload:       payload,
+			Status:        kafka.OutboxStatusPending,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateCreateRequest(
 	companyID, actorID string,
 	req CreatePayrollRequest,
@@ -605,7 +853,7 @@ func parseMonthPeriod(v string) (string, string, error) {
 
 func isValidPayrollStatus(v string) bool {
 	switch v {
-	case StatusDraft, StatusApproved, StatusPaid:
+	case StatusDraft, StatusApproved, StatusPaid, StatusCancelled, StatusReversed:
 		return true
 	default:
 		return false
@@ -674,6 +922,21 @@ func attachPayrollID(payrollID uuid.UUID, items []PayrollComponent) []PayrollCom
 	return items
 }
 
+func componentMetadata(v *string) json.RawMessage {
+	if v == nil || *v == "" {
+		return nil
+	}
+	return json.RawMessage(*v)
+}
+
+func uuidPtrToString(v *uuid.UUID) *string {
+	if v == nil {
+		return nil
+	}
+	s := v.String()
+	return &s
+}
+
 func sumComponents(items []PayrollComponent) int64 {
 	var total int64
 	for _, item := range items {
@@ -694,6 +957,7 @@ func mapToResponse(payroll Payroll) PayrollResponse {
 		ID:             payroll.ID.String(),
 		CompanyID:      payroll.CompanyID.String(),
 		EmployeeID:     payroll.EmployeeID.String(),
+		PayrollType:    payrollTypeOrRegular(payroll.PayrollType),
 		PeriodStart:    payroll.PeriodStart.Format("2006-01-02"),
 		PeriodEnd:      payroll.PeriodEnd.Format("2006-01-02"),
 		BaseSalary:     payroll.BaseSalary,
@@ -702,16 +966,33 @@ func mapToResponse(payroll Payroll) PayrollResponse {
 		OvertimeRate:   payroll.OvertimeRate,
 		TotalOvertime:  payroll.OvertimeAmount,
 		TotalDeduction: payroll.Deduction,
+		EmployerCost:   payroll.EmployerCost,
+		GrossIncome:    payroll.GrossIncome,
+		TaxAmount:      payroll.TaxAmount,
 		Allowance:      payroll.Allowance,
 		Deduction:      payroll.Deduction,
 		NetSalary:      payroll.NetSalary,
 		Status:         payroll.Status,
 		CreatedBy:      payroll.CreatedBy.String(),
+
+		BaseSalaryOverride: payroll.BaseSalaryOverride,
+		DerivedBaseSalary:  payroll.DerivedBaseSalary,
+		Proration:          mapToProrationResponse(payroll),
+		IsStale:            payroll.IsStale,
+		StaleReason:        payroll.StaleReason,
 	}
 
 	if payroll.Employee != nil {
 		resp.EmployeeName = payroll.Employee.FullName
 	}
+	if payroll.RunID != nil {
+		v := payroll.RunID.String()
+		resp.RunID = &v
+	}
+	if payroll.ReferenceDate != nil {
+		v := payroll.ReferenceDate.Format("2006-01-02")
+		resp.ReferenceDate = &v
+	}
 
 	if payroll.ApprovedBy != nil {
 		v := payroll.ApprovedBy.String()
@@ -721,6 +1002,7 @@ func mapToResponse(payroll Payroll) PayrollResponse {
 		v := payroll.PaidAt.Format(time.RFC3339)
 		resp.PaidAt = &v
 	}
+	resp.PaymentReference = payroll.PaymentReference
 	if payroll.ApprovedAt != nil {
 		v := payroll.ApprovedAt.Format(time.RFC3339)
 		resp.ApprovedAt = &v
@@ -732,6 +1014,14 @@ func mapToResponse(payroll Payroll) PayrollResponse {
 		v := payroll.PayslipGeneratedAt.Format(time.RFC3339)
 		resp.PayslipGeneratedAt = &v
 	}
+	resp.ReversalOfID = uuidPtrToString(payroll.ReversalOfID)
+	resp.ReversedByID = uuidPtrToString(payroll.ReversedByID)
+	resp.CancelReason = payroll.CancelReason
+	resp.CancelledBy = uuidPtrToString(payroll.CancelledBy)
+	if payroll.CancelledAt != nil {
+		v := payroll.CancelledAt.Format(time.RFC3339)
+		resp.CancelledAt = &v
+	}
 
 	if len(payroll.Components) > 0 {
 		resp.Components = make([]PayrollComponentResponse, 0, len(payroll.Components))
@@ -744,9 +1034,13 @@ func mapToResponse(payroll Payroll) PayrollResponse {
 				UnitAmount:    item.UnitAmount,
 				TotalAmount:   item.TotalAmount,
 				Notes:         item.Notes,
+				SourceType:    item.SourceType,
+				SourceID:      uuidPtrToString(item.SourceID),
+				Metadata:      componentMetadata(item.Metadata),
 			})
 		}
 	}
+	resp.Approvals = mapToApprovalResponses(payroll.Approvals)
 
 	return resp
 }
@@ -762,6 +1056,7 @@ func mapToListResponse(payrolls []Payroll) []PayrollResponse {
 func mapToBreakdownResponse(payroll Payroll) PayrollBreakdownResponse {
 	allowances := make([]PayrollBreakdownLine, 0)
 	deductions := make([]PayrollBreakdownLine, 0)
+	employerCosts := make([]PayrollBreakdownLine, 0)
 
 	for _, component := range payroll.Components {
 		quantity := component.Quantity
@@ -772,6 +1067,9 @@ func mapToBreakdownResponse(payroll Payroll) PayrollBreakdownResponse {
 			UnitAmount: &unitAmount,
 			Amount:     component.TotalAmount,
 			Notes:      component.Notes,
+			SourceType: component.SourceType,
+			SourceID:   uuidPtrToString(component.SourceID),
+			Metadata:   componentMetadata(component.Metadata),
 		}
 		if component.ComponentType == ComponentTypeAllowance {
 			allowances = append(allowances, line)
@@ -779,6 +1077,9 @@ func mapToBreakdownResponse(payroll Payroll) PayrollBreakdownResponse {
 		if component.ComponentType == ComponentTypeDeduction {
 			deductions = append(deductions, line)
 		}
+		if component.ComponentType == ComponentTypeEmployerContribution {
+			employerCosts = append(employerCosts, line)
+		}
 	}
 
 	allowanceFromComponents := sumBreakdown(allowances)
@@ -802,16 +1103,26 @@ func mapToBreakdownResponse(payroll Payroll) PayrollBreakdownResponse {
 	overtimeHours := payroll.OvertimeHours
 	overtimeRate := payroll.OvertimeRate
 
+	employeeName := ""
+	if payroll.Employee != nil {
+		employeeName = payroll.Employee.FullName
+	}
+
 	return PayrollBreakdownResponse{
-		PayrollID:   payroll.ID.String(),
-		EmployeeID:  payroll.EmployeeID.String(),
-		PeriodStart: payroll.PeriodStart.Format("2006-01-02"),
-		PeriodEnd:   payroll.PeriodEnd.Format("2006-01-02"),
-		Status:      payroll.Status,
+		PayrollID:    payroll.ID.String(),
+		EmployeeID:   payroll.EmployeeID.String(),
+		EmployeeName: employeeName,
+		PayrollType:  payrollTypeOrRegular(payroll.PayrollType),
+		PeriodStart:  payroll.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:    payroll.PeriodEnd.Format("2006-01-02"),
+		Status:       payroll.Status,
+		IsStale:      payroll.IsStale,
 		BaseSalary: PayrollBreakdownLine{
-			Label:  "Base Salary",
+			Label:  baseSalaryLabel(payroll),
 			Amount: payroll.BaseSalary,
+			Notes:  payroll.BaseSalaryNote,
 		},
+		Proration:      mapToProrationResponse(payroll),
 		Allowances:     allowances,
 		AllowanceTotal: payroll.Allowance,
 		Overtime: PayrollBreakdownLine{
@@ -823,9 +1134,19 @@ func mapToBreakdownResponse(payroll Payroll) PayrollBreakdownResponse {
 		Deductions:     deductions,
 		DeductionTotal: payroll.Deduction,
 		NetSalary:      payroll.NetSalary,
+
+		EmployerCosts:     employerCosts,
+		EmployerCostTotal: payroll.EmployerCost,
 	}
 }
 
+func baseSalaryLabel(payroll Payroll) string {
+	if payroll.BaseSalaryOverride {
+		return "Base Salary (manual override)"
+	}
+	return "Base Salary"
+}
+
 func sumBreakdown(lines []PayrollBreakdownLine) int64 {
 	var total int64
 	for _, line := range lines {
@@ -834,28 +1155,30 @@ func sumBreakdown(lines []PayrollBreakdownLine) int64 {
 	return total
 }
 
-func buildPayslipLines(payroll Payroll) []string {
-	lines := []string{
-		"Payslip",
-		fmt.Sprintf("Payroll ID: %s", payroll.ID.String()),
-		fmt.Sprintf("Employee ID: %s", payroll.EmployeeID.String()),
-		fmt.Sprintf("Period: %s to %s", payroll.PeriodStart.Format("2006-01-02"), payroll.PeriodEnd.Format("2006-01-02")),
-		fmt.Sprintf("Status: %s", payroll.Status),
-		"",
-		fmt.Sprintf("Base Salary: %d", payroll.BaseSalary),
-		fmt.Sprintf("Total Allowance: %d", payroll.Allowance),
-		fmt.Sprintf("Overtime: %d x %d = %d", payroll.OvertimeHours, payroll.OvertimeRate, payroll.OvertimeAmount),
-		fmt.Sprintf("Total Deduction: %d", payroll.Deduction),
-		"------------------------------",
-		fmt.Sprintf("Net Salary: %d", payroll.NetSalary),
-	}
-
-	if len(payroll.Components) > 0 {
-		lines = append(lines, "", "Components:")
-		for _, c := range payroll.Components {
-			lines = append(lines, fmt.Sprintf("- %s %s: %d x %d = %d", c.ComponentType, c.ComponentName, c.Quantity, c.UnitAmount, c.TotalAmount))
+// payslipReversalLines menampilkan informasi pembatalan atau reversal pada payslip.
+func payslipReversalLines(payroll Payroll) []string {
+	var lines []string
+	reason := ""
+	if payroll.CancelReason != nil {
+		reason = *payroll.CancelReason
+	}
+	switch {
+	case payroll.ReversalOfID != nil:
+		lines = append(lines,
+			fmt.Sprintf("Reversal of payroll: %s", payroll.ReversalOfID.String()),
+			fmt.Sprintf("Reason: %s", reason),
+		)
+	case payroll.Status == StatusReversed && payroll.ReversedByID != nil:
+		lines = append(lines, fmt.Sprintf("Reversed by payroll: %s", payroll.ReversedByID.String()))
+		if payroll.CancelledAt != nil {
+			lines = append(lines, fmt.Sprintf("Reversed at: %s", payroll.CancelledAt.Format(time.RFC3339)))
 		}
+		lines = append(lines, fmt.Sprintf("Reason: %s", reason))
+	case payroll.Status == StatusCancelled:
+		if payroll.CancelledAt != nil {
+			lines = append(lines, fmt.Sprintf("Cancelled at: %s", payroll.CancelledAt.Format(time.RFC3339)))
+		}
+		lines = append(lines, fmt.Sprintf("Reason: %s", reason))
 	}
-
 	return lines
 }
@@ -1,12 +1,11 @@
 package payroll_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
-	"os"
-	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,22 +13,88 @@ import (
 	"go-hris/internal/messaging/kafka"
 	"go-hris/internal/payroll"
 	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/pagination"
+	"go-hris/internal/shared/storage"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 type fakePayrollRepository struct {
 	withTxFn                 func(tx *sql.Tx) payroll.Repository
 	createFn                 func(ctx context.Context, p *payroll.Payroll) error
 	findAllByCompanyFn       func(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error)
+	findPageByCompanyFn      func(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, int64, error)
 	findByIDAndCompanyFn     func(ctx context.Context, companyID string, id string) (*payroll.Payroll, error)
 	replaceComponentsFn      func(ctx context.Context, companyID string, payrollID string, components []payroll.PayrollComponent) error
 	updateFn                 func(ctx context.Context, p *payroll.Payroll) error
 	deleteFn                 func(ctx context.Context, companyID string, id string) error
 	employeeBelongsToCompany func(ctx context.Context, companyID string, employeeID string) (bool, error)
-	hasOverlappingPeriodFn   func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error)
+	hasOverlappingPeriodFn   func(ctx context.Context, companyID string, employeeID string, payrollType string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error)
+	findRegularInPeriodFn    func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) (*uuid.UUID, error)
+	findByRunFn              func(ctx context.Context, companyID string, runID string) ([]payroll.Payroll, error)
+	createRunFn              func(ctx context.Context, run *payroll.PayrollRun) error
+	updateRunFn              func(ctx context.Context, run *payroll.PayrollRun) error
+	findRunByIDAndCompanyFn  func(ctx context.Context, companyID string, id string) (*payroll.PayrollRun, error)
+	findRunsByCompanyFn      func(ctx context.Context, companyID string) ([]payroll.PayrollRun, error)
+	createRunFailuresFn      func(ctx context.Context, failures []payroll.PayrollRunFailure) error
+	findEmployeesForRunFn    func(ctx context.Context, companyID string, periodStart, periodEnd time.Time, departmentID *string) ([]payroll.RunEmployee, error)
+	findSalaryHistoryFn      func(ctx context.Context, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.SalaryHistory, error)
+	findAttendanceRecordsFn  func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.AttendanceRecord, error)
+	findApprovedLeavesFn     func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.LeaveRange, error)
+	findTaxStatusFn          func(ctx context.Context, companyID string, employeeID string) (string, error)
+	findTaxYearToDateFn      func(ctx context.Context, companyID string, employeeID string, yearStart time.Time, before time.Time) (payroll.TaxYearToDate, error)
+	findTaxSamePeriodFn      func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (payroll.TaxYearToDate, error)
+	findBPJSContributionsFn  func(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) ([]payroll.BPJSContributionRow, error)
+	findBankTransferRowsFn   func(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.BankTransferRow, error)
+	markBankExportedFn       func(ctx context.Context, companyID string, payrollIDs []string, batchID string, exportedAt time.Time) error
+	findJournalPayrollsFn    func(ctx context.Context, companyID string, filter payroll.JournalQueryFilter) ([]payroll.JournalPayrollRow, error)
+	findComponentsByIDsFn    func(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.PayrollComponent, error)
+	findAccountMappingsFn    func(ctx context.Context, companyID string) ([]payroll.AccountMapping, error)
+	replaceAccountMappingsFn func(ctx context.Context, companyID string, mappings []payroll.AccountMapping) error
+	findPayslipProfileFn     func(ctx context.Context, companyID string, employeeID string) (payroll.PayslipProfile, error)
+	findEmploymentPeriodFn   func(ctx context.Context, companyID string, employeeID string, periodStart, periodEnd time.Time) (payroll.EmploymentPeriod, error)
+	findPayslipYearToDateFn  func(ctx context.Context, companyID string, employeeID string, yearStart time.Time, periodEnd time.Time, excludePayrollID string) (payroll.PayslipYearToDate, error)
+	findSettingFn            func(ctx context.Context, companyID string) (*payroll.PayrollSetting, error)
+	upsertSettingFn          func(ctx context.Context, setting *payroll.PayrollSetting) error
+
+	findApprovalStepsFn     func(ctx context.Context, companyID string) ([]payroll.ApprovalStep, error)
+	replaceApprovalStepsFn  func(ctx context.Context, companyID string, steps []payroll.ApprovalStep) error
+	createApprovalFn        func(ctx context.Context, approval *payroll.PayrollApproval) error
+	resetApprovalsFn        func(ctx context.Context, companyID string, payrollID string, runID *string) error
+	resetPendingApprovalsFn func(ctx context.Context, companyID string) error
+
+	findPeriodsFn       func(ctx context.Context, companyID string, from time.Time, to time.Time) ([]payroll.PayrollPeriod, error)
+	findPeriodByStartFn func(ctx context.Context, companyID string, periodStart time.Time) (*payroll.PayrollPeriod, error)
+	savePeriodFn        func(ctx context.Context, period *payroll.PayrollPeriod) error
+	createPeriodAuditFn func(ctx context.Context, audit *payroll.PayrollPeriodAudit) error
+	hasClosedPeriodFn   func(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) (bool, error)
+
+	createTemplateFn       func(ctx context.Context, template *payroll.ComponentTemplate) error
+	updateTemplateFn       func(ctx context.Context, template *payroll.ComponentTemplate) error
+	findTemplatesFn        func(ctx context.Context, companyID string) ([]payroll.ComponentTemplate, error)
+	findTemplateByIDFn     func(ctx context.Context, companyID string, id string) (*payroll.ComponentTemplate, error)
+	templateCodeExistsFn   func(ctx context.Context, companyID string, code string, excludeID *string) (bool, error)
+	createAssignmentFn     func(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error
+	updateAssignmentFn     func(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error
+	deleteAssignmentFn     func(ctx context.Context, companyID string, id string) error
+	findAssignmentByIDFn   func(ctx context.Context, companyID string, id string) (*payroll.EmployeeComponentAssignment, error)
+	findAssignmentsFn      func(ctx context.Context, companyID string, employeeID string) ([]payroll.EmployeeComponentAssignment, error)
+	findEffectiveAssignsFn func(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.EmployeeComponentAssignment, error)
+	assignmentOverlapsFn   func(ctx context.Context, companyID string, employeeID string, templateID string, effectiveFrom time.Time, effectiveTo *time.Time, excludeID *string) (bool, error)
+	markStaleByEmployeeFn  func(ctx context.Context, companyID string, employeeID string, from time.Time, to *time.Time, reason string) error
+	markStaleByTemplateFn  func(ctx context.Context, companyID string, templateID string, reason string) error
+
+	createLoanFn          func(ctx context.Context, loan *payroll.EmployeeLoan) error
+	updateLoanFn          func(ctx context.Context, loan *payroll.EmployeeLoan) error
+	findLoansFn           func(ctx context.Context, companyID string, filter payroll.LoanQueryFilter) ([]payroll.EmployeeLoan, error)
+	findLoanByIDFn        func(ctx context.Context, companyID string, id string) (*payroll.EmployeeLoan, error)
+	findActiveLoansFn     func(ctx context.Context, companyID string, employeeID string, periodEnd time.Time) ([]payroll.EmployeeLoan, error)
+	createRepaymentFn     func(ctx context.Context, repayment *payroll.LoanRepayment) error
+	updateRepaymentFn     func(ctx context.Context, repayment *payroll.LoanRepayment) error
+	findRepaymentsByPayFn func(ctx context.Context, companyID string, payrollID string) ([]payroll.LoanRepayment, error)
 }
 
 type fakeOutboxRepository struct {
@@ -84,6 +149,13 @@ func (f *fakePayrollRepository) FindAllByCompany(ctx context.Context, companyID
 	return nil, nil
 }
 
+func (f *fakePayrollRepository) FindPageByCompany(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, int64, error) {
+	if f.findPageByCompanyFn != nil {
+		return f.findPageByCompanyFn(ctx, companyID, filter)
+	}
+	return nil, 0, nil
+}
+
 func (f *fakePayrollRepository) FindByIDAndCompany(ctx context.Context, companyID string, id string) (*payroll.Payroll, error) {
 	if f.findByIDAndCompanyFn != nil {
 		return f.findByIDAndCompanyFn(ctx, companyID, id)
@@ -119,18 +191,425 @@ func (f *fakePayrollRepository) EmployeeBelongsToCompany(ctx context.Context, co
 	return true, nil
 }
 
-func (f *fakePayrollRepository) HasOverlappingPeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error) {
+func (f *fakePayrollRepository) HasOverlappingPeriod(ctx context.Context, companyID string, employeeID string, payrollType string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (bool, error) {
 	if f.hasOverlappingPeriodFn != nil {
-		return f.hasOverlappingPeriodFn(ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+		return f.hasOverlappingPeriodFn(ctx, companyID, employeeID, payrollType, periodStart, periodEnd, excludePayrollID)
+	}
+	return false, nil
+}
+
+func (f *fakePayrollRepository) FindRegularPayrollIDInPeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) (*uuid.UUID, error) {
+	if f.findRegularInPeriodFn != nil {
+		return f.findRegularInPeriodFn(ctx, companyID, employeeID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindByRun(ctx context.Context, companyID string, runID string) ([]payroll.Payroll, error) {
+	if f.findByRunFn != nil {
+		return f.findByRunFn(ctx, companyID, runID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) CreateRun(ctx context.Context, run *payroll.PayrollRun) error {
+	if f.createRunFn != nil {
+		return f.createRunFn(ctx, run)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) UpdateRun(ctx context.Context, run *payroll.PayrollRun) error {
+	if f.updateRunFn != nil {
+		return f.updateRunFn(ctx, run)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindRunByIDAndCompany(ctx context.Context, companyID string, id string) (*payroll.PayrollRun, error) {
+	if f.findRunByIDAndCompanyFn != nil {
+		return f.findRunByIDAndCompanyFn(ctx, companyID, id)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindRunsByCompany(ctx context.Context, companyID string) ([]payroll.PayrollRun, error) {
+	if f.findRunsByCompanyFn != nil {
+		return f.findRunsByCompanyFn(ctx, companyID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) CreateRunFailures(ctx context.Context, failures []payroll.PayrollRunFailure) error {
+	if f.createRunFailuresFn != nil {
+		return f.createRunFailuresFn(ctx, failures)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindEmployeesForRun(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time, departmentID *string) ([]payroll.RunEmployee, error) {
+	if f.findEmployeesForRunFn != nil {
+		return f.findEmployeesForRunFn(ctx, companyID, periodStart, periodEnd, departmentID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindSalaryHistory(ctx context.Context, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.SalaryHistory, error) {
+	if f.findSalaryHistoryFn != nil {
+		return f.findSalaryHistoryFn(ctx, employeeID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindAttendanceRecords(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.AttendanceRecord, error) {
+	if f.findAttendanceRecordsFn != nil {
+		return f.findAttendanceRecordsFn(ctx, companyID, employeeID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindApprovedLeaves(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.LeaveRange, error) {
+	if f.findApprovedLeavesFn != nil {
+		return f.findApprovedLeavesFn(ctx, companyID, employeeID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindEmployeeTaxStatus(ctx context.Context, companyID string, employeeID string) (string, error) {
+	if f.findTaxStatusFn != nil {
+		return f.findTaxStatusFn(ctx, companyID, employeeID)
+	}
+	return "TK/0", nil
+}
+
+func (f *fakePayrollRepository) FindTaxYearToDate(ctx context.Context, companyID string, employeeID string, yearStart time.Time, before time.Time) (payroll.TaxYearToDate, error) {
+	if f.findTaxYearToDateFn != nil {
+		return f.findTaxYearToDateFn(ctx, companyID, employeeID, yearStart, before)
+	}
+	return payroll.TaxYearToDate{}, nil
+}
+
+func (f *fakePayrollRepository) FindTaxSamePeriod(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time, excludePayrollID *string) (payroll.TaxYearToDate, error) {
+	if f.findTaxSamePeriodFn != nil {
+		return f.findTaxSamePeriodFn(ctx, companyID, employeeID, periodStart, periodEnd, excludePayrollID)
+	}
+	return payroll.TaxYearToDate{}, nil
+}
+
+func (f *fakePayrollRepository) FindBPJSContributions(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) ([]payroll.BPJSContributionRow, error) {
+	if f.findBPJSContributionsFn != nil {
+		return f.findBPJSContributionsFn(ctx, companyID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindBankTransferRows(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.BankTransferRow, error) {
+	if f.findBankTransferRowsFn != nil {
+		return f.findBankTransferRowsFn(ctx, companyID, payrollIDs)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) MarkBankExported(ctx context.Context, companyID string, payrollIDs []string, batchID string, exportedAt time.Time) error {
+	if f.markBankExportedFn != nil {
+		return f.markBankExportedFn(ctx, companyID, payrollIDs, batchID, exportedAt)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindJournalPayrolls(ctx context.Context, companyID string, filter payroll.JournalQueryFilter) ([]payroll.JournalPayrollRow, error) {
+	if f.findJournalPayrollsFn != nil {
+		return f.findJournalPayrollsFn(ctx, companyID, filter)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindComponentsByPayrollIDs(ctx context.Context, companyID string, payrollIDs []string) ([]payroll.PayrollComponent, error) {
+	if f.findComponentsByIDsFn != nil {
+		return f.findComponentsByIDsFn(ctx, companyID, payrollIDs)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindAccountMappings(ctx context.Context, companyID string) ([]payroll.AccountMapping, error) {
+	if f.findAccountMappingsFn != nil {
+		return f.findAccountMappingsFn(ctx, companyID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) ReplaceAccountMappings(ctx context.Context, companyID string, mappings []payroll.AccountMapping) error {
+	if f.replaceAccountMappingsFn != nil {
+		return f.replaceAccountMappingsFn(ctx, companyID, mappings)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindEmploymentPeriod(ctx context.Context, companyID string, employeeID string, periodStart, periodEnd time.Time) (payroll.EmploymentPeriod, error) {
+	if f.findEmploymentPeriodFn != nil {
+		return f.findEmploymentPeriodFn(ctx, companyID, employeeID, periodStart, periodEnd)
+	}
+	return payroll.EmploymentPeriod{}, nil
+}
+
+func (f *fakePayrollRepository) FindPayslipProfile(ctx context.Context, companyID string, employeeID string) (payroll.PayslipProfile, error) {
+	if f.findPayslipProfileFn != nil {
+		return f.findPayslipProfileFn(ctx, companyID, employeeID)
+	}
+	return payroll.PayslipProfile{}, nil
+}
+
+func (f *fakePayrollRepository) FindPayslipYearToDate(ctx context.Context, companyID string, employeeID string, yearStart time.Time, periodEnd time.Time, excludePayrollID string) (payroll.PayslipYearToDate, error) {
+	if f.findPayslipYearToDateFn != nil {
+		return f.findPayslipYearToDateFn(ctx, companyID, employeeID, yearStart, periodEnd, excludePayrollID)
+	}
+	return payroll.PayslipYearToDate{}, nil
+}
+
+func (f *fakePayrollRepository) FindSetting(ctx context.Context, companyID string) (*payroll.PayrollSetting, error) {
+	if f.findSettingFn != nil {
+		return f.findSettingFn(ctx, companyID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) UpsertSetting(ctx context.Context, setting *payroll.PayrollSetting) error {
+	if f.upsertSettingFn != nil {
+		return f.upsertSettingFn(ctx, setting)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindApprovalSteps(ctx context.Context, companyID string) ([]payroll.ApprovalStep, error) {
+	if f.findApprovalStepsFn != nil {
+		return f.findApprovalStepsFn(ctx, companyID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) ReplaceApprovalSteps(ctx context.Context, companyID string, steps []payroll.ApprovalStep) error {
+	if f.replaceApprovalStepsFn != nil {
+		return f.replaceApprovalStepsFn(ctx, companyID, steps)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) CreateApproval(ctx context.Context, approval *payroll.PayrollApproval) error {
+	if f.createApprovalFn != nil {
+		return f.createApprovalFn(ctx, approval)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) ResetApprovals(ctx context.Context, companyID string, payrollID string, runID *string) error {
+	if f.resetApprovalsFn != nil {
+		return f.resetApprovalsFn(ctx, companyID, payrollID, runID)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) ResetPendingApprovals(ctx context.Context, companyID string) error {
+	if f.resetPendingApprovalsFn != nil {
+		return f.resetPendingApprovalsFn(ctx, companyID)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindPeriods(ctx context.Context, companyID string, from time.Time, to time.Time) ([]payroll.PayrollPeriod, error) {
+	if f.findPeriodsFn != nil {
+		return f.findPeriodsFn(ctx, companyID, from, to)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindPeriodByStart(ctx context.Context, companyID string, periodStart time.Time) (*payroll.PayrollPeriod, error) {
+	if f.findPeriodByStartFn != nil {
+		return f.findPeriodByStartFn(ctx, companyID, periodStart)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePayrollRepository) SavePeriod(ctx context.Context, period *payroll.PayrollPeriod) error {
+	if f.savePeriodFn != nil {
+		return f.savePeriodFn(ctx, period)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) CreatePeriodAudit(ctx context.Context, audit *payroll.PayrollPeriodAudit) error {
+	if f.createPeriodAuditFn != nil {
+		return f.createPeriodAuditFn(ctx, audit)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) HasClosedPeriod(ctx context.Context, companyID string, periodStart time.Time, periodEnd time.Time) (bool, error) {
+	if f.hasClosedPeriodFn != nil {
+		return f.hasClosedPeriodFn(ctx, companyID, periodStart, periodEnd)
+	}
+	return false, nil
+}
+
+func (f *fakePayrollRepository) CreateComponentTemplate(ctx context.Context, template *payroll.ComponentTemplate) error {
+	if f.createTemplateFn != nil {
+		return f.createTemplateFn(ctx, template)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) UpdateComponentTemplate(ctx context.Context, template *payroll.ComponentTemplate) error {
+	if f.updateTemplateFn != nil {
+		return f.updateTemplateFn(ctx, template)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindComponentTemplates(ctx context.Context, companyID string) ([]payroll.ComponentTemplate, error) {
+	if f.findTemplatesFn != nil {
+		return f.findTemplatesFn(ctx, companyID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindComponentTemplateByID(ctx context.Context, companyID string, id string) (*payroll.ComponentTemplate, error) {
+	if f.findTemplateByIDFn != nil {
+		return f.findTemplateByIDFn(ctx, companyID, id)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePayrollRepository) ComponentTemplateCodeExists(ctx context.Context, companyID string, code string, excludeID *string) (bool, error) {
+	if f.templateCodeExistsFn != nil {
+		return f.templateCodeExistsFn(ctx, companyID, code, excludeID)
+	}
+	return false, nil
+}
+
+func (f *fakePayrollRepository) CreateComponentAssignment(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error {
+	if f.createAssignmentFn != nil {
+		return f.createAssignmentFn(ctx, assignment)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) UpdateComponentAssignment(ctx context.Context, assignment *payroll.EmployeeComponentAssignment) error {
+	if f.updateAssignmentFn != nil {
+		return f.updateAssignmentFn(ctx, assignment)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) DeleteComponentAssignment(ctx context.Context, companyID string, id string) error {
+	if f.deleteAssignmentFn != nil {
+		return f.deleteAssignmentFn(ctx, companyID, id)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindComponentAssignmentByID(ctx context.Context, companyID string, id string) (*payroll.EmployeeComponentAssignment, error) {
+	if f.findAssignmentByIDFn != nil {
+		return f.findAssignmentByIDFn(ctx, companyID, id)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePayrollRepository) FindComponentAssignments(ctx context.Context, companyID string, employeeID string) ([]payroll.EmployeeComponentAssignment, error) {
+	if f.findAssignmentsFn != nil {
+		return f.findAssignmentsFn(ctx, companyID, employeeID)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindEffectiveComponentAssignments(ctx context.Context, companyID string, employeeID string, periodStart time.Time, periodEnd time.Time) ([]payroll.EmployeeComponentAssignment, error) {
+	if f.findEffectiveAssignsFn != nil {
+		return f.findEffectiveAssignsFn(ctx, companyID, employeeID, periodStart, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) HasOverlappingComponentAssignment(ctx context.Context, companyID string, employeeID string, templateID string, effectiveFrom time.Time, effectiveTo *time.Time, excludeID *string) (bool, error) {
+	if f.assignmentOverlapsFn != nil {
+		return f.assignmentOverlapsFn(ctx, companyID, employeeID, templateID, effectiveFrom, effectiveTo, excludeID)
 	}
 	return false, nil
 }
 
+func (f *fakePayrollRepository) MarkPayrollsStaleByEmployee(ctx context.Context, companyID string, employeeID string, from time.Time, to *time.Time, reason string) error {
+	if f.markStaleByEmployeeFn != nil {
+		return f.markStaleByEmployeeFn(ctx, companyID, employeeID, from, to, reason)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) MarkPayrollsStaleByTemplate(ctx context.Context, companyID string, templateID string, reason string) error {
+	if f.markStaleByTemplateFn != nil {
+		return f.markStaleByTemplateFn(ctx, companyID, templateID, reason)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) CreateLoan(ctx context.Context, loan *payroll.EmployeeLoan) error {
+	if f.createLoanFn != nil {
+		return f.createLoanFn(ctx, loan)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) UpdateLoan(ctx context.Context, loan *payroll.EmployeeLoan) error {
+	if f.updateLoanFn != nil {
+		return f.updateLoanFn(ctx, loan)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindLoans(ctx context.Context, companyID string, filter payroll.LoanQueryFilter) ([]payroll.EmployeeLoan, error) {
+	if f.findLoansFn != nil {
+		return f.findLoansFn(ctx, companyID, filter)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) FindLoanByID(ctx context.Context, companyID string, id string) (*payroll.EmployeeLoan, error) {
+	if f.findLoanByIDFn != nil {
+		return f.findLoanByIDFn(ctx, companyID, id)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakePayrollRepository) FindActiveLoans(ctx context.Context, companyID string, employeeID string, periodEnd time.Time) ([]payroll.EmployeeLoan, error) {
+	if f.findActiveLoansFn != nil {
+		return f.findActiveLoansFn(ctx, companyID, employeeID, periodEnd)
+	}
+	return nil, nil
+}
+
+func (f *fakePayrollRepository) CreateLoanRepayment(ctx context.Context, repayment *payroll.LoanRepayment) error {
+	if f.createRepaymentFn != nil {
+		return f.createRepaymentFn(ctx, repayment)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) UpdateLoanRepayment(ctx context.Context, repayment *payroll.LoanRepayment) error {
+	if f.updateRepaymentFn != nil {
+		return f.updateRepaymentFn(ctx, repayment)
+	}
+	return nil
+}
+
+func (f *fakePayrollRepository) FindLoanRepaymentsByPayroll(ctx context.Context, companyID string, payrollID string) ([]payroll.LoanRepayment, error) {
+	if f.findRepaymentsByPayFn != nil {
+		return f.findRepaymentsByPayFn(ctx, companyID, payrollID)
+	}
+	return nil, nil
+}
+
 type payrollServiceDeps struct {
 	db      *sql.DB
 	sqlMock sqlmock.Sqlmock
 	service payroll.Service
 	repo    *fakePayrollRepository
+	blobs   *storage.LocalStore
 }
 
 func setupPayrollServiceTest(t *testing.T) *payrollServiceDeps {
@@ -140,9 +619,10 @@ func setupPayrollServiceTest(t *testing.T) *payrollServiceDeps {
 	assert.NoError(t, err)
 
 	repo := &fakePayrollRepository{}
-	svc := payroll.NewService(db, repo)
+	blobs := storage.NewLocalStore(t.TempDir(), "/files", []byte("test-signing-key"))
+	svc := payroll.NewServiceWithStorage(db, repo, nil, blobs)
 
-	return &payrollServiceDeps{db: db, sqlMock: sqlMock, service: svc, repo: repo}
+	return &payrollServiceDeps{db: db, sqlMock: sqlMock, service: svc, repo: repo, blobs: blobs}
 }
 
 func expectTx(t *testing.T, mock sqlmock.Sqlmock, commit bool) {
@@ -169,7 +649,7 @@ func TestPayrollService_Create(t *testing.T) {
 		EmployeeID:    employeeID,
 		PeriodStart:   "2026-02-01",
 		PeriodEnd:     "2026-02-28",
-		BaseSalary:    10000000,
+		BaseSalary:    int64Ptr(10000000),
 		Allowance:     250000,
 		OvertimeHours: 3,
 		OvertimeRate:  25000,
@@ -185,9 +665,18 @@ func TestPayrollService_Create(t *testing.T) {
 	}
 	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
 		p.ID = createdPayrollID
+		assert.True(t, p.BaseSalaryOverride)
+		assert.Nil(t, p.DerivedBaseSalary)
 		assert.Equal(t, int64(3*25000), p.OvertimeAmount)
-		assert.Equal(t, int64(200000), p.Deduction)
-		assert.Equal(t, int64(10125000), p.NetSalary)
+		// BPJS karyawan: JHT 2% + JP 1% + Kesehatan 1% dari 10.000.000 = 400.000
+		// BPJS pemberi kerja: JHT 370.000 + JP 200.000 + JKK 24.000 + JKM 30.000 + Kesehatan 400.000
+		assert.Equal(t, int64(1024000), p.EmployerCost)
+		assert.Equal(t, int64(300000), p.PensionContribution)
+		// PPh 21 TER A 3% x bruto (10.325.000 + JKK/JKM/Kesehatan 454.000) = 323.370
+		assert.Equal(t, int64(10779000), p.GrossIncome)
+		assert.Equal(t, int64(323370), p.TaxAmount)
+		assert.Equal(t, int64(200000+400000+323370), p.Deduction)
+		assert.Equal(t, int64(10325000-923370), p.NetSalary)
 		return nil
 	}
 	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, companyID string, id string) (*payroll.Payroll, error) {
@@ -203,6 +692,103 @@ func TestPayrollService_Create(t *testing.T) {
 	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
 }
 
+func TestPayrollService_Create_DerivesBaseSalary(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	history := []payroll.SalaryHistory{
+		{BaseSalary: 8000000, EffectiveDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{BaseSalary: 9000000, EffectiveDate: time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	t.Run("split period on mid-month raise", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			assert.Equal(t, employeeID, eid)
+			return history, nil
+		}
+		var created *payroll.Payroll
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			created = p
+			return nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, companyID string, id string) (*payroll.Payroll, error) {
+			return created, nil
+		}
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+		})
+
+		assert.NoError(t, err)
+		// 14/28 x 8.000.000 + 14/28 x 9.000.000
+		assert.Equal(t, int64(8500000), resp.BaseSalary)
+		// BPJS karyawan 4% = 340.000; PPh 21 TER A 1,75% x (8.500.000 + 385.900) = 155.503
+		assert.Equal(t, int64(8500000-340000-155503), resp.NetSalary)
+		assert.False(t, resp.BaseSalaryOverride)
+		if assert.NotNil(t, created.BaseSalaryNote) {
+			assert.Contains(t, *created.BaseSalaryNote, "2026-02-15 s/d 2026-02-28")
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("manual override keeps derived value", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			return history[:1], nil
+		}
+		var created *payroll.Payroll
+		deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+			created = p
+			return nil
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, companyID string, id string) (*payroll.Payroll, error) {
+			return created, nil
+		}
+
+		resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+			BaseSalary:  int64Ptr(8100000),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(8100000), resp.BaseSalary)
+		assert.True(t, resp.BaseSalaryOverride)
+		if assert.NotNil(t, resp.DerivedBaseSalary) {
+			assert.Equal(t, int64(8000000), *resp.DerivedBaseSalary)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("no salary and no override", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+
+		_, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+			EmployeeID:  employeeID,
+			PeriodStart: "2026-02-01",
+			PeriodEnd:   "2026-02-28",
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrEmployeeSalaryNotFound)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
 func TestPayrollService_Regenerate_OnlyDraft(t *testing.T) {
 	ctx := context.Background()
 	companyID := uuid.New().String()
@@ -217,7 +803,7 @@ func TestPayrollService_Regenerate_OnlyDraft(t *testing.T) {
 		return &payroll.Payroll{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(companyID), Status: payroll.StatusApproved}, nil
 	}
 
-	_, err := deps.service.Regenerate(ctx, companyID, actorID, payrollID, payroll.RegeneratePayrollRequest{BaseSalary: 100})
+	_, err := deps.service.Regenerate(ctx, companyID, actorID, payrollID, payroll.RegeneratePayrollRequest{BaseSalary: int64Ptr(100)})
 
 	assert.ErrorIs(t, err, payrollerrors.ErrRegenerateOnlyDraft)
 	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
@@ -238,7 +824,7 @@ func TestPayrollService_ApproveAndMarkPaid(t *testing.T) {
 			return &payroll.Payroll{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(companyID), Status: payroll.StatusDraft}, nil
 		}
 
-		resp, err := deps.service.Approve(ctx, companyID, actorID, payrollID)
+		resp, err := deps.service.Approve(ctx, companyID, actorID, "Finance", payrollID, payroll.ApprovePayrollRequest{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, payroll.StatusApproved, resp.Status)
@@ -309,21 +895,45 @@ func TestPayrollService_GetAll_RepoError(t *testing.T) {
 
 	deps := setupPayrollServiceTest(t)
 	defer deps.db.Close()
-	deps.repo.findAllByCompanyFn = func(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
+	deps.repo.findPageByCompanyFn = func(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, int64, error) {
 		assert.NotNil(t, filter.Status)
 		assert.Equal(t, payroll.StatusDraft, *filter.Status)
 		assert.NotNil(t, filter.PeriodStart)
 		assert.Equal(t, "2026-02-01", *filter.PeriodStart)
-		return nil, errors.New("db error")
+		return nil, 0, errors.New("db error")
 	}
 
-	resp, err := deps.service.GetAll(ctx, companyID, payroll.GetPayrollsFilterRequest{
+	resp, total, err := deps.service.GetAll(ctx, companyID, payroll.GetPayrollsFilterRequest{
 		Period: "2026-02",
 		Status: "draft",
 	})
 
 	assert.Error(t, err)
 	assert.Nil(t, resp)
+	assert.Zero(t, total)
+}
+
+func TestPayrollService_GetAll_Page(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	deps.repo.findPageByCompanyFn = func(ctx context.Context, companyID string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, int64, error) {
+		assert.Equal(t, 2, filter.Page.Page)
+		assert.Equal(t, 5, filter.Page.PageSize)
+		assert.Equal(t, "payrolls.net_salary ASC, payrolls.id ASC", filter.Page.OrderBy)
+		assert.Equal(t, "budi", filter.Page.Search)
+		return []payroll.Payroll{{ID: uuid.New(), Status: payroll.StatusDraft}}, 6, nil
+	}
+
+	resp, total, err := deps.service.GetAll(ctx, companyID, payroll.GetPayrollsFilterRequest{
+		Request: pagination.Request{Page: 2, PageSize: 5, SortBy: "net_salary", SortDir: "asc", Search: "budi"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, int64(6), total)
 }
 
 func TestPayrollService_GetBreakdown(t *testing.T) {
@@ -411,12 +1021,13 @@ func TestPayrollService_Approve_QueuesPayslipEvent(t *testing.T) {
 	svc := payroll.NewServiceWithOutbox(db, repo, outbox)
 
 	expectTx(t, sqlMock, true)
-	_, err = svc.Approve(ctx, companyID, actorID, payrollID)
+	_, err = svc.Approve(ctx, companyID, actorID, "Finance", payrollID, payroll.ApprovePayrollRequest{})
 	assert.NoError(t, err)
 	assert.NoError(t, sqlMock.ExpectationsWereMet())
 }
 
 func TestPayrollService_GeneratePayslip(t *testing.T) {
+	t.Setenv("PAYSLIP_PUBLIC_BASE_URL", "")
 	ctx := context.Background()
 	companyID := uuid.New().String()
 	payrollID := uuid.New().String()
@@ -424,14 +1035,6 @@ func TestPayrollService_GeneratePayslip(t *testing.T) {
 	deps := setupPayrollServiceTest(t)
 	defer deps.db.Close()
 
-	tmpDir := t.TempDir()
-	_ = os.Setenv("PAYSLIP_STORAGE_DIR", tmpDir)
-	_ = os.Setenv("PAYSLIP_PUBLIC_BASE_URL", "/files/payslips")
-	t.Cleanup(func() {
-		_ = os.Unsetenv("PAYSLIP_STORAGE_DIR")
-		_ = os.Unsetenv("PAYSLIP_PUBLIC_BASE_URL")
-	})
-
 	expectTx(t, deps.sqlMock, true)
 	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, companyID string, id string) (*payroll.Payroll, error) {
 		return &payroll.Payroll{
@@ -454,12 +1057,125 @@ func TestPayrollService_GeneratePayslip(t *testing.T) {
 	resp, err := deps.service.GeneratePayslip(ctx, companyID, payrollID)
 	assert.NoError(t, err)
 	if assert.NotNil(t, resp.PayslipURL) {
-		assert.Contains(t, *resp.PayslipURL, "/files/payslips/payslip_")
+		assert.Equal(t, "/api/v1/payrolls/"+payrollID+"/payslip/download", *resp.PayslipURL)
 	}
 	assert.NotNil(t, resp.PayslipGeneratedAt)
 
-	filename := "payslip_" + payrollID + ".pdf"
-	_, statErr := os.Stat(filepath.Join(tmpDir, filename))
-	assert.NoError(t, statErr)
+	content, err := deps.blobs.Get(ctx, "payslips/payslip_"+payrollID+".pdf")
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+
+	// PAYSLIP_PUBLIC_BASE_URL mengganti base URL link download
+	t.Setenv("PAYSLIP_PUBLIC_BASE_URL", "https://hris.example.com/api/v1/payrolls/")
+	expectTx(t, deps.sqlMock, true)
+	resp, err = deps.service.GeneratePayslip(ctx, companyID, payrollID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, resp.PayslipURL) {
+		assert.Equal(t, "https://hris.example.com/api/v1/payrolls/"+payrollID+"/payslip/download", *resp.PayslipURL)
+	}
 	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
 }
+
+func TestPayrollService_Create_UnpaidLeaveDeduction(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+	leaveID := uuid.New()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, true)
+	// Maret 2026 memiliki 22 hari kerja (Senin-Jumat); cuti UNPAID 5-9 Maret = 3 hari kerja
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{CompanyID: uuid.MustParse(cid), WorkHoursPerDay: 8, WorkDaysPerWeek: 5, TaxCalculator: payroll.TaxCalculatorNone}, nil
+	}
+	deps.repo.findApprovedLeavesFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.LeaveRange, error) {
+		return []payroll.LeaveRange{
+			{ID: leaveID, LeaveType: "UNPAID", StartDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), LeaveType: "ANNUAL", StartDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		}, nil
+	}
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-03-01",
+		PeriodEnd:   "2026-03-31",
+		BaseSalary:  int64Ptr(6600000),
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Components, 1) {
+		component := resp.Components[0]
+		assert.Equal(t, payroll.ComponentTypeDeduction, component.ComponentType)
+		assert.Equal(t, int64(3), component.Quantity)
+		assert.Equal(t, int64(300000), component.UnitAmount)
+		assert.Equal(t, int64(900000), component.TotalAmount)
+		if assert.NotNil(t, component.SourceType) && assert.NotNil(t, component.SourceID) {
+			assert.Equal(t, payroll.ComponentSourceLeave, *component.SourceType)
+			assert.Equal(t, leaveID.String(), *component.SourceID)
+		}
+	}
+	assert.Equal(t, int64(6600000-900000), resp.NetSalary)
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
+
+func TestPayrollService_StalePayroll(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	payrollID := uuid.New().String()
+	reason := "leave changed"
+
+	t.Run("approve rejected while stale", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, false)
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{ID: uuid.MustParse(id), CompanyID: uuid.MustParse(cid), Status: payroll.StatusDraft, IsStale: true, StaleReason: &reason}, nil
+		}
+
+		_, err := deps.service.Approve(ctx, companyID, actorID, "Finance", payrollID, payroll.ApprovePayrollRequest{})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayrollStale)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("regenerate clears stale flag", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		current := &payroll.Payroll{ID: uuid.MustParse(payrollID), CompanyID: uuid.MustParse(companyID), EmployeeID: uuid.New(), Status: payroll.StatusDraft, IsStale: true, StaleReason: &reason}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.Payroll, error) {
+			return current, nil
+		}
+		deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+			assert.False(t, p.IsStale)
+			assert.Nil(t, p.StaleReason)
+			return nil
+		}
+
+		resp, err := deps.service.Regenerate(ctx, companyID, actorID, payrollID, payroll.RegeneratePayrollRequest{BaseSalary: int64Ptr(5000000)})
+
+		assert.NoError(t, err)
+		assert.False(t, resp.IsStale)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,102 @@
+package payroll
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PayrollSetting menyimpan aturan perhitungan payroll per company.
+type PayrollSetting struct {
+	CompanyID uuid.UUID `gorm:"type:uuid;primaryKey"`
+
+	// Jadwal kerja: jam kerja per hari dan jumlah hari kerja per minggu
+	// (5 = Senin-Jumat, 6 = Senin-Sabtu, 7 = setiap hari).
+	WorkHoursPerDay int64 `gorm:"type:bigint;not null;default:8"`
+	WorkDaysPerWeek int   `gorm:"type:int;not null;default:5"`
+
+	// Tarif lembur per jam, dipakai jika request tidak mengisi overtime_rate.
+	OvertimeHourlyRate int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Aturan potongan kehadiran per hari. Nilai 0 berarti tidak ada potongan.
+	AbsentDeductionAmount int64 `gorm:"type:bigint;not null;default:0"`
+	LateDeductionAmount   int64 `gorm:"type:bigint;not null;default:0"`
+
+	// Kode TaxCalculator yang dipakai, atau NONE untuk menonaktifkan pajak.
+	TaxCalculator string `gorm:"type:varchar(30);not null;default:'PPH21'"`
+
+	// Tarif BPJS dalam basis poin (100 = 1%) dari gaji pokok, beserta batas upah.
+	// Tarif 0 berarti program tidak dihitung; batas upah 0 berarti tanpa batas.
+	JHTEmployeeRateBps       int64 `gorm:"column:jht_employee_rate_bps;type:bigint;not null;default:200"`
+	JHTEmployerRateBps       int64 `gorm:"column:jht_employer_rate_bps;type:bigint;not null;default:370"`
+	JPEmployeeRateBps        int64 `gorm:"column:jp_employee_rate_bps;type:bigint;not null;default:100"`
+	JPEmployerRateBps        int64 `gorm:"column:jp_employer_rate_bps;type:bigint;not null;default:200"`
+	JPWageCap                int64 `gorm:"column:jp_wage_cap;type:bigint;not null;default:10547400"`
+	JKKEmployerRateBps       int64 `gorm:"column:jkk_employer_rate_bps;type:bigint;not null;default:24"`
+	JKMEmployerRateBps       int64 `gorm:"column:jkm_employer_rate_bps;type:bigint;not null;default:30"`
+	KesehatanEmployeeRateBps int64 `gorm:"column:kesehatan_employee_rate_bps;type:bigint;not null;default:100"`
+	KesehatanEmployerRateBps int64 `gorm:"column:kesehatan_employer_rate_bps;type:bigint;not null;default:400"`
+	KesehatanWageCap         int64 `gorm:"column:kesehatan_wage_cap;type:bigint;not null;default:12000000"`
+
+	// Rekening sumber (debit) untuk file transfer gaji ke bank. CompanyCode adalah
+	// kode perusahaan/corporate ID yang diberikan bank untuk layanan bulk transfer.
+	DebitBankCode      string `gorm:"type:varchar(20);not null;default:''"`
+	DebitAccountNumber string `gorm:"type:varchar(34);not null;default:''"`
+	DebitAccountName   string `gorm:"type:varchar(150);not null;default:''"`
+	BankCompanyCode    string `gorm:"type:varchar(50);not null;default:''"`
+
+	// Cara menyusun password PDF payslip per karyawan, NONE berarti payslip tidak dienkripsi.
+	PayslipPasswordMode string `gorm:"type:varchar(40);not null;default:'NONE'"`
+
+	// Metode prorata gaji untuk karyawan yang masuk/keluar di tengah periode.
+	ProrationMethod string `gorm:"type:varchar(20);not null;default:'WORKING_DAYS'"`
+
+	UpdatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (PayrollSetting) TableName() string {
+	return "payroll_settings"
+}
+
+const defaultTaxCalculator = TaxCalculatorPPh21
+
+func defaultPayrollSetting(companyID uuid.UUID) PayrollSetting {
+	return PayrollSetting{
+		CompanyID:       companyID,
+		WorkHoursPerDay: 8,
+		WorkDaysPerWeek: 5,
+		TaxCalculator:   defaultTaxCalculator,
+
+		PayslipPasswordMode: PayslipPasswordNone,
+		ProrationMethod:     ProrationWorkingDays,
+
+		JHTEmployeeRateBps:       200,
+		JHTEmployerRateBps:       370,
+		JPEmployeeRateBps:        100,
+		JPEmployerRateBps:        200,
+		JPWageCap:                10_547_400,
+		JKKEmployerRateBps:       24,
+		JKMEmployerRateBps:       30,
+		KesehatanEmployeeRateBps: 100,
+		KesehatanEmployerRateBps: 400,
+		KesehatanWageCap:         12_000_000,
+	}
+}
+
+// AttendanceRecord adalah proyeksi baris attendances untuk perhitungan payroll.
+type AttendanceRecord struct {
+	AttendanceDate time.Time
+	ClockIn        time.Time
+	ClockOut       *time.Time
+	Status         string
+}
+
+// LeaveRange adalah proyeksi cuti yang sudah APPROVED dalam periode payroll.
+type LeaveRange struct {
+	ID        uuid.UUID
+	LeaveType string
+	StartDate time.Time
+	EndDate   time.Time
+}
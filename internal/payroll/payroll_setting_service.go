@@ -0,0 +1,198 @@
+package payroll
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+func (s *service) GetSetting(ctx context.Context, companyID string) (PayrollSettingResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return PayrollSettingResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+
+	setting, err := s.loadSetting(ctx, s.repo, companyUUID)
+	if err != nil {
+		return PayrollSettingResponse{}, err
+	}
+
+	return mapToSettingResponse(setting), nil
+}
+
+func (s *service) UpdateSetting(
+	ctx context.Context,
+	companyID, actorID string,
+	req UpdatePayrollSettingRequest,
+) (PayrollSettingResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return PayrollSettingResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+	actorUUID, err := uuid.Parse(actorID)
+	if err != nil {
+		return PayrollSettingResponse{}, payrollerrors.ErrInvalidActorID
+	}
+	if err := validateSettingRequest(req); err != nil {
+		return PayrollSettingResponse{}, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return PayrollSettingResponse{}, err
+	}
+	defer tx.Rollback()
+
+	qtx := s.repo.WithTx(tx)
+
+	setting, err := s.loadSetting(ctx, qtx, companyUUID)
+	if err != nil {
+		return PayrollSettingResponse{}, err
+	}
+
+	setting.WorkHoursPerDay = req.WorkHoursPerDay
+	setting.WorkDaysPerWeek = req.WorkDaysPerWeek
+	setting.OvertimeHourlyRate = req.OvertimeHourlyRate
+	setting.AbsentDeductionAmount = req.AbsentDeductionAmount
+	setting.LateDeductionAmount = req.LateDeductionAmount
+	if req.TaxCalculator != "" {
+		setting.TaxCalculator = req.TaxCalculator
+	}
+	setIfPresent(&setting.JHTEmployeeRateBps, req.JHTEmployeeRateBps)
+	setIfPresent(&setting.JHTEmployerRateBps, req.JHTEmployerRateBps)
+	setIfPresent(&setting.JPEmployeeRateBps, req.JPEmployeeRateBps)
+	setIfPresent(&setting.JPEmployerRateBps, req.JPEmployerRateBps)
+	setIfPresent(&setting.JPWageCap, req.JPWageCap)
+	setIfPresent(&setting.JKKEmployerRateBps, req.JKKEmployerRateBps)
+	setIfPresent(&setting.JKMEmployerRateBps, req.JKMEmployerRateBps)
+	setIfPresent(&setting.KesehatanEmployeeRateBps, req.KesehatanEmployeeRateBps)
+	setIfPresent(&setting.KesehatanEmployerRateBps, req.KesehatanEmployerRateBps)
+	setIfPresent(&setting.KesehatanWageCap, req.KesehatanWageCap)
+	setIfPresent(&setting.DebitBankCode, upperTrimmed(req.DebitBankCode))
+	setIfPresent(&setting.DebitAccountNumber, trimmed(req.DebitAccountNumber))
+	setIfPresent(&setting.DebitAccountName, trimmed(req.DebitAccountName))
+	setIfPresent(&setting.BankCompanyCode, trimmed(req.BankCompanyCode))
+	setIfPresent(&setting.PayslipPasswordMode, upperTrimmed(req.PayslipPasswordMode))
+	setIfPresent(&setting.ProrationMethod, upperTrimmed(req.ProrationMethod))
+	setting.UpdatedBy = &actorUUID
+
+	if err := qtx.UpsertSetting(ctx, &setting); err != nil {
+		return PayrollSettingResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return PayrollSettingResponse{}, err
+	}
+
+	return mapToSettingResponse(setting), nil
+}
+
+func validateSettingRequest(req UpdatePayrollSettingRequest) error {
+	if req.WorkHoursPerDay < 1 || req.WorkHoursPerDay > 24 {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	if req.WorkDaysPerWeek < 5 || req.WorkDaysPerWeek > 7 {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	if err := validateMoney(req.OvertimeHourlyRate, req.AbsentDeductionAmount, req.LateDeductionAmount); err != nil {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	if req.TaxCalculator != "" && !isValidTaxCalculator(req.TaxCalculator) {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	rates := []*int64{
+		req.JHTEmployeeRateBps, req.JHTEmployerRateBps,
+		req.JPEmployeeRateBps, req.JPEmployerRateBps,
+		req.JKKEmployerRateBps, req.JKMEmployerRateBps,
+		req.KesehatanEmployeeRateBps, req.KesehatanEmployerRateBps,
+	}
+	for _, rate := range rates {
+		if rate != nil && (*rate < 0 || *rate > 10_000) {
+			return payrollerrors.ErrInvalidPayrollSetting
+		}
+	}
+	for _, wageCap := range []*int64{req.JPWageCap, req.KesehatanWageCap} {
+		if wageCap != nil && *wageCap < 0 {
+			return payrollerrors.ErrInvalidPayrollSetting
+		}
+	}
+	if req.DebitAccountNumber != nil {
+		if number := strings.TrimSpace(*req.DebitAccountNumber); number != "" && !isAccountNumber(number) {
+			return payrollerrors.ErrInvalidPayrollSetting
+		}
+	}
+	if mode := upperTrimmed(req.PayslipPasswordMode); mode != nil && !isValidPayslipPasswordMode(*mode) {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	if method := upperTrimmed(req.ProrationMethod); method != nil && !isValidProrationMethod(*method) {
+		return payrollerrors.ErrInvalidPayrollSetting
+	}
+	return nil
+}
+
+// setIfPresent mengisi target hanya jika field opsional dikirim di request.
+func setIfPresent[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+func trimmed(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	out := strings.TrimSpace(*v)
+	return &out
+}
+
+func upperTrimmed(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	out := strings.ToUpper(strings.TrimSpace(*v))
+	return &out
+}
+
+func mapToSettingResponse(setting PayrollSetting) PayrollSettingResponse {
+	resp := PayrollSettingResponse{
+		CompanyID:             setting.CompanyID.String(),
+		WorkHoursPerDay:       setting.WorkHoursPerDay,
+		WorkDaysPerWeek:       setting.WorkDaysPerWeek,
+		OvertimeHourlyRate:    setting.OvertimeHourlyRate,
+		AbsentDeductionAmount: setting.AbsentDeductionAmount,
+		LateDeductionAmount:   setting.LateDeductionAmount,
+		TaxCalculator:         setting.TaxCalculator,
+
+		JHTEmployeeRateBps:       setting.JHTEmployeeRateBps,
+		JHTEmployerRateBps:       setting.JHTEmployerRateBps,
+		JPEmployeeRateBps:        setting.JPEmployeeRateBps,
+		JPEmployerRateBps:        setting.JPEmployerRateBps,
+		JPWageCap:                setting.JPWageCap,
+		JKKEmployerRateBps:       setting.JKKEmployerRateBps,
+		JKMEmployerRateBps:       setting.JKMEmployerRateBps,
+		KesehatanEmployeeRateBps: setting.KesehatanEmployeeRateBps,
+		KesehatanEmployerRateBps: setting.KesehatanEmployerRateBps,
+		KesehatanWageCap:         setting.KesehatanWageCap,
+
+		DebitBankCode:      setting.DebitBankCode,
+		DebitAccountNumber: setting.DebitAccountNumber,
+		DebitAccountName:   setting.DebitAccountName,
+		BankCompanyCode:    setting.BankCompanyCode,
+
+		PayslipPasswordMode: setting.PayslipPasswordMode,
+		ProrationMethod:     prorationMethod(setting),
+	}
+	if setting.UpdatedBy != nil {
+		v := setting.UpdatedBy.String()
+		resp.UpdatedBy = &v
+	}
+	if !setting.UpdatedAt.IsZero() {
+		v := setting.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &v
+	}
+	return resp
+}
@@ -0,0 +1,173 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_GetSetting_Default(t *testing.T) {
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	companyID := uuid.New().String()
+	resp, err := deps.service.GetSetting(context.Background(), companyID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, companyID, resp.CompanyID)
+	assert.Equal(t, int64(8), resp.WorkHoursPerDay)
+	assert.Equal(t, 5, resp.WorkDaysPerWeek)
+}
+
+func TestPayrollService_UpdateSetting(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	t.Run("invalid work days", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.UpdateSetting(ctx, companyID, actorID, payroll.UpdatePayrollSettingRequest{
+			WorkHoursPerDay: 8,
+			WorkDaysPerWeek: 4,
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPayrollSetting)
+	})
+
+	t.Run("invalid payslip password mode", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		mode := "PHONE"
+		_, err := deps.service.UpdateSetting(ctx, companyID, actorID, payroll.UpdatePayrollSettingRequest{
+			WorkHoursPerDay:     8,
+			WorkDaysPerWeek:     5,
+			PayslipPasswordMode: &mode,
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPayrollSetting)
+	})
+
+	t.Run("invalid proration method", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		method := "HOURS"
+		_, err := deps.service.UpdateSetting(ctx, companyID, actorID, payroll.UpdatePayrollSettingRequest{
+			WorkHoursPerDay: 8,
+			WorkDaysPerWeek: 5,
+			ProrationMethod: &method,
+		})
+
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidPayrollSetting)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		expectTx(t, deps.sqlMock, true)
+		deps.repo.upsertSettingFn = func(ctx context.Context, setting *payroll.PayrollSetting) error {
+			assert.Equal(t, companyID, setting.CompanyID.String())
+			assert.Equal(t, int64(50000), setting.LateDeductionAmount)
+			if assert.NotNil(t, setting.UpdatedBy) {
+				assert.Equal(t, actorID, setting.UpdatedBy.String())
+			}
+			return nil
+		}
+
+		resp, err := deps.service.UpdateSetting(ctx, companyID, actorID, payroll.UpdatePayrollSettingRequest{
+			WorkHoursPerDay:     8,
+			WorkDaysPerWeek:     6,
+			OvertimeHourlyRate:  30000,
+			LateDeductionAmount: 50000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6, resp.WorkDaysPerWeek)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestPayrollService_Create_AttendanceInputs(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	actorID := uuid.New().String()
+	employeeID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+
+	expectTx(t, deps.sqlMock, true)
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{
+			CompanyID:             uuid.MustParse(cid),
+			WorkHoursPerDay:       8,
+			WorkDaysPerWeek:       5,
+			OvertimeHourlyRate:    40000,
+			AbsentDeductionAmount: 200000,
+			LateDeductionAmount:   25000,
+		}, nil
+	}
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	at := func(d, h, m int) *time.Time {
+		v := time.Date(2026, 3, d, h, m, 0, 0, time.UTC)
+		return &v
+	}
+	// Periode 2-6 Maret 2026 (Senin-Jumat): hadir Senin-Rabu, cuti Kamis, absen Jumat
+	deps.repo.findAttendanceRecordsFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.AttendanceRecord, error) {
+		return []payroll.AttendanceRecord{
+			{AttendanceDate: day(2), ClockIn: *at(2, 8, 0), ClockOut: at(2, 19, 0), Status: "PRESENT"},  // lembur 3 jam
+			{AttendanceDate: day(3), ClockIn: *at(3, 9, 30), ClockOut: at(3, 18, 0), Status: "LATE"},    // lembur 30 menit
+			{AttendanceDate: day(4), ClockIn: *at(4, 8, 0), ClockOut: at(4, 17, 30), Status: "PRESENT"}, // lembur 1,5 jam
+		}, nil
+	}
+	deps.repo.findApprovedLeavesFn = func(ctx context.Context, cid, eid string, start, end time.Time) ([]payroll.LeaveRange, error) {
+		return []payroll.LeaveRange{{ID: uuid.New(), LeaveType: "ANNUAL", StartDate: day(5), EndDate: day(5)}}, nil
+	}
+
+	var created *payroll.Payroll
+	var components []payroll.PayrollComponent
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-03-02",
+		PeriodEnd:   "2026-03-06",
+		BaseSalary:  int64Ptr(5000000),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), resp.OvertimeHours)
+	assert.Equal(t, int64(40000), resp.OvertimeRate)
+	assert.Equal(t, int64(200000), resp.TotalOvertime)
+	assert.Equal(t, int64(225000), resp.TotalDeduction)
+	assert.Equal(t, int64(5000000+200000-225000), resp.NetSalary)
+	if assert.Len(t, components, 2) {
+		assert.Equal(t, "Absence Deduction", components[0].ComponentName)
+		assert.Equal(t, int64(1), components[0].Quantity)
+		assert.Equal(t, "Late Deduction", components[1].ComponentName)
+		assert.Equal(t, int64(1), components[1].Quantity)
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
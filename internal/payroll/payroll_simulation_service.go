@@ -0,0 +1,158 @@
+package payroll
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+)
+
+// Simulate menjalankan pipeline perhitungan yang sama dengan Create dan Regenerate untuk satu
+// karyawan atau seluruh karyawan aktif (opsional per departemen) tanpa menulis ke database.
+// Jika karyawan sudah punya payroll REGULAR di periode yang sama, simulasi menghitungnya
+// seperti regenerate sehingga pajak periode tersebut tidak terhitung dua kali.
+func (s *service) Simulate(
+	ctx context.Context,
+	companyID string,
+	req SimulatePayrollRequest,
+) (PayrollSimulationResponse, error) {
+	companyUUID, err := uuid.Parse(companyID)
+	if err != nil {
+		return PayrollSimulationResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+
+	periodStart, periodEnd, err := resolveRunPeriod(CreatePayrollRunRequest{
+		Period:      req.Period,
+		PeriodStart: req.PeriodStart,
+		PeriodEnd:   req.PeriodEnd,
+	})
+	if err != nil {
+		return PayrollSimulationResponse{}, err
+	}
+
+	employeeID := strings.TrimSpace(req.EmployeeID)
+	departmentID := strings.TrimSpace(req.DepartmentID)
+	if (employeeID != "" && departmentID != "") ||
+		(employeeID == "" && req.BaseSalary != nil) ||
+		(req.BaseSalary != nil && req.BaseSalaryIncreaseBps != 0) ||
+		req.BaseSalaryIncreaseBps < 0 || req.BaseSalaryIncreaseBps > 10000 {
+		return PayrollSimulationResponse{}, payrollerrors.ErrInvalidSimulation
+	}
+	if req.OvertimeHours < 0 || req.OvertimeRate < 0 {
+		return PayrollSimulationResponse{}, payrollerrors.ErrInvalidMoneyValue
+	}
+
+	input := regularPayrollInput{
+		BaseSalary:            req.BaseSalary,
+		Allowance:             req.Allowance,
+		OvertimeHours:         req.OvertimeHours,
+		OvertimeRate:          req.OvertimeRate,
+		Deduction:             req.Deduction,
+		AllowanceItems:        req.AllowanceItems,
+		DeductionItems:        req.DeductionItems,
+		BaseSalaryIncreaseBps: req.BaseSalaryIncreaseBps,
+	}
+	resp := PayrollSimulationResponse{
+		PeriodStart: periodStart.Format("2006-01-02"),
+		PeriodEnd:   periodEnd.Format("2006-01-02"),
+		Payrolls:    make([]PayrollBreakdownResponse, 0),
+		Failures:    make([]PayrollRunFailureResponse, 0),
+	}
+
+	// Simulasi satu karyawan mengembalikan error langsung, bukan sebagai failure.
+	if employeeID != "" {
+		employeeUUID, err := uuid.Parse(employeeID)
+		if err != nil {
+			return PayrollSimulationResponse{}, payrollerrors.ErrInvalidEmployeeID
+		}
+		belongs, err := s.repo.EmployeeBelongsToCompany(ctx, companyID, employeeID)
+		if err != nil {
+			return PayrollSimulationResponse{}, err
+		}
+		if !belongs {
+			return PayrollSimulationResponse{}, payrollerrors.ErrEmployeeNotInCompany
+		}
+
+		breakdown, err := s.simulatePayroll(ctx, companyUUID, RunEmployee{ID: employeeUUID}, periodStart, periodEnd, input)
+		if err != nil {
+			return PayrollSimulationResponse{}, err
+		}
+		resp.TotalEmployees = 1
+		resp.addPayroll(breakdown)
+		return resp, nil
+	}
+
+	var departmentFilter *string
+	if departmentID != "" {
+		departmentFilter = &departmentID
+	}
+	employees, err := s.repo.FindEmployeesForRun(ctx, companyID, periodStart, periodEnd, departmentFilter)
+	if err != nil {
+		return PayrollSimulationResponse{}, err
+	}
+	if len(employees) == 0 {
+		return PayrollSimulationResponse{}, payrollerrors.ErrPayrollRunNoEmployees
+	}
+
+	resp.TotalEmployees = len(employees)
+	for _, emp := range employees {
+		breakdown, err := s.simulatePayroll(ctx, companyUUID, emp, periodStart, periodEnd, input)
+		if err != nil {
+			code, message := failureDetail(ctx, emp, err)
+			resp.Failures = append(resp.Failures, PayrollRunFailureResponse{
+				EmployeeID:   emp.ID.String(),
+				EmployeeName: emp.FullName,
+				ErrorCode:    code,
+				ErrorMessage: message,
+			})
+			continue
+		}
+		resp.addPayroll(breakdown)
+	}
+	resp.FailedCount = len(resp.Failures)
+	return resp, nil
+}
+
+func (s *service) simulatePayroll(
+	ctx context.Context,
+	companyID uuid.UUID,
+	emp RunEmployee,
+	periodStart, periodEnd time.Time,
+	input regularPayrollInput,
+) (PayrollBreakdownResponse, error) {
+	existingID, err := s.repo.FindRegularPayrollIDInPeriod(ctx, companyID.String(), emp.ID.String(), periodStart, periodEnd)
+	if err != nil {
+		return PayrollBreakdownResponse{}, err
+	}
+
+	payroll := &Payroll{
+		CompanyID:   companyID,
+		EmployeeID:  emp.ID,
+		PayrollType: PayrollTypeRegular,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Status:      StatusDraft,
+	}
+	if emp.FullName != "" {
+		payroll.Employee = &LeaveEmployee{ID: emp.ID, FullName: emp.FullName}
+	}
+	result, err := s.calculateRegularPayroll(ctx, s.repo, payroll, existingID, input)
+	if err != nil {
+		return PayrollBreakdownResponse{}, err
+	}
+	payroll.Components = result.Components
+
+	breakdown := mapToBreakdownResponse(*payroll)
+	breakdown.PayrollID = ""
+	return breakdown, nil
+}
+
+func (r *PayrollSimulationResponse) addPayroll(breakdown PayrollBreakdownResponse) {
+	r.Payrolls = append(r.Payrolls, breakdown)
+	r.SuccessCount++
+	r.TotalNetSalary += breakdown.NetSalary
+	r.TotalEmployerCost += breakdown.EmployerCostTotal
+}
@@ -0,0 +1,194 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/apperror"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// failOnWrite memastikan simulasi tidak pernah menyentuh method tulis repository.
+func failOnWrite(t *testing.T, repo *fakePayrollRepository) {
+	t.Helper()
+	repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		t.Fatal("simulation must not create payroll")
+		return nil
+	}
+	repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+		t.Fatal("simulation must not update payroll")
+		return nil
+	}
+	repo.replaceComponentsFn = func(ctx context.Context, companyID string, payrollID string, components []payroll.PayrollComponent) error {
+		t.Fatal("simulation must not replace components")
+		return nil
+	}
+}
+
+func TestPayrollService_Simulate(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID := uuid.New()
+
+	history := []payroll.SalaryHistory{
+		{BaseSalary: 8000000, EffectiveDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	t.Run("what-if base salary for existing payroll", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		failOnWrite(t, deps.repo)
+
+		existingID := uuid.New()
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			return history, nil
+		}
+		deps.repo.findRegularInPeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time) (*uuid.UUID, error) {
+			assert.Equal(t, employeeID.String(), eid)
+			return &existingID, nil
+		}
+		deps.repo.findTaxSamePeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time, excludePayrollID *string) (payroll.TaxYearToDate, error) {
+			// Payroll yang sudah ada dihitung ulang, bukan dijumlahkan sebagai payroll kedua.
+			if assert.NotNil(t, excludePayrollID) {
+				assert.Equal(t, existingID.String(), *excludePayrollID)
+			}
+			return payroll.TaxYearToDate{}, nil
+		}
+
+		baseSalary := int64(10000000)
+		resp, err := deps.service.Simulate(ctx, companyID, payroll.SimulatePayrollRequest{
+			EmployeeID: employeeID.String(),
+			Period:     "2026-02",
+			BaseSalary: &baseSalary,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.TotalEmployees)
+		assert.Equal(t, 1, resp.SuccessCount)
+		if assert.Len(t, resp.Payrolls, 1) {
+			breakdown := resp.Payrolls[0]
+			assert.Empty(t, breakdown.PayrollID)
+			assert.Equal(t, "2026-02-01", breakdown.PeriodStart)
+			assert.Equal(t, int64(10000000), breakdown.BaseSalary.Amount)
+			assert.Equal(t, "Base Salary (manual override)", breakdown.BaseSalary.Label)
+			assert.Equal(t, int64(1024000), breakdown.EmployerCostTotal)
+			assert.Equal(t, breakdown.BaseSalary.Amount-breakdown.DeductionTotal, breakdown.NetSalary)
+			assert.Equal(t, breakdown.NetSalary, resp.TotalNetSalary)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("department raise with per-employee failures", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		failOnWrite(t, deps.repo)
+
+		departmentID := uuid.New().String()
+		noSalaryID := uuid.New()
+		deps.repo.findEmployeesForRunFn = func(ctx context.Context, cid string, start, end time.Time, deptID *string) ([]payroll.RunEmployee, error) {
+			if assert.NotNil(t, deptID) {
+				assert.Equal(t, departmentID, *deptID)
+			}
+			return []payroll.RunEmployee{
+				{ID: employeeID, FullName: "Andi"},
+				{ID: noSalaryID, FullName: "Budi"},
+			}, nil
+		}
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			if eid == noSalaryID.String() {
+				return nil, nil
+			}
+			return history, nil
+		}
+
+		resp, err := deps.service.Simulate(ctx, companyID, payroll.SimulatePayrollRequest{
+			DepartmentID:          departmentID,
+			PeriodStart:           "2026-02-01",
+			PeriodEnd:             "2026-02-28",
+			BaseSalaryIncreaseBps: 1000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.TotalEmployees)
+		assert.Equal(t, 1, resp.SuccessCount)
+		assert.Equal(t, 1, resp.FailedCount)
+		if assert.Len(t, resp.Payrolls, 1) {
+			assert.Equal(t, "Andi", resp.Payrolls[0].EmployeeName)
+			// 8.000.000 + 10%
+			assert.Equal(t, int64(8800000), resp.Payrolls[0].BaseSalary.Amount)
+			if assert.NotNil(t, resp.Payrolls[0].BaseSalary.Notes) {
+				assert.Contains(t, *resp.Payrolls[0].BaseSalary.Notes, "10.00%")
+			}
+		}
+		if assert.Len(t, resp.Failures, 1) {
+			assert.Equal(t, "Budi", resp.Failures[0].EmployeeName)
+			assert.Equal(t, apperror.CodeNotFound, resp.Failures[0].ErrorCode)
+		}
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("raise for mid-period hire is prorated", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		failOnWrite(t, deps.repo)
+
+		deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return &payroll.PayrollSetting{
+				CompanyID:       uuid.MustParse(cid),
+				WorkHoursPerDay: 8,
+				WorkDaysPerWeek: 5,
+				TaxCalculator:   payroll.TaxCalculatorNone,
+				ProrationMethod: payroll.ProrationWorkingDays,
+			}, nil
+		}
+		deps.repo.findSalaryHistoryFn = func(ctx context.Context, eid string, start, end time.Time) ([]payroll.SalaryHistory, error) {
+			return history, nil
+		}
+		// Masuk Senin 16 Februari 2026: 10 dari 20 hari kerja
+		deps.repo.findEmploymentPeriodFn = func(ctx context.Context, cid, eid string, start, end time.Time) (payroll.EmploymentPeriod, error) {
+			return payroll.EmploymentPeriod{HireDate: time.Date(2026, time.February, 16, 0, 0, 0, 0, time.UTC)}, nil
+		}
+
+		resp, err := deps.service.Simulate(ctx, companyID, payroll.SimulatePayrollRequest{
+			EmployeeID:            employeeID.String(),
+			Period:                "2026-02",
+			BaseSalaryIncreaseBps: 1000,
+		})
+
+		assert.NoError(t, err)
+		if assert.Len(t, resp.Payrolls, 1) {
+			breakdown := resp.Payrolls[0]
+			// (8.000.000 + 10%) x 10/20
+			assert.Equal(t, int64(4400000), breakdown.BaseSalary.Amount)
+			assert.Equal(t, "Base Salary", breakdown.BaseSalary.Label)
+			if assert.NotNil(t, breakdown.BaseSalary.Notes) {
+				assert.Contains(t, *breakdown.BaseSalary.Notes, "10.00%")
+				assert.Contains(t, *breakdown.BaseSalary.Notes, "Prorata masa kerja")
+			}
+		}
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		baseSalary := int64(10000000)
+		_, err := deps.service.Simulate(ctx, companyID, payroll.SimulatePayrollRequest{
+			Period:     "2026-02",
+			BaseSalary: &baseSalary,
+		})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidSimulation)
+
+		_, err = deps.service.Simulate(ctx, companyID, payroll.SimulatePayrollRequest{
+			EmployeeID:   employeeID.String(),
+			DepartmentID: uuid.New().String(),
+			Period:       "2026-02",
+		})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidSimulation)
+	})
+}
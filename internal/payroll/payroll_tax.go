@@ -0,0 +1,211 @@
+package payroll
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	payrollerrors "go-hris/internal/payroll/errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ComponentSourceTax = "TAX"
+
+	// TaxCalculatorNone menonaktifkan perhitungan pajak untuk company.
+	TaxCalculatorNone = "NONE"
+)
+
+// TaxInput adalah data yang dibutuhkan kalkulator pajak untuk satu payroll.
+type TaxInput struct {
+	EmployeeID  uuid.UUID
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	// TaxStatus adalah status pajak karyawan, untuk PPh 21 berupa status PTKP (mis. TK/0, K/1).
+	TaxStatus string
+
+	// GrossIncome adalah penghasilan bruto periode ini: gaji pokok + tunjangan + lembur
+	// + iuran BPJS pemberi kerja yang menjadi objek pajak (JKK, JKM, Kesehatan).
+	GrossIncome int64
+
+	// PensionContribution adalah iuran JHT + JP yang dibayar karyawan periode ini.
+	PensionContribution int64
+
+	// YearToDate adalah akumulasi payroll sebelumnya di tahun pajak yang sama.
+	YearToDate TaxYearToDate
+}
+
+// TaxYearToDate adalah akumulasi bruto dan pajak dari payroll sebelumnya di tahun berjalan.
+type TaxYearToDate struct {
+	GrossIncome         int64
+	TaxAmount           int64
+	PensionContribution int64
+	PayrollCount        int64
+}
+
+// TaxResult adalah hasil perhitungan pajak. Amount negatif berarti lebih bayar
+// (mis. hasil true-up akhir tahun) dan dikembalikan ke karyawan.
+type TaxResult struct {
+	Amount        int64
+	ComponentName string
+
+	// Inputs disimpan sebagai metadata komponen pajak untuk kebutuhan audit.
+	Inputs map[string]any
+}
+
+// TaxCalculator menghitung pajak penghasilan karyawan untuk satu yurisdiksi.
+// Implementasi baru didaftarkan lewat RegisterTaxCalculator lalu dipilih per
+// company melalui payroll setting tax_calculator.
+type TaxCalculator interface {
+	Code() string
+	Calculate(input TaxInput) (TaxResult, error)
+}
+
+var (
+	taxCalculatorsMu sync.RWMutex
+	taxCalculators   = map[string]TaxCalculator{
+		TaxCalculatorPPh21: NewPPh21Calculator(),
+	}
+)
+
+// RegisterTaxCalculator mendaftarkan kalkulator pajak. Kode yang sama akan ditimpa.
+func RegisterTaxCalculator(calculator TaxCalculator) {
+	taxCalculatorsMu.Lock()
+	defer taxCalculatorsMu.Unlock()
+	taxCalculators[calculator.Code()] = calculator
+}
+
+func findTaxCalculator(code string) (TaxCalculator, bool) {
+	taxCalculatorsMu.RLock()
+	defer taxCalculatorsMu.RUnlock()
+	calculator, ok := taxCalculators[code]
+	return calculator, ok
+}
+
+func isValidTaxCalculator(code string) bool {
+	if code == TaxCalculatorNone {
+		return true
+	}
+	_, ok := findTaxCalculator(code)
+	return ok
+}
+
+// calculateTax menjalankan kalkulator pajak sesuai setting company dan menghasilkan
+// komponen pajak: DEDUCTION untuk pajak yang dipotong, ALLOWANCE untuk lebih bayar.
+// Komponen tidak dibuat jika pajak nol; nilai pajak tetap dikembalikan untuk disimpan.
+// Jika ada payroll lain di periode yang sama (mis. REGULAR dan THR), pajak dihitung atas
+// gabungan penghasilannya lalu dikurangi pajak yang sudah dipotong payroll tersebut.
+func (s *service) calculateTax(
+	ctx context.Context,
+	repo Repository,
+	setting PayrollSetting,
+	companyID, employeeID uuid.UUID,
+	payrollID *uuid.UUID,
+	periodStart, periodEnd time.Time,
+	grossIncome, pensionContribution int64,
+) (int64, *PayrollComponent, error) {
+	code := setting.TaxCalculator
+	if code == "" {
+		code = defaultTaxCalculator
+	}
+	if code == TaxCalculatorNone {
+		return 0, nil, nil
+	}
+	calculator, ok := findTaxCalculator(code)
+	if !ok {
+		return 0, nil, payrollerrors.ErrInvalidPayrollSetting
+	}
+
+	taxStatus, err := repo.FindEmployeeTaxStatus(ctx, companyID.String(), employeeID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, payrollerrors.ErrEmployeeNotInCompany
+		}
+		return 0, nil, err
+	}
+
+	yearStart := time.Date(periodEnd.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	ytd, err := repo.FindTaxYearToDate(ctx, companyID.String(), employeeID.String(), yearStart, periodStart)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var excludeID *string
+	if payrollID != nil {
+		v := payrollID.String()
+		excludeID = &v
+	}
+	samePeriod, err := repo.FindTaxSamePeriod(ctx, companyID.String(), employeeID.String(), periodStart, periodEnd, excludeID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result, err := calculator.Calculate(TaxInput{
+		EmployeeID:          employeeID,
+		PeriodStart:         periodStart,
+		PeriodEnd:           periodEnd,
+		TaxStatus:           taxStatus,
+		GrossIncome:         grossIncome + samePeriod.GrossIncome,
+		PensionContribution: pensionContribution + samePeriod.PensionContribution,
+		YearToDate:          ytd,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if samePeriod.PayrollCount > 0 {
+		result.Amount -= samePeriod.TaxAmount
+		if result.Inputs == nil {
+			result.Inputs = map[string]any{}
+		}
+		result.Inputs["same_period_gross_income"] = samePeriod.GrossIncome
+		result.Inputs["same_period_tax"] = samePeriod.TaxAmount
+	}
+	if result.Amount == 0 {
+		return 0, nil, nil
+	}
+
+	metadata, err := json.Marshal(result.Inputs)
+	if err != nil {
+		return 0, nil, err
+	}
+	raw := string(metadata)
+	source := ComponentSourceTax
+
+	componentType := ComponentTypeDeduction
+	amount := result.Amount
+	if amount < 0 {
+		componentType = ComponentTypeAllowance
+		amount = -amount
+	}
+
+	component := &PayrollComponent{
+		ID:            uuid.New(),
+		CompanyID:     companyID,
+		ComponentType: componentType,
+		ComponentName: result.ComponentName,
+		Quantity:      1,
+		UnitAmount:    amount,
+		TotalAmount:   amount,
+		SourceType:    &source,
+		Metadata:      &raw,
+	}
+	if payrollID != nil {
+		component.PayrollID = *payrollID
+	}
+	return result.Amount, component, nil
+}
+
+// appendTaxComponent menambahkan komponen pajak ke daftar sesuai jenisnya.
+func appendTaxComponent(allowances, deductions []PayrollComponent, tax *PayrollComponent) ([]PayrollComponent, []PayrollComponent) {
+	if tax == nil {
+		return allowances, deductions
+	}
+	if tax.ComponentType == ComponentTypeAllowance {
+		return append(allowances, *tax), deductions
+	}
+	return allowances, append(deductions, *tax)
+}
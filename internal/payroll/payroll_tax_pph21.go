@@ -0,0 +1,228 @@
+package payroll
+
+import (
+	payrollerrors "go-hris/internal/payroll/errors"
+	"time"
+)
+
+const (
+	TaxCalculatorPPh21 = "PPH21"
+
+	pph21MethodTER    = "TER"
+	pph21MethodAnnual = "ANNUAL"
+
+	// Biaya jabatan 5% dari bruto, maksimal Rp500.000 per bulan (Rp6.000.000 setahun).
+	pph21OccupationalCostBps      = 500
+	pph21OccupationalCostMonthCap = 500_000
+)
+
+// terBracket adalah satu baris tabel TER bulanan: penghasilan bruto sampai UpTo
+// dikenai tarif RateBps (basis poin, 100 = 1%). UpTo 0 berarti tanpa batas atas.
+type terBracket struct {
+	UpTo    int64
+	RateBps int64
+}
+
+type pasal17Bracket struct {
+	UpTo    int64
+	RateBps int64
+}
+
+// Tarif Efektif Rata-rata bulanan sesuai PP 58/2023.
+var (
+	terCategoryA = []terBracket{
+		{5_400_000, 0}, {5_650_000, 25}, {5_950_000, 50}, {6_300_000, 75},
+		{6_750_000, 100}, {7_500_000, 125}, {8_550_000, 150}, {9_650_000, 175},
+		{10_050_000, 200}, {10_350_000, 225}, {10_700_000, 250}, {11_050_000, 300},
+		{11_600_000, 350}, {12_500_000, 400}, {13_750_000, 500}, {15_100_000, 600},
+		{16_950_000, 700}, {19_750_000, 800}, {24_150_000, 900}, {26_450_000, 1000},
+		{28_000_000, 1100}, {30_050_000, 1200}, {32_400_000, 1300}, {35_400_000, 1400},
+		{39_100_000, 1500}, {43_850_000, 1600}, {47_800_000, 1700}, {51_400_000, 1800},
+		{56_300_000, 1900}, {62_200_000, 2000}, {68_600_000, 2100}, {77_500_000, 2200},
+		{89_000_000, 2300}, {103_000_000, 2400}, {125_000_000, 2500}, {157_000_000, 2600},
+		{206_000_000, 2700}, {337_000_000, 2800}, {454_000_000, 2900}, {550_000_000, 3000},
+		{695_000_000, 3100}, {910_000_000, 3200}, {1_400_000_000, 3300}, {0, 3400},
+	}
+	terCategoryB = []terBracket{
+		{6_200_000, 0}, {6_500_000, 25}, {6_850_000, 50}, {7_300_000, 75},
+		{9_200_000, 100}, {10_750_000, 150}, {11_250_000, 200}, {11_600_000, 250},
+		{12_600_000, 300}, {13_600_000, 400}, {14_950_000, 500}, {16_400_000, 600},
+		{18_450_000, 700}, {21_850_000, 800}, {26_000_000, 900}, {27_700_000, 1000},
+		{29_350_000, 1100}, {31_450_000, 1200}, {33_950_000, 1300}, {37_100_000, 1400},
+		{41_100_000, 1500}, {45_800_000, 1600}, {49_500_000, 1700}, {53_800_000, 1800},
+		{58_500_000, 1900}, {64_000_000, 2000}, {71_000_000, 2100}, {80_000_000, 2200},
+		{93_000_000, 2300}, {109_000_000, 2400}, {129_000_000, 2500}, {163_000_000, 2600},
+		{211_000_000, 2700}, {374_000_000, 2800}, {459_000_000, 2900}, {555_000_000, 3000},
+		{704_000_000, 3100}, {957_000_000, 3200}, {1_405_000_000, 3300}, {0, 3400},
+	}
+	terCategoryC = []terBracket{
+		{6_600_000, 0}, {6_950_000, 25}, {7_350_000, 50}, {7_800_000, 75},
+		{8_850_000, 100}, {9_800_000, 125}, {10_950_000, 150}, {11_200_000, 175},
+		{12_050_000, 200}, {12_950_000, 300}, {14_150_000, 400}, {15_550_000, 500},
+		{17_050_000, 600}, {19_500_000, 700}, {22_700_000, 800}, {26_600_000, 900},
+		{28_100_000, 1000}, {30_100_000, 1100}, {32_600_000, 1200}, {35_400_000, 1300},
+		{38_900_000, 1400}, {43_000_000, 1500}, {47_400_000, 1600}, {51_200_000, 1700},
+		{55_800_000, 1800}, {60_400_000, 1900}, {66_700_000, 2000}, {74_500_000, 2100},
+		{83_200_000, 2200}, {95_600_000, 2300}, {110_000_000, 2400}, {134_000_000, 2500},
+		{169_000_000, 2600}, {221_000_000, 2700}, {390_000_000, 2800}, {463_000_000, 2900},
+		{561_000_000, 3000}, {709_000_000, 3100}, {965_000_000, 3200}, {1_419_000_000, 3300},
+		{0, 3400},
+	}
+
+	// Tarif progresif Pasal 17 UU HPP untuk penghasilan kena pajak setahun.
+	pasal17Brackets = []pasal17Bracket{
+		{60_000_000, 500},
+		{250_000_000, 1500},
+		{500_000_000, 2500},
+		{5_000_000_000, 3000},
+		{0, 3500},
+	}
+)
+
+// ptkpProfile memetakan status PTKP ke kategori TER dan nilai PTKP setahun.
+type ptkpProfile struct {
+	TERCategory string
+	Annual      int64
+}
+
+var ptkpProfiles = map[string]ptkpProfile{
+	"TK/0": {"A", 54_000_000},
+	"TK/1": {"A", 58_500_000},
+	"TK/2": {"B", 63_000_000},
+	"TK/3": {"B", 67_500_000},
+	"K/0":  {"A", 58_500_000},
+	"K/1":  {"B", 63_000_000},
+	"K/2":  {"B", 67_500_000},
+	"K/3":  {"C", 72_000_000},
+}
+
+var terTables = map[string][]terBracket{
+	"A": terCategoryA,
+	"B": terCategoryB,
+	"C": terCategoryC,
+}
+
+type pph21Calculator struct{}
+
+// NewPPh21Calculator membuat kalkulator PPh 21 karyawan tetap. Januari-November
+// memakai TER bulanan, sedangkan Desember menghitung pajak setahun (Pasal 17)
+// dikurangi pajak yang sudah dipotong sebelumnya.
+func NewPPh21Calculator() TaxCalculator {
+	return pph21Calculator{}
+}
+
+func (pph21Calculator) Code() string {
+	return TaxCalculatorPPh21
+}
+
+func (pph21Calculator) Calculate(input TaxInput) (TaxResult, error) {
+	status := input.TaxStatus
+	if status == "" {
+		status = "TK/0"
+	}
+	profile, ok := ptkpProfiles[status]
+	if !ok {
+		return TaxResult{}, payrollerrors.ErrInvalidTaxStatus
+	}
+	if input.GrossIncome < 0 {
+		return TaxResult{}, payrollerrors.ErrInvalidMoneyValue
+	}
+
+	if input.PeriodEnd.Month() == time.December {
+		return calculatePPh21Annual(status, profile, input), nil
+	}
+
+	rate := terRate(terTables[profile.TERCategory], input.GrossIncome)
+	return TaxResult{
+		Amount:        input.GrossIncome * rate / 10_000,
+		ComponentName: "PPh 21",
+		Inputs: map[string]any{
+			"calculator":   TaxCalculatorPPh21,
+			"method":       pph21MethodTER,
+			"ptkp_status":  status,
+			"ter_category": profile.TERCategory,
+			"ter_rate_bps": rate,
+			"gross_income": input.GrossIncome,
+		},
+	}, nil
+}
+
+// calculatePPh21Annual menghitung true-up Desember: PPh 21 setahun atas penghasilan
+// neto dikurangi PTKP, lalu dikurangi PPh 21 yang sudah dipotong Januari-November.
+func calculatePPh21Annual(status string, profile ptkpProfile, input TaxInput) TaxResult {
+	months := input.YearToDate.PayrollCount + 1
+	if months > 12 {
+		months = 12
+	}
+	annualGross := input.YearToDate.GrossIncome + input.GrossIncome
+
+	occupationalCost := annualGross * pph21OccupationalCostBps / 10_000
+	if limit := pph21OccupationalCostMonthCap * months; occupationalCost > limit {
+		occupationalCost = limit
+	}
+	pension := input.YearToDate.PensionContribution + input.PensionContribution
+	annualNet := annualGross - occupationalCost - pension
+
+	// PKP dibulatkan ke bawah dalam ribuan rupiah penuh.
+	taxable := annualNet - profile.Annual
+	if taxable < 0 {
+		taxable = 0
+	}
+	taxable = taxable / 1_000 * 1_000
+
+	annualTax := progressiveTax(taxable)
+	amount := annualTax - input.YearToDate.TaxAmount
+
+	name := "PPh 21"
+	if amount < 0 {
+		name = "PPh 21 Refund"
+	}
+
+	return TaxResult{
+		Amount:        amount,
+		ComponentName: name,
+		Inputs: map[string]any{
+			"calculator":        TaxCalculatorPPh21,
+			"method":            pph21MethodAnnual,
+			"ptkp_status":       status,
+			"ptkp_amount":       profile.Annual,
+			"gross_income":      input.GrossIncome,
+			"months":            months,
+			"annual_gross":      annualGross,
+			"occupational_cost": occupationalCost,
+			"pension":           pension,
+			"annual_net":        annualNet,
+			"taxable_income":    taxable,
+			"annual_tax":        annualTax,
+			"ytd_tax":           input.YearToDate.TaxAmount,
+		},
+	}
+}
+
+func terRate(table []terBracket, gross int64) int64 {
+	for _, bracket := range table {
+		if bracket.UpTo == 0 || gross <= bracket.UpTo {
+			return bracket.RateBps
+		}
+	}
+	return 0
+}
+
+func progressiveTax(taxable int64) int64 {
+	var tax, lower int64
+	for _, bracket := range pasal17Brackets {
+		if taxable <= lower {
+			break
+		}
+		upper := taxable
+		if bracket.UpTo != 0 && bracket.UpTo < upper {
+			upper = bracket.UpTo
+		}
+		tax += (upper - lower) * bracket.RateBps / 10_000
+		lower = bracket.UpTo
+		if bracket.UpTo == 0 {
+			break
+		}
+	}
+	return tax
+}
@@ -0,0 +1,173 @@
+package payroll_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPPh21Calculator_MonthlyTER(t *testing.T) {
+	calc := payroll.NewPPh21Calculator()
+	march := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		status   string
+		gross    int64
+		expected int64
+		category string
+	}{
+		{name: "TK/0 under threshold", status: "TK/0", gross: 5400000, expected: 0, category: "A"},
+		{name: "default status is TK/0", status: "", gross: 10000000, expected: 200000, category: "A"},
+		{name: "K/1 uses category B", status: "K/1", gross: 10000000, expected: 150000, category: "B"},
+		{name: "K/3 uses category C", status: "K/3", gross: 7000000, expected: 35000, category: "C"},
+		{name: "top bracket", status: "TK/0", gross: 2000000000, expected: 680000000, category: "A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Calculate(payroll.TaxInput{
+				PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   march,
+				TaxStatus:   tt.status,
+				GrossIncome: tt.gross,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Amount)
+			assert.Equal(t, "TER", result.Inputs["method"])
+			assert.Equal(t, tt.category, result.Inputs["ter_category"])
+		})
+	}
+}
+
+func TestPPh21Calculator_DecemberTrueUp(t *testing.T) {
+	calc := payroll.NewPPh21Calculator()
+	input := payroll.TaxInput{
+		PeriodStart: time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		TaxStatus:   "TK/0",
+		GrossIncome: 10000000,
+		YearToDate:  payroll.TaxYearToDate{GrossIncome: 110000000, TaxAmount: 2200000, PayrollCount: 11},
+	}
+
+	t.Run("underpaid", func(t *testing.T) {
+		result, err := calc.Calculate(input)
+
+		// Bruto 120jt - biaya jabatan 6jt - PTKP 54jt = PKP 60jt x 5% = 3jt
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3000000-2200000), result.Amount)
+		assert.Equal(t, "PPh 21", result.ComponentName)
+		assert.Equal(t, "ANNUAL", result.Inputs["method"])
+		assert.Equal(t, int64(6000000), result.Inputs["occupational_cost"])
+		assert.Equal(t, int64(60000000), result.Inputs["taxable_income"])
+	})
+
+	t.Run("overpaid returns refund", func(t *testing.T) {
+		overpaid := input
+		overpaid.YearToDate.TaxAmount = 3500000
+
+		result, err := calc.Calculate(overpaid)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-500000), result.Amount)
+		assert.Equal(t, "PPh 21 Refund", result.ComponentName)
+	})
+
+	t.Run("progressive brackets and occupational cost cap per month", func(t *testing.T) {
+		result, err := calc.Calculate(payroll.TaxInput{
+			PeriodStart: input.PeriodStart,
+			PeriodEnd:   input.PeriodEnd,
+			TaxStatus:   "TK/0",
+			GrossIncome: 360054000,
+		})
+
+		// PKP 305.554.000: 60jt x 5% + 190jt x 15% + 55.554.000 x 25%
+		assert.NoError(t, err)
+		assert.Equal(t, int64(500000), result.Inputs["occupational_cost"])
+		assert.Equal(t, int64(3000000+28500000+13888500), result.Amount)
+	})
+}
+
+func TestPPh21Calculator_InvalidStatus(t *testing.T) {
+	_, err := payroll.NewPPh21Calculator().Calculate(payroll.TaxInput{
+		PeriodEnd:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		TaxStatus:   "K/4",
+		GrossIncome: 10000000,
+	})
+
+	assert.ErrorIs(t, err, payrollerrors.ErrInvalidTaxStatus)
+}
+
+func TestPayrollService_Create_TaxComponent(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID := uuid.New().String()
+	actorID := uuid.New().String()
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	expectTx(t, deps.sqlMock, true)
+
+	deps.repo.employeeBelongsToCompany = func(ctx context.Context, cid, eid string) (bool, error) {
+		return true, nil
+	}
+	deps.repo.findSettingFn = func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return &payroll.PayrollSetting{CompanyID: uuid.MustParse(cid), WorkHoursPerDay: 8, WorkDaysPerWeek: 5, TaxCalculator: payroll.TaxCalculatorPPh21}, nil
+	}
+	deps.repo.findTaxStatusFn = func(ctx context.Context, cid, eid string) (string, error) {
+		return "TK/0", nil
+	}
+	deps.repo.findTaxYearToDateFn = func(ctx context.Context, cid, eid string, yearStart, before time.Time) (payroll.TaxYearToDate, error) {
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), yearStart)
+		assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), before)
+		return payroll.TaxYearToDate{GrossIncome: 110000000, TaxAmount: 3500000, PayrollCount: 11}, nil
+	}
+	var components []payroll.PayrollComponent
+	var created *payroll.Payroll
+	deps.repo.createFn = func(ctx context.Context, p *payroll.Payroll) error {
+		created = p
+		return nil
+	}
+	deps.repo.replaceComponentsFn = func(ctx context.Context, cid, pid string, items []payroll.PayrollComponent) error {
+		components = items
+		return nil
+	}
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		created.Components = components
+		return created, nil
+	}
+
+	resp, err := deps.service.Create(ctx, companyID, actorID, payroll.CreatePayrollRequest{
+		EmployeeID:  employeeID,
+		PeriodStart: "2026-12-01",
+		PeriodEnd:   "2026-12-31",
+		BaseSalary:  int64Ptr(10000000),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000000), resp.GrossIncome)
+	assert.Equal(t, int64(-500000), resp.TaxAmount)
+	assert.Equal(t, int64(10500000), resp.NetSalary)
+	if assert.Len(t, resp.Components, 1) {
+		component := resp.Components[0]
+		assert.Equal(t, payroll.ComponentTypeAllowance, component.ComponentType)
+		assert.Equal(t, "PPh 21 Refund", component.ComponentName)
+		assert.Equal(t, int64(500000), component.TotalAmount)
+		if assert.NotNil(t, component.SourceType) {
+			assert.Equal(t, payroll.ComponentSourceTax, *component.SourceType)
+		}
+		var metadata map[string]any
+		assert.NoError(t, json.Unmarshal(component.Metadata, &metadata))
+		assert.Equal(t, "ANNUAL", metadata["method"])
+		assert.Equal(t, "TK/0", metadata["ptkp_status"])
+	}
+	assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+}
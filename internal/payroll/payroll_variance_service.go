@@ -0,0 +1,411 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	VarianceEmployeeNew      = "NEW"
+	VarianceEmployeeMissing  = "MISSING"
+	VarianceEmployeeExisting = "EXISTING"
+
+	defaultVarianceThresholdBps = 1000 // 10%
+)
+
+type varianceComponentKey struct {
+	ComponentType string
+	ComponentName string
+}
+
+// varianceEmployee mengakumulasi payroll efektif satu karyawan dalam satu periode.
+type varianceEmployee struct {
+	ID           uuid.UUID
+	Name         string
+	NetSalary    int64
+	EmployerCost int64
+	Components   map[varianceComponentKey]int64
+}
+
+// GetVarianceReport membandingkan payroll dua periode per karyawan dan per komponen untuk
+// ditinjau sebelum approve. Payroll batal, payroll yang dibalik, dan payroll penyesuaian
+// reversal tidak dihitung sehingga yang dibandingkan adalah payroll efektif.
+func (s *service) GetVarianceReport(
+	ctx context.Context,
+	companyID string,
+	req PayrollVarianceFilterRequest,
+) (PayrollVarianceResponse, error) {
+	if _, err := uuid.Parse(companyID); err != nil {
+		return PayrollVarianceResponse{}, payrollerrors.ErrInvalidCompanyID
+	}
+
+	runID := strings.TrimSpace(req.RunID)
+	period := strings.TrimSpace(req.Period)
+	if (runID == "") == (period == "") || req.ThresholdAmount < 0 || (req.ThresholdBps != nil && *req.ThresholdBps < 0) {
+		return PayrollVarianceResponse{}, payrollerrors.ErrInvalidVarianceRequest
+	}
+	payrollType, err := normalizePayrollType(req.PayrollType)
+	if err != nil {
+		return PayrollVarianceResponse{}, err
+	}
+	thresholdBps := int64(defaultVarianceThresholdBps)
+	if req.ThresholdBps != nil {
+		thresholdBps = *req.ThresholdBps
+	}
+
+	var departmentID *string
+	if v := strings.TrimSpace(req.DepartmentID); v != "" {
+		departmentID = &v
+	}
+
+	resp := PayrollVarianceResponse{
+		CompanyID:        companyID,
+		PayrollType:      payrollType,
+		ThresholdBps:     thresholdBps,
+		ThresholdAmount:  req.ThresholdAmount,
+		Employees:        make([]PayrollVarianceEmployeeRow, 0),
+		Components:       make([]PayrollVarianceComponentRow, 0),
+		NewEmployees:     make([]PayrollVarianceEmployeeRef, 0),
+		MissingEmployees: make([]PayrollVarianceEmployeeRef, 0),
+		NewComponents:    make([]PayrollVarianceComponentRef, 0),
+	}
+
+	var periodStart, periodEnd time.Time
+	var current []Payroll
+	if runID != "" {
+		run, err := s.repo.FindRunByIDAndCompany(ctx, companyID, runID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return PayrollVarianceResponse{}, payrollerrors.ErrPayrollRunNotFound
+			}
+			return PayrollVarianceResponse{}, err
+		}
+		periodStart, periodEnd = run.PeriodStart, run.PeriodEnd
+		payrollType = payrollTypeOrRegular(run.PayrollType)
+		resp.PayrollType = payrollType
+		resp.RunID = &runID
+		if departmentID == nil && run.DepartmentID != nil {
+			v := run.DepartmentID.String()
+			departmentID = &v
+		}
+		current, err = s.repo.FindByRun(ctx, companyID, runID)
+		if err != nil {
+			return PayrollVarianceResponse{}, err
+		}
+	} else {
+		start, end, err := parseMonthPeriod(period)
+		if err != nil {
+			return PayrollVarianceResponse{}, err
+		}
+		periodStart, _ = parseDate(start)
+		periodEnd, _ = parseDate(end)
+		current, err = s.findVariancePayrolls(ctx, companyID, periodStart, periodEnd, departmentID, payrollType)
+		if err != nil {
+			return PayrollVarianceResponse{}, err
+		}
+	}
+
+	compareStart := time.Date(periodStart.Year(), periodStart.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	compareEnd := compareStart.AddDate(0, 1, -1)
+	if v := strings.TrimSpace(req.ComparePeriod); v != "" {
+		start, end, err := parseMonthPeriod(v)
+		if err != nil {
+			return PayrollVarianceResponse{}, payrollerrors.ErrInvalidVarianceRequest
+		}
+		compareStart, _ = parseDate(start)
+		compareEnd, _ = parseDate(end)
+		if compareStart.Equal(periodStart) {
+			return PayrollVarianceResponse{}, payrollerrors.ErrInvalidVarianceRequest
+		}
+	}
+	previous, err := s.findVariancePayrolls(ctx, companyID, compareStart, compareEnd, departmentID, payrollType)
+	if err != nil {
+		return PayrollVarianceResponse{}, err
+	}
+
+	resp.PeriodStart = periodStart.Format("2006-01-02")
+	resp.PeriodEnd = periodEnd.Format("2006-01-02")
+	resp.ComparePeriodStart = compareStart.Format("2006-01-02")
+	resp.ComparePeriodEnd = compareEnd.Format("2006-01-02")
+
+	current = effectiveVariancePayrolls(current)
+	previous = effectiveVariancePayrolls(previous)
+	payrollIDs := make([]string, 0, len(current)+len(previous))
+	for _, p := range append(append([]Payroll{}, current...), previous...) {
+		payrollIDs = append(payrollIDs, p.ID.String())
+	}
+	components := make(map[uuid.UUID][]PayrollComponent)
+	if len(payrollIDs) > 0 {
+		items, err := s.repo.FindComponentsByPayrollIDs(ctx, companyID, payrollIDs)
+		if err != nil {
+			return PayrollVarianceResponse{}, err
+		}
+		for _, item := range items {
+			components[item.PayrollID] = append(components[item.PayrollID], item)
+		}
+	}
+
+	currentByEmployee := groupVarianceEmployees(current, components)
+	previousByEmployee := groupVarianceEmployees(previous, components)
+	buildVarianceReport(&resp, currentByEmployee, previousByEmployee, thresholdBps, req.ThresholdAmount)
+	return resp, nil
+}
+
+func (s *service) findVariancePayrolls(
+	ctx context.Context,
+	companyID string,
+	periodStart, periodEnd time.Time,
+	departmentID *string,
+	payrollType string,
+) ([]Payroll, error) {
+	start := periodStart.Format("2006-01-02")
+	end := periodEnd.Format("2006-01-02")
+	payrolls, err := s.repo.FindAllByCompany(ctx, companyID, PayrollQueryFilter{
+		PeriodStart:  &start,
+		PeriodEnd:    &end,
+		DepartmentID: departmentID,
+		PayrollType:  &payrollType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Payroll yang hanya beririsan dengan periode (mis. periode 26-25) masuk ke bulan mulainya.
+	inPeriod := make([]Payroll, 0, len(payrolls))
+	for _, p := range payrolls {
+		if !p.PeriodStart.Before(periodStart) && !p.PeriodStart.After(periodEnd) {
+			inPeriod = append(inPeriod, p)
+		}
+	}
+	return inPeriod, nil
+}
+
+func effectiveVariancePayrolls(payrolls []Payroll) []Payroll {
+	out := make([]Payroll, 0, len(payrolls))
+	for _, p := range payrolls {
+		if p.Status == StatusCancelled || p.Status == StatusReversed || p.ReversalOfID != nil {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// groupVarianceEmployees menjumlahkan payroll per karyawan. Gaji pokok, lembur, dan nilai
+// lump-sum di luar komponen ikut dijadikan baris komponen agar perubahan net terjelaskan.
+func groupVarianceEmployees(payrolls []Payroll, components map[uuid.UUID][]PayrollComponent) map[uuid.UUID]*varianceEmployee {
+	out := make(map[uuid.UUID]*varianceEmployee, len(payrolls))
+	for _, p := range payrolls {
+		emp, ok := out[p.EmployeeID]
+		if !ok {
+			emp = &varianceEmployee{ID: p.EmployeeID, Components: make(map[varianceComponentKey]int64)}
+			if p.Employee != nil {
+				emp.Name = p.Employee.FullName
+			}
+			out[p.EmployeeID] = emp
+		}
+		emp.NetSalary += p.NetSalary
+		emp.EmployerCost += p.EmployerCost
+
+		add := func(componentType, name string, amount int64) {
+			if amount != 0 {
+				emp.Components[varianceComponentKey{ComponentType: componentType, ComponentName: name}] += amount
+			}
+		}
+		add(JournalItemBaseSalary, "Base Salary", p.BaseSalary)
+		add(JournalItemOvertime, "Overtime", p.OvertimeAmount)
+
+		var allowances, deductions int64
+		for _, item := range components[p.ID] {
+			add(item.ComponentType, item.ComponentName, item.TotalAmount)
+			switch item.ComponentType {
+			case ComponentTypeAllowance:
+				allowances += item.TotalAmount
+			case ComponentTypeDeduction:
+				deductions += item.TotalAmount
+			}
+		}
+		add(ComponentTypeAllowance, "Allowance (core)", p.Allowance-allowances)
+		add(ComponentTypeDeduction, "Deduction (core)", p.Deduction-deductions)
+	}
+	return out
+}
+
+func buildVarianceReport(
+	resp *PayrollVarianceResponse,
+	current, previous map[uuid.UUID]*varianceEmployee,
+	thresholdBps, thresholdAmount int64,
+) {
+	companyCurrent := make(map[varianceComponentKey]int64)
+	companyPrevious := make(map[varianceComponentKey]int64)
+
+	employeeIDs := make(map[uuid.UUID]struct{}, len(current)+len(previous))
+	for id, emp := range current {
+		employeeIDs[id] = struct{}{}
+		resp.Totals.CurrentNet += emp.NetSalary
+		resp.Totals.CurrentEmployerCost += emp.EmployerCost
+		for key, amount := range emp.Components {
+			companyCurrent[key] += amount
+		}
+	}
+	for id, emp := range previous {
+		employeeIDs[id] = struct{}{}
+		resp.Totals.PreviousNet += emp.NetSalary
+		resp.Totals.PreviousEmployerCost += emp.EmployerCost
+		for key, amount := range emp.Components {
+			companyPrevious[key] += amount
+		}
+	}
+	resp.Totals.CurrentEmployees = len(current)
+	resp.Totals.PreviousEmployees = len(previous)
+	resp.Totals.NetChange = resp.Totals.CurrentNet - resp.Totals.PreviousNet
+
+	for id := range employeeIDs {
+		cur, prev := current[id], previous[id]
+		row := PayrollVarianceEmployeeRow{EmployeeID: id.String(), Status: VarianceEmployeeExisting}
+		var curComponents, prevComponents map[varianceComponentKey]int64
+		switch {
+		case prev == nil:
+			row.Status = VarianceEmployeeNew
+			row.EmployeeName = cur.Name
+			row.CurrentNet = cur.NetSalary
+			curComponents = cur.Components
+			resp.NewEmployees = append(resp.NewEmployees, PayrollVarianceEmployeeRef{EmployeeID: row.EmployeeID, EmployeeName: cur.Name, NetSalary: cur.NetSalary})
+		case cur == nil:
+			row.Status = VarianceEmployeeMissing
+			row.EmployeeName = prev.Name
+			row.PreviousNet = prev.NetSalary
+			prevComponents = prev.Components
+			resp.MissingEmployees = append(resp.MissingEmployees, PayrollVarianceEmployeeRef{EmployeeID: row.EmployeeID, EmployeeName: prev.Name, NetSalary: prev.NetSalary})
+		default:
+			row.EmployeeName = cur.Name
+			row.CurrentNet = cur.NetSalary
+			row.PreviousNet = prev.NetSalary
+			curComponents = cur.Components
+			prevComponents = prev.Components
+		}
+		row.NetChange = row.CurrentNet - row.PreviousNet
+		if row.PreviousNet != 0 {
+			bps := row.NetChange * 10000 / abs64(row.PreviousNet)
+			row.NetChangeBps = &bps
+		}
+		if row.Status == VarianceEmployeeExisting {
+			row.Flagged = exceedsVarianceThreshold(row.PreviousNet, row.NetChange, thresholdBps, thresholdAmount)
+		}
+		if row.Flagged {
+			resp.FlaggedCount++
+		}
+
+		row.Components = make([]PayrollVarianceComponentRow, 0)
+		for _, line := range diffVarianceComponents(curComponents, prevComponents) {
+			if line.Change != 0 {
+				row.Components = append(row.Components, line)
+			}
+		}
+		resp.Employees = append(resp.Employees, row)
+	}
+
+	// Karyawan yang ditandai lebih dulu, lalu perubahan terbesar.
+	sort.Slice(resp.Employees, func(i, j int) bool {
+		a, b := resp.Employees[i], resp.Employees[j]
+		if a.Flagged != b.Flagged {
+			return a.Flagged
+		}
+		if abs64(a.NetChange) != abs64(b.NetChange) {
+			return abs64(a.NetChange) > abs64(b.NetChange)
+		}
+		return a.EmployeeName < b.EmployeeName
+	})
+	sort.Slice(resp.NewEmployees, func(i, j int) bool { return resp.NewEmployees[i].EmployeeName < resp.NewEmployees[j].EmployeeName })
+	sort.Slice(resp.MissingEmployees, func(i, j int) bool {
+		return resp.MissingEmployees[i].EmployeeName < resp.MissingEmployees[j].EmployeeName
+	})
+
+	resp.Components = diffVarianceComponents(companyCurrent, companyPrevious)
+	for _, line := range resp.Components {
+		if line.IsNew {
+			resp.NewComponents = append(resp.NewComponents, PayrollVarianceComponentRef{
+				ComponentType: line.ComponentType,
+				ComponentName: line.ComponentName,
+			})
+		}
+	}
+}
+
+// exceedsVarianceThreshold menandai perubahan net salary yang melewati persentase threshold
+// dari net periode pembanding, atau nominal threshold jika diisi.
+func exceedsVarianceThreshold(previousNet, change, thresholdBps, thresholdAmount int64) bool {
+	if change == 0 {
+		return false
+	}
+	if thresholdAmount > 0 && abs64(change) >= thresholdAmount {
+		return true
+	}
+	if previousNet == 0 {
+		return true
+	}
+	return abs64(change)*10000 >= thresholdBps*abs64(previousNet)
+}
+
+func diffVarianceComponents(current, previous map[varianceComponentKey]int64) []PayrollVarianceComponentRow {
+	keys := make([]varianceComponentKey, 0, len(current)+len(previous))
+	for key := range current {
+		keys = append(keys, key)
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].ComponentType != keys[j].ComponentType {
+			return varianceTypeOrder(keys[i].ComponentType) < varianceTypeOrder(keys[j].ComponentType)
+		}
+		return keys[i].ComponentName < keys[j].ComponentName
+	})
+
+	rows := make([]PayrollVarianceComponentRow, 0, len(keys))
+	for _, key := range keys {
+		cur, inCurrent := current[key]
+		prev, inPrevious := previous[key]
+		rows = append(rows, PayrollVarianceComponentRow{
+			ComponentType:  key.ComponentType,
+			ComponentName:  key.ComponentName,
+			PreviousAmount: prev,
+			CurrentAmount:  cur,
+			Change:         cur - prev,
+			IsNew:          inCurrent && !inPrevious,
+			IsRemoved:      inPrevious && !inCurrent,
+		})
+	}
+	return rows
+}
+
+func varianceTypeOrder(componentType string) int {
+	switch componentType {
+	case JournalItemBaseSalary:
+		return 0
+	case JournalItemOvertime:
+		return 1
+	case ComponentTypeAllowance:
+		return 2
+	case ComponentTypeDeduction:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+package payroll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_GetVarianceReport(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	janStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	janEnd := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	febStart := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	febEnd := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	andi, budi, citra, dewi := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	newPayroll := func(employeeID uuid.UUID, name string, start, end time.Time, base, net int64) payroll.Payroll {
+		return payroll.Payroll{
+			ID:          uuid.New(),
+			EmployeeID:  employeeID,
+			Employee:    &payroll.LeaveEmployee{ID: employeeID, FullName: name},
+			PayrollType: payroll.PayrollTypeRegular,
+			PeriodStart: start,
+			PeriodEnd:   end,
+			BaseSalary:  base,
+			NetSalary:   net,
+			Status:      payroll.StatusApproved,
+		}
+	}
+
+	andiJan := newPayroll(andi, "Andi", janStart, janEnd, 10000000, 10000000)
+	budiJan := newPayroll(budi, "Budi", janStart, janEnd, 8000000, 8000000)
+	citraJan := newPayroll(citra, "Citra", janStart, janEnd, 6000000, 6000000)
+	cancelledJan := newPayroll(dewi, "Dewi", janStart, janEnd, 5000000, 5000000)
+	cancelledJan.Status = payroll.StatusCancelled
+
+	andiFeb := newPayroll(andi, "Andi", febStart, febEnd, 10000000, 10500000)
+	andiFeb.Allowance = 500000
+	budiFeb := newPayroll(budi, "Budi", febStart, febEnd, 10000000, 10000000)
+	dewiFeb := newPayroll(dewi, "Dewi", febStart, febEnd, 5000000, 5000000)
+	// Payroll periode 26-25 bulan sebelumnya beririsan dengan Februari tapi milik Januari.
+	overlapping := newPayroll(citra, "Citra", time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 25, 0, 0, 0, 0, time.UTC), 1, 1)
+
+	setup := func(t *testing.T) *payrollServiceDeps {
+		deps := setupPayrollServiceTest(t)
+		deps.repo.findAllByCompanyFn = func(ctx context.Context, cid string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
+			if assert.NotNil(t, filter.PayrollType) {
+				assert.Equal(t, payroll.PayrollTypeRegular, *filter.PayrollType)
+			}
+			switch *filter.PeriodStart {
+			case "2026-01-01":
+				return []payroll.Payroll{andiJan, budiJan, citraJan, cancelledJan}, nil
+			case "2026-02-01":
+				return []payroll.Payroll{andiFeb, budiFeb, dewiFeb, overlapping}, nil
+			}
+			return nil, nil
+		}
+		deps.repo.findComponentsByIDsFn = func(ctx context.Context, cid string, payrollIDs []string) ([]payroll.PayrollComponent, error) {
+			return []payroll.PayrollComponent{
+				{PayrollID: andiFeb.ID, ComponentType: payroll.ComponentTypeAllowance, ComponentName: "Tunjangan Transport", TotalAmount: 500000},
+			}, nil
+		}
+		return deps
+	}
+
+	t.Run("period compared with previous month", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		resp, err := deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{Period: "2026-02"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-01-01", resp.ComparePeriodStart)
+		assert.Equal(t, "2026-01-31", resp.ComparePeriodEnd)
+		assert.Equal(t, int64(1000), resp.ThresholdBps)
+		assert.Equal(t, 3, resp.Totals.PreviousEmployees)
+		assert.Equal(t, 3, resp.Totals.CurrentEmployees)
+		assert.Equal(t, int64(24000000), resp.Totals.PreviousNet)
+		assert.Equal(t, int64(25500000), resp.Totals.CurrentNet)
+
+		// Budi naik 25% sehingga ditandai; Andi hanya naik 5%.
+		assert.Equal(t, 1, resp.FlaggedCount)
+		if assert.Len(t, resp.Employees, 4) {
+			first := resp.Employees[0]
+			assert.Equal(t, "Budi", first.EmployeeName)
+			assert.True(t, first.Flagged)
+			assert.Equal(t, int64(2000000), first.NetChange)
+			if assert.NotNil(t, first.NetChangeBps) {
+				assert.Equal(t, int64(2500), *first.NetChangeBps)
+			}
+			if assert.Len(t, first.Components, 1) {
+				assert.Equal(t, "BASE_SALARY", first.Components[0].ComponentType)
+				assert.Equal(t, int64(2000000), first.Components[0].Change)
+			}
+		}
+		for _, row := range resp.Employees {
+			if row.EmployeeName == "Andi" {
+				assert.False(t, row.Flagged)
+				if assert.Len(t, row.Components, 1) {
+					assert.Equal(t, "Tunjangan Transport", row.Components[0].ComponentName)
+					assert.True(t, row.Components[0].IsNew)
+				}
+			}
+		}
+
+		if assert.Len(t, resp.NewEmployees, 1) {
+			assert.Equal(t, "Dewi", resp.NewEmployees[0].EmployeeName)
+		}
+		if assert.Len(t, resp.MissingEmployees, 1) {
+			assert.Equal(t, "Citra", resp.MissingEmployees[0].EmployeeName)
+		}
+		if assert.Len(t, resp.NewComponents, 1) {
+			assert.Equal(t, "Tunjangan Transport", resp.NewComponents[0].ComponentName)
+		}
+	})
+
+	t.Run("amount threshold flags small percentage change", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		resp, err := deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{
+			Period:          "2026-02",
+			ThresholdAmount: 500000,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.FlaggedCount)
+	})
+
+	t.Run("run scope uses run period and department", func(t *testing.T) {
+		deps := setup(t)
+		defer deps.db.Close()
+
+		runID := uuid.New()
+		departmentID := uuid.New()
+		deps.repo.findRunByIDAndCompanyFn = func(ctx context.Context, cid string, id string) (*payroll.PayrollRun, error) {
+			return &payroll.PayrollRun{ID: runID, DepartmentID: &departmentID, PeriodStart: febStart, PeriodEnd: febEnd}, nil
+		}
+		deps.repo.findByRunFn = func(ctx context.Context, cid string, id string) ([]payroll.Payroll, error) {
+			assert.Equal(t, runID.String(), id)
+			return []payroll.Payroll{andiFeb}, nil
+		}
+		allByCompany := deps.repo.findAllByCompanyFn
+		deps.repo.findAllByCompanyFn = func(ctx context.Context, cid string, filter payroll.PayrollQueryFilter) ([]payroll.Payroll, error) {
+			if assert.NotNil(t, filter.DepartmentID) {
+				assert.Equal(t, departmentID.String(), *filter.DepartmentID)
+			}
+			return allByCompany(ctx, cid, filter)
+		}
+
+		resp, err := deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{RunID: runID.String()})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, resp.RunID) {
+			assert.Equal(t, runID.String(), *resp.RunID)
+		}
+		assert.Equal(t, 1, resp.Totals.CurrentEmployees)
+		assert.Len(t, resp.MissingEmployees, 2)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+
+		_, err := deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidVarianceRequest)
+
+		_, err = deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{
+			Period: "2026-02",
+			RunID:  uuid.New().String(),
+		})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidVarianceRequest)
+
+		_, err = deps.service.GetVarianceReport(ctx, companyID, payroll.PayrollVarianceFilterRequest{
+			Period:        "2026-02",
+			ComparePeriod: "2026-02",
+		})
+		assert.ErrorIs(t, err, payrollerrors.ErrInvalidVarianceRequest)
+	})
+}
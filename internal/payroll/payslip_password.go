@@ -0,0 +1,67 @@
+package payroll
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	payrollerrors "go-hris/internal/payroll/errors"
+)
+
+// Mode password PDF payslip, diatur per company lewat payroll settings.
+const (
+	PayslipPasswordNone                    = "NONE"
+	PayslipPasswordBirthDateEmployeeNumber = "BIRTH_DATE_EMPLOYEE_NUMBER" // DDMMYYYY + nomor karyawan
+	PayslipPasswordEmployeeNumber          = "EMPLOYEE_NUMBER"
+	PayslipPasswordBirthDate               = "BIRTH_DATE" // DDMMYYYY
+)
+
+func isValidPayslipPasswordMode(mode string) bool {
+	switch mode {
+	case PayslipPasswordNone, PayslipPasswordBirthDateEmployeeNumber, PayslipPasswordEmployeeNumber, PayslipPasswordBirthDate:
+		return true
+	}
+	return false
+}
+
+// payslipPassword menyusun password pembuka PDF untuk karyawan. String kosong berarti
+// payslip tidak dienkripsi. Data karyawan yang kurang membuat generate gagal agar
+// payslip tidak pernah tersimpan tanpa proteksi saat company mewajibkannya.
+func payslipPassword(mode string, profile PayslipProfile) (string, error) {
+	employeeNumber := strings.TrimSpace(profile.EmployeeNumber)
+	birthDate := ""
+	if profile.BirthDate != nil {
+		birthDate = profile.BirthDate.Format("02012006")
+	}
+
+	switch mode {
+	case "", PayslipPasswordNone:
+		return "", nil
+	case PayslipPasswordBirthDateEmployeeNumber:
+		if birthDate == "" || employeeNumber == "" {
+			return "", payrollerrors.ErrPayslipPasswordUnavailable
+		}
+		return birthDate + employeeNumber, nil
+	case PayslipPasswordEmployeeNumber:
+		if employeeNumber == "" {
+			return "", payrollerrors.ErrPayslipPasswordUnavailable
+		}
+		return employeeNumber, nil
+	case PayslipPasswordBirthDate:
+		if birthDate == "" {
+			return "", payrollerrors.ErrPayslipPasswordUnavailable
+		}
+		return birthDate, nil
+	}
+	return "", payrollerrors.ErrInvalidPayrollSetting
+}
+
+// randomOwnerPassword dipakai sebagai owner password PDF supaya pembatasan hak akses
+// (cetak saja) tidak bisa dilepas dengan password karyawan.
+func randomOwnerPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,120 @@
+package payroll_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+	payrollerrors "go-hris/internal/payroll/errors"
+	"go-hris/internal/shared/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_GeneratePayslip_Password(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	birthDate := time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC)
+
+	newPayroll := func() *payroll.Payroll {
+		return &payroll.Payroll{
+			ID:          uuid.New(),
+			CompanyID:   uuid.MustParse(companyID),
+			EmployeeID:  uuid.New(),
+			PeriodStart: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+			Status:      payroll.StatusApproved,
+			BaseSalary:  5000000,
+			NetSalary:   5000000,
+		}
+	}
+	withMode := func(mode string) func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+		return func(ctx context.Context, cid string) (*payroll.PayrollSetting, error) {
+			return &payroll.PayrollSetting{CompanyID: uuid.MustParse(cid), PayslipPasswordMode: mode}, nil
+		}
+	}
+
+	t.Run("encrypts pdf when company requires password", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		existing := newPayroll()
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return existing, nil
+		}
+		deps.repo.findSettingFn = withMode(payroll.PayslipPasswordBirthDateEmployeeNumber)
+		deps.repo.findPayslipProfileFn = func(ctx context.Context, cid, employeeID string) (payroll.PayslipProfile, error) {
+			return payroll.PayslipProfile{CompanyName: "PT Maju", EmployeeName: "Budi", EmployeeNumber: "EMP-000001", BirthDate: &birthDate}, nil
+		}
+
+		_, err := deps.service.GeneratePayslip(ctx, companyID, existing.ID.String())
+
+		assert.NoError(t, err)
+		content, err := deps.blobs.Get(ctx, "payslips/payslip_"+existing.ID.String()+".pdf")
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "/Encrypt")
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("missing birth date fails instead of writing unprotected pdf", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, false)
+
+		existing := newPayroll()
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return existing, nil
+		}
+		deps.repo.findSettingFn = withMode(payroll.PayslipPasswordBirthDate)
+		deps.repo.findPayslipProfileFn = func(ctx context.Context, cid, employeeID string) (payroll.PayslipProfile, error) {
+			return payroll.PayslipProfile{CompanyName: "PT Maju", EmployeeName: "Budi", EmployeeNumber: "EMP-000001"}, nil
+		}
+
+		_, err := deps.service.GeneratePayslip(ctx, companyID, existing.ID.String())
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayslipPasswordUnavailable)
+		_, getErr := deps.blobs.Get(ctx, "payslips/payslip_"+existing.ID.String()+".pdf")
+		assert.ErrorIs(t, getErr, storage.ErrNotFound)
+	})
+}
+
+func TestPayrollService_GetPayslipDownload(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+	employeeID := uuid.New()
+	payrollID := uuid.New()
+	payslipURL := "/api/v1/payrolls/" + payrollID.String() + "/payslip/download"
+
+	deps := setupPayrollServiceTest(t)
+	defer deps.db.Close()
+	deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+		return &payroll.Payroll{ID: payrollID, EmployeeID: employeeID, PayslipURL: &payslipURL}, nil
+	}
+
+	t.Run("owner gets expiring signed url", func(t *testing.T) {
+		download, err := deps.service.GetPayslipDownload(ctx, companyID, employeeID.String(), payrollID.String(), false)
+
+		assert.NoError(t, err)
+		parsed, parseErr := url.Parse(download.URL)
+		assert.NoError(t, parseErr)
+		assert.Equal(t, "/files/payslips/payslip_"+payrollID.String()+".pdf", parsed.Path)
+		assert.NoError(t, deps.blobs.Verify("payslips/payslip_"+payrollID.String()+".pdf", parsed.Query().Get("expires"), parsed.Query().Get("signature")))
+		assert.NotEmpty(t, download.ExpiresAt)
+	})
+
+	t.Run("other employee without payroll read is forbidden", func(t *testing.T) {
+		_, err := deps.service.GetPayslipDownload(ctx, companyID, uuid.New().String(), payrollID.String(), false)
+
+		assert.ErrorIs(t, err, payrollerrors.ErrPayslipForbidden)
+	})
+
+	t.Run("payroll reader can download any payslip", func(t *testing.T) {
+		_, err := deps.service.GetPayslipDownload(ctx, companyID, uuid.New().String(), payrollID.String(), true)
+
+		assert.NoError(t, err)
+	})
+}
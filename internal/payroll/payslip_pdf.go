@@ -2,58 +2,468 @@ package payroll
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
+	"image"
+	_ "image/jpeg" // registrasi decoder untuk validasi logo
+	_ "image/png"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
 )
 
-func buildSimplePayslipPDF(lines []string) ([]byte, error) {
-	if len(lines) == 0 {
-		lines = []string{"Payslip"}
+// PayslipProfile adalah data company dan karyawan untuk header payslip.
+type PayslipProfile struct {
+	CompanyName    string
+	CompanyLogo    *string // Data URI PNG/JPEG
+	EmployeeName   string
+	EmployeeNumber string
+	DepartmentName *string
+	PositionName   *string
+	PTKPStatus     string `gorm:"column:ptkp_status"`
+	BirthDate      *time.Time
+}
+
+// PayslipYearToDate adalah akumulasi payroll sejak awal tahun sampai periode payslip.
+type PayslipYearToDate struct {
+	GrossIncome         int64
+	Deduction           int64
+	TaxAmount           int64
+	PensionContribution int64
+	NetSalary           int64
+	PayrollCount        int64
+}
+
+type payslipLine struct {
+	Label  string
+	Detail string
+	Amount int64
+}
+
+type payslipDocument struct {
+	PayrollID   string
+	Title       string
+	PayrollType string
+	Profile     PayslipProfile
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Status      string
+	Notes       []string
+
+	Earnings       []payslipLine
+	Deductions     []payslipLine
+	EmployerCosts  []payslipLine
+	TotalEarnings  int64
+	TotalDeduction int64
+	NetSalary      int64
+
+	YearToDate  PayslipYearToDate
+	GeneratedAt time.Time
+}
+
+// buildPayslipDocument menyusun isi payslip dari breakdown payroll, sehingga angka di
+// PDF selalu sama dengan endpoint breakdown.
+func buildPayslipDocument(payroll Payroll, profile PayslipProfile, ytd PayslipYearToDate, generatedAt time.Time) payslipDocument {
+	breakdown := mapToBreakdownResponse(payroll)
+
+	doc := payslipDocument{
+		PayrollID:   payroll.ID.String(),
+		Title:       payslipTitle(payroll.PayrollType),
+		PayrollType: payrollTypeOrRegular(payroll.PayrollType),
+		Profile:     profile,
+		PeriodStart: payroll.PeriodStart,
+		PeriodEnd:   payroll.PeriodEnd,
+		Status:      payroll.Status,
+		Notes:       payslipReversalLines(payroll),
+		YearToDate:  ytd,
+		GeneratedAt: generatedAt,
+	}
+	if payroll.BaseSalaryNote != nil && *payroll.BaseSalaryNote != "" {
+		doc.Notes = append(doc.Notes, *payroll.BaseSalaryNote)
+	}
+
+	// Payslip off-cycle (THR, bonus, koreksi) tidak memuat baris gaji pokok.
+	if !payroll.IsOffCycle() {
+		doc.Earnings = append(doc.Earnings, payslipLine{Label: breakdown.BaseSalary.Label, Amount: breakdown.BaseSalary.Amount})
+	}
+	if payroll.ReferenceDate != nil {
+		doc.Notes = append(doc.Notes, "Tanggal hari raya: "+payroll.ReferenceDate.Format("2006-01-02"))
+	}
+	for _, line := range breakdown.Allowances {
+		doc.Earnings = append(doc.Earnings, toPayslipLine(line))
+	}
+	if payroll.OvertimeAmount != 0 {
+		doc.Earnings = append(doc.Earnings, payslipLine{
+			Label:  "Overtime",
+			Detail: fmt.Sprintf("%d h x %s", payroll.OvertimeHours, formatRupiah(payroll.OvertimeRate)),
+			Amount: payroll.OvertimeAmount,
+		})
+	}
+	for _, line := range breakdown.Deductions {
+		doc.Deductions = append(doc.Deductions, toPayslipLine(line))
+	}
+	for _, line := range breakdown.EmployerCosts {
+		doc.EmployerCosts = append(doc.EmployerCosts, toPayslipLine(line))
+	}
+
+	doc.TotalEarnings = payroll.BaseSalary + payroll.Allowance + payroll.OvertimeAmount
+	doc.TotalDeduction = payroll.Deduction
+	doc.NetSalary = payroll.NetSalary
+	return doc
+}
+
+// payslipTitle memilih judul template payslip sesuai jenis payroll.
+func payslipTitle(payrollType string) string {
+	switch payrollType {
+	case PayrollTypeTHR:
+		return "Slip THR / Religious Holiday Allowance"
+	case PayrollTypeBonus:
+		return "Slip Bonus / Bonus Slip"
+	case PayrollTypeCorrection:
+		return "Slip Koreksi Gaji / Payroll Correction"
+	}
+	return "Slip Gaji / Payslip"
+}
+
+func toPayslipLine(line PayrollBreakdownLine) payslipLine {
+	out := payslipLine{Label: line.Label, Amount: line.Amount}
+	if line.Quantity != nil && line.UnitAmount != nil && *line.Quantity > 1 {
+		out.Detail = fmt.Sprintf("%d x %s", *line.Quantity, formatRupiah(*line.UnitAmount))
+	}
+	return out
+}
+
+const (
+	payslipFont      = "GoSans"
+	payslipMargin    = 15.0
+	payslipRowHeight = 6.5
+	payslipLogoName  = "company-logo"
+)
+
+var (
+	payslipAccent    = [3]int{31, 78, 121}
+	payslipHeaderRow = [3]int{225, 234, 243}
+	payslipStripe    = [3]int{246, 248, 250}
+)
+
+// renderPayslipPDF menghasilkan payslip A4 dengan font Go (TrueType, tertanam sebagai
+// subset UTF-8) sehingga nama dan keterangan berbahasa Indonesia tampil benar tanpa
+// bergantung pada font sistem atau binary eksternal. Tabel yang melewati batas halaman
+// dilanjutkan ke halaman berikutnya beserta judul kolomnya. Jika password diisi, PDF
+// dienkripsi dan hanya bisa dibuka dengan password tersebut.
+func renderPayslipPDF(doc payslipDocument, password string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	if password != "" {
+		ownerPassword, err := randomOwnerPassword()
+		if err != nil {
+			return nil, err
+		}
+		pdf.SetProtection(fpdf.CnProtectPrint, password, ownerPassword)
+	}
+	pdf.SetCreationDate(doc.GeneratedAt)
+	pdf.SetModificationDate(doc.GeneratedAt)
+	pdf.SetTitle(fmt.Sprintf("Payslip %s %s", doc.Profile.EmployeeName, doc.PeriodStart.Format("2006-01")), true)
+	pdf.SetAuthor(doc.Profile.CompanyName, true)
+	pdf.SetCreator("go-hris", false)
+	pdf.AddUTF8FontFromBytes(payslipFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(payslipFont, "B", gobold.TTF)
+	pdf.SetMargins(payslipMargin, payslipMargin, payslipMargin)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("")
+
+	hasLogo := registerPayslipLogo(pdf, doc.Profile.CompanyLogo)
+	pdf.SetHeaderFuncMode(func() { drawPayslipHeader(pdf, doc, hasLogo) }, true)
+	pdf.SetFooterFunc(func() { drawPayslipFooter(pdf, doc) })
+
+	pdf.AddPage()
+	drawEmployeeBlock(pdf, doc)
+	drawNotes(pdf, doc.Notes)
+
+	drawAmountTable(pdf, "Earnings", doc.Earnings, "Total Earnings", doc.TotalEarnings)
+	drawAmountTable(pdf, "Deductions", doc.Deductions, "Total Deductions", doc.TotalDeduction)
+	drawNetSalary(pdf, doc.NetSalary)
+	if len(doc.EmployerCosts) > 0 {
+		var total int64
+		for _, line := range doc.EmployerCosts {
+			total += line.Amount
+		}
+		drawAmountTable(pdf, "Employer Contributions (not deducted from salary)", doc.EmployerCosts, "Total Employer Contributions", total)
+	}
+	drawYearToDate(pdf, doc)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// registerPayslipLogo memasang logo company jika berupa data URI PNG/JPEG yang valid.
+// Logo yang rusak dilewati agar payslip tetap bisa dibuat.
+func registerPayslipLogo(pdf *fpdf.Fpdf, logo *string) bool {
+	if logo == nil {
+		return false
+	}
+	var imageType, encoded string
+	switch {
+	case strings.HasPrefix(*logo, "data:image/png;base64,"):
+		imageType, encoded = "PNG", strings.TrimPrefix(*logo, "data:image/png;base64,")
+	case strings.HasPrefix(*logo, "data:image/jpeg;base64,"):
+		imageType, encoded = "JPG", strings.TrimPrefix(*logo, "data:image/jpeg;base64,")
+	default:
+		return false
+	}
+	content, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return false
 	}
+	if _, _, err := image.DecodeConfig(bytes.NewReader(content)); err != nil {
+		return false
+	}
+
+	pdf.RegisterImageOptionsReader(payslipLogoName, fpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(content))
+	if pdf.Err() {
+		pdf.ClearError()
+		return false
+	}
+	return true
+}
+
+func drawPayslipHeader(pdf *fpdf.Fpdf, doc payslipDocument, hasLogo bool) {
+	pageWidth, _ := pdf.GetPageSize()
+	top := payslipMargin
+	textX := payslipMargin
+	if hasLogo {
+		pdf.ImageOptions(payslipLogoName, payslipMargin, top, 0, 16, false, fpdf.ImageOptions{}, 0, "")
+		textX = payslipMargin + 40
+	}
+
+	pdf.SetXY(textX, top)
+	pdf.SetFont(payslipFont, "B", 15)
+	pdf.SetTextColor(payslipAccent[0], payslipAccent[1], payslipAccent[2])
+	pdf.CellFormat(pageWidth-payslipMargin-textX, 8, doc.Profile.CompanyName, "", 2, "L", false, 0, "")
+	pdf.SetFont(payslipFont, "", 10)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.CellFormat(pageWidth-payslipMargin-textX, 5, doc.Title, "", 2, "L", false, 0, "")
+	pdf.CellFormat(pageWidth-payslipMargin-textX, 5, formatPayslipPeriod(doc.PeriodStart, doc.PeriodEnd), "", 2, "L", false, 0, "")
+
+	lineY := top + 20
+	pdf.SetDrawColor(payslipAccent[0], payslipAccent[1], payslipAccent[2])
+	pdf.SetLineWidth(0.6)
+	pdf.Line(payslipMargin, lineY, pageWidth-payslipMargin, lineY)
+	pdf.SetLineWidth(0.2)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetXY(payslipMargin, lineY+4)
+}
+
+func drawPayslipFooter(pdf *fpdf.Fpdf, doc payslipDocument) {
+	pageWidth, _ := pdf.GetPageSize()
+	width := (pageWidth - 2*payslipMargin) / 2
+	pdf.SetY(-14)
+	pdf.SetFont(payslipFont, "", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(width, 5, fmt.Sprintf("Payroll %s · generated %s UTC", doc.PayrollID, doc.GeneratedAt.UTC().Format("2006-01-02 15:04")), "", 0, "L", false, 0, "")
+	pdf.CellFormat(width, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+func drawEmployeeBlock(pdf *fpdf.Fpdf, doc payslipDocument) {
+	pageWidth, _ := pdf.GetPageSize()
+	half := (pageWidth - 2*payslipMargin) / 2
+	labelWidth := 32.0
+
+	left := [][2]string{
+		{"Employee", doc.Profile.EmployeeName},
+		{"Employee No.", doc.Profile.EmployeeNumber},
+		{"Department", stringOrDash(doc.Profile.DepartmentName)},
+		{"Position", stringOrDash(doc.Profile.PositionName)},
+	}
+	right := [][2]string{
+		{"Period", formatPayslipPeriod(doc.PeriodStart, doc.PeriodEnd)},
+		{"Payroll Type", doc.PayrollType},
+		{"Status", doc.Status},
+		{"Tax Status", doc.Profile.PTKPStatus},
+	}
+
+	for i := 0; i < len(left) || i < len(right); i++ {
+		for col, rows := range [][][2]string{left, right} {
+			x := payslipMargin + float64(col)*half
+			pdf.SetX(x)
+			if i >= len(rows) {
+				pdf.CellFormat(half, payslipRowHeight, "", "", 0, "L", false, 0, "")
+				continue
+			}
+			pdf.SetFont(payslipFont, "", 9)
+			pdf.SetTextColor(100, 100, 100)
+			pdf.CellFormat(labelWidth, payslipRowHeight, rows[i][0], "", 0, "L", false, 0, "")
+			pdf.SetFont(payslipFont, "B", 10)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.CellFormat(half-labelWidth, payslipRowHeight, fitText(pdf, rows[i][1], half-labelWidth), "", 0, "L", false, 0, "")
+		}
+		pdf.Ln(payslipRowHeight)
+	}
+	pdf.Ln(3)
+}
+
+func drawNotes(pdf *fpdf.Fpdf, notes []string) {
+	if len(notes) == 0 {
+		return
+	}
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetFont(payslipFont, "", 9)
+	pdf.SetFillColor(255, 244, 229)
+	for _, note := range notes {
+		pdf.MultiCell(pageWidth-2*payslipMargin, 5, note, "", "L", true)
+	}
+	pdf.Ln(3)
+}
+
+// drawAmountTable menggambar tabel keterangan/rincian/nominal. Jika baris berikutnya
+// tidak muat, halaman baru dibuat dan judul kolom diulang.
+func drawAmountTable(pdf *fpdf.Fpdf, title string, lines []payslipLine, totalLabel string, total int64) {
+	pageWidth, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	tableWidth := pageWidth - 2*payslipMargin
+	amountWidth := 40.0
+	detailWidth := 45.0
+	labelWidth := tableWidth - amountWidth - detailWidth
 
-	var content strings.Builder
-	content.WriteString("BT\n/F1 12 Tf\n50 800 Td\n")
+	ensureSpace := func(height float64) bool {
+		if pdf.GetY()+height > pageHeight-bottom {
+			pdf.AddPage()
+			return true
+		}
+		return false
+	}
+	drawColumns := func(continued bool) {
+		heading := title
+		if continued {
+			heading += " (continued)"
+		}
+		pdf.SetFont(payslipFont, "B", 11)
+		pdf.SetTextColor(payslipAccent[0], payslipAccent[1], payslipAccent[2])
+		pdf.CellFormat(tableWidth, 7, heading, "", 1, "L", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont(payslipFont, "B", 9)
+		pdf.SetFillColor(payslipHeaderRow[0], payslipHeaderRow[1], payslipHeaderRow[2])
+		pdf.CellFormat(labelWidth, payslipRowHeight, "Description", "B", 0, "L", true, 0, "")
+		pdf.CellFormat(detailWidth, payslipRowHeight, "Detail", "B", 0, "L", true, 0, "")
+		pdf.CellFormat(amountWidth, payslipRowHeight, "Amount", "B", 1, "R", true, 0, "")
+	}
+
+	// Judul tabel tidak boleh sendirian di bawah halaman.
+	ensureSpace(7 + 2*payslipRowHeight)
+	drawColumns(false)
+
+	if len(lines) == 0 {
+		lines = []payslipLine{{Label: "-"}}
+	}
 	for i, line := range lines {
-		escaped := pdfEscape(line)
-		if i == 0 {
-			content.WriteString(fmt.Sprintf("(%s) Tj\n", escaped))
-			continue
+		if ensureSpace(payslipRowHeight) {
+			drawColumns(true)
+		}
+		pdf.SetFont(payslipFont, "", 9)
+		fill := i%2 == 1
+		pdf.SetFillColor(payslipStripe[0], payslipStripe[1], payslipStripe[2])
+		pdf.CellFormat(labelWidth, payslipRowHeight, fitText(pdf, line.Label, labelWidth), "", 0, "L", fill, 0, "")
+		pdf.CellFormat(detailWidth, payslipRowHeight, fitText(pdf, line.Detail, detailWidth), "", 0, "L", fill, 0, "")
+		amount := ""
+		if line.Label != "-" {
+			amount = formatRupiah(line.Amount)
 		}
-		content.WriteString(fmt.Sprintf("T* (%s) Tj\n", escaped))
+		pdf.CellFormat(amountWidth, payslipRowHeight, amount, "", 1, "R", fill, 0, "")
 	}
-	content.WriteString("ET")
 
-	stream := content.String()
-	objects := []string{
-		"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n",
-		"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n",
-		"3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>\nendobj\n",
-		"4 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n",
-		fmt.Sprintf("5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(stream), stream),
+	ensureSpace(payslipRowHeight)
+	pdf.SetFont(payslipFont, "B", 9)
+	pdf.CellFormat(labelWidth+detailWidth, payslipRowHeight, totalLabel, "T", 0, "L", false, 0, "")
+	pdf.CellFormat(amountWidth, payslipRowHeight, formatRupiah(total), "T", 1, "R", false, 0, "")
+	pdf.Ln(4)
+}
+
+func drawNetSalary(pdf *fpdf.Fpdf, netSalary int64) {
+	pageWidth, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	if pdf.GetY()+12 > pageHeight-bottom {
+		pdf.AddPage()
 	}
+	tableWidth := pageWidth - 2*payslipMargin
+	pdf.SetFillColor(payslipAccent[0], payslipAccent[1], payslipAccent[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont(payslipFont, "B", 12)
+	pdf.CellFormat(tableWidth-60, 10, "  Net Salary (Take Home Pay)", "", 0, "L", true, 0, "")
+	pdf.CellFormat(60, 10, formatRupiah(netSalary)+"  ", "", 1, "R", true, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(6)
+}
 
-	var out bytes.Buffer
-	out.WriteString("%PDF-1.4\n")
-	offsets := make([]int, 0, len(objects)+1)
-	offsets = append(offsets, 0)
+func drawYearToDate(pdf *fpdf.Fpdf, doc payslipDocument) {
+	ytd := doc.YearToDate
+	lines := []payslipLine{
+		{Label: "Gross Income", Amount: ytd.GrossIncome},
+		{Label: "Total Deductions", Amount: ytd.Deduction},
+		{Label: "Income Tax (PPh 21)", Amount: ytd.TaxAmount},
+		{Label: "Pension Contribution (JHT + JP)", Amount: ytd.PensionContribution},
+	}
+	title := fmt.Sprintf("Year to Date %d (%d payroll)", doc.PeriodEnd.Year(), ytd.PayrollCount)
+	drawAmountTable(pdf, title, lines, "Net Salary Year to Date", ytd.NetSalary)
+}
 
-	for _, obj := range objects {
-		offsets = append(offsets, out.Len())
-		out.WriteString(obj)
+// fitText memotong teks dengan elipsis agar muat di lebar sel.
+func fitText(pdf *fpdf.Fpdf, text string, width float64) string {
+	limit := width - 2
+	if pdf.GetStringWidth(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > limit {
+		runes = runes[:len(runes)-1]
 	}
+	return string(runes) + "…"
+}
 
-	xrefStart := out.Len()
-	out.WriteString(fmt.Sprintf("xref\n0 %d\n", len(offsets)))
-	out.WriteString("0000000000 65535 f \n")
-	for i := 1; i < len(offsets); i++ {
-		out.WriteString(fmt.Sprintf("%010d 00000 n \n", offsets[i]))
+func stringOrDash(v *string) string {
+	if v == nil || strings.TrimSpace(*v) == "" {
+		return "-"
 	}
-	out.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF", len(offsets), xrefStart))
+	return *v
+}
+
+var indonesianMonths = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
 
-	return out.Bytes(), nil
+// formatPayslipPeriod menampilkan periode dengan nama bulan Indonesia, mis. "1 - 31 Maret 2026".
+func formatPayslipPeriod(start, end time.Time) string {
+	formatDate := func(t time.Time) string {
+		return fmt.Sprintf("%d %s %d", t.Day(), indonesianMonths[t.Month()-1], t.Year())
+	}
+	if start.Year() == end.Year() && start.Month() == end.Month() {
+		return fmt.Sprintf("%d - %s", start.Day(), formatDate(end))
+	}
+	return formatDate(start) + " - " + formatDate(end)
 }
 
-func pdfEscape(v string) string {
-	replacer := strings.NewReplacer("\\", "\\\\", "(", "\\(", ")", "\\)")
-	return replacer.Replace(v)
+// formatRupiah memformat nominal dengan pemisah ribuan titik, mis. "Rp 10.500.000".
+func formatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(r)
+	}
+	return sign + "Rp " + b.String()
 }
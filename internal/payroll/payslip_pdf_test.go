@@ -0,0 +1,146 @@
+package payroll_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-hris/internal/payroll"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayrollService_GeneratePayslip_BrandedPDF(t *testing.T) {
+	ctx := context.Background()
+	companyID := uuid.New().String()
+
+	t.Run("renders branded multi-page payslip with year to date", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		components := make([]payroll.PayrollComponent, 0, 60)
+		var allowance int64
+		for i := 1; i <= 60; i++ {
+			components = append(components, payroll.PayrollComponent{
+				ComponentType: payroll.ComponentTypeAllowance,
+				ComponentName: fmt.Sprintf("Tunjangan Proyek %02d", i),
+				Quantity:      1,
+				UnitAmount:    100000,
+				TotalAmount:   100000,
+			})
+			allowance += 100000
+		}
+		existing := &payroll.Payroll{
+			ID:          uuid.New(),
+			CompanyID:   uuid.MustParse(companyID),
+			EmployeeID:  uuid.New(),
+			PeriodStart: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+			Status:      payroll.StatusApproved,
+			BaseSalary:  10000000,
+			Allowance:   allowance,
+			GrossIncome: 10000000 + allowance,
+			TaxAmount:   500000,
+			Deduction:   500000,
+			NetSalary:   9500000 + allowance,
+			Components:  components,
+		}
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return existing, nil
+		}
+		department := "Keuangan & Akuntansi"
+		deps.repo.findPayslipProfileFn = func(ctx context.Context, cid, employeeID string) (payroll.PayslipProfile, error) {
+			assert.Equal(t, existing.EmployeeID.String(), employeeID)
+			logo := testLogoDataURI(t)
+			return payroll.PayslipProfile{
+				CompanyName:    "PT Maju Sejahtera",
+				CompanyLogo:    &logo,
+				EmployeeName:   "Siti Nurhaliza Ayu Pramesti",
+				EmployeeNumber: "EMP-0001",
+				DepartmentName: &department,
+				PTKPStatus:     "K/1",
+			}, nil
+		}
+		var ytdStart, ytdEnd time.Time
+		deps.repo.findPayslipYearToDateFn = func(ctx context.Context, cid, employeeID string, yearStart, periodEnd time.Time, excludePayrollID string) (payroll.PayslipYearToDate, error) {
+			ytdStart, ytdEnd = yearStart, periodEnd
+			assert.Equal(t, existing.ID.String(), excludePayrollID)
+			return payroll.PayslipYearToDate{GrossIncome: 30000000, NetSalary: 28000000, PayrollCount: 2}, nil
+		}
+		var updated *payroll.Payroll
+		deps.repo.updateFn = func(ctx context.Context, p *payroll.Payroll) error {
+			updated = p
+			return nil
+		}
+
+		resp, err := deps.service.GeneratePayslip(ctx, companyID, existing.ID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), ytdStart)
+		assert.Equal(t, existing.PeriodEnd, ytdEnd)
+		if assert.NotNil(t, resp.PayslipURL) {
+			assert.Equal(t, "/api/v1/payrolls/"+existing.ID.String()+"/payslip/download", *resp.PayslipURL)
+		}
+		assert.NotNil(t, updated)
+
+		content, err := deps.blobs.Get(ctx, "payslips/payslip_"+existing.ID.String()+".pdf")
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+		assert.Contains(t, string(content), "/FontFile2", "font TrueType harus tertanam")
+		assert.Contains(t, string(content), "/Subtype /Image", "logo company harus tertanam")
+		assert.NotContains(t, string(content), "/Encrypt", "tanpa payslip_password_mode PDF tidak dienkripsi")
+		pages := regexp.MustCompile(`/Type /Page\b`).FindAll(content, -1)
+		assert.GreaterOrEqual(t, len(pages), 2, "komponen yang banyak harus berlanjut ke halaman berikutnya")
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid logo is skipped", func(t *testing.T) {
+		deps := setupPayrollServiceTest(t)
+		defer deps.db.Close()
+		expectTx(t, deps.sqlMock, true)
+
+		deps.repo.findByIDAndCompanyFn = func(ctx context.Context, cid, id string) (*payroll.Payroll, error) {
+			return &payroll.Payroll{
+				ID:          uuid.New(),
+				EmployeeID:  uuid.New(),
+				PeriodStart: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+				PeriodEnd:   time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+				Status:      payroll.StatusApproved,
+				BaseSalary:  5000000,
+				NetSalary:   5000000,
+			}, nil
+		}
+		deps.repo.findPayslipProfileFn = func(ctx context.Context, cid, employeeID string) (payroll.PayslipProfile, error) {
+			logo := "data:image/png;base64,bm90LWEtcG5n"
+			return payroll.PayslipProfile{CompanyName: "PT Maju", CompanyLogo: &logo, EmployeeName: "Budi"}, nil
+		}
+
+		resp, err := deps.service.GeneratePayslip(ctx, companyID, uuid.New().String())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.PayslipURL)
+		assert.NoError(t, deps.sqlMock.ExpectationsWereMet())
+	})
+}
+
+func testLogoDataURI(t *testing.T) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, color.RGBA{R: 31, G: 78, B: 121, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
@@ -44,3 +44,25 @@ func Wrap(err error, code, message string, httpStatus int) *AppError {
 		Err:        err,
 	}
 }
+
+// WithDetails menempelkan detail (mis. ID data yang bermasalah) ke error tanpa mengubah
+// sentinel aslinya, sehingga errors.Is tetap cocok dan ToHTTP mengisi field details.
+func WithDetails(err error, details any) error {
+	if err == nil {
+		return nil
+	}
+	return &detailedError{err: err, details: details}
+}
+
+type detailedError struct {
+	err     error
+	details any
+}
+
+func (e *detailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *detailedError) Unwrap() error {
+	return e.err
+}
@@ -16,11 +16,16 @@ type HTTPError struct {
 func ToHTTP(err error) *HTTPError {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
+		var details any
+		var detailed *detailedError
+		if errors.As(err, &detailed) {
+			details = detailed.details
+		}
 		return &HTTPError{
 			Status:  appErr.HTTPStatus,
 			Code:    appErr.Code,
 			Message: appErr.Message,
-			Details: nil,
+			Details: details,
 		}
 	}
 
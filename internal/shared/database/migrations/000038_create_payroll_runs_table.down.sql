@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_payrolls_run_id;
+
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS run_id;
+
+DROP INDEX IF EXISTS idx_payroll_run_failures_run_id;
+DROP TABLE IF EXISTS payroll_run_failures;
+
+DROP INDEX IF EXISTS idx_payroll_runs_company_period;
+DROP TABLE IF EXISTS payroll_runs;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS payroll_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    department_id UUID,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    total_employees INT NOT NULL DEFAULT 0,
+    success_count INT NOT NULL DEFAULT 0,
+    failed_count INT NOT NULL DEFAULT 0,
+    total_net_salary BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT, APPROVED, PAID
+    created_by UUID NOT NULL,
+    approved_by UUID,
+    approved_at TIMESTAMPTZ,
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_runs_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payroll_runs_department FOREIGN KEY (department_id) REFERENCES departments (id) ON DELETE SET NULL,
+    CONSTRAINT chk_payroll_runs_period CHECK (period_start <= period_end)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_runs_company_period ON payroll_runs (company_id, period_start, period_end);
+
+CREATE TABLE IF NOT EXISTS payroll_run_failures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL,
+    company_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    error_code VARCHAR(50) NOT NULL,
+    error_message TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_run_failures_run FOREIGN KEY (run_id) REFERENCES payroll_runs (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payroll_run_failures_employee FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_run_failures_run_id ON payroll_run_failures (run_id);
+
+-- Payroll yang dibuat lewat run menyimpan referensi ke run-nya
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS run_id UUID REFERENCES payroll_runs (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payrolls_run_id ON payrolls (run_id);
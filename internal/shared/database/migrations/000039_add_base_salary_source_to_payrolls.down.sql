@@ -0,0 +1,4 @@
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS base_salary_note,
+    DROP COLUMN IF EXISTS derived_base_salary,
+    DROP COLUMN IF EXISTS base_salary_override;
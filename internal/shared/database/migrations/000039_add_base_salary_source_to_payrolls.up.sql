@@ -0,0 +1,8 @@
+-- Gaji pokok payroll kini diturunkan dari employee_salaries; override manual dicatat
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS base_salary_override BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS derived_base_salary BIGINT,
+    ADD COLUMN IF NOT EXISTS base_salary_note TEXT;
+
+-- Payroll lama diinput manual oleh HR
+UPDATE payrolls SET base_salary_override = TRUE;
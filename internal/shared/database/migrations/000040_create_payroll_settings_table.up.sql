@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS payroll_settings (
+    company_id UUID PRIMARY KEY,
+    work_hours_per_day BIGINT NOT NULL DEFAULT 8,
+    work_days_per_week INT NOT NULL DEFAULT 5, -- 5 = Senin-Jumat, 6 = Senin-Sabtu, 7 = setiap hari
+    overtime_hourly_rate BIGINT NOT NULL DEFAULT 0,
+    absent_deduction_amount BIGINT NOT NULL DEFAULT 0,
+    late_deduction_amount BIGINT NOT NULL DEFAULT 0,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_settings_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT chk_payroll_settings_work_hours CHECK (work_hours_per_day BETWEEN 1 AND 24),
+    CONSTRAINT chk_payroll_settings_work_days CHECK (work_days_per_week BETWEEN 5 AND 7),
+    CONSTRAINT chk_payroll_settings_amounts CHECK (
+        overtime_hourly_rate >= 0
+        AND absent_deduction_amount >= 0
+        AND late_deduction_amount >= 0
+    )
+);
@@ -0,0 +1,9 @@
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS stale_reason,
+    DROP COLUMN IF EXISTS is_stale;
+
+DROP INDEX IF EXISTS idx_payroll_components_source;
+
+ALTER TABLE payroll_components
+    DROP COLUMN IF EXISTS source_id,
+    DROP COLUMN IF EXISTS source_type;
@@ -0,0 +1,11 @@
+-- Komponen otomatis (mis. potongan cuti tidak dibayar) menyimpan referensi ke sumbernya
+ALTER TABLE payroll_components
+    ADD COLUMN IF NOT EXISTS source_type VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS source_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_payroll_components_source ON payroll_components (source_type, source_id);
+
+-- Payroll DRAFT ditandai stale saat data sumber berubah setelah payroll dibuat
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS is_stale BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS stale_reason TEXT;
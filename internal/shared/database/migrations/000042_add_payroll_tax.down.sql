@@ -0,0 +1,17 @@
+ALTER TABLE payroll_settings
+    DROP COLUMN IF EXISTS tax_calculator;
+
+ALTER TABLE payroll_components
+    DROP COLUMN IF EXISTS metadata;
+
+DROP INDEX IF EXISTS idx_payrolls_employee_tax_year;
+
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS tax_amount,
+    DROP COLUMN IF EXISTS gross_income;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS chk_employees_ptkp_status;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS ptkp_status;
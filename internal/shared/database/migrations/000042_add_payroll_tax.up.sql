@@ -0,0 +1,21 @@
+-- Status PTKP karyawan untuk PPh 21 (TK/0-TK/3, K/0-K/3)
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS ptkp_status VARCHAR(5) NOT NULL DEFAULT 'TK/0';
+
+ALTER TABLE employees
+    ADD CONSTRAINT chk_employees_ptkp_status
+    CHECK (ptkp_status IN ('TK/0', 'TK/1', 'TK/2', 'TK/3', 'K/0', 'K/1', 'K/2', 'K/3'));
+
+-- Bruto dan pajak per payroll, dasar akumulasi year-to-date untuk true-up Desember
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS gross_income BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_amount BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_payrolls_employee_tax_year ON payrolls (company_id, employee_id, period_start);
+
+-- Input perhitungan komponen otomatis (mis. pajak) untuk audit
+ALTER TABLE payroll_components
+    ADD COLUMN IF NOT EXISTS metadata JSONB;
+
+ALTER TABLE payroll_settings
+    ADD COLUMN IF NOT EXISTS tax_calculator VARCHAR(30) NOT NULL DEFAULT 'PPH21';
@@ -0,0 +1,24 @@
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS employer_cost,
+    DROP COLUMN IF EXISTS pension_contribution;
+
+DELETE FROM payroll_components WHERE component_type = 'EMPLOYER_CONTRIBUTION';
+
+ALTER TABLE payroll_components
+    DROP CONSTRAINT IF EXISTS chk_payroll_components_type;
+
+ALTER TABLE payroll_components
+    ADD CONSTRAINT chk_payroll_components_type
+    CHECK (component_type IN ('ALLOWANCE', 'DEDUCTION'));
+
+ALTER TABLE payroll_settings
+    DROP COLUMN IF EXISTS kesehatan_wage_cap,
+    DROP COLUMN IF EXISTS kesehatan_employer_rate_bps,
+    DROP COLUMN IF EXISTS kesehatan_employee_rate_bps,
+    DROP COLUMN IF EXISTS jkm_employer_rate_bps,
+    DROP COLUMN IF EXISTS jkk_employer_rate_bps,
+    DROP COLUMN IF EXISTS jp_wage_cap,
+    DROP COLUMN IF EXISTS jp_employer_rate_bps,
+    DROP COLUMN IF EXISTS jp_employee_rate_bps,
+    DROP COLUMN IF EXISTS jht_employer_rate_bps,
+    DROP COLUMN IF EXISTS jht_employee_rate_bps;
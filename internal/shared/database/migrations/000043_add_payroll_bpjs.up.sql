@@ -0,0 +1,24 @@
+-- Tarif BPJS per company dalam basis poin (100 = 1%) beserta batas upah
+ALTER TABLE payroll_settings
+    ADD COLUMN IF NOT EXISTS jht_employee_rate_bps BIGINT NOT NULL DEFAULT 200,
+    ADD COLUMN IF NOT EXISTS jht_employer_rate_bps BIGINT NOT NULL DEFAULT 370,
+    ADD COLUMN IF NOT EXISTS jp_employee_rate_bps BIGINT NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS jp_employer_rate_bps BIGINT NOT NULL DEFAULT 200,
+    ADD COLUMN IF NOT EXISTS jp_wage_cap BIGINT NOT NULL DEFAULT 10547400,
+    ADD COLUMN IF NOT EXISTS jkk_employer_rate_bps BIGINT NOT NULL DEFAULT 24,
+    ADD COLUMN IF NOT EXISTS jkm_employer_rate_bps BIGINT NOT NULL DEFAULT 30,
+    ADD COLUMN IF NOT EXISTS kesehatan_employee_rate_bps BIGINT NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS kesehatan_employer_rate_bps BIGINT NOT NULL DEFAULT 400,
+    ADD COLUMN IF NOT EXISTS kesehatan_wage_cap BIGINT NOT NULL DEFAULT 12000000;
+
+-- Iuran pemberi kerja disimpan sebagai komponen EMPLOYER_CONTRIBUTION (di luar net salary)
+ALTER TABLE payroll_components
+    DROP CONSTRAINT IF EXISTS chk_payroll_components_type;
+
+ALTER TABLE payroll_components
+    ADD CONSTRAINT chk_payroll_components_type
+    CHECK (component_type IN ('ALLOWANCE', 'DEDUCTION', 'EMPLOYER_CONTRIBUTION'));
+
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS pension_contribution BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS employer_cost BIGINT NOT NULL DEFAULT 0;
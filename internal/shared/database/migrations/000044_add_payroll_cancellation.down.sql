@@ -0,0 +1,30 @@
+DELETE FROM role_permissions rp
+USING permissions p
+WHERE rp.permission_id = p.id
+  AND p.resource = 'payroll'
+  AND p.action = 'cancel';
+
+DELETE FROM permissions
+WHERE resource = 'payroll'
+  AND action = 'cancel';
+
+DROP INDEX IF EXISTS uq_payrolls_active_employee_period;
+
+-- Payroll penyesuaian dihapus agar constraint unik lama bisa dipasang kembali
+DELETE FROM payroll_components
+WHERE payroll_id IN (SELECT id FROM payrolls WHERE reversal_of_id IS NOT NULL);
+
+DELETE FROM payrolls
+WHERE reversal_of_id IS NOT NULL;
+
+ALTER TABLE payrolls
+    ADD CONSTRAINT unique_employee_payroll_period UNIQUE (employee_id, period_start, period_end);
+
+DROP INDEX IF EXISTS idx_payrolls_reversal_of_id;
+
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancel_reason,
+    DROP COLUMN IF EXISTS reversed_by_id,
+    DROP COLUMN IF EXISTS reversal_of_id;
@@ -0,0 +1,34 @@
+-- Pembatalan (APPROVED) dan reversal (PAID) payroll
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS reversal_of_id UUID REFERENCES payrolls(id),
+    ADD COLUMN IF NOT EXISTS reversed_by_id UUID REFERENCES payrolls(id),
+    ADD COLUMN IF NOT EXISTS cancel_reason TEXT,
+    ADD COLUMN IF NOT EXISTS cancelled_by UUID,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payrolls_reversal_of_id ON payrolls (reversal_of_id);
+
+-- Payroll penyesuaian berbagi periode dengan payroll asli, dan periode yang dibatalkan
+-- boleh di-generate ulang. Unik hanya untuk payroll aktif.
+ALTER TABLE payrolls
+    DROP CONSTRAINT IF EXISTS unique_employee_payroll_period;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payrolls_active_employee_period
+    ON payrolls (employee_id, period_start, period_end)
+    WHERE deleted_at IS NULL
+      AND reversal_of_id IS NULL
+      AND status NOT IN ('CANCELLED', 'REVERSED');
+
+INSERT INTO permissions (id, resource, action, label, category)
+VALUES (gen_random_uuid(), 'payroll', 'cancel', 'Batalkan Payroll', 'Payroll')
+ON CONFLICT (resource, action) DO UPDATE
+SET
+    label = EXCLUDED.label,
+    category = EXCLUDED.category;
+
+INSERT INTO role_permissions (role_id, permission_id, created_at)
+SELECT r.id, p.id, now()
+FROM roles r
+JOIN permissions p ON p.resource = 'payroll' AND p.action = 'cancel'
+WHERE UPPER(r.name) IN ('SUPERADMIN', 'OWNER', 'HR')
+ON CONFLICT DO NOTHING;
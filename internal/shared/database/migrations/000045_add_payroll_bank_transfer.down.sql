@@ -0,0 +1,13 @@
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS payment_reference;
+
+ALTER TABLE payroll_settings
+    DROP COLUMN IF EXISTS bank_company_code,
+    DROP COLUMN IF EXISTS debit_account_name,
+    DROP COLUMN IF EXISTS debit_account_number,
+    DROP COLUMN IF EXISTS debit_bank_code;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS bank_account_name,
+    DROP COLUMN IF EXISTS bank_account_number,
+    DROP COLUMN IF EXISTS bank_code;
@@ -0,0 +1,16 @@
+-- Rekening tujuan transfer gaji karyawan
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS bank_code VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS bank_account_number VARCHAR(34),
+    ADD COLUMN IF NOT EXISTS bank_account_name VARCHAR(150);
+
+-- Rekening sumber (debit) per company untuk file bulk transfer
+ALTER TABLE payroll_settings
+    ADD COLUMN IF NOT EXISTS debit_bank_code VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS debit_account_number VARCHAR(34) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS debit_account_name VARCHAR(150) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS bank_company_code VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Referensi transaksi bank saat payroll ditandai PAID dari file hasil transfer
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS payment_reference VARCHAR(100);
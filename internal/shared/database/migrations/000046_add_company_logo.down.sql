@@ -0,0 +1,2 @@
+ALTER TABLE companies
+    DROP COLUMN IF EXISTS logo;
@@ -0,0 +1,3 @@
+-- Logo company (data URI PNG/JPEG) untuk header payslip
+ALTER TABLE companies
+    ADD COLUMN IF NOT EXISTS logo TEXT;
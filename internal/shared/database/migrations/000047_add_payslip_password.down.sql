@@ -0,0 +1,5 @@
+ALTER TABLE payroll_settings
+    DROP COLUMN IF EXISTS payslip_password_mode;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS birth_date;
@@ -0,0 +1,7 @@
+-- Tanggal lahir karyawan, dipakai sebagai bagian password PDF payslip
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS birth_date DATE;
+
+-- Mode password payslip per company: NONE, BIRTH_DATE_EMPLOYEE_NUMBER, EMPLOYEE_NUMBER, BIRTH_DATE
+ALTER TABLE payroll_settings
+    ADD COLUMN IF NOT EXISTS payslip_password_mode VARCHAR(40) NOT NULL DEFAULT 'NONE';
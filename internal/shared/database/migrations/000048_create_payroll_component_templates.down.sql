@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS employee_payroll_components;
+
+DROP TABLE IF EXISTS payroll_component_templates;
@@ -0,0 +1,45 @@
+-- Master komponen gaji berulang per company (tunjangan transport, uang makan, iuran koperasi)
+CREATE TABLE IF NOT EXISTS payroll_component_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(120) NOT NULL,
+    component_type VARCHAR(20) NOT NULL, -- ALLOWANCE, DEDUCTION
+    calculation_type VARCHAR(30) NOT NULL, -- FIXED, PERCENT_OF_BASE, PER_ATTENDANCE_DAY
+    amount BIGINT NOT NULL DEFAULT 0,
+    rate_bps BIGINT NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID NOT NULL,
+    updated_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_component_templates_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT uq_payroll_component_templates_code UNIQUE (company_id, code),
+    CONSTRAINT chk_payroll_component_templates_type CHECK (component_type IN ('ALLOWANCE', 'DEDUCTION')),
+    CONSTRAINT chk_payroll_component_templates_calculation CHECK (calculation_type IN ('FIXED', 'PERCENT_OF_BASE', 'PER_ATTENDANCE_DAY'))
+);
+
+-- Assignment template ke karyawan dengan rentang berlaku, nilai boleh di-override per karyawan
+CREATE TABLE IF NOT EXISTS employee_payroll_components (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    template_id UUID NOT NULL,
+    amount BIGINT,
+    rate_bps BIGINT,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    notes TEXT,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_employee_payroll_components_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_payroll_components_employee FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_payroll_components_template FOREIGN KEY (template_id) REFERENCES payroll_component_templates (id) ON DELETE CASCADE,
+    CONSTRAINT chk_employee_payroll_components_range CHECK (effective_to IS NULL OR effective_from <= effective_to)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_payroll_components_employee
+    ON employee_payroll_components (company_id, employee_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_employee_payroll_components_template
+    ON employee_payroll_components (template_id);
@@ -0,0 +1,13 @@
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS proration_base_days,
+    DROP COLUMN IF EXISTS prorated_days,
+    DROP COLUMN IF EXISTS proration_method;
+
+ALTER TABLE payroll_component_templates
+    DROP COLUMN IF EXISTS prorate;
+
+ALTER TABLE payroll_settings
+    DROP COLUMN IF EXISTS proration_method;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS termination_date;
@@ -0,0 +1,17 @@
+-- Tanggal keluar karyawan (hari kerja terakhir), dipakai untuk prorata payroll
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS termination_date DATE;
+
+-- Metode prorata per company: WORKING_DAYS, CALENDAR_DAYS
+ALTER TABLE payroll_settings
+    ADD COLUMN IF NOT EXISTS proration_method VARCHAR(20) NOT NULL DEFAULT 'WORKING_DAYS';
+
+-- Komponen template yang ikut diprorata sesuai masa kerja karyawan
+ALTER TABLE payroll_component_templates
+    ADD COLUMN IF NOT EXISTS prorate BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Hasil prorata masa kerja per payroll, proration_method NULL berarti tidak diprorata
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS proration_method VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS prorated_days BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS proration_base_days BIGINT NOT NULL DEFAULT 0;
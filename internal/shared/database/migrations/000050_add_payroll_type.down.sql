@@ -0,0 +1,27 @@
+-- Rollback ditolak selama masih ada payroll/run off-cycle: menghapus THR, bonus atau koreksi
+-- akan menghilangkan data gaji yang sudah dibayar. Pindahkan atau hapus datanya secara manual dulu.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM payrolls WHERE payroll_type <> 'REGULAR')
+        OR EXISTS (SELECT 1 FROM payroll_runs WHERE payroll_type <> 'REGULAR') THEN
+        RAISE EXCEPTION 'cannot roll back payroll_type: non-REGULAR payrolls or payroll runs exist';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS uq_payrolls_active_employee_period;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payrolls_active_employee_period
+    ON payrolls (employee_id, period_start, period_end)
+    WHERE deleted_at IS NULL
+      AND reversal_of_id IS NULL
+      AND status NOT IN ('CANCELLED', 'REVERSED');
+
+DROP INDEX IF EXISTS idx_payrolls_company_payroll_type;
+
+ALTER TABLE payroll_runs
+    DROP COLUMN IF EXISTS reference_date,
+    DROP COLUMN IF EXISTS payroll_type;
+
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS reference_date,
+    DROP COLUMN IF EXISTS payroll_type;
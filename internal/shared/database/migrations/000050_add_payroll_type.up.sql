@@ -0,0 +1,22 @@
+-- Jenis payroll: REGULAR (gaji bulanan), THR, BONUS, CORRECTION
+-- reference_date adalah tanggal hari raya untuk perhitungan masa kerja THR
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS payroll_type VARCHAR(20) NOT NULL DEFAULT 'REGULAR',
+    ADD COLUMN IF NOT EXISTS reference_date DATE;
+
+ALTER TABLE payroll_runs
+    ADD COLUMN IF NOT EXISTS payroll_type VARCHAR(20) NOT NULL DEFAULT 'REGULAR',
+    ADD COLUMN IF NOT EXISTS reference_date DATE;
+
+CREATE INDEX IF NOT EXISTS idx_payrolls_company_payroll_type ON payrolls (company_id, payroll_type);
+
+-- Payroll off-cycle boleh berdampingan dengan payroll REGULAR di periode yang sama.
+-- REGULAR dan THR tetap unik per karyawan per periode; BONUS dan CORRECTION tidak dibatasi.
+DROP INDEX IF EXISTS uq_payrolls_active_employee_period;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payrolls_active_employee_period
+    ON payrolls (employee_id, period_start, period_end, payroll_type)
+    WHERE deleted_at IS NULL
+      AND reversal_of_id IS NULL
+      AND status NOT IN ('CANCELLED', 'REVERSED')
+      AND payroll_type IN ('REGULAR', 'THR');
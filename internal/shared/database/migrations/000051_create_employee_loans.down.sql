@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS employee_loan_repayments;
+
+DROP TABLE IF EXISTS employee_loans;
@@ -0,0 +1,53 @@
+-- Pinjaman dan kasbon karyawan, dikembalikan lewat potongan payroll REGULAR
+CREATE TABLE IF NOT EXISTS employee_loans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    loan_type VARCHAR(20) NOT NULL, -- LOAN, SALARY_ADVANCE
+    principal BIGINT NOT NULL,
+    installment_count BIGINT NOT NULL,
+    installment_amount BIGINT NOT NULL,
+    start_period DATE NOT NULL, -- Tanggal pertama bulan cicilan pertama
+    outstanding_balance BIGINT NOT NULL,
+    paid_installments BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE', -- ACTIVE, PAID_OFF
+    notes TEXT,
+    paid_off_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_employee_loans_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_loans_employee FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE,
+    CONSTRAINT chk_employee_loans_type CHECK (loan_type IN ('LOAN', 'SALARY_ADVANCE')),
+    CONSTRAINT chk_employee_loans_status CHECK (status IN ('ACTIVE', 'PAID_OFF')),
+    CONSTRAINT chk_employee_loans_amounts CHECK (principal > 0 AND installment_count > 0 AND outstanding_balance >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_loans_employee
+    ON employee_loans (company_id, employee_id, status);
+
+-- Riwayat pengurangan sisa pinjaman: potongan payroll (PAYROLL) atau pelunasan dipercepat (PAYOFF).
+-- Potongan dari payroll yang dihapus, dibatalkan, atau dibalik ditandai reversed_at.
+CREATE TABLE IF NOT EXISTS employee_loan_repayments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    loan_id UUID NOT NULL,
+    payroll_id UUID,
+    repayment_type VARCHAR(20) NOT NULL, -- PAYROLL, PAYOFF
+    amount BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    paid_on DATE NOT NULL,
+    notes TEXT,
+    reversed_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_employee_loan_repayments_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_loan_repayments_loan FOREIGN KEY (loan_id) REFERENCES employee_loans (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_loan_repayments_payroll FOREIGN KEY (payroll_id) REFERENCES payrolls (id) ON DELETE SET NULL,
+    CONSTRAINT chk_employee_loan_repayments_type CHECK (repayment_type IN ('PAYROLL', 'PAYOFF'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_loan_repayments_loan
+    ON employee_loan_repayments (loan_id);
+CREATE INDEX IF NOT EXISTS idx_employee_loan_repayments_payroll
+    ON employee_loan_repayments (payroll_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payrolls_company_period_status;
+DROP TABLE IF EXISTS payroll_account_mappings;
@@ -0,0 +1,28 @@
+-- Mapping pos payroll ke bagan akun company untuk export jurnal akuntansi.
+-- Pos tanpa mapping memakai akun bawaan aplikasi.
+CREATE TABLE IF NOT EXISTS payroll_account_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    component_type VARCHAR(30) NOT NULL, -- BASE_SALARY, OVERTIME, ALLOWANCE, DEDUCTION, EMPLOYER_CONTRIBUTION, NET_PAYABLE
+    source_type VARCHAR(30), -- Opsional, mis. TAX, BPJS, LOAN
+    component_name VARCHAR(120), -- Opsional, dicocokkan tanpa membedakan huruf besar/kecil
+    account_code VARCHAR(50) NOT NULL,
+    account_name VARCHAR(120) NOT NULL,
+    contra_account_code VARCHAR(50), -- Akun utang untuk EMPLOYER_CONTRIBUTION
+    contra_account_name VARCHAR(120),
+    split_by_cost_center BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_account_mappings_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT chk_payroll_account_mappings_type CHECK (
+        component_type IN ('BASE_SALARY', 'OVERTIME', 'ALLOWANCE', 'DEDUCTION', 'EMPLOYER_CONTRIBUTION', 'NET_PAYABLE')
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payroll_account_mappings_key
+    ON payroll_account_mappings (company_id, component_type, COALESCE(source_type, ''), LOWER(COALESCE(component_name, '')));
+
+-- Sumber jurnal: payroll per run atau per periode beserta komponennya
+CREATE INDEX IF NOT EXISTS idx_payrolls_company_period_status
+    ON payrolls (company_id, period_start, status);
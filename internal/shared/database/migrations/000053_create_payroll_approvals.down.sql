@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payroll_approvals;
+DROP TABLE IF EXISTS payroll_approval_steps;
@@ -0,0 +1,40 @@
+-- Rantai approval payroll per company. Langkah dengan min_net_total > 0 hanya wajib
+-- jika total net salary payroll/run mencapai nilai tersebut (mis. sign-off Owner).
+CREATE TABLE IF NOT EXISTS payroll_approval_steps (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    step_order INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    role VARCHAR(50) NOT NULL, -- Nama role yang boleh menyetujui langkah ini, mis. HR, Finance, Owner
+    min_net_total BIGINT NOT NULL DEFAULT 0,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_approval_steps_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT uq_payroll_approval_steps_order UNIQUE (company_id, step_order),
+    CONSTRAINT chk_payroll_approval_steps_min_net_total CHECK (min_net_total >= 0)
+);
+
+-- Jejak approval per langkah untuk satu payroll atau satu payroll run.
+CREATE TABLE IF NOT EXISTS payroll_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    payroll_id UUID,
+    run_id UUID,
+    step_order INT NOT NULL,
+    step_name VARCHAR(100) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    actor_id UUID NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_approvals_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payroll_approvals_payroll FOREIGN KEY (payroll_id) REFERENCES payrolls (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payroll_approvals_run FOREIGN KEY (run_id) REFERENCES payroll_runs (id) ON DELETE CASCADE,
+    CONSTRAINT chk_payroll_approvals_target CHECK ((payroll_id IS NULL) <> (run_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payroll_approvals_payroll_step
+    ON payroll_approvals (payroll_id, step_order) WHERE payroll_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payroll_approvals_run_step
+    ON payroll_approvals (run_id, step_order) WHERE run_id IS NOT NULL;
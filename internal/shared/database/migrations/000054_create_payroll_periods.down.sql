@@ -0,0 +1,12 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id
+    FROM permissions
+    WHERE resource = 'payroll' AND action = 'manage'
+);
+
+DELETE FROM permissions
+WHERE resource = 'payroll' AND action = 'manage';
+
+DROP TABLE IF EXISTS payroll_period_audits;
+DROP TABLE IF EXISTS payroll_periods;
@@ -0,0 +1,50 @@
+-- Periode payroll bulanan per company. Bulan tanpa baris dianggap OPEN.
+-- Periode CLOSED mengunci payroll dan perubahan gaji yang berlaku surut ke periode tersebut.
+CREATE TABLE IF NOT EXISTS payroll_periods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    closed_by UUID,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_periods_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT uq_payroll_periods_company_start UNIQUE (company_id, period_start),
+    CONSTRAINT chk_payroll_periods_status CHECK (status IN ('OPEN', 'PROCESSING', 'CLOSED')),
+    CONSTRAINT chk_payroll_periods_range CHECK (period_end >= period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_periods_company_status
+    ON payroll_periods (company_id, status, period_end);
+
+-- Jejak perubahan status periode, termasuk alasan reopen.
+CREATE TABLE IF NOT EXISTS payroll_period_audits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    period_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL, -- PROCESS, CLOSE, REOPEN
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT,
+    actor_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payroll_period_audits_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_payroll_period_audits_period FOREIGN KEY (period_id) REFERENCES payroll_periods (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payroll_period_audits_period
+    ON payroll_period_audits (period_id, created_at);
+
+-- payroll:manage untuk membuka kembali periode yang sudah ditutup.
+INSERT INTO permissions (id, resource, action, label, category)
+VALUES (gen_random_uuid(), 'payroll', 'manage', 'Buka Kembali Periode Payroll', 'Payroll')
+ON CONFLICT (resource, action) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id, created_at)
+SELECT r.id, p.id, now()
+FROM roles r
+JOIN permissions p ON p.resource = 'payroll' AND p.action = 'manage'
+WHERE r.name IN ('Owner', 'SUPERADMIN')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_employees_termination_date;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS chk_employees_termination_reason;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS terminated_at,
+    DROP COLUMN IF EXISTS terminated_by,
+    DROP COLUMN IF EXISTS rehire_eligible,
+    DROP COLUMN IF EXISTS termination_note,
+    DROP COLUMN IF EXISTS termination_reason;
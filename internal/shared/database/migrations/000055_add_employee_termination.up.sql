@@ -0,0 +1,16 @@
+-- Detail offboarding karyawan. termination_date (hari kerja terakhir) sudah ada sejak 000049.
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS termination_reason VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS termination_note TEXT,
+    ADD COLUMN IF NOT EXISTS rehire_eligible BOOLEAN,
+    ADD COLUMN IF NOT EXISTS terminated_by UUID,
+    ADD COLUMN IF NOT EXISTS terminated_at TIMESTAMPTZ;
+
+ALTER TABLE employees
+    ADD CONSTRAINT chk_employees_termination_reason
+    CHECK (termination_reason IS NULL OR termination_reason IN ('RESIGNATION', 'DISMISSAL', 'CONTRACT_END'));
+
+-- Dipakai sweep worker untuk menonaktifkan user karyawan yang sudah melewati hari terakhir
+CREATE INDEX IF NOT EXISTS idx_employees_termination_date
+    ON employees (termination_date)
+    WHERE termination_reason IS NOT NULL;
@@ -0,0 +1,28 @@
+-- Job import karyawan massal dari CSV/XLSX. Baris valid disimpan di import_rows dan diproses
+-- async oleh consumer; baris yang gagal validasi (skipped_rows) maupun gagal dibuat
+-- (failed_rows) dicatat di results.
+CREATE TABLE IF NOT EXISTS employee_imports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    skipped_rows INTEGER NOT NULL DEFAULT 0,
+    import_rows JSONB NOT NULL DEFAULT '[]'::jsonb,
+    results JSONB NOT NULL DEFAULT '[]'::jsonb,
+    request_id VARCHAR(100),
+    requested_by UUID,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_employee_imports_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT chk_employee_imports_status CHECK (status IN ('PENDING', 'PROCESSING', 'COMPLETED', 'FAILED')),
+    CONSTRAINT chk_employee_imports_progress CHECK (processed_rows <= total_rows)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_imports_company_created
+    ON employee_imports (company_id, created_at DESC);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_employees_company_manager;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS chk_employees_manager_not_self;
+
+ALTER TABLE employees
+    DROP CONSTRAINT IF EXISTS fk_employees_manager;
+
+ALTER TABLE employees
+    DROP COLUMN IF EXISTS manager_id;
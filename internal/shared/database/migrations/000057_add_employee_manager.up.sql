@@ -0,0 +1,17 @@
+-- Garis pelaporan karyawan (atasan langsung). Validasi satu company dan pencegahan siklus
+-- dilakukan di service karena butuh traversal rekursif.
+ALTER TABLE employees
+    ADD COLUMN IF NOT EXISTS manager_id UUID;
+
+ALTER TABLE employees
+    ADD CONSTRAINT fk_employees_manager
+    FOREIGN KEY (manager_id) REFERENCES employees (id) ON DELETE SET NULL;
+
+ALTER TABLE employees
+    ADD CONSTRAINT chk_employees_manager_not_self
+    CHECK (manager_id IS NULL OR manager_id <> id);
+
+-- Dipakai traversal org chart dan lookup bawahan langsung
+CREATE INDEX IF NOT EXISTS idx_employees_company_manager
+    ON employees (company_id, manager_id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_users_email_trgm;
+DROP INDEX IF EXISTS idx_users_company_email;
+DROP INDEX IF EXISTS idx_payrolls_company_period_start;
+DROP INDEX IF EXISTS idx_attendances_company_date_id;
+DROP INDEX IF EXISTS idx_leaves_company_start_date;
+DROP INDEX IF EXISTS idx_employees_employee_number_trgm;
+DROP INDEX IF EXISTS idx_employees_email_trgm;
+DROP INDEX IF EXISTS idx_employees_full_name_trgm;
+DROP INDEX IF EXISTS idx_employees_company_full_name;
+DROP INDEX IF EXISTS idx_employees_company_hire_date;
+DROP INDEX IF EXISTS idx_employees_company_status;
+DROP INDEX IF EXISTS idx_employees_company_position;
+DROP INDEX IF EXISTS idx_employees_company_department;
+-- Extension pg_trgm tidak di-drop karena bisa dipakai objek lain.
@@ -0,0 +1,37 @@
+-- Index untuk list dengan pagination, filter, sorting dan search di database.
+-- Search memakai ILIKE '%q%' sehingga butuh index trigram (pg_trgm), bukan btree.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- employees: filter departemen/posisi/status/tanggal masuk per company, sort default nama
+CREATE INDEX IF NOT EXISTS idx_employees_company_department
+    ON employees (company_id, department_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_company_position
+    ON employees (company_id, position_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_company_status
+    ON employees (company_id, employment_status) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_company_hire_date
+    ON employees (company_id, hire_date) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_company_full_name
+    ON employees (company_id, full_name, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_full_name_trgm
+    ON employees USING gin (full_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_employees_email_trgm
+    ON employees USING gin (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_employees_employee_number_trgm
+    ON employees USING gin (employee_number gin_trgm_ops);
+
+-- leaves dan attendances: sort default tanggal terbaru per company
+CREATE INDEX IF NOT EXISTS idx_leaves_company_start_date
+    ON leaves (company_id, start_date DESC, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendances_company_date_id
+    ON attendances (company_id, attendance_date DESC, clock_in DESC, id) WHERE deleted_at IS NULL;
+
+-- payrolls: sort default periode terbaru per company
+CREATE INDEX IF NOT EXISTS idx_payrolls_company_period_start
+    ON payrolls (company_id, period_start DESC, id);
+
+-- users: search email dan sort default email per company
+CREATE INDEX IF NOT EXISTS idx_users_company_email
+    ON users (company_id, email) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm
+    ON users USING gin (email gin_trgm_ops);
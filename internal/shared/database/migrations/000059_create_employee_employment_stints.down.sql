@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_employee_employment_stints_employee;
+DROP TABLE IF EXISTS employee_employment_stints;
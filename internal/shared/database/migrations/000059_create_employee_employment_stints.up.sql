@@ -0,0 +1,21 @@
+-- Masa kerja sebelumnya karyawan yang di-rehire. Baris employees hanya menyimpan masa kerja
+-- berjalan, sehingga payroll dan laporan periode lama membaca window dari tabel ini.
+CREATE TABLE IF NOT EXISTS employee_employment_stints (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL,
+    employee_id UUID NOT NULL,
+    hire_date DATE NOT NULL,
+    termination_date DATE,
+    termination_reason VARCHAR(20),
+    termination_note TEXT,
+    rehire_eligible BOOLEAN,
+    terminated_by UUID,
+    terminated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_employee_employment_stints_company FOREIGN KEY (company_id) REFERENCES companies (id) ON DELETE CASCADE,
+    CONSTRAINT fk_employee_employment_stints_employee FOREIGN KEY (employee_id) REFERENCES employees (id) ON DELETE CASCADE,
+    CONSTRAINT chk_employee_employment_stints_range CHECK (termination_date IS NULL OR termination_date >= hire_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_employee_employment_stints_employee
+    ON employee_employment_stints (company_id, employee_id, hire_date);
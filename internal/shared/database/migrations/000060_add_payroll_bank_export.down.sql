@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_payrolls_bank_export_batch_id;
+
+ALTER TABLE payrolls
+    DROP COLUMN IF EXISTS bank_exported_at,
+    DROP COLUMN IF EXISTS bank_export_batch_id;
@@ -0,0 +1,6 @@
+-- Penanda payroll yang sudah masuk file bulk transfer, mencegah pembayaran ganda
+ALTER TABLE payrolls
+    ADD COLUMN IF NOT EXISTS bank_export_batch_id VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS bank_exported_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_payrolls_bank_export_batch_id ON payrolls (bank_export_batch_id);
@@ -0,0 +1,9 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (
+    SELECT id
+    FROM permissions
+    WHERE resource = 'payroll' AND action = 'read_all'
+);
+
+DELETE FROM permissions
+WHERE resource = 'payroll' AND action = 'read_all';
@@ -0,0 +1,13 @@
+-- payroll:read_all untuk membaca payroll dan payslip seluruh karyawan. payroll:read juga
+-- dimiliki role Employee, jadi akses data karyawan lain dipisah ke permission sendiri.
+INSERT INTO permissions (id, resource, action, label, category)
+VALUES (gen_random_uuid(), 'payroll', 'read_all', 'Lihat Payroll Semua Karyawan', 'Payroll')
+ON CONFLICT (resource, action) DO NOTHING;
+
+-- Nama role tidak konsisten antar seed (mis. 'Owner' dan 'OWNER'), jadi dicocokkan tanpa case.
+INSERT INTO role_permissions (role_id, permission_id, created_at)
+SELECT r.id, p.id, now()
+FROM roles r
+JOIN permissions p ON p.resource = 'payroll' AND p.action = 'read_all'
+WHERE UPPER(r.name) IN ('SUPERADMIN', 'OWNER', 'HR', 'FINANCE')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,139 @@
+package pagination
+
+import (
+	"strings"
+
+	"go-hris/internal/shared/response"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Request adalah parameter list standar dari query string. Di-embed ke DTO filter tiap modul
+// lalu dibind bersama filter lain lewat ShouldBindQuery.
+type Request struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+	SortBy   string `form:"sort_by"`
+	SortDir  string `form:"sort_dir"` // asc atau desc
+	Search   string `form:"q"`
+}
+
+// Sort mendefinisikan kolom yang boleh dipakai sort_by. Nama kolom SQL hanya berasal dari
+// whitelist ini, tidak pernah langsung dari input.
+type Sort struct {
+	Columns    map[string]string // key sort_by -> kolom SQL
+	Default    string            // key sort_by jika kosong atau tidak dikenal
+	DefaultDir string            // arah untuk sort default, asc atau desc
+	Secondary  map[string]string // key sort_by -> kolom urutan kedua sebelum tie breaker (opsional)
+	TieBreaker string            // kolom unik (mis. id) agar urutan antar halaman stabil
+}
+
+// Query adalah Request yang sudah dinormalisasi dan siap dipakai repository.
+type Query struct {
+	Page     int
+	PageSize int
+	OrderBy  string
+	Search   string
+}
+
+func (r Request) limits() (int, int) {
+	page := r.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := r.PageSize
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return page, pageSize
+}
+
+// Query menormalisasi page dan page_size (default 10, maksimal 100) serta menerjemahkan
+// sort_by/sort_dir lewat whitelist sort. Nilai yang tidak dikenal jatuh ke sort default.
+func (r Request) Query(sort Sort) Query {
+	page, pageSize := r.limits()
+
+	dir := strings.ToLower(strings.TrimSpace(r.SortDir))
+	key := strings.ToLower(strings.TrimSpace(r.SortBy))
+	column, ok := sort.Columns[key]
+	if !ok {
+		key = sort.Default
+		column = sort.Columns[key]
+		if dir != "asc" && dir != "desc" {
+			dir = strings.ToLower(sort.DefaultDir)
+		}
+	}
+	if dir != "desc" {
+		dir = "asc"
+	}
+
+	orderBy := ""
+	if column != "" {
+		orderBy = column + " " + strings.ToUpper(dir)
+		if secondary := sort.Secondary[key]; secondary != "" && secondary != column {
+			orderBy += ", " + secondary + " " + strings.ToUpper(dir)
+		}
+		if sort.TieBreaker != "" && sort.TieBreaker != column {
+			orderBy += ", " + sort.TieBreaker + " " + strings.ToUpper(dir)
+		}
+	}
+
+	return Query{
+		Page:     page,
+		PageSize: pageSize,
+		OrderBy:  orderBy,
+		Search:   strings.TrimSpace(r.Search),
+	}
+}
+
+// Meta membangun meta pagination response dari total data hasil filter.
+func (r Request) Meta(total int64) response.PaginationMeta {
+	page, pageSize := r.limits()
+	return response.NewPaginationMeta(total, page, pageSize)
+}
+
+func (q Query) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
+
+// Paginate menerapkan urutan dan limit/offset halaman. Dipasang setelah Count agar total
+// tidak terpotong.
+func Paginate(q Query) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.OrderBy != "" {
+			db = db.Order(q.OrderBy)
+		}
+		return db.Limit(q.PageSize).Offset(q.Offset())
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search memfilter case-insensitive (ILIKE) pada salah satu kolom. % dan _ dari input
+// di-escape agar dicari apa adanya. Kolom perlu index trigram (pg_trgm) agar pola '%q%'
+// tidak full scan.
+func Search(term string, columns ...string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		term = strings.TrimSpace(term)
+		if term == "" || len(columns) == 0 {
+			return db
+		}
+
+		pattern := "%" + likeEscaper.Replace(term) + "%"
+		conds := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			conds[i] = column + " ILIKE ?"
+			args[i] = pattern
+		}
+		return db.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+}
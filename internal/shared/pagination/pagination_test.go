@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var testSort = Sort{
+	Columns: map[string]string{
+		"name":       "employees.full_name",
+		"created_at": "employees.created_at",
+	},
+	Default:    "created_at",
+	DefaultDir: "desc",
+	Secondary:  map[string]string{"name": "employees.employee_number"},
+	TieBreaker: "employees.id",
+}
+
+func TestRequest_Query(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		q := Request{}.Query(testSort)
+		assert.Equal(t, 1, q.Page)
+		assert.Equal(t, DefaultPageSize, q.PageSize)
+		assert.Equal(t, "employees.created_at DESC, employees.id DESC", q.OrderBy)
+	})
+
+	t.Run("whitelisted sort", func(t *testing.T) {
+		q := Request{Page: 3, PageSize: 500, SortBy: "Name", SortDir: "DESC", Search: "  budi "}.Query(testSort)
+		assert.Equal(t, 3, q.Page)
+		assert.Equal(t, MaxPageSize, q.PageSize)
+		assert.Equal(t, 200, q.Offset())
+		assert.Equal(t, "employees.full_name DESC, employees.employee_number DESC, employees.id DESC", q.OrderBy)
+		assert.Equal(t, "budi", q.Search)
+	})
+
+	t.Run("unknown sort falls back to default", func(t *testing.T) {
+		q := Request{SortBy: "password; DROP TABLE users", SortDir: "asc"}.Query(testSort)
+		assert.Equal(t, "employees.created_at ASC, employees.id ASC", q.OrderBy)
+
+		q = Request{SortBy: "name", SortDir: "sideways"}.Query(testSort)
+		assert.Equal(t, "employees.full_name ASC, employees.employee_number ASC, employees.id ASC", q.OrderBy)
+	})
+}
+
+func TestRequest_BindQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	type filter struct {
+		Request
+		Status string `form:"status"`
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?page=2&page_size=25&sort_by=name&sort_dir=desc&q=ani&status=active", nil)
+
+	var f filter
+	assert.NoError(t, c.ShouldBindQuery(&f))
+	assert.Equal(t, Request{Page: 2, PageSize: 25, SortBy: "name", SortDir: "desc", Search: "ani"}, f.Request)
+	assert.Equal(t, "active", f.Status)
+
+	meta := f.Meta(51)
+	assert.Equal(t, int64(51), meta.Total)
+	assert.Equal(t, 3, meta.TotalPages)
+	assert.Equal(t, 25, meta.PageSize)
+}
+
+func TestScopes(t *testing.T) {
+	sqlDB, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer sqlDB.Close()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{DryRun: true})
+	assert.NoError(t, err)
+
+	q := Request{Page: 2, PageSize: 20, SortBy: "name", Search: `50%_off`}.Query(testSort)
+	stmt := db.Table("employees").
+		Scopes(Search(q.Search, "employees.full_name", "employees.email"), Paginate(q)).
+		Find(&[]map[string]interface{}{}).Statement
+
+	assert.Equal(t,
+		`SELECT * FROM "employees" WHERE (employees.full_name ILIKE $1 OR employees.email ILIKE $2) ORDER BY employees.full_name ASC, employees.employee_number ASC, employees.id ASC LIMIT $3 OFFSET $4`,
+		stmt.SQL.String(),
+	)
+	assert.Equal(t, []interface{}{`%50\%\_off%`, `%50\%\_off%`, 20, 20}, stmt.Vars)
+
+	// Search kosong tidak menambah kondisi
+	stmt = db.Table("employees").Scopes(Search("  ", "employees.full_name")).Find(&[]map[string]interface{}{}).Statement
+	assert.Equal(t, `SELECT * FROM "employees"`, stmt.SQL.String())
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// devSigningKey dipakai driver local di luar production jika STORAGE_SIGNING_KEY kosong,
+// agar setup lokal tetap jalan tanpa konfigurasi tambahan. Jangan dipakai di production.
+const devSigningKey = "go-hris-local-dev-signing-key"
+
+var (
+	ErrNotFound   = errors.New("storage: object not found")
+	ErrInvalidKey = errors.New("storage: invalid object key")
+)
+
+// BlobStore menyimpan file (payslip, dokumen karyawan, dll.) dengan key berbentuk path
+// relatif, mis. "payslips/payslip_<id>.pdf". File tidak pernah disajikan permanen;
+// akses dari luar selalu lewat SignedURL yang kedaluwarsa.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// NewFromEnv membuat BlobStore sesuai STORAGE_DRIVER (local atau s3, default local).
+//
+// local: STORAGE_LOCAL_DIR (default "storage"), STORAGE_PUBLIC_BASE_URL (default "/files"),
+// STORAGE_SIGNING_KEY (wajib jika APP_ENV=production, selain itu default key development).
+// s3: S3_ENDPOINT, S3_REGION (default us-east-1), S3_BUCKET, S3_ACCESS_KEY_ID,
+// S3_SECRET_ACCESS_KEY, S3_USE_PATH_STYLE (default true, sesuai MinIO), S3_PUBLIC_ENDPOINT (opsional).
+func NewFromEnv() (BlobStore, error) {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_DRIVER")))
+	switch driver {
+	case "", "local":
+		signingKey := os.Getenv("STORAGE_SIGNING_KEY")
+		if signingKey == "" {
+			if os.Getenv("APP_ENV") == "production" {
+				return nil, fmt.Errorf("STORAGE_SIGNING_KEY is required for local storage in production")
+			}
+			signingKey = devSigningKey
+		}
+		return NewLocalStore(
+			envOrDefault("STORAGE_LOCAL_DIR", "storage"),
+			envOrDefault("STORAGE_PUBLIC_BASE_URL", "/files"),
+			[]byte(signingKey),
+		), nil
+	case "s3":
+		cfg := S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          envOrDefault("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    !strings.EqualFold(os.Getenv("S3_USE_PATH_STYLE"), "false"),
+			PublicEndpoint:  os.Getenv("S3_PUBLIC_ENDPOINT"),
+		}
+		return NewS3Store(cfg)
+	}
+	return nil, fmt.Errorf("unsupported STORAGE_DRIVER %q", driver)
+}
+
+// cleanKey menolak key absolut atau yang keluar dari root (mis. "../").
+func cleanKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+func envOrDefault(name, fallback string) string {
+	if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+		return v
+	}
+	return fallback
+}
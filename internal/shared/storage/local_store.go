@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+
+// LocalStore menyimpan file di filesystem lokal. URL download ditandatangani HMAC-SHA256
+// dan disajikan oleh Handler, sehingga hanya pemegang URL yang belum kedaluwarsa yang
+// bisa mengunduh. Cocok untuk development atau deployment satu host dengan volume bersama.
+type LocalStore struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewLocalStore(dir, baseURL string, signingKey []byte) *LocalStore {
+	return &LocalStore{
+		dir:        dir,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: signingKey,
+		now:        time.Now,
+	}
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, content []byte, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+	// Tulis ke file sementara lalu rename agar pembaca tidak melihat file setengah jadi.
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return content, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.baseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// Verify memeriksa tanda tangan dan masa berlaku URL yang dibuat SignedURL.
+func (s *LocalStore) Verify(key, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+	expected := s.sign(key, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// RoutePath adalah pola route gin untuk Handler, diturunkan dari base URL.
+func (s *LocalStore) RoutePath() string {
+	base := s.baseURL
+	if u, err := url.Parse(s.baseURL); err == nil {
+		base = u.Path
+	}
+	return strings.TrimRight(base, "/") + "/*key"
+}
+
+// Handler menyajikan file lokal untuk URL yang ditandatangani. Request tanpa tanda
+// tangan valid ditolak dengan 403 tanpa membedakan file ada atau tidak.
+func (s *LocalStore) Handler(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if err := s.Verify(key, c.Query("expires"), c.Query("signature")); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "message": err.Error()})
+		return
+	}
+
+	content, err := s.Get(c.Request.Context(), key)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidKey) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": http.StatusText(status)})
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Content-Disposition", "attachment; filename=\""+path.Base(key)+"\"")
+	c.Data(http.StatusOK, contentType, content)
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeKey meng-encode setiap segmen key tanpa meng-encode pemisah "/".
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
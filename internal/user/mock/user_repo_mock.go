@@ -115,6 +115,22 @@ func (mr *MockRepositoryMockRecorder) FindByID(ctx, companyID, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepository)(nil).FindByID), ctx, companyID, id)
 }
 
+// FindPageByCompany mocks base method.
+func (m *MockRepository) FindPageByCompany(ctx context.Context, companyID string, filter user.UserQueryFilter) ([]user.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByCompany", ctx, companyID, filter)
+	ret0, _ := ret[0].([]user.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByCompany indicates an expected call of FindPageByCompany.
+func (mr *MockRepositoryMockRecorder) FindPageByCompany(ctx, companyID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByCompany", reflect.TypeOf((*MockRepository)(nil).FindPageByCompany), ctx, companyID, filter)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, u *user.User) error {
 	m.ctrl.T.Helper()
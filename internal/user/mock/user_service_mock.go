@@ -41,32 +41,32 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
-// ChangePassword mocks base method.
-func (m *MockService) ChangePassword(ctx context.Context, companyID, userID, currentPassword, newPassword string) error {
+// AssignRole mocks base method.
+func (m *MockService) AssignRole(ctx context.Context, companyID, userID, roleName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangePassword", ctx, companyID, userID, currentPassword, newPassword)
+	ret := m.ctrl.Call(m, "AssignRole", ctx, companyID, userID, roleName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ChangePassword indicates an expected call of ChangePassword.
-func (mr *MockServiceMockRecorder) ChangePassword(ctx, companyID, userID, currentPassword, newPassword any) *gomock.Call {
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockServiceMockRecorder) AssignRole(ctx, companyID, userID, roleName any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockService)(nil).ChangePassword), ctx, companyID, userID, currentPassword, newPassword)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockService)(nil).AssignRole), ctx, companyID, userID, roleName)
 }
 
-// AssignRole mocks base method.
-func (m *MockService) AssignRole(ctx context.Context, companyID, userID, roleName string) error {
+// ChangePassword mocks base method.
+func (m *MockService) ChangePassword(ctx context.Context, companyID, userID, currentPassword, newPassword string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignRole", ctx, companyID, userID, roleName)
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, companyID, userID, currentPassword, newPassword)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AssignRole indicates an expected call of AssignRole.
-func (mr *MockServiceMockRecorder) AssignRole(ctx, companyID, userID, roleName any) *gomock.Call {
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockServiceMockRecorder) ChangePassword(ctx, companyID, userID, currentPassword, newPassword any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockService)(nil).AssignRole), ctx, companyID, userID, roleName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockService)(nil).ChangePassword), ctx, companyID, userID, currentPassword, newPassword)
 }
 
 // Create mocks base method.
@@ -99,18 +99,19 @@ func (mr *MockServiceMockRecorder) ForceResetPassword(ctx, companyID, userID, ne
 }
 
 // GetAll mocks base method.
-func (m *MockService) GetAll(ctx context.Context, companyID string) ([]user.UserResponse, error) {
+func (m *MockService) GetAll(ctx context.Context, companyID string, filterReq user.GetUsersFilterRequest) ([]user.UserResponse, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, companyID)
+	ret := m.ctrl.Call(m, "GetAll", ctx, companyID, filterReq)
 	ret0, _ := ret[0].([]user.UserResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockServiceMockRecorder) GetAll(ctx, companyID any) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAll(ctx, companyID, filterReq any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockService)(nil).GetAll), ctx, companyID, filterReq)
 }
 
 // GetAllWithRoles mocks base method.
@@ -185,3 +186,41 @@ func (mr *MockServiceMockRecorder) ToggleStatus(ctx, companyID, id, isActive any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleStatus", reflect.TypeOf((*MockService)(nil).ToggleStatus), ctx, companyID, id, isActive)
 }
+
+// MockRoleAssigner is a mock of RoleAssigner interface.
+type MockRoleAssigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleAssignerMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleAssignerMockRecorder is the mock recorder for MockRoleAssigner.
+type MockRoleAssignerMockRecorder struct {
+	mock *MockRoleAssigner
+}
+
+// NewMockRoleAssigner creates a new mock instance.
+func NewMockRoleAssigner(ctrl *gomock.Controller) *MockRoleAssigner {
+	mock := &MockRoleAssigner{ctrl: ctrl}
+	mock.recorder = &MockRoleAssignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleAssigner) EXPECT() *MockRoleAssignerMockRecorder {
+	return m.recorder
+}
+
+// AssignRoleToEmployee mocks base method.
+func (m *MockRoleAssigner) AssignRoleToEmployee(companyID, employeeID, roleName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRoleToEmployee", companyID, employeeID, roleName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRoleToEmployee indicates an expected call of AssignRoleToEmployee.
+func (mr *MockRoleAssignerMockRecorder) AssignRoleToEmployee(companyID, employeeID, roleName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRoleToEmployee", reflect.TypeOf((*MockRoleAssigner)(nil).AssignRoleToEmployee), companyID, employeeID, roleName)
+}
@@ -1,5 +1,19 @@
 package user
 
+import "go-hris/internal/shared/pagination"
+
+// GetUsersFilterRequest adalah query string list user. sort_by: email (default), full_name,
+// created_at, id; q mencari email atau nama karyawan.
+type GetUsersFilterRequest struct {
+	pagination.Request
+	IsActive *bool `form:"is_active"`
+}
+
+type UserQueryFilter struct {
+	IsActive *bool
+	Page     pagination.Query
+}
+
 type CreateUserRequest struct {
 	EmployeeID string `json:"employee_id" binding:"required,uuid"`
 	Email      string `json:"email" binding:"required,email"`
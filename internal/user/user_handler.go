@@ -2,9 +2,6 @@ package user
 
 import (
 	"net/http"
-	"sort"
-	"strconv"
-	"strings"
 
 	"go-hris/internal/shared/apperror"
 	"go-hris/internal/shared/contextutil"
@@ -45,64 +42,20 @@ func (h *Handler) GetAll(c *gin.Context) {
 	companyID := c.GetString("company_id")
 	h.logger.Debug("http get all users", zap.String("company_id", companyID))
 
-	resp, err := h.svc.GetAll(ctx, companyID)
-	if err != nil {
-		writeError(c, err)
+	var filterReq GetUsersFilterRequest
+	if err := c.ShouldBindQuery(&filterReq); err != nil {
+		response.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "Input tidak valid", err.Error())
 		return
 	}
 
-	q := strings.TrimSpace(strings.ToLower(c.Query("q")))
-	if q != "" {
-		filtered := make([]UserResponse, 0, len(resp))
-		for _, u := range resp {
-			if strings.Contains(strings.ToLower(u.Email), q) {
-				filtered = append(filtered, u)
-			}
-		}
-		resp = filtered
-	}
-
-	sortBy := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort_by", "email")))
-	sortDir := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort_dir", "asc")))
-	if sortDir != "desc" {
-		sortDir = "asc"
-	}
-
-	sort.Slice(resp, func(i, j int) bool {
-		var less bool
-		switch sortBy {
-		case "id":
-			less = resp[i].ID < resp[j].ID
-		default:
-			less = strings.ToLower(resp[i].Email) < strings.ToLower(resp[j].Email)
-		}
-		if sortDir == "desc" {
-			return !less
-		}
-		return less
-	})
-
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
-	}
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	if pageSize < 1 {
-		pageSize = 10
-	}
-
-	total := int64(len(resp))
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > len(resp) {
-		start = len(resp)
-	}
-	if end > len(resp) {
-		end = len(resp)
+	resp, total, err := h.svc.GetAll(ctx, companyID, filterReq)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
 
-	meta := response.NewPaginationMeta(total, page, pageSize)
-	response.Success(c, http.StatusOK, resp[start:end], &meta)
+	meta := filterReq.Meta(total)
+	response.Success(c, http.StatusOK, resp, &meta)
 }
 
 func (h *Handler) GetById(c *gin.Context) {
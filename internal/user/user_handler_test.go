@@ -18,7 +18,7 @@ import (
 )
 
 type fakeUserService struct {
-	GetAllFn             func(ctx context.Context, companyID string) ([]user.UserResponse, error)
+	GetAllFn             func(ctx context.Context, companyID string, filterReq user.GetUsersFilterRequest) ([]user.UserResponse, int64, error)
 	GetAllWithRolesFn    func(ctx context.Context, companyID string) ([]user.UserWithRolesResponse, error)
 	GetByIDFn            func(ctx context.Context, companyID, id string) (user.UserResponse, error)
 	CreateFn             func(ctx context.Context, companyID string, req user.CreateUserRequest) (user.UserResponse, error)
@@ -30,8 +30,8 @@ type fakeUserService struct {
 	ForceResetPasswordFn func(ctx context.Context, companyID, id, new string) error
 }
 
-func (f *fakeUserService) GetAll(ctx context.Context, cid string) ([]user.UserResponse, error) {
-	return f.GetAllFn(ctx, cid)
+func (f *fakeUserService) GetAll(ctx context.Context, cid string, filterReq user.GetUsersFilterRequest) ([]user.UserResponse, int64, error) {
+	return f.GetAllFn(ctx, cid, filterReq)
 }
 
 func (f *fakeUserService) GetByID(ctx context.Context, cid, id string) (user.UserResponse, error) {
@@ -75,11 +75,13 @@ func TestUserHandler_GetAll(t *testing.T) {
 		companyID := uuid.New().String()
 
 		svc := &fakeUserService{
-			GetAllFn: func(ctx context.Context, cid string) ([]user.UserResponse, error) {
+			GetAllFn: func(ctx context.Context, cid string, filterReq user.GetUsersFilterRequest) ([]user.UserResponse, int64, error) {
 				assert.Equal(t, companyID, cid)
+				assert.False(t, *filterReq.IsActive)
+				assert.Equal(t, "user", filterReq.Search)
 				return []user.UserResponse{
 					{ID: uuid.New().String(), Email: "user@mail.com"},
-				}, nil
+				}, 1, nil
 			},
 		}
 
@@ -87,7 +89,7 @@ func TestUserHandler_GetAll(t *testing.T) {
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 
-		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req := httptest.NewRequest(http.MethodGet, "/users?q=user&is_active=false", nil)
 		c.Request = req
 		c.Set("company_id", companyID)
 
@@ -95,12 +97,26 @@ func TestUserHandler_GetAll(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.Contains(t, w.Body.String(), "user@mail.com")
+		assert.Contains(t, w.Body.String(), `"total":1`)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		h := setupHandler(&fakeUserService{})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		c.Request = httptest.NewRequest(http.MethodGet, "/users?is_active=maybe", nil)
+		c.Set("company_id", uuid.New().String())
+
+		h.GetAll(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("service error", func(t *testing.T) {
 		svc := &fakeUserService{
-			GetAllFn: func(ctx context.Context, cid string) ([]user.UserResponse, error) {
-				return nil, errors.New("service error")
+			GetAllFn: func(ctx context.Context, cid string, filterReq user.GetUsersFilterRequest) ([]user.UserResponse, int64, error) {
+				return nil, 0, errors.New("service error")
 			},
 		}
 
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/tenant"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ type Repository interface {
 	FindByID(ctx context.Context, companyID string, id string) (*User, error)
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindAllByCompany(ctx context.Context, companyID string) ([]User, error)
+	FindPageByCompany(ctx context.Context, companyID string, filter UserQueryFilter) ([]User, int64, error)
 	FindAllByCompanyWithRoles(ctx context.Context, companyID string) ([]UserWithRolesRow, error)
 	Update(ctx context.Context, u *User) error
 }
@@ -69,6 +71,42 @@ func (r *repository) FindAllByCompany(ctx context.Context, companyID string) ([]
 	return users, err
 }
 
+var userListSort = pagination.Sort{
+	Columns: map[string]string{
+		"email":      "users.email",
+		"full_name":  `"Employee".full_name`,
+		"created_at": "users.created_at",
+		"id":         "users.id",
+	},
+	Default:    "email",
+	DefaultDir: "asc",
+	TieBreaker: "users.id",
+}
+
+// FindPageByCompany mengembalikan satu halaman user sesuai filter beserta total seluruh data
+// yang cocok. Alias "Employee" berasal dari Joins("Employee").
+func (r *repository) FindPageByCompany(ctx context.Context, companyID string, filter UserQueryFilter) ([]User, int64, error) {
+	db := r.db.WithContext(ctx).
+		Model(&User{}).
+		Joins("Employee").
+		Where("users.company_id = ?", companyID).
+		Scopes(pagination.Search(filter.Page.Search, "users.email", `"Employee".full_name`))
+
+	if filter.IsActive != nil {
+		db = db.Where("users.is_active = ?", *filter.IsActive)
+	}
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []User
+	err := db.Scopes(pagination.Paginate(filter.Page)).Find(&users).Error
+	return users, total, err
+}
+
 func (r *repository) Update(ctx context.Context, u *User) error {
 	columns := []string{"IsActive", "UpdatedAt"}
 
@@ -15,7 +15,7 @@ import (
 //go:generate mockgen -source=user_service.go -destination=mock/user_service_mock.go -package=mock
 
 type Service interface {
-	GetAll(ctx context.Context, companyID string) ([]UserResponse, error)
+	GetAll(ctx context.Context, companyID string, filterReq GetUsersFilterRequest) ([]UserResponse, int64, error)
 	GetAllWithRoles(ctx context.Context, companyID string) ([]UserWithRolesResponse, error)
 	GetByID(ctx context.Context, companyID, id string) (UserResponse, error)
 
@@ -49,10 +49,13 @@ func NewService(repo Repository, roleAssigner ...RoleAssigner) Service {
 	}
 }
 
-func (s *service) GetAll(ctx context.Context, companyID string) ([]UserResponse, error) {
-	users, err := s.repo.FindAllByCompany(ctx, companyID)
+func (s *service) GetAll(ctx context.Context, companyID string, filterReq GetUsersFilterRequest) ([]UserResponse, int64, error) {
+	users, total, err := s.repo.FindPageByCompany(ctx, companyID, UserQueryFilter{
+		IsActive: filterReq.IsActive,
+		Page:     filterReq.Query(userListSort),
+	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	resp := make([]UserResponse, len(users))
@@ -60,7 +63,7 @@ func (s *service) GetAll(ctx context.Context, companyID string) ([]UserResponse,
 		resp[i] = mapToResponse(u)
 	}
 
-	return resp, nil
+	return resp, total, nil
 }
 
 func (s *service) GetByID(ctx context.Context, companyID, id string) (UserResponse, error) {
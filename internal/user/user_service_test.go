@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"go-hris/internal/shared/pagination"
 	"go-hris/internal/user"
 	mock_user "go-hris/internal/user/mock"
 
@@ -33,20 +34,30 @@ func TestUserService_GetAll(t *testing.T) {
 		mockRepo := mock_user.NewMockRepository(ctrl)
 		svc := user.NewService(mockRepo)
 
+		active := true
 		mockRepo.EXPECT().
-			FindAllByCompany(gomock.Any(), companyID).
-			Return([]user.User{
-				{
-					ID:       uuid.New(),
-					Email:    "john@mail.com",
-					IsActive: true,
-				},
-			}, nil)
-
-		res, err := svc.GetAll(ctx, companyID)
+			FindPageByCompany(gomock.Any(), companyID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, cid string, filter user.UserQueryFilter) ([]user.User, int64, error) {
+				assert.True(t, *filter.IsActive)
+				assert.Equal(t, "john", filter.Page.Search)
+				assert.Equal(t, "users.created_at DESC, users.id DESC", filter.Page.OrderBy)
+				return []user.User{
+					{
+						ID:       uuid.New(),
+						Email:    "john@mail.com",
+						IsActive: true,
+					},
+				}, 11, nil
+			})
+
+		res, total, err := svc.GetAll(ctx, companyID, user.GetUsersFilterRequest{
+			Request:  pagination.Request{SortBy: "created_at", SortDir: "desc", Search: "john"},
+			IsActive: &active,
+		})
 
 		assert.NoError(t, err)
 		assert.Len(t, res, 1)
+		assert.Equal(t, int64(11), total)
 		assert.Equal(t, "john@mail.com", res[0].Email)
 	})
 
@@ -58,10 +69,10 @@ func TestUserService_GetAll(t *testing.T) {
 		svc := user.NewService(mockRepo)
 
 		mockRepo.EXPECT().
-			FindAllByCompany(gomock.Any(), companyID).
-			Return(nil, errors.New("db error"))
+			FindPageByCompany(gomock.Any(), companyID, gomock.Any()).
+			Return(nil, int64(0), errors.New("db error"))
 
-		res, err := svc.GetAll(ctx, companyID)
+		res, _, err := svc.GetAll(ctx, companyID, user.GetUsersFilterRequest{})
 
 		assert.Error(t, err)
 		assert.Nil(t, res)